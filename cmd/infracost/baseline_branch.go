@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// baselineWorktree is a git worktree of the baseline branch in a temp dir, so
+// the projects can be diffed against their version on that branch.
+type baselineWorktree struct {
+	repoDir string
+	dir     string
+}
+
+// baselineWorktrees are the worktrees of the baseline branch, one for each git
+// repo of the projects, since the projects of a config file can be in
+// different repos.
+type baselineWorktrees struct {
+	branch    string
+	worktrees map[string]*baselineWorktree
+}
+
+func newBaselineWorktrees(branch string) *baselineWorktrees {
+	return &baselineWorktrees{
+		branch:    branch,
+		worktrees: make(map[string]*baselineWorktree),
+	}
+}
+
+// path returns the path in the baseline worktree of the git repo that contains
+// the path. The worktree is checked out the first time a path in its repo is
+// used.
+func (w *baselineWorktrees) path(path string) (string, error) {
+	repoDir, err := gitRepoDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	worktree, ok := w.worktrees[repoDir]
+	if !ok {
+		worktree, err = addBaselineWorktree(w.branch, repoDir)
+		if err != nil {
+			return "", err
+		}
+		w.worktrees[repoDir] = worktree
+	}
+
+	return worktree.path(path)
+}
+
+// remove removes all the worktrees.
+func (w *baselineWorktrees) remove() {
+	for _, worktree := range w.worktrees {
+		worktree.remove()
+	}
+}
+
+// gitRepoDir returns the top level dir of the git repo that contains the path.
+func gitRepoDir(path string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", errors.New("git could not be found, it's needed to check out --baseline-branch")
+	}
+
+	dir, err := existingDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	repoDir, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", errors.Errorf("%s is not in a git repo, --baseline-branch needs the projects to be in one", path)
+	}
+
+	return repoDir, nil
+}
+
+// addBaselineWorktree checks out the branch into a temp worktree of the git
+// repo. The current branch's changes don't need to be committed since only the
+// branch is checked out.
+func addBaselineWorktree(branch string, repoDir string) (*baselineWorktree, error) {
+	if _, err := runGit(repoDir, "rev-parse", "--verify", "--quiet", branch+"^{commit}"); err != nil {
+		return nil, errors.Errorf("Branch %s could not be found in %s", branch, repoDir)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "infracost-baseline")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating the baseline worktree dir")
+	}
+
+	// git worktree add needs the dir to not exist or be empty
+	if _, err := runGit(repoDir, "worktree", "add", "--detach", tmpDir, branch); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, errors.Wrap(err, fmt.Sprintf("Error checking out %s", branch))
+	}
+
+	log.Infof("Checked out %s to %s", branch, tmpDir)
+
+	return &baselineWorktree{repoDir: repoDir, dir: tmpDir}, nil
+}
+
+// path returns the path in the worktree of a path in the repo.
+func (w *baselineWorktree) path(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	// git returns the repo dir with any symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	rel, err := filepath.Rel(w.repoDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("%s is not in the git repo %s", path, w.repoDir)
+	}
+
+	return filepath.Join(w.dir, rel), nil
+}
+
+// remove removes the worktree and its temp dir.
+func (w *baselineWorktree) remove() {
+	if _, err := runGit(w.repoDir, "worktree", "remove", "--force", w.dir); err != nil {
+		log.Debugf("Could not remove the baseline worktree: %s", err)
+	}
+
+	os.RemoveAll(w.dir)
+	_, _ = runGit(w.repoDir, "worktree", "prune")
+}
+
+// runUntilDone returns the error of f, or exits with exitCodeError if the ctx
+// is done first, e.g. on Ctrl-C. Returning instead of calling os.Exit lets the
+// caller's deferred cleanup, like removing the worktrees, still run.
+func runUntilDone(ctx context.Context, f func() error) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return &exitCodeErr{exitCodeError}
+	}
+}
+
+// hasTerraform returns false if the path doesn't exist or is a dir without any
+// Terraform files, e.g. the project is new in the current branch.
+func hasTerraform(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	if !info.IsDir() {
+		return true
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(path, "*.tf"))
+	return len(matches) > 0
+}
+
+func existingDir(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Errorf("No such file or directory %s", path)
+	}
+
+	if info.IsDir() {
+		return path, nil
+	}
+
+	return filepath.Dir(path), nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	log.Debugf("Running command: %s", cmd.String())
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.New(strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates a git repo with main.tf in dir committed to the main
+// branch, then changes main.tf on a feature branch without committing it.
+func newTestRepo(t *testing.T, dir string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git could not be found")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)
+		_, err := runGit(repo, args...)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, dir), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repo, dir, "main.tf"), []byte("# main"), 0600))
+
+	git("init", "--quiet")
+	git("checkout", "--quiet", "-b", "main")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "main")
+	git("checkout", "--quiet", "-b", "feature")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repo, dir, "main.tf"), []byte("# feature"), 0600))
+
+	return repo
+}
+
+func TestBaselineWorktrees(t *testing.T) {
+	repo1 := newTestRepo(t, "app")
+	repo2 := newTestRepo(t, "db")
+
+	worktrees := newBaselineWorktrees("main")
+
+	path1, err := worktrees.path(filepath.Join(repo1, "app"))
+	require.NoError(t, err)
+	b, err := ioutil.ReadFile(filepath.Join(path1, "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "# main", string(b))
+
+	// A file path is in the worktree of its dir
+	path, err := worktrees.path(filepath.Join(repo1, "app", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(path1, "main.tf"), path)
+
+	// Each repo has its own worktree
+	path2, err := worktrees.path(filepath.Join(repo2, "db"))
+	require.NoError(t, err)
+	assert.NotEqual(t, filepath.Dir(path1), filepath.Dir(path2))
+	assert.True(t, hasTerraform(path2))
+	assert.Len(t, worktrees.worktrees, 2)
+
+	worktrees.remove()
+
+	for _, p := range []string{path1, path2} {
+		_, err := os.Stat(p)
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	out, err := runGit(repo1, "worktree", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, out, filepath.Dir(path1))
+}
+
+func TestBaselineWorktreesErrors(t *testing.T) {
+	repo := newTestRepo(t, "app")
+
+	_, err := newBaselineWorktrees("missing").path(filepath.Join(repo, "app"))
+	assert.EqualError(t, err, "Branch missing could not be found in "+mustEvalSymlinks(t, repo))
+
+	_, err = newBaselineWorktrees("main").path(t.TempDir())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not in a git repo")
+
+	_, err = newBaselineWorktrees("main").path(filepath.Join(repo, "missing"))
+	assert.Error(t, err)
+}
+
+func TestRunUntilDone(t *testing.T) {
+	assert.NoError(t, runUntilDone(context.Background(), func() error { return nil }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err := runUntilDone(ctx, func() error {
+		<-block
+		return nil
+	})
+	assert.Equal(t, &exitCodeErr{exitCodeError}, err)
+}
+
+func mustEvalSymlinks(t *testing.T, path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	require.NoError(t, err)
+	return resolved
+}
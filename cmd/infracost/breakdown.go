@@ -19,17 +19,39 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 
       terraform plan -out tfplan.binary
       terraform show -json tfplan.binary > plan.json
-      infracost breakdown --path plan.json`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
-				return err
-			}
+      infracost breakdown --path plan.json
+
+  Re-run the breakdown whenever the Terraform files change:
+
+      infracost breakdown --path /path/to/code --watch
 
+  Only estimate the resources of the db module:
+
+      infracost breakdown --path plan.json --filter-path 'module.db.*'
+
+  Show the cost diff against a previously saved baseline:
+
+      infracost breakdown --path /path/to/code --baseline-out baseline.json
+      infracost breakdown --path /path/to/code --compare-to baseline.json --format diff`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			err := loadRunFlags(cfg, cmd)
 			if err != nil {
 				return err
 			}
 
+			// Offline runs with a pricing DB don't need an API key
+			if cfg.PricingDBPath == "" {
+				if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			cfg.FilterPaths, _ = cmd.Flags().GetStringArray("filter-path")
+
+			if compareTo, _ := cmd.Flags().GetString("compare-to"); compareTo != "" {
+				cfg.CompareTo = resolvePath(cfg, compareTo)
+			}
+
 			cfg.Environment.OutputFormat = cfg.Format
 
 			err = checkRunConfig(cfg)
@@ -37,6 +59,18 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 				ui.PrintUsageErrorAndExit(cmd, err.Error())
 			}
 
+			if cfg.Format == "diff" && cfg.CompareTo == "" {
+				ui.PrintUsageErrorAndExit(cmd, "--compare-to is required for diff output format, or use infracost diff")
+			}
+
+			if cfg.CompareTo != "" && cfg.Format != "diff" {
+				ui.PrintWarning("compare-to is only supported for diff output format.\n")
+			}
+
+			if cfg.Watch {
+				return runWatch(cmd, cfg)
+			}
+
 			return runMain(cmd, cfg)
 		},
 	}
@@ -44,8 +78,27 @@ func breakdownCmd(cfg *config.Config) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
-	cmd.Flags().String("format", "table", "Output format: json, table, html")
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nOnly supported by table output format")
+	cmd.Flags().String("format", "table", "Output format: json, table, diff, html, report, markdown, csv, template")
+	cmd.Flags().String("markdown-style", "", "Style of the markdown output format: plain, or github for PR comments with a summary table, the breakdown\ncollapsed in a <details> block and emoji for cost changes. gitlab is an alias of github for MR comments. Defaults to plain")
+	cmd.Flags().String("csv-delimiter", ",", "Field delimiter for csv output format, e.g. ; for European locales")
+	cmd.Flags().String("html-template", "", "Path to a Go template file used as the layout for html and report output formats")
+	cmd.Flags().String("template-file", "", "Path to a Go text/template file used by the template output format")
+	cmd.Flags().Float64("project-growth", 0, "Monthly growth rate, e.g. 0.05 for 5%, used to add 3, 6 and 12 month cost projections to the JSON output.\nThis is a naive compound growth model, not a forecast of actual usage")
+	cmd.Flags().String("group-by", "", "Group the resources of the JSON output by: service, region, module or tag:<key>, with a subtotal for each group.\nGrouped JSON can't be used as the input of other commands, e.g. diffs")
+	cmd.Flags().Bool("json-flat", false, "Output the JSON as a flat array with a record for each cost component, including the project, resource, run time and metadata.\nUseful for loading into data warehouses, it can't be used as the input of other commands")
+	cmd.Flags().String("sort", "", "Sort the resources of the table output by: monthlyCost, name or path (the projects), prefix with - for descending order, e.g. -monthlyCost")
+	cmd.Flags().Bool("sign", false, "Add a signature field to the JSON output, an HMAC-SHA256 of the JSON using the INFRACOST_SIGNING_KEY environment variable or signing_key config value.\nUse infracost output --verify-signature to check it")
+	cmd.Flags().Bool("plan-metadata", false, "Include the Terraform version, plan format version and working directory of each project in the JSON output")
+	cmd.Flags().String("baseline-out", "", "Path to write the Infracost JSON to, in addition to the normal output, for use as a baseline of later diffs")
+	cmd.Flags().StringArray("filter-path", []string{}, "Only estimate the resources with addresses that match the glob, e.g. 'module.db.*', where * matches any characters.\nOther resources aren't priced. Repeat for more globs")
+	cmd.Flags().String("compare-to", "", "Path to an Infracost JSON baseline, e.g. from --baseline-out, to diff the breakdown against. Use with --format diff")
+	cmd.Flags().Bool("explain", false, "Show how each cost is calculated from its price and quantity. Supported by table and JSON output formats")
+	cmd.Flags().Bool("suggest-alternatives", false, "Suggest cheaper instance types and volume types of the same size for AWS resources. Suggestions are heuristic.\nSupported by table and JSON output formats")
+	cmd.Flags().Int("max-resource-depth", 0, "Collapse sub-resources nested deeper than this into their parent in table and html output. Costs still include them")
+	cmd.Flags().Bool("wrap-cells", false, "Wrap long names and units over multiple lines in table output")
+	cmd.Flags().Bool("interactive", false, "Explore the table output in an interactive terminal UI. Falls back to the table if not run in a terminal")
+	cmd.Flags().Bool("watch", false, "Re-run the breakdown when the .tf or .tfvars files of the path change, showing the change in the monthly cost. Terraform plans are reused while the Terraform files and plan flags are unchanged. Only runs in a terminal")
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost or all.\nAliases: qty (monthlyQuantity), cost (monthlyCost), hourly (hourlyCost).\nOnly supported by table, markdown and json output formats. Pruned JSON can't be used as the input of other commands, e.g. diffs")
 
 	return cmd
 }
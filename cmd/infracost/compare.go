@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func compareCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the monthly costs of environments side by side",
+		Long: `Compare the monthly costs of environments side by side
+
+Each Infracost JSON file is shown as a column named after the file, e.g.
+prod.json is shown as prod. Resources that an environment doesn't have are
+left blank.`,
+		Example: `  Compare dev, staging and prod:
+
+      infracost compare --path dev.json --path staging.json --path prod.json
+
+  Compare the cost of each resource type as CSV:
+
+      infracost compare --path dev.json --path prod.json --group-by type --format csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("path") {
+				m := fmt.Sprintf("No path specified\n\nUse the %s flag to specify the path to an Infracost JSON file of each environment.", ui.PrimaryString("--path"))
+				ui.PrintUsageErrorAndExit(cmd, m)
+			}
+
+			format, _ := cmd.Flags().GetString("format")
+			format = strings.ToLower(format)
+			if format != "table" && format != "csv" && format != "html" {
+				ui.PrintUsageErrorAndExit(cmd, "--format only supports table, csv and html")
+			}
+
+			groupBy, _ := cmd.Flags().GetString("group-by")
+			if groupBy != "resource" && groupBy != "type" {
+				ui.PrintUsageErrorAndExit(cmd, "--group-by only supports resource and type")
+			}
+
+			inputFiles := []string{}
+			paths, _ := cmd.Flags().GetStringArray("path")
+			for _, path := range paths {
+				matches, _ := filepath.Glob(resolvePath(cfg, path))
+				inputFiles = append(inputFiles, matches...)
+			}
+
+			if len(inputFiles) == 0 {
+				ui.PrintUsageErrorAndExit(cmd, "No Infracost JSON files match --path")
+			}
+
+			names := environmentNames(inputFiles)
+
+			inputs := make([]output.ReportInput, 0, len(inputFiles))
+			for i, f := range inputFiles {
+				j, err := loadInfracostJSON(f)
+				if err != nil {
+					return err
+				}
+
+				inputs = append(inputs, output.ReportInput{
+					Metadata: map[string]string{
+						"environment": names[i],
+						"filename":    f,
+					},
+					Root: j,
+				})
+			}
+
+			c := output.BuildComparison(inputs, groupBy == "type")
+			opts := output.Options{NoColor: cfg.NoColor}
+
+			var (
+				b   []byte
+				err error
+			)
+			switch format {
+			case "csv":
+				b, err = output.ToCompareCSV(c, opts)
+			case "html":
+				b, err = output.ToCompareHTML(c, opts)
+			default:
+				b, err = output.ToCompareTable(c, opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to the Infracost JSON file of an environment, glob patterns need quotes. Repeat for each environment")
+	cmd.Flags().String("format", "table", "Output format: table, csv, html")
+	cmd.Flags().String("group-by", "resource", "Show a row for each: resource, type")
+
+	return cmd
+}
+
+// environmentNames names each file by its base name without the extension,
+// using the full path if the base names aren't unique.
+func environmentNames(files []string) []string {
+	names := make([]string, 0, len(files))
+	seen := make(map[string]bool)
+	unique := true
+
+	for _, f := range files {
+		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		if seen[name] {
+			unique = false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	if !unique {
+		return files
+	}
+
+	return names
+}
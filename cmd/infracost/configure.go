@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func configureCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "configure",
+		Short: "Display or change global configuration",
+		Long: fmt.Sprintf(`Display or change global configuration.
+
+Settings are saved to %s and are overridden by
+environment variables and flags.
+
+Supported keys: %s`, config.ConfigurationFilePath(), strings.Join(config.ConfigurationKeys(), ", ")),
+		Example: `  Set your pricing API endpoint:
+
+      infracost configure set pricing_api_endpoint https://cloud-pricing-api
+
+  Get your saved pricing API endpoint:
+
+      infracost configure get pricing_api_endpoint`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(configureSetCmd(cfg), configureGetCmd(cfg))
+
+	return cmd
+}
+
+func configureSetCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a global configuration value",
+		Long: fmt.Sprintf(`Set a global configuration value. An empty value unsets the key.
+
+Supported keys: %s`, strings.Join(config.ConfigurationKeys(), ", ")),
+		Example: `      infracost configure set currency EUR`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cfg.Configuration.Set(args[0], args[1])
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			err = cfg.Configuration.Save()
+			if err != nil {
+				return err
+			}
+
+			ui.PrintSuccessf("Saved %s to %s", args[0], config.ConfigurationFilePath())
+
+			return nil
+		},
+	}
+}
+
+func configureGetCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Get a global configuration value",
+		Long: fmt.Sprintf(`Get a global configuration value.
+
+Supported keys: %s`, strings.Join(config.ConfigurationKeys(), ", ")),
+		Example: `      infracost configure get currency`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := cfg.Configuration.Get(args[0])
+			if err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
+			if value == "" {
+				ui.PrintWarningf("No value saved for %s in %s", args[0], config.ConfigurationFilePath())
+				return nil
+			}
+
+			fmt.Println(value)
+
+			return nil
+		},
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
@@ -11,7 +16,11 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "diff",
 		Short: "Show diff of monthly costs between current and planned state",
-		Long:  "Show diff of monthly costs between current and planned state",
+		Long: `Show diff of monthly costs between current and planned state
+
+If there are no cost changes a short message is shown and the command exits
+with code 2, or code 0 with --signal-direction. Use --always-comment to show
+the full diff and exit with code 0.`,
 		Example: `  Use Terraform directory with any required Terraform flags:
 
       infracost diff --path /path/to/code --terraform-plan-flags "-var-file=my.tfvars"
@@ -20,10 +29,27 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 
       terraform plan -out tfplan.binary
       terraform show -json tfplan.binary > plan.json
-      infracost diff --path plan.json`,
+      infracost diff --path plan.json
+
+  Show the cost impact of a provider upgrade by comparing plans generated with each provider version:
+
+      infracost diff --path plan-aws-v4.json --compare-to-plan plan-aws-v3.json
+
+  Diff a Terraform directory against its version on the main branch:
+
+      infracost diff --path /path/to/code --baseline-branch main
+
+  Fail the build if the monthly cost increases by more than 10% or $500:
+
+      infracost diff --path /path/to/code --threshold-percent 10 --threshold-absolute 500
+
+  Show the combined cost change of open PRs from the Infracost JSON of each one:
+
+      infracost diff --compare-to main.json --path pr1.json --path pr2.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
-				return err
+			if cmd.Flags().Changed("compare-to") {
+				compareTo, _ := cmd.Flags().GetString("compare-to")
+				return runMultiDiff(cmd, cfg, compareTo)
 			}
 
 			err := loadRunFlags(cfg, cmd)
@@ -31,28 +57,123 @@ func diffCmd(cfg *config.Config) *cobra.Command {
 				return err
 			}
 
+			// Offline runs with a pricing DB don't need an API key
+			if cfg.PricingDBPath == "" {
+				if err := checkAPIKey(cfg.APIKey, cfg.PricingAPIEndpoint, cfg.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
 			err = checkRunConfig(cfg)
 			if err != nil {
 				ui.PrintUsageErrorAndExit(cmd, err.Error())
 			}
 
+			if cmd.Flags().Changed("compare-to-plan") {
+				compareToPlan, _ := cmd.Flags().GetString("compare-to-plan")
+				cfg.CompareToPlan = resolvePath(cfg, compareToPlan)
+			}
+			cfg.BaselineBranch, _ = cmd.Flags().GetString("baseline-branch")
+
 			err = checkDiffConfig(cfg)
 			if err != nil {
 				ui.PrintUsageErrorAndExit(cmd, err.Error())
 			}
 
-			cfg.Format = "diff"
+			// Markdown shows the diff when the projects have one
+			if cfg.Format != "markdown" {
+				cfg.Format = "diff"
+			}
+			cfg.AlwaysComment, _ = cmd.Flags().GetBool("always-comment")
+			cfg.SignalDirection, _ = cmd.Flags().GetBool("signal-direction")
+			for _, name := range []string{"threshold-percent", "threshold-absolute"} {
+				if !cmd.Flags().Changed(name) {
+					continue
+				}
+
+				threshold, _ := cmd.Flags().GetFloat64(name)
+				if threshold < 0 {
+					ui.PrintUsageErrorAndExit(cmd, fmt.Sprintf("--%s must be 0 or more", name))
+				}
+
+				if name == "threshold-percent" {
+					cfg.ThresholdPercent = &threshold
+				} else {
+					cfg.ThresholdAbsolute = &threshold
+				}
+			}
+			cfg.CollapseByType, _ = cmd.Flags().GetBool("collapse-by-type")
+			cfg.FilterResourceTypes, _ = cmd.Flags().GetStringSlice("filter-resource-type")
+			cfg.DiffContext, _ = cmd.Flags().GetInt("diff-context")
+			if cfg.DiffContext < 0 {
+				ui.PrintUsageErrorAndExit(cmd, "--diff-context must be 0 or more")
+			}
+
+			for _, projectCfg := range cfg.Projects {
+				projectCfg.CompareToPath = cfg.CompareToPlan
+			}
+
+			if cfg.BaselineBranch != "" {
+				worktrees := newBaselineWorktrees(cfg.BaselineBranch)
+				defer worktrees.remove()
+
+				// Ctrl-C cancels the run instead of exiting, so the worktrees
+				// are still removed
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+				defer stop()
+
+				for _, projectCfg := range cfg.Projects {
+					projectCfg.CompareToPath, err = worktrees.path(projectCfg.Path)
+					if err != nil {
+						return err
+					}
+				}
+
+				return runUntilDone(ctx, func() error {
+					return runMain(cmd, cfg)
+				})
+			}
 
 			return runMain(cmd, cfg)
 		},
 	}
 
+	// --path is repeated for the Infracost JSON of each PR with --compare-to
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to the Terraform directory or JSON/plan file. Repeat for the Infracost JSON file of each PR with --compare-to")
 	addRunFlags(cmd)
 
+	cmd.Flags().String("format", "diff", "Output format: diff, markdown")
+	cmd.Flags().String("markdown-style", "", "Style of the markdown output format: plain, or github for PR comments with a summary table, the breakdown\ncollapsed in a <details> block and emoji for cost changes. gitlab is an alias of github for MR comments. Defaults to plain")
+	cmd.Flags().Bool("always-comment", false, "Show the full diff even if there are no cost changes, instead of exiting with code 2")
+	cmd.Flags().Float64("threshold-percent", 0, "Exit with code 4 if the total monthly cost increases by more than this percent of the past monthly cost")
+	cmd.Flags().Float64("threshold-absolute", 0, "Exit with code 4 if the total monthly cost increases by more than this amount, e.g. 500. The amount is in the --currency\nif it's set. Can be used with --threshold-percent, exceeding either fails")
+	cmd.Flags().Bool("signal-direction", false, "Exit with code 10 if the total monthly cost increases, 11 if it decreases and 0 if it is unchanged,\nincluding when there are no cost changes. Policy violations and errors take precedence")
+	cmd.Flags().Bool("collapse-by-type", false, "Show the diff as a cost change rollup per resource type instead of per resource")
+	cmd.Flags().StringSlice("filter-resource-type", []string{}, "Comma separated list of resource types to show in the diff, e.g. aws_instance. Totals still include all resources")
+	cmd.Flags().String("compare-to-plan", "", "Path to a Terraform plan JSON file, plan file or directory to diff against instead of the prior state,\ne.g. a plan generated with an older provider version. Cost changes are attributed to provider version changes where possible")
+	cmd.Flags().String("baseline-branch", "", "Git branch to diff against, e.g. main. Its Terraform is checked out into a temp worktree and the projects are diffed against it")
+	cmd.Flags().String("compare-to", "", "Path to the Infracost JSON file of a baseline that each --path Infracost JSON file is diffed against, e.g. the\nmain branch and a file per open PR. Shows the change of each one, their combined change and the resources changed by more than one")
+	cmd.Flags().StringSlice("fields", []string{"monthlyCost"}, "Comma separated list of the changes to show for each resource: price,monthlyQuantity,unit,hourlyCost,monthlyCost or all.\nAliases: qty (monthlyQuantity), cost (monthlyCost), hourly (hourlyCost). unit adds the unit to the quantity and price changes")
+	cmd.Flags().Int("diff-context", 0, "Number of unchanged resources to show either side of each changed resource, ordered by address")
+
 	return cmd
 }
 
 func checkDiffConfig(cfg *config.Config) error {
+	if cfg.CompareToPlan != "" && len(cfg.Projects) > 1 {
+		return errors.New("--compare-to-plan can only be used with a single project")
+	}
+
+	if cfg.CompareToPlan != "" && cfg.BaselineBranch != "" {
+		return errors.New("--compare-to-plan and --baseline-branch cannot be used together")
+	}
+
+	for _, projectConfig := range cfg.Projects {
+		if cfg.BaselineBranch != "" && projectConfig.TerraformCloudRun != "" {
+			return errors.New("--baseline-branch cannot be used with a Terraform Cloud run")
+		}
+	}
+
 	for _, projectConfig := range cfg.Projects {
 		if projectConfig.TerraformUseState {
 			return errors.New("terraform_use_state cannot be used with `infracost diff` as the Terraform state only contains the current state")
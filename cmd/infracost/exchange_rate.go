@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type exchangeRateResponse struct {
+	Rates map[string]float64 `json:"rates"`
+}
+
+// fetchExchangeRate returns the amount of the currency for 1 USD from the
+// exchange rate API, e.g. https://api.frankfurter.app/latest?from=USD&to=EUR.
+func fetchExchangeRate(endpoint string, currency string) (float64, error) {
+	currency = strings.ToUpper(currency)
+
+	u := fmt.Sprintf("%s/latest?from=%s&to=%s", strings.TrimRight(endpoint, "/"), output.BaseCurrency, url.QueryEscape(currency))
+	log.Debugf("Fetching exchange rate: %s", u)
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error fetching exchange rate")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return 0, errors.Errorf("invalid response from exchange rate API: %s", resp.Status)
+	}
+
+	var r exchangeRateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return 0, errors.Wrap(err, "Error parsing exchange rate")
+	}
+
+	rate, ok := r.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, errors.Errorf("no exchange rate for %s", currency)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import "github.com/infracost/infracost/internal/output"
+
+// Exit codes returned by the CLI. Any error not listed here exits with exitCodeError.
+//
+// The cost increase and decrease codes are only used by `infracost diff
+// --signal-direction`, which exits with exitCodeOK if the total cost is
+// unchanged. Errors, policy violations and exceeded cost thresholds take
+// precedence over them.
+//
+// exitCodeNoCostChanges is used by `infracost diff` when there are no cost
+// changes and --always-comment isn't set. Errors and policy violations take
+// precedence over it, and --signal-direction replaces it with exitCodeOK.
+//
+// exitCodeThresholdExceeded is used by `infracost diff --threshold-percent` and
+// `--threshold-absolute` when the total cost increase exceeds either of them.
+const (
+	exitCodeOK                = 0
+	exitCodeError             = 1
+	exitCodeNoCostChanges     = 2
+	exitCodePolicyDenied      = 3
+	exitCodeThresholdExceeded = 4
+	exitCodeCostIncrease      = 10
+	exitCodeCostDecrease      = 11
+)
+
+// exitCodeErr is returned by commands that have finished successfully but
+// need the process to exit with a specific non-zero exit code.
+type exitCodeErr struct {
+	code int
+}
+
+func (e *exitCodeErr) Error() string {
+	return ""
+}
+
+// costDirectionErr returns the exit code error for the sign of the total
+// monthly cost change of the diff, or nil if it is unchanged.
+func costDirectionErr(r output.Root) error {
+	switch r.DiffTotalMonthlyCost().Sign() {
+	case 1:
+		return &exitCodeErr{exitCodeCostIncrease}
+	case -1:
+		return &exitCodeErr{exitCodeCostDecrease}
+	default:
+		return nil
+	}
+}
+
+// noCostChangesErr returns the exit code error of a diff without cost
+// changes. With --signal-direction the cost is unchanged so it exits with
+// exitCodeOK instead of exitCodeNoCostChanges.
+func noCostChangesErr(signalDirection bool) error {
+	if signalDirection {
+		return nil
+	}
+	return &exitCodeErr{exitCodeNoCostChanges}
+}
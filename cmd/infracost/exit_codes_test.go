@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoCostChangesErr(t *testing.T) {
+	assert.Equal(t, &exitCodeErr{exitCodeNoCostChanges}, noCostChangesErr(false))
+	assert.Nil(t, noCostChangesErr(true))
+}
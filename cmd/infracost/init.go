@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// initUsageFileName is the name of the usage file created in each project dir
+// by init --sync-usage-file.
+const initUsageFileName = "infracost-usage.yml"
+
+func initCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a config file for the Terraform directories of a repo",
+		Long: `Create a config file for the Terraform directories of a repo
+
+The directories under the current directory that have Terraform files are each
+added as a project. Hidden directories and directories named modules are
+skipped since they're usually caches or modules called by the projects.
+
+An existing config file is only overwritten after confirming it, otherwise it's
+kept and its projects are used to sync the usage files.`,
+		Example: `  Create infracost.yml for the current directory:
+
+      infracost init
+
+  Also create a usage file for each project with its usage-based resources:
+
+      infracost init --sync-usage-file`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile, _ := cmd.Flags().GetString("config-file")
+			syncUsageFile, _ := cmd.Flags().GetBool("sync-usage-file")
+
+			root := resolvePath(cfg, ".")
+			configFilePath := resolvePath(cfg, configFile)
+
+			projects, err := initProjects(cfg, root, configFilePath, syncUsageFile)
+			if err != nil {
+				return err
+			}
+
+			if syncUsageFile {
+				err = initUsageFiles(cfg, projects)
+				if err != nil {
+					return err
+				}
+			}
+
+			printInitNextSteps(configFile, syncUsageFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("config-file", "infracost.yml", "Path to the config file to create")
+	cmd.Flags().Bool("sync-usage-file", false, "Create or sync a usage file in each project directory with its usage-based resources (experimental)")
+
+	return cmd
+}
+
+// initProjects writes the config file with a project for each Terraform dir
+// under the root and returns its projects. If the config file exists and
+// overwriting it isn't confirmed, the projects of the existing file are
+// returned instead.
+func initProjects(cfg *config.Config, root string, configFilePath string, syncUsageFile bool) ([]*config.Project, error) {
+	if _, err := os.Stat(configFilePath); err == nil {
+		overwrite, err := promptInitOverwrite(configFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if !overwrite {
+			fmt.Fprintf(os.Stderr, "Keeping the existing config file %s\n", configFilePath)
+
+			cfgFile, err := config.LoadConfigFile(configFilePath)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, p := range cfgFile.Projects {
+				p.Path = resolvePath(cfg, p.Path)
+				p.UsageFile = resolvePath(cfg, p.UsageFile)
+			}
+
+			return cfgFile.Projects, nil
+		}
+	}
+
+	dirs, err := findTerraformDirs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("No Terraform directories found under %s", ui.DisplayPath(root))
+	}
+
+	projects := make([]*config.Project, 0, len(dirs))
+	for _, dir := range dirs {
+		p := &config.Project{Path: dir}
+		if syncUsageFile {
+			p.UsageFile = filepath.ToSlash(filepath.Join(dir, initUsageFileName))
+		}
+		projects = append(projects, p)
+	}
+
+	b, err := yaml.Marshal(config.ConfigFileSpec{
+		Version:  "0.1",
+		Projects: projects,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating the config file")
+	}
+
+	b = append([]byte("# Infracost config file, see https://infracost.io/config-file\n"), b...)
+
+	err = ioutil.WriteFile(configFilePath, b, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error writing the config file")
+	}
+
+	ui.PrintSuccessf("Created %s with %d projects", configFilePath, len(projects))
+
+	for _, p := range projects {
+		p.Path = resolvePath(cfg, p.Path)
+		p.UsageFile = resolvePath(cfg, p.UsageFile)
+	}
+
+	return projects, nil
+}
+
+// findTerraformDirs returns the dirs under the root that have Terraform files,
+// relative to the root. Hidden dirs such as .terraform, modules and
+// node_modules are skipped, and symlinked dirs aren't followed.
+func findTerraformDirs(root string) ([]string, error) {
+	dirs := make([]string, 0)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		if path != root && (strings.HasPrefix(name, ".") || name == "modules" || name == "node_modules") {
+			return filepath.SkipDir
+		}
+
+		if !terraform.IsTerraformDir(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding Terraform directories")
+	}
+
+	sort.Strings(dirs)
+
+	return dirs, nil
+}
+
+// initUsageFiles syncs the usage file of each project with its usage-based
+// resources, creating it if it doesn't exist. Existing usage files are only
+// synced after confirming it since they're rewritten.
+func initUsageFiles(cfg *config.Config, projects []*config.Project) error {
+	for _, projectCfg := range projects {
+		if projectCfg.UsageFile == "" {
+			continue
+		}
+
+		if _, err := os.Stat(projectCfg.UsageFile); err == nil {
+			sync, err := promptInitOverwrite(projectCfg.UsageFile)
+			if err != nil {
+				return err
+			}
+			if !sync {
+				fmt.Fprintf(os.Stderr, "Keeping the existing usage file %s\n", projectCfg.UsageFile)
+				continue
+			}
+		}
+
+		provider, err := providers.Detect(cfg, projectCfg)
+		if err != nil {
+			return errors.Wrapf(err, "Error detecting project %s", projectCfg.Path)
+		}
+
+		cfg.Environment.SetProjectEnvironment(provider.Type(), projectCfg)
+
+		u, err := usage.LoadFromFile(projectCfg.UsageFile, true)
+		if err != nil {
+			return err
+		}
+
+		project, err := provider.LoadResources(u)
+		if err != nil {
+			return err
+		}
+
+		err = usage.SyncUsageData(project, u, projectCfg.UsageFile)
+		if err != nil {
+			return err
+		}
+
+		ui.PrintSuccessf("Synced %s", projectCfg.UsageFile)
+	}
+
+	return nil
+}
+
+// promptInitOverwrite asks to overwrite the existing file. Files are never
+// overwritten if there's no terminal to ask.
+func promptInitOverwrite(path string) (bool, error) {
+	if !isInteractiveTerminal() {
+		ui.PrintWarningf("%s already exists, run infracost init in a terminal to overwrite it", path)
+		return false, nil
+	}
+
+	p := promptui.Prompt{
+		Label:     fmt.Sprintf("%s already exists, would you like to overwrite it", path),
+		IsConfirm: true,
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrAbort) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func printInitNextSteps(configFile string, syncUsageFile bool) {
+	msg := fmt.Sprintf("\n%s\n", ui.BoldString("Next steps:"))
+
+	if syncUsageFile {
+		msg += fmt.Sprintf("  Set the usage of the resources in each %s, see https://infracost.io/usage-file\n", initUsageFileName)
+	} else {
+		msg += fmt.Sprintf("  Add usage files with %s, see https://infracost.io/usage-file\n", ui.PrimaryString("infracost init --sync-usage-file"))
+	}
+
+	msg += fmt.Sprintf("  Run %s to see the cost breakdown\n", ui.PrimaryString(fmt.Sprintf("infracost breakdown --config-file %s", configFile)))
+	msg += fmt.Sprintf("  Run %s to see the cost diff\n", ui.PrimaryString(fmt.Sprintf("infracost diff --config-file %s", configFile)))
+
+	fmt.Fprint(os.Stderr, msg)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTerraformDirs(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		symlinks map[string]string
+		expected []string
+	}{
+		{
+			name:     "root",
+			files:    []string{"main.tf"},
+			expected: []string{"."},
+		},
+		{
+			name:     "nested",
+			files:    []string{"envs/prod/main.tf", "envs/dev/main.tf", "envs/README.md", "live/terragrunt.hcl"},
+			expected: []string{"envs/dev", "envs/prod", "live"},
+		},
+		{
+			name:     "excluded dirs",
+			files:    []string{"app/main.tf", "app/.terraform/modules/vpc/main.tf", ".github/main.tf", "modules/vpc/main.tf", "node_modules/cdk/main.tf"},
+			expected: []string{"app"},
+		},
+		{
+			name:     "symlinks aren't followed",
+			files:    []string{"shared/main.tf"},
+			symlinks: map[string]string{"app/shared": "../shared", "loop": "."},
+			expected: []string{"shared"},
+		},
+		{
+			name:     "no Terraform",
+			files:    []string{"README.md"},
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+
+			for _, f := range test.files {
+				path := filepath.Join(root, filepath.FromSlash(f))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, ioutil.WriteFile(path, []byte{}, 0600))
+			}
+
+			for link, target := range test.symlinks {
+				path := filepath.Join(root, filepath.FromSlash(link))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, os.Symlink(filepath.FromSlash(target), path))
+			}
+
+			dirs, err := findTerraformDirs(root)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, dirs)
+		})
+	}
+}
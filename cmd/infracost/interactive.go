@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+)
+
+// interactivePageSize is the number of rows shown at once, the rest are
+// scrolled to.
+const interactivePageSize = 20
+
+// interactiveItem is a pointer in the select items so rows with the same text
+// are still different items.
+type interactiveItem struct {
+	Line string
+}
+
+var interactiveTemplates = &promptui.SelectTemplates{
+	Label:    "{{ . }}",
+	Active:   `{{ "›" | cyan }} {{ .Line | cyan }}`,
+	Inactive: "  {{ .Line }}",
+	Selected: "",
+}
+
+// isInteractiveTerminal returns true if both stdin and stdout are a terminal,
+// so output isn't piped anywhere else.
+func isInteractiveTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// runInteractive lets the user explore the breakdown until they quit. Enter
+// expands or collapses the selected project, module or resource and / searches
+// the rows.
+func runInteractive(out output.Root) error {
+	e := output.NewExplorer(out)
+	cursor, scroll := 0, 0
+
+	for {
+		rows := e.Rows()
+
+		sortAction := "[Sort by name]"
+		if !e.SortByCost() {
+			sortAction = "[Sort by cost]"
+		}
+
+		filterAction := "[Filter by type: all]"
+		if e.ResourceType() != "" {
+			filterAction = fmt.Sprintf("[Filter by type: %s]", e.ResourceType())
+		}
+
+		actions := []string{sortAction, filterAction, "[Quit]"}
+
+		items := make([]*interactiveItem, 0, len(actions)+len(rows))
+		for _, a := range actions {
+			items = append(items, &interactiveItem{Line: a})
+		}
+		for _, l := range output.FormatExploreRows(rows, out.TargetCurrency) {
+			items = append(items, &interactiveItem{Line: l})
+		}
+
+		s := promptui.Select{
+			Label:        e.Title(),
+			Items:        items,
+			Size:         interactivePageSize,
+			Templates:    interactiveTemplates,
+			HideSelected: true,
+			Searcher:     interactiveSearcher(items),
+		}
+
+		i, _, err := s.RunCursorAt(cursor, scroll)
+		if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "Error running interactive mode")
+		}
+
+		cursor, scroll = i, s.ScrollPosition()
+
+		switch i {
+		case 0:
+			e.ToggleSort()
+		case 1:
+			resourceType, err := promptForResourceType(e.ResourceTypes())
+			if err != nil {
+				return err
+			}
+			e.SetResourceType(resourceType)
+			cursor, scroll = 0, 0
+		case 2:
+			return nil
+		default:
+			if row := rows[i-len(actions)]; row.HasChildren {
+				e.Toggle(row.Key)
+			}
+		}
+	}
+}
+
+// promptForResourceType returns the selected resource type, or an empty string
+// to show all resource types.
+func promptForResourceType(resourceTypes []string) (string, error) {
+	items := make([]*interactiveItem, 0, len(resourceTypes)+1)
+	items = append(items, &interactiveItem{Line: "All resource types"})
+	for _, t := range resourceTypes {
+		items = append(items, &interactiveItem{Line: t})
+	}
+
+	s := promptui.Select{
+		Label:        "Filter by resource type",
+		Items:        items,
+		Size:         interactivePageSize,
+		Templates:    interactiveTemplates,
+		HideSelected: true,
+		Searcher:     interactiveSearcher(items),
+	}
+
+	i, _, err := s.Run()
+	if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "Error running interactive mode")
+	}
+	if i == 0 {
+		return "", nil
+	}
+
+	return resourceTypes[i-1], nil
+}
+
+func interactiveSearcher(items []*interactiveItem) func(string, int) bool {
+	return func(input string, index int) bool {
+		return strings.Contains(strings.ToLower(items[index].Line), strings.ToLower(strings.TrimSpace(input)))
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// runMultiDiff diffs the Infracost JSON of each PR against the shared baseline
+// and shows their individual and combined cost changes.
+func runMultiDiff(cmd *cobra.Command, cfg *config.Config, baselinePath string) error {
+	if cmd.Flags().Changed("config-file") || cmd.Flags().Changed("compare-to-plan") || cmd.Flags().Changed("baseline-branch") {
+		ui.PrintUsageErrorAndExit(cmd, "--compare-to cannot be used with --config-file, --compare-to-plan or --baseline-branch")
+	}
+
+	paths := pathFlagValues(cmd)
+	if len(paths) == 0 {
+		ui.PrintUsageErrorAndExit(cmd, "--compare-to needs a --path to the Infracost JSON file of each PR")
+	}
+
+	files, err := globPaths(cfg, paths)
+	if err != nil {
+		return err
+	}
+
+	baselinePath = resolvePath(cfg, baselinePath)
+	baseline, err := loadInfracostJSON(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	names := environmentNames(files)
+
+	prs := make([]output.ReportInput, 0, len(files))
+	for i, f := range files {
+		j, err := loadInfracostJSON(f)
+		if err != nil {
+			return err
+		}
+
+		prs = append(prs, output.ReportInput{
+			Metadata: map[string]string{
+				"name":     names[i],
+				"filename": f,
+			},
+			Root: j,
+		})
+	}
+
+	m := output.BuildMultiDiff(filepath.Base(baselinePath), baseline, prs)
+
+	b, err := output.ToMultiDiff(m, output.Options{NoColor: cfg.NoColor})
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(b))
+
+	return nil
+}
+
+// pathFlagValues returns the values of --path, which can only be repeated on
+// the diff command.
+func pathFlagValues(cmd *cobra.Command) []string {
+	if f := cmd.Flags().Lookup("path"); f != nil && f.Value.Type() == "stringArray" {
+		paths, _ := cmd.Flags().GetStringArray("path")
+		return paths
+	}
+
+	path, _ := cmd.Flags().GetString("path")
+	if path == "" {
+		return []string{}
+	}
+	return []string{path}
+}
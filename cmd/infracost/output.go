@@ -1,23 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/semver"
 )
 
 var minOutputVersion = "0.1"
-var maxOutputVersion = "0.1"
+var maxOutputVersion = "0.4"
 
 func outputCmd(cfg *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,9 +37,25 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 
       infracost output --format html --path out*.json > output.html
 
+  Create an HTML report with the breakdown, diff and metadata in tabs:
+
+      infracost output --format report --path out*.json > report.html
+
   Merge multiple Infracost JSON files:
 
-      infracost output --format json --path out*.json`,
+      infracost output --format json --path out*.json
+
+  Create a JUnit XML report where projects costing more than $1,000/month fail:
+
+      infracost output --format junit --threshold 1000 --path out*.json > infracost-junit.xml
+
+  Create a SARIF file for code scanning with resources costing more than $500/month:
+
+      infracost output --format sarif --resource-threshold 500 --path out*.json > infracost.sarif
+
+  Check the signature of an Infracost JSON file generated with --sign:
+
+      INFRACOST_SIGNING_KEY=... infracost output --verify-signature --path out.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inputFiles := []string{}
 
@@ -48,26 +69,44 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 				}
 
 				paths, _ := cmd.Flags().GetStringArray("path")
-				for _, path := range paths {
-					matches, _ := filepath.Glob(path)
-					inputFiles = append(inputFiles, matches...)
+				var err error
+				inputFiles, err = globPaths(cfg, paths)
+				if err != nil {
+					return err
 				}
 			}
 
+			sortFiles, _ := cmd.Flags().GetBool("sort-files")
+			inputFiles = orderInputFiles(inputFiles, sortFiles)
+
+			sign, _ := cmd.Flags().GetBool("sign")
+			verifySignature, _ := cmd.Flags().GetBool("verify-signature")
+			if (sign || verifySignature) && cfg.SigningKey == "" {
+				ui.PrintUsageErrorAndExit(cmd, "--sign and --verify-signature require a signing key, set it with INFRACOST_SIGNING_KEY or infracost configure set signing_key")
+			}
+
+			filterPaths, _ := cmd.Flags().GetStringArray("filter-path")
+			filterMatches := 0
+
+			pathFilters := schema.CompilePathFilters(filterPaths)
+
 			inputs := make([]output.ReportInput, 0, len(inputFiles))
 			for _, f := range inputFiles {
-				data, err := ioutil.ReadFile(f)
-				if err != nil {
-					return errors.Wrap(err, "Error reading JSON file")
+				if verifySignature {
+					if err := verifyInfracostJSONSignature(f, cfg.SigningKey); err != nil {
+						return err
+					}
 				}
 
-				j, err := output.Load(data)
+				j, err := loadInfracostJSON(f)
 				if err != nil {
-					return errors.Wrap(err, "Error parsing JSON file")
+					return err
 				}
 
-				if !checkOutputVersion(j.Version) {
-					return fmt.Errorf("Invalid Infracost JSON file version. Supported versions are %s ≤ x ≤ %s", minOutputVersion, maxOutputVersion)
+				if len(filterPaths) > 0 {
+					var matches int
+					j, matches = output.FilterByPath(j, pathFilters)
+					filterMatches += matches
 				}
 
 				inputs = append(inputs, output.ReportInput{
@@ -78,20 +117,24 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 				})
 			}
 
-			format, _ := cmd.Flags().GetString("format")
+			if len(filterPaths) > 0 && len(inputFiles) > 0 && filterMatches == 0 {
+				ui.PrintWarningf("No resources match --filter-path %s", strings.Join(filterPaths, ", "))
+			}
 
-			validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
+			format := cfg.Format
+			if cmd.Flags().Changed("format") || format == "" {
+				format, _ = cmd.Flags().GetString("format")
+			}
 
 			fields := []string{"monthlyQuantity", "unit", "monthlyCost"}
 			if cmd.Flags().Changed("fields") {
 				if c, _ := cmd.Flags().GetStringSlice("fields"); len(c) == 0 {
 					ui.PrintWarningf("fields is empty, using defaults: %s", cmd.Flag("fields").DefValue)
 				} else {
-					fields, _ = cmd.Flags().GetStringSlice("fields")
-					for _, f := range fields {
-						if !contains(validFields, f) {
-							ui.PrintWarningf("Invalid field '%s' specified, valid fields are: %s", f, validFields)
-						}
+					var err error
+					fields, err = resolveFields(c)
+					if err != nil {
+						ui.PrintUsageErrorAndExit(cmd, err.Error())
 					}
 				}
 			}
@@ -102,26 +145,216 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 				GroupLabel: "File",
 				Fields:     fields,
 			}
+			opts.Locale, _ = cmd.Flags().GetString("locale")
+			if err := output.ValidateLocale(opts.Locale); err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+
 			opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+			opts.SummaryOnly, _ = cmd.Flags().GetBool("summary-only")
+			opts.NoSummary, _ = cmd.Flags().GetBool("no-summary")
+			if opts.NoSummary && opts.SummaryOnly {
+				ui.PrintUsageErrorAndExit(cmd, "--no-summary and --summary-only cannot be used together")
+			}
+			if opts.NoSummary && format != "table" && format != "diff" && format != "markdown" {
+				ui.PrintWarning("no-summary is only supported for table, diff and markdown output formats.\n")
+			}
+			opts.WrapCells, _ = cmd.Flags().GetBool("wrap-cells")
+			opts.CostPeriod, _ = cmd.Flags().GetString("cost-period")
+			if err := checkCostPeriod(opts.CostPeriod, format); err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+			opts.SortKey, _ = cmd.Flags().GetString("sort")
+			if err := output.ValidateSortKey(opts.SortKey); err != nil {
+				ui.PrintUsageErrorAndExit(cmd, err.Error())
+			}
+			if opts.SortKey != "" && format != "table" && format != "diff" {
+				ui.PrintWarning("sort is only supported for table and diff output formats.\n")
+			}
+			opts.CollapseByType, _ = cmd.Flags().GetBool("collapse-by-type")
+			opts.FilterResourceTypes, _ = cmd.Flags().GetStringSlice("filter-resource-type")
+			opts.DiffContext, _ = cmd.Flags().GetInt("diff-context")
+			if opts.DiffContext < 0 {
+				ui.PrintUsageErrorAndExit(cmd, "--diff-context must be 0 or more")
+			}
+			if (opts.CollapseByType || len(opts.FilterResourceTypes) > 0 || opts.DiffContext > 0) && format != "diff" {
+				ui.PrintWarning("collapse-by-type, filter-resource-type and diff-context are only supported for diff output format.\n")
+			}
+
+			if cmd.Flags().Changed("project-growth") {
+				growth, _ := cmd.Flags().GetFloat64("project-growth")
+				if err := checkProjectGrowth(growth); err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+
+				if format != "json" {
+					ui.PrintWarning("project-growth is only supported for JSON output format.\n")
+				}
+
+				opts.ProjectGrowth = decimalPtr(decimal.NewFromFloat(growth))
+			}
+
+			if cmd.Flags().Changed("threshold") {
+				threshold, _ := cmd.Flags().GetFloat64("threshold")
+				if threshold < 0 {
+					ui.PrintUsageErrorAndExit(cmd, "--threshold must be 0 or more")
+				}
+
+				if format != "junit" {
+					ui.PrintWarning("threshold is only supported for junit output format.\n")
+				}
+
+				opts.Threshold = decimalPtr(decimal.NewFromFloat(threshold))
+			}
+
+			for _, name := range []string{"resource-threshold", "resource-error-threshold"} {
+				if !cmd.Flags().Changed(name) {
+					continue
+				}
+
+				threshold, _ := cmd.Flags().GetFloat64(name)
+				if threshold < 0 {
+					ui.PrintUsageErrorAndExit(cmd, fmt.Sprintf("--%s must be 0 or more", name))
+				}
+
+				if format != "sarif" {
+					ui.PrintWarningf("%s is only supported for sarif output format.\n", name)
+				}
+
+				if name == "resource-threshold" {
+					opts.ResourceThreshold = decimalPtr(decimal.NewFromFloat(threshold))
+				} else {
+					opts.ResourceErrorThreshold = decimalPtr(decimal.NewFromFloat(threshold))
+				}
+			}
+
+			if format == "sarif" && opts.ResourceThreshold == nil && opts.ResourceErrorThreshold == nil {
+				ui.PrintWarning("sarif output format has no results without --resource-threshold or --resource-error-threshold.\n")
+			}
+
+			if cmd.Flags().Changed("max-resource-depth") {
+				depth, _ := cmd.Flags().GetInt("max-resource-depth")
+				if err := checkMaxResourceDepth(depth, format); err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+				opts.MaxResourceDepth = &depth
+			}
+
+			opts.Dedupe, _ = cmd.Flags().GetString("dedupe")
+			if opts.Dedupe != "" {
+				if opts.Dedupe != output.DedupeFirstWins && opts.Dedupe != output.DedupeWarn {
+					ui.PrintUsageErrorAndExit(cmd, fmt.Sprintf("Invalid dedupe policy %s, it must be one of: %s, %s", opts.Dedupe, output.DedupeFirstWins, output.DedupeWarn))
+				}
+
+				warnDuplicateResources(output.FindDuplicateResources(inputs, opts.GroupKey), opts.Dedupe)
+			}
 
 			combined := output.Combine(inputs, opts)
+			// The costs of Infracost JSON generated with --currency are already converted
+			opts.Currency = combined.TargetCurrency
+
+			opts.Explain, _ = cmd.Flags().GetBool("explain")
+			if opts.Explain {
+				if format != "table" && format != "json" {
+					ui.PrintWarning("explain is only supported for table and JSON output formats.\n")
+				}
+
+				output.AddExplanations(&combined)
+			}
+
+			if strict, _ := cmd.Flags().GetBool("strict"); strict {
+				ignoreTypes, _ := cmd.Flags().GetStringSlice("strict-ignore-type")
+				if err := checkStrict(combined, ignoreTypes); err != nil {
+					return err
+				}
+			}
 
 			var (
 				b   []byte
 				err error
 			)
 
-			if cmd.Flags().Changed("fields") && format != "table" {
-				ui.PrintWarning("fields is only supported for table output format (HTML support coming soon)")
+			if sign && format != "json" {
+				ui.PrintWarning("sign is only supported for JSON output format.\n")
+			}
+
+			if cmd.Flags().Changed("fields") && format != "table" && format != "markdown" && format != "json" && format != "diff" {
+				ui.PrintWarning("fields is only supported for table, markdown, json and diff output formats (HTML support coming soon)")
 			}
 			switch strings.ToLower(format) {
 			case "json":
-				b, err = output.ToJSON(combined, opts)
-			case "html":
+				if cmd.Flags().Changed("fields") {
+					opts.JSONFields = fields
+				}
+				if sign {
+					b, err = signedJSON(combined, opts, cfg.SigningKey)
+					break
+				}
+				// Stream the JSON since it can be very large, the newline is printed below
+				err = output.WriteJSON(os.Stdout, combined, opts)
+			case "html", "report":
+				opts.Report = strings.ToLower(format) == "report"
+				templatePath, _ := cmd.Flags().GetString("html-template")
+				opts.HTMLTemplate, err = loadHTMLTemplate(templatePath)
+				if err != nil {
+					return err
+				}
+
 				b, err = output.ToHTML(combined, opts)
+			case "csv":
+				delimiter, _ := cmd.Flags().GetString("csv-delimiter")
+				opts.CSVDelimiter, err = output.ParseCSVDelimiter(delimiter)
+				if err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+
+				b, err = output.ToCSV(combined, opts)
+				// The final newline is printed below
+				b = bytes.TrimSuffix(b, []byte("\n"))
+			case "junit":
+				b, err = output.ToJUnit(combined, opts)
+			case "sarif":
+				opts.SARIFBaseDir = sarifBaseDir()
+				b, err = output.ToSARIF(combined, opts)
+			case "markdown":
+				opts.MarkdownStyle, _ = cmd.Flags().GetString("markdown-style")
+				if err := output.ValidateMarkdownStyle(opts.MarkdownStyle); err != nil {
+					ui.PrintUsageErrorAndExit(cmd, err.Error())
+				}
+				if opts.MarkdownStyle == "" {
+					opts.MarkdownStyle = output.MarkdownStylePlain
+				}
+				b, err = output.ToMarkdown(combined, opts)
+			case "template":
+				opts.TemplateName, _ = cmd.Flags().GetString("template-file")
+				if opts.TemplateName == "" {
+					ui.PrintUsageErrorAndExit(cmd, "--template-file is required for template output format")
+				}
+
+				opts.Template, err = loadTemplateFile(opts.TemplateName)
+				if err != nil {
+					return err
+				}
+
+				b, err = output.ToTemplate(combined, opts)
+				// The final newline is printed below
+				b = bytes.TrimSuffix(b, []byte("\n"))
 			case "diff":
+				if cmd.Flags().Changed("fields") {
+					opts.DiffFields = fields
+				}
 				b, err = output.ToDiff(combined, opts)
 			default:
+				interactive, _ := cmd.Flags().GetBool("interactive")
+				if interactive && isInteractiveTerminal() {
+					err = runInteractive(combined)
+					break
+				}
+
+				if interactive {
+					log.Info("Not running in a terminal, showing the table instead of the interactive UI")
+				}
+
 				b, err = output.ToTable(combined, opts)
 			}
 			if err != nil {
@@ -130,15 +363,59 @@ func outputCmd(cfg *config.Config) *cobra.Command {
 
 			fmt.Println(string(b))
 
+			if policyPath, _ := cmd.Flags().GetString("policy-path"); policyPath != "" {
+				input, err := policyInput(combined)
+				if err != nil {
+					return err
+				}
+
+				violations, err := policy.Evaluate(policyPath, input)
+				if err != nil {
+					return err
+				}
+
+				if len(violations) > 0 {
+					return reportPolicyViolations(violations)
+				}
+			}
+
 			return nil
 		},
 	}
 
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files")
-
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html")
+	cmd.Flags().Bool("sort-files", false, "Sort the Infracost JSON files by path instead of using the order of the --path flags, so the output doesn't depend on it")
+
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, report, markdown, csv, template, junit, sarif")
+	cmd.Flags().String("markdown-style", "", "Style of the markdown output format: plain, or github for PR comments with a summary table, the breakdown\ncollapsed in a <details> block and emoji for cost changes. gitlab is an alias of github for MR comments. Defaults to plain")
+	cmd.Flags().String("csv-delimiter", ",", "Field delimiter for csv output format, e.g. ; for European locales")
+	cmd.Flags().String("html-template", "", "Path to a Go template file used as the layout for html and report output formats")
+	cmd.Flags().String("template-file", "", "Path to a Go text/template file used by the template output format")
+	cmd.Flags().Bool("interactive", false, "Explore the table output in an interactive terminal UI. Falls back to the table if not run in a terminal")
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nOnly supported by table output format")
+	cmd.Flags().String("locale", "en-US", "Locale used for number formatting in table, diff and HTML output, e.g. de-DE")
+	cmd.Flags().Float64("project-growth", 0, "Monthly growth rate, e.g. 0.05 for 5%, used to add 3, 6 and 12 month cost projections to the JSON output.\nThis is a naive compound growth model, not a forecast of actual usage")
+	cmd.Flags().Bool("summary-only", false, "Only show the totals and resource counts, not the per-resource breakdown")
+	cmd.Flags().Bool("no-summary", false, "Only show the resource rows, not the totals and resource counts. Applicable to table, diff and markdown output formats")
+	cmd.Flags().String("cost-period", "", "Period of the overall total shown first, with the monthly total for reference: hourly, monthly, yearly.\nApplicable to table, diff and markdown output formats. Defaults to the hourly and monthly totals")
+	cmd.Flags().Bool("collapse-by-type", false, "Show the diff as a cost change rollup per resource type instead of per resource. Only supported by diff output format")
+	cmd.Flags().StringArray("filter-path", []string{}, "Only include the resources with addresses that match the glob, e.g. 'module.db.*', where * matches any characters.\nTotals only include them too. Repeat for more globs")
+	cmd.Flags().StringSlice("filter-resource-type", []string{}, "Comma separated list of resource types to show in the diff, e.g. aws_instance. Totals still include all resources")
+	cmd.Flags().Int("diff-context", 0, "Number of unchanged resources to show either side of each changed resource, ordered by address. Only supported by diff output format")
+	cmd.Flags().Bool("explain", false, "Show how each cost is calculated from its price and quantity. Supported by table and JSON output formats")
+	cmd.Flags().String("dedupe", "", fmt.Sprintf("Count resources with the same address and type in more than one file once (%s) or only warn about them (%s)", output.DedupeFirstWins, output.DedupeWarn))
+	cmd.Flags().String("policy-path", "", "Path to a Rego policy file or directory. The command fails if any data.infracost.deny rule matches")
+	cmd.Flags().Int("max-resource-depth", 0, "Collapse sub-resources nested deeper than this into their parent in table and html output. Costs still include them")
+	cmd.Flags().Bool("strict", false, "Fail if any resources are not supported yet. Free resources are always allowed")
+	cmd.Flags().StringSlice("strict-ignore-type", []string{}, "Comma separated list of unsupported resource types that don't fail --strict, e.g. aws_appsync_graphql_api")
+	cmd.Flags().Bool("wrap-cells", false, "Wrap long names and units over multiple lines in table output")
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: price,monthlyQuantity,unit,hourlyCost,monthlyCost or all.\nAliases: qty (monthlyQuantity), cost (monthlyCost), hourly (hourlyCost).\nOnly supported by table, markdown, json and diff output formats. Pruned JSON can't be used as the input of other commands, e.g. diffs")
+	cmd.Flags().String("sort", "", "Sort the resources of the table and diff output by: monthlyCost, name or path (the projects), prefix with - for descending order, e.g. -monthlyCost")
+	cmd.Flags().Float64("threshold", 0, "Monthly cost in dollars above which a project is a failed test case in junit output format")
+	cmd.Flags().Float64("resource-threshold", 0, "Monthly cost above which a resource is a warning in sarif output format, in the currency of the Infracost JSON")
+	cmd.Flags().Float64("resource-error-threshold", 0, "Monthly cost above which a resource is an error in sarif output format, in the currency of the Infracost JSON")
+	cmd.Flags().Bool("sign", false, "Add a signature field to the JSON output, an HMAC-SHA256 of the JSON using the INFRACOST_SIGNING_KEY environment variable or signing_key config value")
+	cmd.Flags().Bool("verify-signature", false, "Fail unless each Infracost JSON file has a signature that matches its contents and the signing key, see --sign")
 
 	return cmd
 }
@@ -192,11 +469,262 @@ func reportCmd(cfg *config.Config) *cobra.Command {
 	return cmd
 }
 
-func checkOutputVersion(v string) bool {
-	if !strings.HasPrefix(v, "v") {
-		v = "v" + v
+func warnDuplicateResources(duplicates []output.DuplicateResource, policy string) {
+	for _, d := range duplicates {
+		if policy == output.DedupeFirstWins {
+			ui.PrintWarningf("%s is in %s, only counting it in %s", d.Name, strings.Join(d.Inputs, ", "), d.Inputs[0])
+		} else {
+			ui.PrintWarningf("%s is in %s and is counted more than once", d.Name, strings.Join(d.Inputs, ", "))
+		}
+	}
+}
+
+// globPaths returns the files matching the --path glob patterns. It returns
+// an error if a pattern is invalid or doesn't match any files, since that's
+// usually a typo and the files would be silently left out otherwise.
+func globPaths(cfg *config.Config, paths []string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+	unmatched := make([]string, 0)
+
+	for _, path := range paths {
+		matches, err := filepath.Glob(resolvePath(cfg, path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid --path %s", path)
+		}
+
+		if len(matches) == 0 {
+			unmatched = append(unmatched, path)
+		}
+
+		files = append(files, matches...)
+	}
+
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("No files match --path %s", strings.Join(unmatched, ", "))
+	}
+
+	return files, nil
+}
+
+// uniqueFiles returns the files without duplicates, which happen when --path
+// globs overlap, keeping the first of each file so the order is unchanged.
+func uniqueFiles(files []string) []string {
+	seen := make(map[string]bool, len(files))
+	unique := make([]string, 0, len(files))
+
+	for _, f := range files {
+		key := filepath.Clean(f)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		unique = append(unique, f)
+	}
+
+	return unique
+}
+
+// orderInputFiles returns the unique files, sorted by path if sortFiles is set
+// so the output doesn't depend on the order of the --path flags.
+func orderInputFiles(files []string, sortFiles bool) []string {
+	files = uniqueFiles(files)
+	if sortFiles {
+		sort.Strings(files)
+	}
+
+	return files
+}
+
+// sarifBaseDir returns the root of the git repo of the working dir, so the
+// SARIF results can be shown on the files of the repo, or the working dir if
+// it isn't in a repo.
+func sarifBaseDir() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	if repoDir, err := runGit(wd, "rev-parse", "--show-toplevel"); err == nil {
+		return repoDir
+	}
+
+	return wd
+}
+
+// checkOutputVersion returns an error if the Infracost JSON version is older
+// or newer than the supported versions.
+func checkOutputVersion(v string) error {
+	sv := v
+	if !strings.HasPrefix(sv, "v") {
+		sv = "v" + sv
+	}
+
+	if semver.Compare(sv, "v"+maxOutputVersion) > 0 {
+		return fmt.Errorf("Infracost JSON file version %s is newer than the supported versions %s ≤ x ≤ %s, upgrade Infracost to use it", v, minOutputVersion, maxOutputVersion)
+	}
+
+	if !semver.IsValid(sv) || semver.Compare(sv, "v"+minOutputVersion) < 0 {
+		return fmt.Errorf("Infracost JSON file version %s is older than the supported versions %s ≤ x ≤ %s, regenerate it with this version of Infracost", v, minOutputVersion, maxOutputVersion)
+	}
+
+	return nil
+}
+
+// loadInfracostJSON reads an Infracost JSON file and checks it's a supported
+// version. Older supported versions are upconverted to the current version so
+// they can be used the same way, e.g. as the baseline of a diff.
+func loadInfracostJSON(path string) (output.Root, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return output.Root{}, errors.Wrap(err, "Error reading JSON file")
+	}
+
+	j, err := output.Load(data)
+	if err != nil {
+		return output.Root{}, errors.Wrap(err, "Error parsing JSON file")
+	}
+
+	if err := checkOutputVersion(j.Version); err != nil {
+		return output.Root{}, errors.Wrapf(err, "Invalid Infracost JSON file %s", path)
+	}
+
+	if j.Version != maxOutputVersion {
+		log.Debugf("Upconverting Infracost JSON file %s from version %s to %s", path, j.Version, maxOutputVersion)
+	}
+
+	if j.GroupBy != "" {
+		return output.Root{}, errors.New("Infracost JSON generated with --group-by can't be used as an input, generate it without --group-by")
+	}
+
+	return output.Upconvert(j), nil
+}
+
+// verifyInfracostJSONSignature returns an error if the Infracost JSON file
+// isn't signed with the key or was modified after it was signed.
+func verifyInfracostJSONSignature(path string, key string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "Error reading JSON file")
+	}
+
+	return errors.Wrapf(output.VerifyJSONSignature(data, key), "Invalid Infracost JSON file %s", path)
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+var validFields = []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
+
+// fieldAliases are the shorter names that can be used with --fields.
+var fieldAliases = map[string]string{
+	"qty":    "monthlyQuantity",
+	"cost":   "monthlyCost",
+	"hourly": "hourlyCost",
+}
+
+// resolveFields maps the --fields values to the valid field names. Fields are
+// matched case-insensitively, aliases are resolved and "all" selects every
+// valid field. An invalid field is an error suggesting the closest valid field
+// or alias.
+func resolveFields(fields []string) ([]string, error) {
+	resolved := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		name := strings.ToLower(strings.TrimSpace(f))
+
+		if name == "all" {
+			for _, v := range validFields {
+				resolved = appendField(resolved, v)
+			}
+			continue
+		}
+
+		if alias, ok := fieldAliases[name]; ok {
+			resolved = appendField(resolved, alias)
+			continue
+		}
+
+		valid := ""
+		for _, v := range validFields {
+			if strings.ToLower(v) == name {
+				valid = v
+				break
+			}
+		}
+
+		if valid == "" {
+			return nil, fmt.Errorf("Invalid field '%s' specified, did you mean '%s'? Valid fields are: %s", f, closestField(name), validFields)
+		}
+
+		resolved = appendField(resolved, valid)
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("No valid fields specified, valid fields are: %s", validFields)
+	}
+
+	return resolved, nil
+}
+
+// appendField adds the field unless it's already selected, e.g. by an alias.
+func appendField(fields []string, f string) []string {
+	if contains(fields, f) {
+		return fields
+	}
+	return append(fields, f)
+}
+
+// closestField returns the valid field or alias with the smallest edit
+// distance to the given name.
+func closestField(name string) string {
+	candidates := make([]string, 0, len(validFields)+len(fieldAliases))
+	candidates = append(candidates, validFields...)
+	for alias := range fieldAliases {
+		candidates = append(candidates, alias)
+	}
+	sort.Strings(candidates)
+
+	closest := ""
+	closestDistance := -1
+	for _, c := range candidates {
+		d := levenshteinDistance(name, strings.ToLower(c))
+		if closestDistance == -1 || d < closestDistance {
+			closest, closestDistance = c, d
+		}
+	}
+
+	return closest
+}
+
+func levenshteinDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
 	}
-	return semver.Compare(v, "v"+minOutputVersion) >= 0 && semver.Compare(v, "v"+maxOutputVersion) <= 0
+	return b
 }
 
 func contains(arr []string, e string) bool {
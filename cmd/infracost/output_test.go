@@ -0,0 +1,135 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputDedupeFlag(t *testing.T) {
+	cmd := outputCmd(&config.Config{})
+
+	require.NoError(t, cmd.ParseFlags([]string{"--dedupe", "warn", "--path", "out.json"}))
+
+	dedupe, err := cmd.Flags().GetString("dedupe")
+	require.NoError(t, err)
+	assert.Equal(t, output.DedupeWarn, dedupe)
+	assert.Empty(t, cmd.Flags().Args())
+
+	cmd = outputCmd(&config.Config{})
+	assert.Error(t, cmd.ParseFlags([]string{"--dedupe"}))
+}
+
+func TestResolveFields(t *testing.T) {
+	tests := []struct {
+		fields   []string
+		expected []string
+	}{
+		{[]string{"monthlyCost"}, []string{"monthlyCost"}},
+		{[]string{"MonthlyCost", " price "}, []string{"monthlyCost", "price"}},
+		{[]string{"qty", "cost", "hourly"}, []string{"monthlyQuantity", "monthlyCost", "hourlyCost"}},
+		{[]string{"cost", "monthlyCost"}, []string{"monthlyCost"}},
+		{[]string{"all"}, validFields},
+		{[]string{"unit", "ALL"}, []string{"unit", "price", "monthlyQuantity", "hourlyCost", "monthlyCost"}},
+	}
+
+	for _, test := range tests {
+		fields, err := resolveFields(test.fields)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, fields, test.fields)
+	}
+}
+
+func TestResolveFieldsInvalid(t *testing.T) {
+	fields, err := resolveFields([]string{"bogus"})
+	assert.Nil(t, fields)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid field 'bogus' specified")
+
+	// Valid fields don't let an invalid field through
+	fields, err = resolveFields([]string{"unit", "montlyCost"})
+	assert.Nil(t, fields)
+	assert.EqualError(t, err, "Invalid field 'montlyCost' specified, did you mean 'monthlyCost'? Valid fields are: [price monthlyQuantity unit hourlyCost monthlyCost]")
+
+	_, err = resolveFields([]string{""})
+	assert.Error(t, err)
+
+	fields, err = resolveFields([]string{"unit"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unit"}, fields)
+}
+
+func TestClosestField(t *testing.T) {
+	assert.Equal(t, "monthlyCost", closestField("montlycost"))
+	assert.Equal(t, "hourly", closestField("hourl"))
+	assert.Equal(t, "qty", closestField("qy"))
+	assert.Equal(t, "unit", closestField("units"))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"unit", "unit", 0},
+		{"unit", "units", 1},
+		{"price", "prize", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, levenshteinDistance(test.a, test.b), test.a+" "+test.b)
+		assert.Equal(t, test.expected, levenshteinDistance(test.b, test.a), test.b+" "+test.a)
+	}
+}
+
+func TestGlobPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.json"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600))
+	}
+
+	cfg := &config.Config{}
+
+	files, err := globPaths(cfg, []string{filepath.Join(dir, "*.json")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}, files)
+
+	missing := filepath.Join(dir, "missing", "*.json")
+	_, err = globPaths(cfg, []string{filepath.Join(dir, "a.json"), missing, filepath.Join(dir, "c.json")})
+	assert.EqualError(t, err, "No files match --path "+missing+", "+filepath.Join(dir, "c.json"))
+
+	_, err = globPaths(cfg, []string{filepath.Join(dir, "[")})
+	assert.Error(t, err)
+}
+
+func TestUniqueFiles(t *testing.T) {
+	files := []string{"b.json", "a.json", "./b.json", "dir/../a.json", "c.json"}
+	assert.Equal(t, []string{"b.json", "a.json", "c.json"}, uniqueFiles(files))
+	assert.Empty(t, uniqueFiles(nil))
+}
+
+func TestOrderInputFiles(t *testing.T) {
+	files := []string{"prod/b.json", "dev/a.json", "prod/b.json"}
+	assert.Equal(t, []string{"prod/b.json", "dev/a.json"}, orderInputFiles(files, false))
+	assert.Equal(t, []string{"dev/a.json", "prod/b.json"}, orderInputFiles(files, true))
+
+	// Overlapping globs only add each file once
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.json"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600))
+	}
+
+	globbed, err := globPaths(&config.Config{}, []string{filepath.Join(dir, "b.json"), filepath.Join(dir, "*.json")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "b.json"), filepath.Join(dir, "a.json")}, orderInputFiles(globbed, false))
+	assert.Equal(t, []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}, orderInputFiles(globbed, true))
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+)
+
+// policyInput returns the Infracost JSON used as the input document of the
+// policies. All resources are included even if the output is summary only.
+func policyInput(r output.Root) ([]byte, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating policy input")
+	}
+	return b, nil
+}
+
+func reportPolicyViolations(violations []policy.Violation) error {
+	noun := "violations"
+	if len(violations) == 1 {
+		noun = "violation"
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%s\n", ui.ErrorStringf("%d policy %s:", len(violations), noun))
+
+	for _, v := range violations {
+		if v.Resource != "" {
+			fmt.Fprintf(os.Stderr, "  %s %s\n", ui.BoldString(v.Resource+":"), v.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "  %s\n", v.Message)
+		}
+	}
+
+	return &exitCodeErr{exitCodePolicyDenied}
+}
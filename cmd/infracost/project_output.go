@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// writeProjectOutputs writes the output of each project that sets an
+// out_file in the config file, in the format of that project. The projects
+// are in the same order as the config file projects. The costs are converted
+// to the --currency if the format of the project supports it.
+func writeProjectOutputs(cfg *config.Config, projects []*schema.Project, opts output.Options) error {
+	for i, projectCfg := range cfg.Projects {
+		if projectCfg.OutFile == "" || i >= len(projects) {
+			continue
+		}
+
+		r := output.ToOutputFormat([]*schema.Project{projects[i]})
+
+		projectOpts := opts
+		projectOpts.Currency = ""
+		if cfg.CurrencyRate != nil && !strings.EqualFold(cfg.Currency, output.BaseCurrency) && supportsCurrency(projectCfg.Format) {
+			r = output.ConvertCurrency(r, cfg.Currency, decimal.NewFromFloat(*cfg.CurrencyRate))
+			projectOpts.Currency = r.TargetCurrency
+		}
+
+		b, err := formatProjectOutput(cfg, projectCfg.Format, r, projectOpts)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error generating output of project %s", projectCfg.Path))
+		}
+
+		err = ioutil.WriteFile(projectCfg.OutFile, b, 0600)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error writing output of project %s", projectCfg.Path))
+		}
+
+		log.Infof("Wrote %s output of %s to %s", projectCfg.Format, projectCfg.Path, projectCfg.OutFile)
+	}
+
+	return nil
+}
+
+func formatProjectOutput(cfg *config.Config, format string, r output.Root, opts output.Options) ([]byte, error) {
+	switch format {
+	case "json":
+		return output.ToJSON(r, opts)
+	case "html":
+		var err error
+		opts.HTMLTemplate, err = loadHTMLTemplate(cfg.HTMLTemplate)
+		if err != nil {
+			return nil, err
+		}
+		return output.ToHTML(r, opts)
+	case "csv":
+		opts.CSVDelimiter, _ = output.ParseCSVDelimiter(cfg.CSVDelimiter)
+		return output.ToCSV(r, opts)
+	case "markdown":
+		opts.MarkdownStyle = markdownStyle(cfg)
+		return output.ToMarkdown(r, opts)
+	case "diff":
+		return output.ToDiff(r, opts)
+	default:
+		return output.ToTable(r, opts)
+	}
+}
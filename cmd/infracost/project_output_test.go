@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestWriteProjectOutputsCurrency(t *testing.T) {
+	dir := t.TempDir()
+	rate := 0.5
+
+	cfg := &config.Config{
+		Currency:     "EUR",
+		CurrencyRate: &rate,
+		Projects: []*config.Project{
+			{Path: "a", Format: "json", OutFile: filepath.Join(dir, "a.json")},
+		},
+	}
+	projects := []*schema.Project{schema.NewProject("a", nil)}
+
+	require.NoError(t, writeProjectOutputs(cfg, projects, output.Options{}))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "a.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", gjson.GetBytes(b, "targetCurrency").String())
+	assert.Equal(t, "0.5", gjson.GetBytes(b, "exchangeRate").String())
+}
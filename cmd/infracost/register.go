@@ -176,8 +176,7 @@ func createAPIKey(endpoint string, name string, email string) (*createAPIKeyResp
 
 	config.AddNoAuthHeaders(req)
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	resp, err := config.HTTPClient().Do(req)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error sending API key request")
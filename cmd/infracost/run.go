@@ -2,74 +2,146 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/events"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
 	"github.com/infracost/infracost/internal/prices"
 	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/providers/terraform/aws"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// addRunFlags adds the flags of the commands that run the projects. Commands
+// can add their own --path first, e.g. diff repeats it for --compare-to.
 func addRunFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	if cmd.Flags().Lookup("path") == nil {
+		cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	}
 
 	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().String("capacity-basis", "", "Capacity to price autoscaling groups on if capacity_basis and instances aren't set in the usage file: desired, min, max. Defaults to desired")
+	cmd.Flags().String("usage-annotations", "", "Read '# infracost: key=value' usage comments above the resource blocks of a Terraform directory: off, override, fallback. With override they take precedence over the usage file, with fallback the usage file does")
 
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
-	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to select before planning, the previous workspace is selected again afterwards. Applicable when path is a Terraform directory")
+	cmd.Flags().StringArray("terraform-var-from", []string{}, "Terraform variable to read from Vault using VAULT_ADDR and VAULT_TOKEN, e.g. vault:secret/path#key=tfvar_name. Can be repeated")
+	cmd.Flags().String("terraform-cloud-run", "", "Terraform Cloud run ID to fetch the plan JSON from, e.g. run-CZcmD7eagjhyX0vN. Used instead of path")
+	cmd.Flags().String("terraform-cloud-host", "", "Terraform Cloud or Enterprise host, defaults to app.terraform.io")
+	cmd.Flags().String("terraform-cloud-token", "", "Terraform Cloud API token, defaults to INFRACOST_TERRAFORM_CLOUD_TOKEN or the terraform login credentials")
 
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
+	cmd.Flags().Bool("summary-only", false, "Only show the totals and resource counts, not the per-resource breakdown")
+	cmd.Flags().Bool("no-summary", false, "Only show the resource rows, not the totals and resource counts. Applicable to table, diff and markdown output formats")
+	cmd.Flags().Bool("only-changes", false, "Only include resources changed by the plan, ignoring tag-only changes such as provider default_tags")
+	cmd.Flags().String("cost-period", "", "Period of the overall total shown first, with the monthly total for reference: hourly, monthly, yearly.\nApplicable to table, diff and markdown output formats. Defaults to the hourly and monthly totals")
+
+	cmd.Flags().String("currency", "", "Currency to convert the costs to, e.g. EUR, using --currency-rate. Defaults to INFRACOST_CURRENCY or USD.\nSupported by json, table, diff, html, report and markdown output formats")
+	cmd.Flags().Float64("currency-rate", 0, "Exchange rate used by --currency, the amount of the currency for 1 USD, e.g. 0.92.\nDefaults to the latest rate from INFRACOST_EXCHANGE_RATE_API_ENDPOINT")
+
+	cmd.Flags().String("locale", "en-US", "Locale used for number formatting in table, diff and HTML output, e.g. de-DE")
+
+	cmd.Flags().String("policy-path", "", "Path to a Rego policy file or directory. The run fails if any data.infracost.deny rule matches")
+
+	cmd.Flags().Bool("strict", false, "Fail if any resources are not supported yet. Free resources are always allowed")
+	cmd.Flags().StringSlice("strict-ignore-type", []string{}, "Comma separated list of unsupported resource types that don't fail --strict, e.g. aws_appsync_graphql_api")
+
+	cmd.Flags().StringArray("region-mapping", []string{}, "Map a region alias to a cloud region, e.g. prod-east=us-east-1. Can be repeated")
+	cmd.Flags().String("default-region", "", "Region used for resources with an unknown region that is not mapped by --region-mapping. If not set their region is used as is, with a warning")
+
+	cmd.Flags().Duration("pricing-cache-ttl", 24*time.Hour, "How long pricing API results are cached on disk for, e.g. 1h. The cache dir can be set with INFRACOST_PRICING_CACHE_DIR")
+	cmd.Flags().Int("pricing-api-concurrency", 0, "Most concurrent requests to the pricing API, lower it if the API rate limits the run. Defaults to 4 per CPU, between 4 and 16")
+	cmd.Flags().Bool("no-cache", false, "Don't use or update the cache of pricing API results")
+	cmd.Flags().String("pricing-db-path", "", "Path to a pricing DB created with --pricing-db-out, used instead of the pricing API for offline runs.\nResources with prices that aren't in it are skipped")
+	cmd.Flags().String("pricing-db-out", "", "Path to save the pricing API results of the run to as a pricing DB for --pricing-db-path. Results are added to an existing file")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
 }
 
 func runMain(cmd *cobra.Command, cfg *config.Config) error {
+	_, err := runProjects(cmd, cfg)
+	return err
+}
+
+// runProjects prints the output of the projects and returns it, so watch mode
+// can compare it with the previous run.
+func runProjects(cmd *cobra.Command, cfg *config.Config) (output.Root, error) {
+	var r output.Root
 	projects := make([]*schema.Project, 0)
 
+	var baseline *output.Root
+	if cfg.CompareTo != "" {
+		b, err := loadInfracostJSON(cfg.CompareTo)
+		if err != nil {
+			return r, err
+		}
+
+		// The costs are compared in USD and converted to the --currency after
+		b, err = output.ConvertToBaseCurrency(b)
+		if err != nil {
+			return r, errors.Wrapf(err, "Error loading %s", cfg.CompareTo)
+		}
+		baseline = &b
+	}
+	baselineResources := make(map[*schema.Project][]*schema.Resource)
+
+	pathFilters := schema.CompilePathFilters(cfg.FilterPaths)
+
 	for _, projectCfg := range cfg.Projects {
 		provider, err := providers.Detect(cfg, projectCfg)
 
 		if err != nil {
 			m := fmt.Sprintf("%s\n\n", err)
 			m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
-			m += " - Terraform plan JSON file\n - Terraform directory\n - Terraform plan file"
+			m += " - Terraform plan JSON file\n - Terraform directory\n - Terraform plan file\n - Azure Resource Manager template JSON file"
 
 			if cmd.Name() != "diff" {
 				m += "\n - Terraform state JSON file"
 			}
 
-			return events.NewError(errors.New(m), "Could not detect path type")
+			return r, events.NewError(errors.New(m), "Could not detect path type")
 		}
 
 		if cmd.Name() == "diff" && provider.Type() == "terraform_state_json" {
 			m := "Cannot use Terraform state JSON with the infracost diff command.\n\n"
 			m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
 			m += " - Terraform plan JSON file\n - Terraform directory\n - Terraform plan file"
-			return events.NewError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
+			return r, events.NewError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
 		}
 
 		m := fmt.Sprintf("Detected %s at %s", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+		if projectCfg.TerraformCloudRun != "" {
+			m = fmt.Sprintf("Detected %s %s", provider.DisplayType(), projectCfg.TerraformCloudRun)
+		}
 		if cfg.IsLogging() {
 			log.Info(m)
 		} else {
 			fmt.Fprintln(os.Stderr, m)
 		}
 
+		if projectCfg.TerraformWorkspace != "" && (projectCfg.TerraformUseState || !isTerraformDirProvider(provider)) {
+			ui.PrintWarning("Ignoring terraform-workspace since it's only used when path is a Terraform directory without terraform-use-state.\n")
+		}
+
 		cfg.Environment.SetProjectEnvironment(provider.Type(), projectCfg)
 
 		u, err := usage.LoadFromFile(projectCfg.UsageFile, cfg.SyncUsageFile)
 		if err != nil {
-			return err
+			return r, err
 		}
 		if len(u) > 0 {
 			cfg.Environment.HasUsageFile = true
@@ -77,18 +149,49 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 
 		project, err := provider.LoadResources(u)
 		if err != nil {
-			return err
+			return r, err
+		}
+
+		if projectCfg.CompareToPath != "" {
+			err = loadComparePlan(cfg, projectCfg, project, u)
+			if err != nil {
+				return r, err
+			}
+		} else if !cfg.PlanMetadata {
+			project.PlanMetadata = nil
 		}
 
 		projects = append(projects, project)
 
+		if baseline != nil {
+			baselineResources[project] = make([]*schema.Resource, 0)
+			if p, ok := output.BaselineProject(*baseline, project, len(cfg.Projects)); ok {
+				baselineResources[project] = output.BaselineResources(p)
+				if len(cfg.FilterPaths) > 0 {
+					baselineResources[project] = schema.FilterResourcesByPath(baselineResources[project], pathFilters)
+				}
+			} else {
+				ui.PrintWarningf("No project in %s matches %s, so all its resources are shown as added", ui.DisplayPath(cfg.CompareTo), ui.DisplayPath(project.Path))
+			}
+		}
+
 		if cfg.SyncUsageFile {
 			err = usage.SyncUsageData(project, u, projectCfg.UsageFile)
 			if err != nil {
-				return err
+				return r, err
 			}
 		}
 
+		// The usage file is synced first so it keeps the usage of the resources
+		// that are filtered out
+		if len(cfg.FilterPaths) > 0 && project.FilterResourcesByPath(pathFilters) == 0 {
+			ui.PrintWarningf("No resources of %s match --filter-path %s", ui.DisplayPath(project.Path), strings.Join(cfg.FilterPaths, ", "))
+		}
+
+		if cfg.OnlyChanges {
+			project.RemoveUnchangedResources()
+		}
+
 		if !cfg.IsLogging() {
 			fmt.Fprintln(os.Stderr, "")
 		}
@@ -106,7 +209,7 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 			fmt.Fprintln(os.Stderr, "")
 
 			if e := unwrapped(err); errors.Is(e, prices.ErrInvalidAPIKey) {
-				return errors.New(fmt.Sprintf("%v\n%s %s %s %s %s\n%s",
+				return r, errors.New(fmt.Sprintf("%v\n%s %s %s %s %s\n%s",
 					e.Error(),
 					"Please check your",
 					ui.PrimaryString(config.CredentialsFilePath()),
@@ -118,24 +221,99 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 			}
 
 			if e, ok := err.(*prices.PricingAPIError); ok {
-				return errors.New(fmt.Sprintf("%v\n%s", e.Error(), "We have been notified of this issue."))
+				return r, errors.New(fmt.Sprintf("%v\n%s", e.Error(), "We have been notified of this issue."))
 			}
 
-			return err
+			return r, err
 		}
 
 		schema.CalculateCosts(project)
+
+		// The baseline resources already have their costs so they're only
+		// added after the prices of the project are populated
+		if baseline != nil {
+			project.PastResources = baselineResources[project]
+			project.HasDiff = true
+		}
+
 		project.CalculateDiff()
+
+		if cfg.SuggestAlternatives {
+			if err := prices.SuggestAlternatives(cfg, project); err != nil {
+				spinner.Fail()
+				return r, err
+			}
+		}
 	}
 
 	spinner.Success()
 
-	r := output.ToOutputFormat(projects)
+	r = output.ToOutputFormat(projects)
 
 	opts := output.Options{
 		ShowSkipped: cfg.ShowSkipped,
+		SummaryOnly: cfg.SummaryOnly,
+		NoSummary:   cfg.NoSummary,
 		NoColor:     cfg.NoColor,
 		Fields:      cfg.Fields,
+
+		CollapseByType:      cfg.CollapseByType,
+		DiffContext:         cfg.DiffContext,
+		FilterResourceTypes: cfg.FilterResourceTypes,
+		Explain:             cfg.Explain,
+		MaxResourceDepth:    cfg.MaxResourceDepth,
+		WrapCells:           cfg.WrapCells,
+		SortKey:             cfg.Sort,
+		CostPeriod:          cfg.CostPeriod,
+		Locale:              cfg.Locale,
+	}
+
+	if cmd.Flags().Changed("fields") {
+		opts.DiffFields = cfg.Fields
+	}
+
+	// The costs are converted before anything is written so the baseline and
+	// explanations are in the same currency as the output
+	if cfg.CurrencyRate != nil && !strings.EqualFold(cfg.Currency, output.BaseCurrency) && supportsCurrency(cfg.Format) {
+		r = output.ConvertCurrency(r, cfg.Currency, decimal.NewFromFloat(*cfg.CurrencyRate))
+		opts.Currency = r.TargetCurrency
+	}
+
+	if cfg.Explain {
+		output.AddExplanations(&r)
+	}
+
+	if cfg.ProjectGrowth != nil {
+		opts.ProjectGrowth = decimalPtr(decimal.NewFromFloat(*cfg.ProjectGrowth))
+	}
+
+	if cfg.BaselineOut != "" {
+		if err := writeBaseline(cfg.BaselineOut, r); err != nil {
+			return r, err
+		}
+	}
+
+	if err := writeProjectOutputs(cfg, projects, opts); err != nil {
+		return r, err
+	}
+
+	if cfg.Strict {
+		if err := checkStrict(r, cfg.StrictIgnoreTypes); err != nil {
+			return r, err
+		}
+	}
+
+	var violations []policy.Violation
+	if cfg.PolicyPath != "" {
+		input, err := policyInput(r)
+		if err != nil {
+			return r, err
+		}
+
+		violations, err = policy.Evaluate(cfg.PolicyPath, input)
+		if err != nil {
+			return r, err
+		}
 	}
 
 	var (
@@ -146,38 +324,210 @@ func runMain(cmd *cobra.Command, cfg *config.Config) error {
 
 	switch strings.ToLower(cfg.Format) {
 	case "json":
-		b, err = output.ToJSON(r, opts)
-		out = string(b)
-	case "html":
+		if cmd.Flags().Changed("fields") {
+			opts.JSONFields = cfg.Fields
+		}
+		opts.JSONGroupBy = cfg.GroupBy
+		if cfg.JSONFlat {
+			b, err = output.ToFlatJSON(r, opts)
+			out = string(b)
+			break
+		}
+		if cfg.Sign {
+			b, err = signedJSON(r, opts, cfg.SigningKey)
+			out = string(b)
+			break
+		}
+		// Stream the JSON since it can be very large, the newline is printed below
+		err = output.WriteJSON(os.Stdout, r, opts)
+	case "html", "report":
+		opts.Report = strings.ToLower(cfg.Format) == "report"
+		opts.HTMLTemplate, err = loadHTMLTemplate(cfg.HTMLTemplate)
+		if err != nil {
+			return r, err
+		}
+
 		b, err = output.ToHTML(r, opts)
 		out = string(b)
+	case "csv":
+		// The delimiter has already been validated by checkRunConfig
+		opts.CSVDelimiter, _ = output.ParseCSVDelimiter(cfg.CSVDelimiter)
+		b, err = output.ToCSV(r, opts)
+		// The final newline is printed below
+		out = strings.TrimSuffix(string(b), "\n")
+	case "markdown":
+		opts.MarkdownStyle = markdownStyle(cfg)
+		b, err = output.ToMarkdown(r, opts)
+		out = string(b)
+	case "template":
+		opts.TemplateName = cfg.TemplateFile
+		opts.Template, err = loadTemplateFile(cfg.TemplateFile)
+		if err != nil {
+			return r, err
+		}
+
+		b, err = output.ToTemplate(r, opts)
+		// The final newline is printed below
+		out = strings.TrimSuffix(string(b), "\n")
 	case "diff":
+		if !cfg.AlwaysComment && !r.HasCostChanges(cfg.FilterResourceTypes) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "\nNo cost changes detected. Use %s to show the full diff.\n", ui.PrimaryString("--always-comment"))
+			if len(violations) > 0 {
+				return r, reportPolicyViolations(violations)
+			}
+			return r, noCostChangesErr(cfg.SignalDirection)
+		}
+
 		b, err = output.ToDiff(r, opts)
 		out = fmt.Sprintf("\n%s", string(b))
 	case "table_deprecated":
 		b, err = output.ToTableDeprecated(r, opts)
 		out = fmt.Sprintf("\n%s", string(b))
 	default:
+		if cfg.Interactive && isInteractiveTerminal() {
+			err = runInteractive(r)
+			break
+		}
+
+		if cfg.Interactive {
+			log.Info("Not running in a terminal, showing the table instead of the interactive UI")
+		}
+
 		b, err = output.ToTable(r, opts)
 		out = fmt.Sprintf("\n%s", string(b))
 	}
 
 	if err != nil {
-		return errors.Wrap(err, "Error generating output")
+		return r, errors.Wrap(err, "Error generating output")
 	}
 
 	fmt.Printf("%s\n", out)
 
+	if len(violations) > 0 {
+		return r, reportPolicyViolations(violations)
+	}
+
+	// The absolute threshold is in the --currency, so it's checked against the
+	// converted costs
+	if cfg.ThresholdPercent != nil || cfg.ThresholdAbsolute != nil {
+		breaches := output.CheckCostThresholds(r, optionalDecimal(cfg.ThresholdPercent), optionalDecimal(cfg.ThresholdAbsolute))
+		if len(breaches) > 0 {
+			return r, reportThresholdBreaches(breaches)
+		}
+	}
+
+	if cfg.SignalDirection {
+		return r, costDirectionErr(r)
+	}
+
+	return r, nil
+}
+
+// loadComparePlan replaces the past resources of the project with the
+// resources of the compare-to plan, so the diff is between the two plans. The
+// plan metadata of both is kept so the diff can show the provider versions.
+// With --baseline-branch, a project without Terraform on that branch is new,
+// so it has no past resources.
+func loadComparePlan(cfg *config.Config, projectCfg *config.Project, project *schema.Project, u map[string]*schema.UsageData) error {
+	if cfg.BaselineBranch != "" && !hasTerraform(projectCfg.CompareToPath) {
+		log.Infof("%s has no Terraform on %s, all of its resources are new", ui.DisplayPath(projectCfg.Path), cfg.BaselineBranch)
+		project.PastResources = []*schema.Resource{}
+		project.HasDiff = true
+		return nil
+	}
+
+	compareCfg := *projectCfg
+	compareCfg.Path = projectCfg.CompareToPath
+	compareCfg.TerraformCloudRun = ""
+
+	provider, err := providers.Detect(cfg, &compareCfg)
+	if err != nil {
+		return errors.Wrap(err, "Could not detect the path type to compare to")
+	}
+
+	if provider.Type() == "terraform_state_json" {
+		return errors.New("The path to compare to cannot be a Terraform state JSON file")
+	}
+
+	m := fmt.Sprintf("Detected %s at %s to compare to", provider.DisplayType(), ui.DisplayPath(compareCfg.Path))
+	if cfg.BaselineBranch != "" {
+		m = fmt.Sprintf("Detected %s on %s to compare to", provider.DisplayType(), cfg.BaselineBranch)
+	}
+	if cfg.IsLogging() {
+		log.Info(m)
+	} else {
+		fmt.Fprintln(os.Stderr, m)
+	}
+
+	pastProject, err := provider.LoadResources(u)
+	if err != nil {
+		return err
+	}
+
+	// The attribute changes are from the prior state of the plan, not the
+	// compare-to plan, so they can't be used as the reasons of the diff
+	for _, r := range project.Resources {
+		r.AttributeChanges = nil
+	}
+
+	project.PastResources = pastProject.Resources
+	project.PastPlanMetadata = pastProject.PlanMetadata
+	project.HasDiff = true
+
+	return nil
+}
+
+// writeBaseline writes the full Infracost JSON, without any summary-only or
+// projection options, so it can be loaded as the baseline of a later diff.
+func writeBaseline(path string, r output.Root) error {
+	b, err := output.ToJSON(r, output.Options{})
+	if err != nil {
+		return errors.Wrap(err, "Error generating baseline JSON")
+	}
+
+	err = ioutil.WriteFile(path, b, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error writing baseline file")
+	}
+
+	log.Infof("Wrote baseline to %s", path)
+
 	return nil
 }
 
+// isTerraformDirProvider returns true if the provider runs Terraform in a dir,
+// so the Terraform workspace can be selected.
+func isTerraformDirProvider(provider schema.Provider) bool {
+	return provider.Type() == "terraform_dir" || provider.Type() == "terragrunt_dir"
+}
+
+// markdownStyle returns the --markdown-style, which defaults to plain Markdown.
+func markdownStyle(cfg *config.Config) string {
+	if cfg.MarkdownStyle == "" {
+		return output.MarkdownStylePlain
+	}
+	return cfg.MarkdownStyle
+}
+
+// signedJSON returns the Infracost JSON with a signature so its consumers can
+// check it wasn't modified, see output.SignJSON.
+func signedJSON(r output.Root, opts output.Options, key string) ([]byte, error) {
+	b, err := output.ToJSON(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.SignJSON(b, key)
+}
+
 func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
-	hasPathFlag := cmd.Flags().Changed("path")
+	hasPathFlag := cmd.Flags().Changed("path") || cmd.Flags().Changed("terraform-cloud-run")
 	hasConfigFile := cmd.Flags().Changed("config-file")
 
 	if cmd.Name() != "infracost" && !hasPathFlag && !hasConfigFile {
 		m := fmt.Sprintf("No path specified\n\nUse the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
-		m += " - Terraform plan JSON file\n - Terraform directory\n - Terraform plan file\n - Terraform state JSON file"
+		m += " - Terraform plan JSON file\n - Terraform directory\n - Terraform plan file\n - Terraform state JSON file\n - Azure Resource Manager template JSON file"
+		m += fmt.Sprintf("\n\nTo use a Terraform Cloud run, use the %s flag instead", ui.PrimaryString("--terraform-cloud-run"))
 		m += "\n\nAlternatively, use --config-file to process multiple projects, see https://infracost.io/config-file"
 
 		ui.PrintUsageErrorAndExit(cmd, m)
@@ -185,23 +535,33 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 
 	hasProjectFlags := (hasPathFlag ||
 		cmd.Flags().Changed("usage-file") ||
+		cmd.Flags().Changed("usage-annotations") ||
 		cmd.Flags().Changed("terraform-plan-flags") ||
 		cmd.Flags().Changed("terraform-workspace") ||
-		cmd.Flags().Changed("terraform-use-state"))
+		cmd.Flags().Changed("terraform-var-from") ||
+		cmd.Flags().Changed("terraform-use-state") ||
+		cmd.Flags().Changed("terraform-cloud-host") ||
+		cmd.Flags().Changed("terraform-cloud-token"))
 
 	if hasConfigFile && hasProjectFlags {
 		m := "--config-file flag cannot be used with the following flags: "
-		m += "--path, --terraform-*, --usage-file"
+		m += "--path, --terraform-*, --usage-file, --usage-annotations"
 		ui.PrintUsageErrorAndExit(cmd, m)
 	}
 
 	if hasConfigFile {
 		cfgFilePath, _ := cmd.Flags().GetString("config-file")
-		err := cfg.LoadFromConfigFile(cfgFilePath)
+		err := cfg.LoadFromConfigFile(resolvePath(cfg, cfgFilePath))
 
 		if err != nil {
 			return err
 		}
+
+		for _, project := range cfg.Projects {
+			project.Path = resolvePath(cfg, project.Path)
+			project.UsageFile = resolvePath(cfg, project.UsageFile)
+			project.OutFile = resolvePath(cfg, project.OutFile)
+		}
 	}
 
 	projectCfg := &config.Project{}
@@ -220,31 +580,132 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	}
 
 	if hasProjectFlags {
-		projectCfg.Path, _ = cmd.Flags().GetString("path")
-		projectCfg.UsageFile, _ = cmd.Flags().GetString("usage-file")
+		paths := pathFlagValues(cmd)
+		if len(paths) > 1 {
+			ui.PrintUsageErrorAndExit(cmd, "--path can only be repeated with infracost diff --compare-to, use --config-file to run multiple projects")
+		}
+
+		path := ""
+		if len(paths) == 1 {
+			path = paths[0]
+		}
+		projectCfg.Path = resolvePath(cfg, path)
+		usageFile, _ := cmd.Flags().GetString("usage-file")
+		projectCfg.UsageFile = resolvePath(cfg, usageFile)
 		projectCfg.TerraformPlanFlags, _ = cmd.Flags().GetString("terraform-plan-flags")
 		projectCfg.TerraformWorkspace, _ = cmd.Flags().GetString("terraform-workspace")
+		projectCfg.TerraformVarsFrom, _ = cmd.Flags().GetStringArray("terraform-var-from")
 		projectCfg.TerraformUseState, _ = cmd.Flags().GetBool("terraform-use-state")
+		projectCfg.TerraformCloudRun, _ = cmd.Flags().GetString("terraform-cloud-run")
+
+		// These override INFRACOST_TERRAFORM_CLOUD_HOST and INFRACOST_TERRAFORM_CLOUD_TOKEN
+		if cmd.Flags().Changed("terraform-cloud-host") {
+			projectCfg.TerraformCloudHost, _ = cmd.Flags().GetString("terraform-cloud-host")
+		}
+		if cmd.Flags().Changed("terraform-cloud-token") {
+			projectCfg.TerraformCloudToken, _ = cmd.Flags().GetString("terraform-cloud-token")
+		}
+		if cmd.Flags().Changed("usage-annotations") {
+			projectCfg.UsageAnnotations, _ = cmd.Flags().GetString("usage-annotations")
+		}
 	}
 
-	cfg.Format, _ = cmd.Flags().GetString("format")
+	// The format can also be set with `infracost configure set format`
+	if cmd.Flags().Changed("format") {
+		cfg.Format, _ = cmd.Flags().GetString("format")
+	}
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+	cfg.SummaryOnly, _ = cmd.Flags().GetBool("summary-only")
+	cfg.NoSummary, _ = cmd.Flags().GetBool("no-summary")
+	cfg.OnlyChanges, _ = cmd.Flags().GetBool("only-changes")
+	cfg.Locale, _ = cmd.Flags().GetString("locale")
+
+	if cmd.Flags().Changed("html-template") {
+		cfg.HTMLTemplate, _ = cmd.Flags().GetString("html-template")
+	}
+	if cmd.Flags().Changed("template-file") {
+		cfg.TemplateFile, _ = cmd.Flags().GetString("template-file")
+	}
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
+	cfg.Explain, _ = cmd.Flags().GetBool("explain")
+	cfg.SuggestAlternatives, _ = cmd.Flags().GetBool("suggest-alternatives")
+	cfg.Interactive, _ = cmd.Flags().GetBool("interactive")
+	cfg.Watch, _ = cmd.Flags().GetBool("watch")
+	cfg.GroupBy, _ = cmd.Flags().GetString("group-by")
+	cfg.JSONFlat, _ = cmd.Flags().GetBool("json-flat")
+	cfg.PlanMetadata, _ = cmd.Flags().GetBool("plan-metadata")
+	cfg.PolicyPath, _ = cmd.Flags().GetString("policy-path")
+	cfg.BaselineOut, _ = cmd.Flags().GetString("baseline-out")
+	cfg.CSVDelimiter, _ = cmd.Flags().GetString("csv-delimiter")
+	cfg.WrapCells, _ = cmd.Flags().GetBool("wrap-cells")
+	cfg.Sign, _ = cmd.Flags().GetBool("sign")
+	cfg.Sort, _ = cmd.Flags().GetString("sort")
+	cfg.MarkdownStyle, _ = cmd.Flags().GetString("markdown-style")
+	cfg.CostPeriod, _ = cmd.Flags().GetString("cost-period")
+	cfg.CapacityBasis, _ = cmd.Flags().GetString("capacity-basis")
+	cfg.Strict, _ = cmd.Flags().GetBool("strict")
+	if cmd.Flags().Changed("pricing-cache-ttl") {
+		cfg.PricingCacheTTL, _ = cmd.Flags().GetDuration("pricing-cache-ttl")
+	}
+	if cmd.Flags().Changed("pricing-api-concurrency") {
+		cfg.PricingAPIConcurrency, _ = cmd.Flags().GetInt("pricing-api-concurrency")
+	}
+	if cmd.Flags().Changed("no-cache") {
+		cfg.NoPricingCache, _ = cmd.Flags().GetBool("no-cache")
+	}
+	if cmd.Flags().Changed("pricing-db-path") {
+		pricingDBPath, _ := cmd.Flags().GetString("pricing-db-path")
+		cfg.PricingDBPath = resolvePath(cfg, pricingDBPath)
+	}
+	if pricingDBOut, _ := cmd.Flags().GetString("pricing-db-out"); pricingDBOut != "" {
+		cfg.PricingDBOut = resolvePath(cfg, pricingDBOut)
+	}
+	cfg.StrictIgnoreTypes, _ = cmd.Flags().GetStringSlice("strict-ignore-type")
+	cfg.DefaultRegion, _ = cmd.Flags().GetString("default-region")
 
-	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
+	regionMapping, _ := cmd.Flags().GetStringArray("region-mapping")
+	if len(regionMapping) > 0 {
+		var err error
+		cfg.RegionMapping, err = terraform.ParseRegionMapping(regionMapping)
+		if err != nil {
+			ui.PrintUsageErrorAndExit(cmd, err.Error())
+		}
+	}
+
+	if cmd.Flags().Changed("max-resource-depth") {
+		depth, _ := cmd.Flags().GetInt("max-resource-depth")
+		cfg.MaxResourceDepth = &depth
+	}
+
+	if cmd.Flags().Changed("project-growth") {
+		growth, _ := cmd.Flags().GetFloat64("project-growth")
+		cfg.ProjectGrowth = &growth
+	}
+
+	if cmd.Flags().Changed("currency") {
+		currency, _ := cmd.Flags().GetString("currency")
+		cfg.Currency = strings.ToUpper(currency)
+	}
+
+	if cmd.Flags().Changed("currency-rate") {
+		rate, _ := cmd.Flags().GetFloat64("currency-rate")
+		cfg.CurrencyRate = &rate
+	}
 
 	if cmd.Flags().Changed("fields") {
 		if c, _ := cmd.Flags().GetStringSlice("fields"); len(c) == 0 {
 			ui.PrintWarningf("fields is empty, using defaults: %s", cmd.Flag("fields").DefValue)
-		} else if cfg.Fields != nil && cfg.Format != "table" {
-			ui.PrintWarning("fields is only supported for table output format (HTML support coming soon)")
 		} else {
-			cfg.Fields, _ = cmd.Flags().GetStringSlice("fields")
-			for _, f := range cfg.Fields {
-				if !contains(validFields, f) {
-					ui.PrintWarningf("Invalid field '%s' specified, valid fields are: %s", f, validFields)
-				}
+			if cfg.Format != "table" && cfg.Format != "markdown" && cfg.Format != "json" && cfg.Format != "diff" {
+				ui.PrintWarning("fields is only supported for table, markdown, json and diff output formats (HTML support coming soon)")
 			}
+
+			// Invalid fields are an error even if the format doesn't use them
+			resolved, err := resolveFields(c)
+			if err != nil {
+				return err
+			}
+			cfg.Fields = resolved
 		}
 	}
 
@@ -252,10 +713,148 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 }
 
 func checkRunConfig(cfg *config.Config) error {
+	if cfg.PricingAPIConcurrency < 0 {
+		return errors.New("--pricing-api-concurrency must be 0 or more")
+	}
+
+	if cfg.PricingDBPath != "" && cfg.PricingDBOut != "" {
+		return errors.New("--pricing-db-path and --pricing-db-out cannot be used together since offline runs don't get any prices from the pricing API")
+	}
+
 	if cfg.Format == "json" && cfg.ShowSkipped {
 		ui.PrintWarning("show-skipped is not needed with JSON output format as that always includes them.\n")
 	}
 
+	if cfg.NoSummary && cfg.SummaryOnly {
+		return errors.New("--no-summary and --summary-only cannot be used together")
+	}
+
+	if cfg.NoSummary && cfg.Format != "table" && cfg.Format != "diff" && cfg.Format != "markdown" {
+		ui.PrintWarning("no-summary is only supported for table, diff and markdown output formats.\n")
+	}
+
+	if cfg.Format == "csv" {
+		if _, err := output.ParseCSVDelimiter(cfg.CSVDelimiter); err != nil {
+			return err
+		}
+	}
+
+	if err := output.ValidateLocale(cfg.Locale); err != nil {
+		return err
+	}
+
+	if cfg.HTMLTemplate != "" && cfg.Format != "html" && cfg.Format != "report" {
+		ui.PrintWarning("html-template is only supported for html and report output formats.\n")
+	}
+
+	if cfg.Format == "template" && cfg.TemplateFile == "" {
+		return errors.New("--template-file is required for template output format")
+	}
+
+	if cfg.TemplateFile != "" && cfg.Format != "template" {
+		ui.PrintWarning("template-file is only supported for template output format.\n")
+	}
+
+	if err := output.ValidateJSONGroupBy(cfg.GroupBy); err != nil {
+		return err
+	}
+
+	if cfg.GroupBy != "" && cfg.Format != "json" {
+		ui.PrintWarning("group-by is only supported for JSON output format.\n")
+	}
+
+	if cfg.JSONFlat && cfg.GroupBy != "" {
+		return errors.New("--json-flat and --group-by cannot be used together")
+	}
+
+	if cfg.JSONFlat && cfg.Format != "json" {
+		ui.PrintWarning("json-flat is only supported for JSON output format.\n")
+	}
+
+	if err := output.ValidateMarkdownStyle(cfg.MarkdownStyle); err != nil {
+		return err
+	}
+
+	if err := checkCostPeriod(cfg.CostPeriod, cfg.Format); err != nil {
+		return err
+	}
+
+	if err := aws.ValidateCapacityBasis(cfg.CapacityBasis); err != nil {
+		return err
+	}
+
+	if cfg.MarkdownStyle != "" && cfg.Format != "markdown" {
+		ui.PrintWarning("markdown-style is only supported for markdown output format.\n")
+	}
+
+	if err := output.ValidateSortKey(cfg.Sort); err != nil {
+		return err
+	}
+
+	if cfg.Sort != "" && cfg.Format != "table" && cfg.Format != "diff" {
+		ui.PrintWarning("sort is only supported for table and diff output formats.\n")
+	}
+
+	if cfg.Sign {
+		if cfg.SigningKey == "" {
+			return errors.New("--sign requires a signing key, set it with INFRACOST_SIGNING_KEY or infracost configure set signing_key")
+		}
+
+		if cfg.JSONFlat {
+			return errors.New("--sign and --json-flat cannot be used together")
+		}
+
+		if cfg.Format != "json" {
+			ui.PrintWarning("sign is only supported for JSON output format.\n")
+		}
+	}
+
+	if cfg.PlanMetadata && cfg.Format != "json" {
+		ui.PrintWarning("plan-metadata is only supported for JSON output format.\n")
+	}
+
+	if cfg.Interactive && cfg.Format != "table" {
+		ui.PrintWarning("interactive is only supported for table output format.\n")
+	}
+
+	if cfg.Watch && cfg.Interactive {
+		return errors.New("--watch and --interactive cannot be used together")
+	}
+
+	if cfg.Explain && cfg.Format != "table" && cfg.Format != "json" {
+		ui.PrintWarning("explain is only supported for table and JSON output formats.\n")
+	}
+
+	if cfg.SuggestAlternatives && cfg.Format != "table" && cfg.Format != "json" {
+		ui.PrintWarning("suggest-alternatives is only supported for table and JSON output formats.\n")
+	}
+
+	if cfg.MaxResourceDepth != nil {
+		if err := checkMaxResourceDepth(*cfg.MaxResourceDepth, cfg.Format); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ProjectGrowth != nil {
+		if err := checkProjectGrowth(*cfg.ProjectGrowth); err != nil {
+			return err
+		}
+
+		if cfg.Format != "json" {
+			ui.PrintWarning("project-growth is only supported for JSON output format.\n")
+		}
+	}
+
+	if err := checkCurrency(cfg); err != nil {
+		return err
+	}
+
+	for _, project := range cfg.Projects {
+		if err := terraform.ValidateUsageAnnotations(project.UsageAnnotations); err != nil {
+			return err
+		}
+	}
+
 	if cfg.SyncUsageFile {
 		missingUsageFile := make([]string, 0)
 		for _, project := range cfg.Projects {
@@ -275,6 +874,107 @@ func checkRunConfig(cfg *config.Config) error {
 	return nil
 }
 
+func loadHTMLTemplate(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading HTML template file")
+	}
+
+	return string(b), nil
+}
+
+func loadTemplateFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading template file")
+	}
+
+	return string(b), nil
+}
+
+func checkProjectGrowth(growth float64) error {
+	g := decimal.NewFromFloat(growth)
+	if g.LessThan(output.MinProjectionGrowthRate) || g.GreaterThan(output.MaxProjectionGrowthRate) {
+		return fmt.Errorf("project-growth must be a monthly growth rate between %s and %s, e.g. 0.05 for 5%%", output.MinProjectionGrowthRate, output.MaxProjectionGrowthRate)
+	}
+
+	return nil
+}
+
+// checkCurrency validates the currency and its exchange rate. If no rate is
+// set it is fetched once, so --watch re-runs and all the projects use the same
+// rate. Without a rate the costs can't be converted so it falls back to USD.
+func checkCurrency(cfg *config.Config) error {
+	if err := config.ValidateCurrency(cfg.Currency); err != nil {
+		return err
+	}
+
+	if cfg.CurrencyRate != nil && *cfg.CurrencyRate <= 0 {
+		return errors.New("currency-rate must be more than 0")
+	}
+
+	if cfg.Currency == "" || strings.EqualFold(cfg.Currency, output.BaseCurrency) {
+		return nil
+	}
+
+	if cfg.CurrencyRate == nil {
+		rate, err := fetchExchangeRate(cfg.ExchangeRateAPIEndpoint, cfg.Currency)
+		if err != nil {
+			log.Debugf("Error fetching exchange rate: %s", err)
+			ui.PrintWarningf("No exchange rate for %s, set --currency-rate to convert the costs. Using %s.\n", cfg.Currency, output.BaseCurrency)
+			cfg.Currency = output.BaseCurrency
+			return nil
+		}
+
+		cfg.CurrencyRate = &rate
+	}
+
+	if !supportsCurrency(cfg.Format) {
+		ui.PrintWarning("currency is only supported for json, table, diff, html, report and markdown output formats.\n")
+	}
+
+	return nil
+}
+
+// supportsCurrency returns true if the costs of the output format can be
+// converted to another currency.
+func supportsCurrency(format string) bool {
+	switch strings.ToLower(format) {
+	case "", "json", "table", "diff", "html", "report", "markdown":
+		return true
+	}
+
+	return false
+}
+
+func checkCostPeriod(period string, format string) error {
+	if err := output.ValidateCostPeriod(period); err != nil {
+		return err
+	}
+
+	if period != "" && format != "table" && format != "diff" && format != "markdown" {
+		ui.PrintWarning("cost-period is only supported for table, diff and markdown output formats.\n")
+	}
+
+	return nil
+}
+
+func checkMaxResourceDepth(depth int, format string) error {
+	if depth < 0 {
+		return fmt.Errorf("max-resource-depth must be 0 or more, where 0 only shows top-level resources")
+	}
+
+	if format != "table" && format != "html" && format != "report" {
+		ui.PrintWarning("max-resource-depth is only supported for table and html output formats.\n")
+	}
+
+	return nil
+}
+
 func unwrapped(err error) error {
 	e := err
 	for errors.Unwrap(e) != nil {
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPathFlag(t *testing.T) {
+	cmd := breakdownCmd(&config.Config{})
+	require.NoError(t, cmd.ParseFlags([]string{"--path", "a", "--path", "b"}))
+	assert.Equal(t, []string{"b"}, pathFlagValues(cmd))
+
+	cmd = diffCmd(&config.Config{})
+	require.NoError(t, cmd.ParseFlags([]string{"--path", "a", "--path", "b"}))
+	assert.Equal(t, []string{"a", "b"}, pathFlagValues(cmd))
+}
+
+func TestCheckCurrencyFetchesRateOnce(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/latest", r.URL.Path)
+		assert.Equal(t, "USD", r.URL.Query().Get("from"))
+		assert.Equal(t, "EUR", r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"amount":1.0,"base":"USD","rates":{"EUR":0.92}}`)
+	}))
+	defer ts.Close()
+
+	cfg := &config.Config{Currency: "EUR", Format: "table", ExchangeRateAPIEndpoint: ts.URL}
+	require.NoError(t, checkCurrency(cfg))
+	require.NotNil(t, cfg.CurrencyRate)
+	assert.Equal(t, 0.92, *cfg.CurrencyRate)
+
+	require.NoError(t, checkCurrency(cfg))
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, "EUR", cfg.Currency)
+}
+
+func TestCheckCurrencyFallsBackToUSD(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	cfg := &config.Config{Currency: "EUR", Format: "table", ExchangeRateAPIEndpoint: ts.URL}
+	require.NoError(t, checkCurrency(cfg))
+	assert.Nil(t, cfg.CurrencyRate)
+	assert.Equal(t, "USD", cfg.Currency)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/pkg/errors"
+)
+
+// checkStrict returns an error listing the unsupported resource types, apart
+// from the ignored types. Free resources are not unsupported so never fail.
+func checkStrict(r output.Root, ignoreTypes []string) error {
+	if r.Summary == nil || r.Summary.UnsupportedResourceCounts == nil {
+		return nil
+	}
+
+	unsupported := make([]string, 0)
+	for t, c := range *r.Summary.UnsupportedResourceCounts {
+		if c == 0 || contains(ignoreTypes, t) {
+			continue
+		}
+
+		unsupported = append(unsupported, fmt.Sprintf("%d x %s", c, t))
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	sort.Strings(unsupported)
+
+	return errors.Errorf("Strict mode failed as these resource types are not supported yet:\n  %s\nUse --strict-ignore-type to ignore known unsupported types", strings.Join(unsupported, "\n  "))
+}
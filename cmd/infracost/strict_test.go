@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStrict(t *testing.T) {
+	r := output.Root{
+		Summary: &output.Summary{
+			UnsupportedResourceCounts: &map[string]int{
+				"aws_appsync_graphql_api": 2,
+				"aws_amplify_app":         1,
+				"aws_iam_role":            0,
+			},
+		},
+	}
+
+	err := checkStrict(r, nil)
+	assert.EqualError(t, err, "Strict mode failed as these resource types are not supported yet:\n  1 x aws_amplify_app\n  2 x aws_appsync_graphql_api\nUse --strict-ignore-type to ignore known unsupported types")
+
+	err = checkStrict(r, []string{"aws_amplify_app"})
+	assert.EqualError(t, err, "Strict mode failed as these resource types are not supported yet:\n  2 x aws_appsync_graphql_api\nUse --strict-ignore-type to ignore known unsupported types")
+
+	assert.NoError(t, checkStrict(r, []string{"aws_amplify_app", "aws_appsync_graphql_api"}))
+}
+
+func TestCheckStrictNoUnsupported(t *testing.T) {
+	assert.NoError(t, checkStrict(output.Root{}, nil))
+	assert.NoError(t, checkStrict(output.Root{Summary: &output.Summary{}}, nil))
+	assert.NoError(t, checkStrict(output.Root{Summary: &output.Summary{UnsupportedResourceCounts: &map[string]int{}}}, nil))
+}
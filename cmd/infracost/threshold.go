@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// reportThresholdBreaches prints the cost thresholds that the diff exceeds and
+// returns the exit code error for them.
+func reportThresholdBreaches(breaches []output.ThresholdBreach) error {
+	noun := "thresholds"
+	if len(breaches) == 1 {
+		noun = "threshold"
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%s\n", ui.ErrorStringf("Cost %s exceeded:", noun))
+
+	for _, b := range breaches {
+		fmt.Fprintf(os.Stderr, "  %s\n", b.Message)
+	}
+
+	return &exitCodeErr{exitCodeThresholdExceeded}
+}
+
+func optionalDecimal(f *float64) *decimal.Decimal {
+	if f == nil {
+		return nil
+	}
+
+	return decimalPtr(decimal.NewFromFloat(*f))
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// watchPollInterval is how often the watched files are checked for changes.
+	watchPollInterval = 500 * time.Millisecond
+	// watchDebounce is how long the files must be unchanged for before the
+	// breakdown is re-run, so saving several files at once only runs it once.
+	watchDebounce = time.Second
+)
+
+// runWatch runs the breakdown and re-runs it whenever the Terraform files of
+// the projects change, until it's interrupted with Ctrl-C.
+func runWatch(cmd *cobra.Command, cfg *config.Config) error {
+	if !isInteractiveTerminal() {
+		return errors.New("--watch can only be used in a terminal")
+	}
+
+	paths := make([]string, 0, len(cfg.Projects))
+	for _, projectCfg := range cfg.Projects {
+		if projectCfg.Path != "" {
+			paths = append(paths, projectCfg.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return errors.New("--watch requires a --path to watch")
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	var previousCost *decimal.Decimal
+	hasPrevious := false
+	files := watchedFiles(paths)
+
+	for {
+		r, err := runProjects(cmd, cfg)
+		if err != nil {
+			ui.PrintError(err.Error())
+		} else {
+			if hasPrevious {
+				fmt.Fprintf(os.Stderr, "%s %s\n", ui.BoldString("Monthly cost change:"), output.FormatCostChangeSummary(previousCost, r.TotalMonthlyCost, r.TargetCurrency))
+			} else {
+				fmt.Fprintf(os.Stderr, "%s %s\n", ui.BoldString("Monthly cost:"), output.FormatCostChangeSummary(nil, r.TotalMonthlyCost, r.TargetCurrency))
+			}
+
+			previousCost = r.TotalMonthlyCost
+			hasPrevious = true
+		}
+
+		fmt.Fprintf(os.Stderr, "\nWatching %s for changes, press Ctrl-C to exit\n", strings.Join(displayPaths(paths), ", "))
+
+		var changed bool
+		files, changed = waitForChanges(paths, files, interrupt)
+		if !changed {
+			fmt.Fprintln(os.Stderr, "")
+			return nil
+		}
+
+		fmt.Fprintln(os.Stderr, "\nChanges detected, re-running the breakdown")
+	}
+}
+
+// waitForChanges polls the files until they've changed and then stayed the
+// same for the debounce period. It returns the changed files, or false if it
+// was interrupted.
+func waitForChanges(paths []string, files map[string]time.Time, interrupt <-chan os.Signal) (map[string]time.Time, bool) {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+
+	for {
+		select {
+		case <-interrupt:
+			return files, false
+		case <-ticker.C:
+			current := watchedFiles(paths)
+			if !sameFiles(files, current) {
+				files = current
+				changedAt = time.Now()
+				continue
+			}
+
+			if !changedAt.IsZero() && time.Since(changedAt) >= watchDebounce {
+				return files, true
+			}
+		}
+	}
+}
+
+// watchedFiles returns the modification times of the Terraform files in the
+// paths, see terraform.PlanFileExtensions. Paths that are files, e.g. plan JSON files, are watched themselves.
+func watchedFiles(paths []string) map[string]time.Time {
+	files := make(map[string]time.Time)
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		if !info.IsDir() {
+			files[path] = info.ModTime()
+			continue
+		}
+
+		_ = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if info.IsDir() {
+				// Skip the .terraform dir since Terraform writes to it when
+				// generating the plan, and other hidden dirs such as .git
+				if p != path && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			// The same files as the plan cache, so a change that invalidates
+			// the cached plan always re-runs the breakdown
+			if terraform.IsPlanFile(p) {
+				files[p] = info.ModTime()
+			}
+
+			return nil
+		})
+	}
+
+	return files
+}
+
+func sameFiles(a map[string]time.Time, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for p, t := range a {
+		if u, ok := b[p]; !ok || !u.Equal(t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func displayPaths(paths []string) []string {
+	display := make([]string, 0, len(paths))
+	for _, p := range paths {
+		display = append(display, ui.DisplayPath(p))
+	}
+
+	return display
+}
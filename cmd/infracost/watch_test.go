@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.tf", "main.tf.json", "prod.tfvars", "prod.tfvars.json", "terragrunt.hcl", "README.md", ".terraform/modules/x.tf"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("x"), 0600))
+	}
+
+	plan := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, ioutil.WriteFile(plan, []byte("{}"), 0600))
+
+	files := watchedFiles([]string{dir, plan, filepath.Join(dir, "missing")})
+
+	names := make([]string, 0, len(files))
+	for p := range files {
+		names = append(names, p)
+	}
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "main.tf"),
+		filepath.Join(dir, "main.tf.json"),
+		filepath.Join(dir, "prod.tfvars"),
+		filepath.Join(dir, "prod.tfvars.json"),
+		filepath.Join(dir, "terragrunt.hcl"),
+		plan,
+	}, names)
+}
+
+func TestWaitForChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0600))
+
+	paths := []string{dir}
+	files := watchedFiles(paths)
+
+	interrupt := make(chan os.Signal, 1)
+	interrupt <- os.Interrupt
+	_, changed := waitForChanges(paths, files, interrupt)
+	assert.False(t, changed)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	current, changed := waitForChanges(paths, files, make(chan os.Signal))
+	assert.True(t, changed)
+	assert.True(t, current[path].Equal(later))
+}
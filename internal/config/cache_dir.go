@@ -0,0 +1,51 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CacheDir returns the dir that the state is cached in, or an empty string if
+// it can't be cached on disk. That's either because it's turned off with
+// --no-cache-dir or because the dir can't be written to, e.g. when the home
+// dir is read-only. It's resolved the first time it's needed, so nothing is
+// written until then.
+func (c *Config) CacheDir() string {
+	if c.NoCacheDir {
+		return ""
+	}
+
+	if !c.cacheDirResolved {
+		c.cacheDir = writableDir(userConfigDir())
+		c.cacheDirResolved = true
+	}
+
+	return c.cacheDir
+}
+
+// writableDir returns the dir, creating it if needed, or an empty string if it
+// can't be written to.
+func writableDir(dir string) string {
+	if dir == "" {
+		return ""
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err == nil {
+		var f *os.File
+		f, err = ioutil.TempFile(dir, ".write-check")
+		if err == nil {
+			f.Close()
+			err = os.Remove(f.Name())
+		}
+	}
+
+	if err != nil {
+		logrus.Debugf("Not caching to %s since it can't be written to: %s", dir, err)
+		return ""
+	}
+
+	return dir
+}
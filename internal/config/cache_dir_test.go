@@ -0,0 +1,38 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritableDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "infracost")
+	assert.Equal(t, dir, writableDir(dir))
+	assert.DirExists(t, dir)
+
+	// The write check file is removed
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	// A dir can't be created under a file
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, ioutil.WriteFile(file, []byte{}, 0600))
+	assert.Equal(t, "", writableDir(filepath.Join(file, "infracost")))
+
+	assert.Equal(t, "", writableDir(""))
+}
+
+func TestCacheDir(t *testing.T) {
+	dir := t.TempDir()
+
+	c := &Config{cacheDir: dir, cacheDirResolved: true}
+	assert.Equal(t, dir, c.CacheDir())
+
+	c.NoCacheDir = true
+	assert.Equal(t, "", c.CacheDir())
+}
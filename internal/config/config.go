@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -12,36 +13,115 @@ import (
 )
 
 type Project struct {
-	Path                string `yaml:"path,omitempty" ignored:"true"`
-	TerraformPlanFlags  string `yaml:"terraform_plan_flags,omitempty" ignored:"true"`
-	TerraformBinary     string `yaml:"terraform_binary,omitempty" envconfig:"INFRACOST_TERRAFORM_BINARY"`
-	TerraformWorkspace  string `yaml:"terraform_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_WORKSPACE"`
-	TerraformCloudHost  string `yaml:"terraform_cloud_host,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_HOST"`
-	TerraformCloudToken string `yaml:"terraform_cloud_token,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_TOKEN"`
-	UsageFile           string `yaml:"usage_file,omitempty" ignored:"true"`
-	TerraformUseState   bool   `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	Path                string   `yaml:"path,omitempty" ignored:"true"`
+	TerraformPlanFlags  string   `yaml:"terraform_plan_flags,omitempty" ignored:"true"`
+	TerraformBinary     string   `yaml:"terraform_binary,omitempty" envconfig:"INFRACOST_TERRAFORM_BINARY"`
+	TerraformWorkspace  string   `yaml:"terraform_workspace,omitempty" envconfig:"INFRACOST_TERRAFORM_WORKSPACE"`
+	TerraformCloudHost  string   `yaml:"terraform_cloud_host,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_HOST"`
+	TerraformCloudToken string   `yaml:"terraform_cloud_token,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_TOKEN"`
+	TerraformCloudRun   string   `yaml:"terraform_cloud_run,omitempty" ignored:"true"`
+	UsageFile           string   `yaml:"usage_file,omitempty" ignored:"true"`
+	TerraformUseState   bool     `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	TerraformVarsFrom   []string `yaml:"terraform_vars_from,omitempty" ignored:"true"`
+	UsageAnnotations    string   `yaml:"usage_annotations,omitempty" ignored:"true"`
+	// Format and OutFile write the output of just this project to a file, as
+	// well as the combined output of all the projects
+	Format  string `yaml:"format,omitempty" ignored:"true"`
+	OutFile string `yaml:"out_file,omitempty" ignored:"true"`
+	// CompareToPath is the plan or Terraform dir the project is diffed
+	// against, set by --compare-to-plan or --baseline-branch
+	CompareToPath string `yaml:"-" ignored:"true"`
 }
 
 type Config struct { // nolint:golint
-	Environment *Environment
-	State       *State
-	Credentials Credentials
+	Environment   *Environment
+	State         *State
+	Credentials   Credentials
+	Configuration *Configuration
 
 	Version         string `yaml:"version,omitempty" ignored:"true"`
 	LogLevel        string `yaml:"log_level,omitempty" envconfig:"INFRACOST_LOG_LEVEL"`
 	NoColor         bool   `yaml:"no_color,omitempty" envconfig:"INFRACOST_NO_COLOR"`
 	SkipUpdateCheck bool   `yaml:"skip_update_check,omitempty" envconfig:"INFRACOST_SKIP_UPDATE_CHECK"`
+	// NoCacheDir keeps the state in memory instead of caching it on disk
+	NoCacheDir bool `yaml:"no_cache_dir,omitempty" envconfig:"INFRACOST_NO_CACHE_DIR"`
 
 	APIKey                    string `envconfig:"INFRACOST_API_KEY"`
 	PricingAPIEndpoint        string `yaml:"pricing_api_endpoint,omitempty" envconfig:"INFRACOST_PRICING_API_ENDPOINT"`
 	DefaultPricingAPIEndpoint string `yaml:"default_pricing_api_endpoint,omitempty" envconfig:"INFRACOST_DEFAULT_PRICING_API_ENDPOINT"`
 	DashboardAPIEndpoint      string `yaml:"dashboard_api_endpoint,omitempty" envconfig:"INFRACOST_DASHBOARD_API_ENDPOINT"`
-
-	Projects      []*Project `yaml:"projects" ignored:"true"`
-	Format        string     `yaml:"format,omitempty" ignored:"true"`
-	ShowSkipped   bool       `yaml:"show_skipped,omitempty" ignored:"true"`
-	SyncUsageFile bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
-	Fields        []string   `yaml:"fields,omitempty" ignored:"true"`
+	Currency                  string `yaml:"currency,omitempty" envconfig:"INFRACOST_CURRENCY"`
+	Proxy                     string `yaml:"proxy,omitempty" envconfig:"INFRACOST_PROXY"`
+	CACert                    string `yaml:"ca_cert,omitempty" envconfig:"INFRACOST_CA_CERT"`
+	// ExchangeRateAPIEndpoint is where the exchange rate of the Currency is
+	// fetched from if no CurrencyRate is set
+	ExchangeRateAPIEndpoint string `yaml:"exchange_rate_api_endpoint,omitempty" envconfig:"INFRACOST_EXCHANGE_RATE_API_ENDPOINT"`
+	// PricingCacheDir is where pricing API results are cached, defaulting to
+	// a dir in the cache dir. They're cached for PricingCacheTTL.
+	PricingCacheDir string        `yaml:"pricing_cache_dir,omitempty" envconfig:"INFRACOST_PRICING_CACHE_DIR"`
+	PricingCacheTTL time.Duration `yaml:"pricing_cache_ttl,omitempty" envconfig:"INFRACOST_PRICING_CACHE_TTL"`
+	NoPricingCache  bool          `yaml:"no_pricing_cache,omitempty" envconfig:"INFRACOST_NO_PRICING_CACHE"`
+	// PricingAPIConcurrency is the most concurrent requests to the pricing API,
+	// defaulting to 4 per CPU between 4 and 16
+	PricingAPIConcurrency int `yaml:"pricing_api_concurrency,omitempty" envconfig:"INFRACOST_PRICING_API_CONCURRENCY"`
+	// PricingDBPath is the pricing DB used instead of the pricing API for
+	// offline runs, and PricingDBOut is where the pricing API results of the
+	// run are saved to create one
+	PricingDBPath string `yaml:"pricing_db_path,omitempty" envconfig:"INFRACOST_PRICING_DB_PATH"`
+	PricingDBOut  string `yaml:"pricing_db_out,omitempty" ignored:"true"`
+	// SigningKey is the HMAC key used to sign and verify Infracost JSON
+	SigningKey string `yaml:"signing_key,omitempty" envconfig:"INFRACOST_SIGNING_KEY"`
+
+	Projects            []*Project `yaml:"projects" ignored:"true"`
+	Format              string     `yaml:"format,omitempty" ignored:"true"`
+	ShowSkipped         bool       `yaml:"show_skipped,omitempty" ignored:"true"`
+	SummaryOnly         bool       `yaml:"summary_only,omitempty" ignored:"true"`
+	NoSummary           bool       `yaml:"no_summary,omitempty" ignored:"true"`
+	SyncUsageFile       bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
+	AlwaysComment       bool       `yaml:"always_comment,omitempty" ignored:"true"`
+	CompareToPlan       string     `yaml:"compare_to_plan,omitempty" ignored:"true"`
+	CompareTo           string     `yaml:"compare_to,omitempty" ignored:"true"`
+	BaselineBranch      string     `yaml:"baseline_branch,omitempty" ignored:"true"`
+	OnlyChanges         bool       `yaml:"only_changes,omitempty" ignored:"true"`
+	SignalDirection     bool       `yaml:"signal_direction,omitempty" ignored:"true"`
+	ProjectGrowth       *float64   `yaml:"project_growth,omitempty" ignored:"true"`
+	CurrencyRate        *float64   `yaml:"currency_rate,omitempty" ignored:"true"`
+	HTMLTemplate        string     `yaml:"html_template,omitempty" ignored:"true"`
+	TemplateFile        string     `yaml:"template_file,omitempty" ignored:"true"`
+	Locale              string     `yaml:"locale,omitempty" ignored:"true"`
+	CollapseByType      bool       `yaml:"collapse_by_type,omitempty" ignored:"true"`
+	DiffContext         int        `yaml:"diff_context,omitempty" ignored:"true"`
+	FilterResourceTypes []string   `yaml:"filter_resource_types,omitempty" ignored:"true"`
+	FilterPaths         []string   `yaml:"filter_paths,omitempty" ignored:"true"`
+	Explain             bool       `yaml:"explain,omitempty" ignored:"true"`
+	SuggestAlternatives bool       `yaml:"suggest_alternatives,omitempty" ignored:"true"`
+	Interactive         bool       `yaml:"interactive,omitempty" ignored:"true"`
+	Watch               bool       `yaml:"watch,omitempty" ignored:"true"`
+	PlanMetadata        bool       `yaml:"plan_metadata,omitempty" ignored:"true"`
+	PolicyPath          string     `yaml:"policy_path,omitempty" ignored:"true"`
+	BaselineOut         string     `yaml:"baseline_out,omitempty" ignored:"true"`
+	CSVDelimiter        string     `yaml:"csv_delimiter,omitempty" ignored:"true"`
+	Fields              []string   `yaml:"fields,omitempty" ignored:"true"`
+	GroupBy             string     `yaml:"group_by,omitempty" ignored:"true"`
+	JSONFlat            bool       `yaml:"json_flat,omitempty" ignored:"true"`
+	MaxResourceDepth    *int       `yaml:"max_resource_depth,omitempty" ignored:"true"`
+	PathBase            string     `yaml:"path_base,omitempty" ignored:"true"`
+	WrapCells           bool       `yaml:"wrap_cells,omitempty" ignored:"true"`
+	Sign                bool       `yaml:"sign,omitempty" ignored:"true"`
+	Sort                string     `yaml:"sort,omitempty" ignored:"true"`
+	MarkdownStyle       string     `yaml:"markdown_style,omitempty" ignored:"true"`
+	CostPeriod          string     `yaml:"cost_period,omitempty" ignored:"true"`
+	CapacityBasis       string     `yaml:"capacity_basis,omitempty" ignored:"true"`
+	ThresholdPercent    *float64   `yaml:"threshold_percent,omitempty" ignored:"true"`
+	ThresholdAbsolute   *float64   `yaml:"threshold_absolute,omitempty" ignored:"true"`
+	Strict              bool       `yaml:"strict,omitempty" ignored:"true"`
+	StrictIgnoreTypes   []string   `yaml:"strict_ignore_types,omitempty" ignored:"true"`
+
+	RegionMapping map[string]string `yaml:"region_mapping,omitempty" ignored:"true"`
+	DefaultRegion string            `yaml:"default_region,omitempty" ignored:"true"`
+
+	cacheDir         string
+	cacheDirResolved bool
 }
 
 func init() {
@@ -61,6 +141,9 @@ func DefaultConfig() *Config {
 		DefaultPricingAPIEndpoint: "https://pricing.api.infracost.io",
 		PricingAPIEndpoint:        "https://pricing.api.infracost.io",
 		DashboardAPIEndpoint:      "https://dashboard.api.infracost.io",
+		Currency:                  "USD",
+		ExchangeRateAPIEndpoint:   "https://api.frankfurter.app",
+		PricingCacheTTL:           24 * time.Hour,
 
 		Projects: []*Project{{}},
 
@@ -88,7 +171,12 @@ func (c *Config) LoadFromConfigFile(path string) error {
 }
 
 func (c *Config) LoadFromEnv() error {
-	err := c.loadEnvVars()
+	err := loadConfiguration(c)
+	if err != nil {
+		return err
+	}
+
+	err = c.loadEnvVars()
 	if err != nil {
 		return err
 	}
@@ -98,11 +186,10 @@ func (c *Config) LoadFromEnv() error {
 		return err
 	}
 
-	err = loadState(c)
+	err = c.ConfigureHTTPClient()
 	if err != nil {
-		logrus.Fatal(err)
+		return err
 	}
-	c.Environment.InstallID = c.State.InstallID
 
 	err = loadCredentials(c)
 	if err != nil {
@@ -112,6 +199,13 @@ func (c *Config) LoadFromEnv() error {
 	return nil
 }
 
+// LoadState loads the state from the cache dir. It's not part of LoadFromEnv
+// since the cache dir can be turned off with the --no-cache-dir flag.
+func (c *Config) LoadState() {
+	loadState(c)
+	c.Environment.InstallID = c.State.InstallID
+}
+
 func (c *Config) loadEnvVars() error {
 	err := envconfig.Process("", c)
 	if err != nil {
@@ -13,6 +13,10 @@ import (
 const minConfigFileVersion = "0.1"
 const maxConfigFileVersion = "0.1"
 
+// ProjectOutputFormats are the output formats a project can write to its
+// out_file.
+var ProjectOutputFormats = []string{"json", "table", "html", "csv", "markdown", "diff"}
+
 type ConfigFileSpec struct { // nolint:golint
 	Version  string     `yaml:"version"`
 	Projects []*Project `yaml:"projects" ignored:"true"`
@@ -39,9 +43,40 @@ func LoadConfigFile(path string) (ConfigFileSpec, error) {
 		return cfgFile, fmt.Errorf("Invalid config file version. Supported versions are %s ≤ x ≤ %s", minConfigFileVersion, maxConfigFileVersion)
 	}
 
+	for _, p := range cfgFile.Projects {
+		err = checkProjectOutput(p)
+		if err != nil {
+			return cfgFile, err
+		}
+	}
+
 	return cfgFile, nil
 }
 
+// checkProjectOutput checks the format and out_file of the project, so
+// unknown formats fail before the projects are run. The format defaults to
+// JSON if only the out_file is set.
+func checkProjectOutput(p *Project) error {
+	if p.Format == "" && p.OutFile == "" {
+		return nil
+	}
+
+	if p.OutFile == "" {
+		return fmt.Errorf("Project %s has a format but no out_file to write it to", p.Path)
+	}
+
+	if p.Format == "" {
+		p.Format = "json"
+	}
+
+	p.Format = strings.ToLower(p.Format)
+	if !contains(ProjectOutputFormats, p.Format) {
+		return fmt.Errorf("Invalid format %s for project %s. Supported formats are %s", p.Format, p.Path, strings.Join(ProjectOutputFormats, ", "))
+	}
+
+	return nil
+}
+
 func checkVersion(v string) bool {
 	if !strings.HasPrefix(v, "v") {
 		v = "v" + v
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Configuration is the persistent user configuration set with
+// `infracost configure set`. It has a lower precedence than env vars and flags.
+type Configuration struct {
+	APIKey             string `yaml:"api_key,omitempty"`
+	PricingAPIEndpoint string `yaml:"pricing_api_endpoint,omitempty"`
+	Currency           string `yaml:"currency,omitempty"`
+	Format             string `yaml:"format,omitempty"`
+	Proxy              string `yaml:"proxy,omitempty"`
+	CACert             string `yaml:"ca_cert,omitempty"`
+	SigningKey         string `yaml:"signing_key,omitempty"`
+}
+
+type configurationKey struct {
+	get      func(c *Configuration) string
+	set      func(c *Configuration, v string)
+	validate func(v string) error
+}
+
+var validConfigurationFormats = []string{"json", "table", "html", "report", "markdown", "csv"}
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ValidateCurrency returns an error unless the currency is empty or a 3 letter
+// currency code.
+func ValidateCurrency(v string) error {
+	if v != "" && !currencyRegex.MatchString(strings.ToUpper(v)) {
+		return errors.New("currency must be a 3 letter ISO 4217 currency code, e.g. EUR")
+	}
+	return nil
+}
+
+var configurationKeys = map[string]configurationKey{
+	"api_key": {
+		get: func(c *Configuration) string { return c.APIKey },
+		set: func(c *Configuration, v string) { c.APIKey = v },
+	},
+	"pricing_api_endpoint": {
+		get: func(c *Configuration) string { return c.PricingAPIEndpoint },
+		set: func(c *Configuration, v string) { c.PricingAPIEndpoint = strings.TrimRight(v, "/") },
+		validate: func(v string) error {
+			if v != "" && !strings.HasPrefix(v, "http://") && !strings.HasPrefix(v, "https://") {
+				return errors.New("pricing_api_endpoint must be an http or https URL")
+			}
+			return nil
+		},
+	},
+	"currency": {
+		get:      func(c *Configuration) string { return c.Currency },
+		set:      func(c *Configuration, v string) { c.Currency = strings.ToUpper(v) },
+		validate: ValidateCurrency,
+	},
+	"proxy": {
+		get: func(c *Configuration) string { return c.Proxy },
+		set: func(c *Configuration, v string) { c.Proxy = v },
+		validate: func(v string) error {
+			if v == "" {
+				return nil
+			}
+			_, err := ParseProxyURL(v)
+			return err
+		},
+	},
+	"ca_cert": {
+		get: func(c *Configuration) string { return c.CACert },
+		set: func(c *Configuration, v string) { c.CACert = v },
+		validate: func(v string) error {
+			if v != "" && !fileExists(v) {
+				return fmt.Errorf("CA certificate file %s does not exist", v)
+			}
+			return nil
+		},
+	},
+	"signing_key": {
+		get: func(c *Configuration) string { return c.SigningKey },
+		set: func(c *Configuration, v string) { c.SigningKey = v },
+	},
+	"format": {
+		get: func(c *Configuration) string { return c.Format },
+		set: func(c *Configuration, v string) { c.Format = strings.ToLower(v) },
+		validate: func(v string) error {
+			if v != "" && !contains(validConfigurationFormats, strings.ToLower(v)) {
+				return fmt.Errorf("format must be one of: %s", strings.Join(validConfigurationFormats, ", "))
+			}
+			return nil
+		},
+	},
+}
+
+// ConfigurationKeys returns the keys that can be set in the configuration file.
+func ConfigurationKeys() []string {
+	return []string{"api_key", "pricing_api_endpoint", "currency", "format", "proxy", "ca_cert", "signing_key"}
+}
+
+// Get returns the value of the given key or an error if the key is unknown.
+func (c *Configuration) Get(key string) (string, error) {
+	k, ok := configurationKeys[key]
+	if !ok {
+		return "", unknownConfigurationKeyErr(key)
+	}
+
+	return k.get(c), nil
+}
+
+// Set validates and sets the value of the given key. An empty value unsets the key.
+func (c *Configuration) Set(key string, value string) error {
+	k, ok := configurationKeys[key]
+	if !ok {
+		return unknownConfigurationKeyErr(key)
+	}
+
+	if k.validate != nil {
+		if err := k.validate(value); err != nil {
+			return err
+		}
+	}
+
+	k.set(c, value)
+
+	return nil
+}
+
+func (c *Configuration) Save() error {
+	return writeConfigurationFile(c)
+}
+
+func unknownConfigurationKeyErr(key string) error {
+	return fmt.Errorf("Unknown configuration key %s. Supported keys are: %s", key, strings.Join(ConfigurationKeys(), ", "))
+}
+
+// loadConfiguration reads the configuration file and applies its values to the
+// config. This is only done the first time so the values don't overwrite any
+// values that have since been set by flags.
+func loadConfiguration(cfg *Config) error {
+	if cfg.Configuration != nil {
+		return nil
+	}
+
+	var err error
+
+	cfg.Configuration, err = readConfigurationFileIfExists()
+	if err != nil {
+		return errors.New("Error parsing configuration YAML: " + strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+
+	if cfg.Configuration.APIKey != "" {
+		cfg.APIKey = cfg.Configuration.APIKey
+	}
+
+	if cfg.Configuration.PricingAPIEndpoint != "" {
+		cfg.PricingAPIEndpoint = cfg.Configuration.PricingAPIEndpoint
+	}
+
+	if cfg.Configuration.Currency != "" {
+		cfg.Currency = cfg.Configuration.Currency
+	}
+
+	if cfg.Configuration.Format != "" {
+		cfg.Format = cfg.Configuration.Format
+	}
+
+	if cfg.Configuration.Proxy != "" {
+		cfg.Proxy = cfg.Configuration.Proxy
+	}
+
+	if cfg.Configuration.CACert != "" {
+		cfg.CACert = cfg.Configuration.CACert
+	}
+
+	if cfg.Configuration.SigningKey != "" {
+		cfg.SigningKey = cfg.Configuration.SigningKey
+	}
+
+	return nil
+}
+
+func readConfigurationFileIfExists() (*Configuration, error) {
+	if !fileExists(ConfigurationFilePath()) {
+		return &Configuration{}, nil
+	}
+
+	data, err := ioutil.ReadFile(ConfigurationFilePath())
+	if err != nil {
+		return &Configuration{}, err
+	}
+
+	var c Configuration
+	err = yaml.Unmarshal(data, &c)
+
+	return &c, err
+}
+
+func writeConfigurationFile(c *Configuration) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(ConfigurationFilePath()), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(ConfigurationFilePath(), data, 0600)
+}
+
+func ConfigurationFilePath() string { // nolint:golint
+	return path.Join(userConfigDir(), "configuration.yml")
+}
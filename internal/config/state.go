@@ -7,43 +7,59 @@ import (
 	"path"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type State struct {
 	InstallID              string `json:"installId"`
 	LatestReleaseVersion   string `json:"latestReleaseVersion"`
 	LatestReleaseCheckedAt string `json:"latestReleaseCheckedAt"`
+
+	// path is the file the state is saved to, if it's empty the state is only
+	// kept in memory
+	path string
 }
 
-func loadState(cfg *Config) error {
-	var err error
+// loadState reads the state from the cache dir. If there's no cache dir, or
+// the state can't be read or saved, it's kept in memory for the run instead.
+func loadState(cfg *Config) {
+	cfg.State = &State{}
 
-	cfg.State, err = readStateFileIfExists()
-	if err != nil {
-		return err
+	dir := cfg.CacheDir()
+	if dir != "" {
+		s, err := readStateFileIfExists(path.Join(dir, ".state.json"))
+		if err != nil {
+			logrus.Debugf("Could not read the state file: %s", err)
+		} else {
+			cfg.State = s
+		}
+		cfg.State.path = path.Join(dir, ".state.json")
 	}
 
 	if cfg.State.InstallID == "" {
 		cfg.State.InstallID = uuid.New().String()
-		err = cfg.State.Save()
+		err := cfg.State.Save()
 		if err != nil {
-			return err
+			logrus.Debugf("Could not save the state file: %s", err)
 		}
 	}
-
-	return nil
 }
 
+// Save writes the state to its file, unless it's only kept in memory.
 func (s *State) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
 	return writeStateFile(s)
 }
 
-func readStateFileIfExists() (*State, error) {
-	if !fileExists(stateFilePath()) {
+func readStateFileIfExists(stateFilePath string) (*State, error) {
+	if !fileExists(stateFilePath) {
 		return &State{}, nil
 	}
 
-	data, err := ioutil.ReadFile(stateFilePath())
+	data, err := ioutil.ReadFile(stateFilePath)
 	if err != nil {
 		return &State{}, err
 	}
@@ -60,14 +76,10 @@ func writeStateFile(s *State) error {
 		return err
 	}
 
-	err = os.MkdirAll(path.Dir(stateFilePath()), 0700)
+	err = os.MkdirAll(path.Dir(s.path), 0700)
 	if err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile(stateFilePath(), data, 0600)
-}
-
-func stateFilePath() string {
-	return path.Join(userConfigDir(), ".state.json")
+	return ioutil.WriteFile(s.path, data, 0600)
 }
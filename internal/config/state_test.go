@@ -0,0 +1,47 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadState(t *testing.T) {
+	dir := t.TempDir()
+
+	c := &Config{cacheDir: dir, cacheDirResolved: true}
+	loadState(c)
+	assert.NotEmpty(t, c.State.InstallID)
+
+	// A new install ID is saved so it's the same on the next run
+	next := &Config{cacheDir: dir, cacheDirResolved: true}
+	loadState(next)
+	assert.Equal(t, c.State.InstallID, next.State.InstallID)
+
+	next.State.LatestReleaseVersion = "v0.9.0"
+	require.NoError(t, next.State.Save())
+
+	last := &Config{cacheDir: dir, cacheDirResolved: true}
+	loadState(last)
+	assert.Equal(t, "v0.9.0", last.State.LatestReleaseVersion)
+}
+
+func TestLoadStateInMemory(t *testing.T) {
+	dir := t.TempDir()
+
+	c := &Config{cacheDir: dir, cacheDirResolved: true, NoCacheDir: true}
+	loadState(c)
+	assert.NotEmpty(t, c.State.InstallID)
+	require.NoError(t, c.State.Save())
+	assert.NoFileExists(t, filepath.Join(dir, ".state.json"))
+
+	// A state file that can't be read is replaced by a new state
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".state.json"), []byte("{"), 0600))
+
+	c = &Config{cacheDir: dir, cacheDirResolved: true}
+	loadState(c)
+	assert.NotEmpty(t, c.State.InstallID)
+}
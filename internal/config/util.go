@@ -35,3 +35,13 @@ func fileExists(path string) bool {
 
 	return !info.IsDir()
 }
+
+func contains(arr []string, e string) bool {
+	for _, a := range arr {
+		if a == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -12,6 +12,11 @@ import (
 )
 
 func SendReport(cfg *config.Config, key string, data interface{}) {
+	// Offline runs with a pricing DB don't send any requests
+	if cfg.PricingDBPath != "" {
+		return
+	}
+
 	if cfg.PricingAPIEndpoint != cfg.DefaultPricingAPIEndpoint && config.IsFalsy(os.Getenv("INFRACOST_SELF_HOSTED_TELEMETRY")) {
 		return
 	}
@@ -36,8 +41,7 @@ func SendReport(cfg *config.Config, key string, data interface{}) {
 
 	config.AddAuthHeaders(cfg.APIKey, req)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := config.HTTPClient().Do(req)
 	if err != nil {
 		log.Debugf("Unable to send event: %v", err)
 		return
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// alternativesForBreakdown returns a line for each alternative suggested for
+// the resources in the breakdown, or an empty string if there are none.
+func alternativesForBreakdown(breakdown Breakdown, nf numberFormat) string {
+	lines := ""
+	for _, r := range breakdown.Resources {
+		for _, a := range r.Alternatives {
+			lines += fmt.Sprintf(" %s: %s\n", r.Name, formatAlternative(a, nf))
+		}
+	}
+
+	if lines == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s\n%s", ui.BoldString("Cheaper alternatives (heuristic, check the specs before switching):"), lines)
+}
+
+// formatAlternative returns the suggestion, e.g.
+// "m5.xlarge in us-east-1: consider m6i.xlarge (-8%, saves $11.68/mo)".
+func formatAlternative(a Alternative, nf numberFormat) string {
+	current := a.Current
+	if a.Region != "" {
+		current = fmt.Sprintf("%s in %s", a.Current, a.Region)
+	}
+
+	return fmt.Sprintf("%s: consider %s (-%s%%, saves %s/mo)", current, a.Suggested, a.SavingPercent.StringFixed(0), formatCost2DP(&a.MonthlySaving, nf))
+}
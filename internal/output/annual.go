@@ -0,0 +1,62 @@
+package output
+
+import "github.com/shopspring/decimal"
+
+var monthsPerYear = decimal.NewFromInt(12)
+
+// withAnnualCosts returns a copy of the output with the annual cost of each
+// resource and total set to 12 times its monthly cost. They're set just before
+// the JSON is written so they match the monthly costs, e.g. after the currency
+// is converted.
+func withAnnualCosts(out Root) Root {
+	out.Resources = annualResources(out.Resources)
+	out.TotalAnnualCost = annualCost(out.TotalMonthlyCost)
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		p.PastBreakdown = annualBreakdown(p.PastBreakdown)
+		p.Breakdown = annualBreakdown(p.Breakdown)
+		p.Diff = annualBreakdown(p.Diff)
+		projects = append(projects, p)
+	}
+	if out.Projects != nil {
+		out.Projects = projects
+	}
+
+	return out
+}
+
+func annualBreakdown(b *Breakdown) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	annual := *b
+	annual.Resources = annualResources(b.Resources)
+	annual.TotalAnnualCost = annualCost(b.TotalMonthlyCost)
+
+	return &annual
+}
+
+func annualResources(resources []Resource) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	annual := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		r.AnnualCost = annualCost(r.MonthlyCost)
+		r.SubResources = annualResources(r.SubResources)
+		annual = append(annual, r)
+	}
+
+	return annual
+}
+
+func annualCost(monthlyCost *decimal.Decimal) *decimal.Decimal {
+	if monthlyCost == nil {
+		return nil
+	}
+
+	return decimalPtr(monthlyCost.Mul(monthsPerYear))
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"path/filepath"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// BaselineProject returns the project of the baseline Infracost JSON with the
+// same path as the project. If both only have one project they're compared
+// whatever their paths, since the baseline is often generated from a
+// different checkout of the same code.
+func BaselineProject(baseline Root, project *schema.Project, projectCount int) (Project, bool) {
+	for _, p := range baseline.Projects {
+		if filepath.Clean(p.Path) == filepath.Clean(project.Path) {
+			return p, true
+		}
+	}
+
+	if len(baseline.Projects) == 1 && projectCount == 1 {
+		return baseline.Projects[0], true
+	}
+
+	return Project{}, false
+}
+
+// BaselineResources returns the resources of the baseline project with their
+// costs, so they can be used as the past resources of a diff. Their costs are
+// already calculated so they shouldn't be priced again.
+func BaselineResources(p Project) []*schema.Resource {
+	resources := make([]*schema.Resource, 0)
+	if p.Breakdown == nil {
+		return resources
+	}
+
+	for _, r := range p.Breakdown.Resources {
+		resources = append(resources, baselineResource(r))
+	}
+
+	return resources
+}
+
+func baselineResource(r Resource) *schema.Resource {
+	res := &schema.Resource{
+		Name:         r.Name,
+		Tags:         r.Tags,
+		Region:       r.Region,
+		HourlyCost:   r.HourlyCost,
+		MonthlyCost:  r.MonthlyCost,
+		ResourceHash: r.ResourceHash,
+	}
+
+	for _, c := range r.CostComponents {
+		cc := &schema.CostComponent{
+			Name:            c.Name,
+			Unit:            c.Unit,
+			UnitMultiplier:  1,
+			HourlyQuantity:  c.HourlyQuantity,
+			MonthlyQuantity: c.MonthlyQuantity,
+			HourlyCost:      c.HourlyCost,
+			MonthlyCost:     c.MonthlyCost,
+		}
+		cc.SetPrice(c.Price)
+		res.CostComponents = append(res.CostComponents, cc)
+	}
+
+	for _, s := range r.SubResources {
+		res.SubResources = append(res.SubResources, baselineResource(s))
+	}
+
+	return res
+}
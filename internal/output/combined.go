@@ -27,11 +27,37 @@ func Combine(inputs []ReportInput, opts Options) Root {
 	projects := make([]Project, 0)
 	summaries := make([]*Summary, 0, len(inputs))
 
+	dedupe := opts.Dedupe == DedupeFirstWins
+	seenResources := make(map[resourceKey]bool)
+	seenProjectResources := make(map[resourceKey]bool)
+	dedupedResources := make([]Resource, 0)
+
 	for _, input := range inputs {
+		inputProjects := input.Root.Projects
+		inputResources := input.Root.Resources
+
+		var removed []Resource
+
+		if dedupe {
+			inputProjects = make([]Project, 0, len(input.Root.Projects))
+			for _, p := range input.Root.Projects {
+				p.Breakdown = dedupeBreakdown(p.Breakdown, seenProjectResources)
+				inputProjects = append(inputProjects, p)
+			}
+			for _, p := range input.Root.Projects {
+				if p.Breakdown != nil {
+					markResourcesSeen(p.Breakdown.Resources, seenProjectResources)
+				}
+			}
 
-		projects = append(projects, input.Root.Projects...)
+			inputResources, removed = dedupeResources(input.Root.Resources, seenResources)
+			markResourcesSeen(inputResources, seenResources)
+			dedupedResources = append(dedupedResources, removed...)
+		}
+
+		projects = append(projects, inputProjects...)
 
-		for _, r := range input.Root.Resources {
+		for _, r := range inputResources {
 			for k, v := range input.Metadata {
 				if r.Metadata == nil {
 					r.Metadata = make(map[string]string)
@@ -57,21 +83,49 @@ func Combine(inputs []ReportInput, opts Options) Root {
 			totalMonthlyCost = decimalPtr(totalMonthlyCost.Add(*input.Root.TotalMonthlyCost))
 		}
 
+		for _, r := range removed {
+			totalHourlyCost = subtractDecimal(totalHourlyCost, r.HourlyCost)
+			totalMonthlyCost = subtractDecimal(totalMonthlyCost, r.MonthlyCost)
+		}
+
 		summaries = append(summaries, input.Root.Summary)
 	}
 
 	sortResources(combined.Resources, opts.GroupKey)
 
 	combined.Version = outputVersion
+	combined.BaseCurrency = BaseCurrency
+	combined.TargetCurrency, combined.ExchangeRate = combinedCurrency(inputs)
 	combined.Projects = projects
 	combined.TotalHourlyCost = totalHourlyCost
 	combined.TotalMonthlyCost = totalMonthlyCost
 	combined.TimeGenerated = time.Now()
 	combined.Summary = combinedResourceSummaries(summaries)
 
+	if dedupe {
+		removeFromSummary(combined.Summary, dedupedResources)
+	}
+
 	return combined
 }
 
+// combinedCurrency returns the currency and exchange rate of the inputs if
+// they were all converted with the same rate.
+func combinedCurrency(inputs []ReportInput) (string, *decimal.Decimal) {
+	if len(inputs) == 0 || inputs[0].Root.ExchangeRate == nil {
+		return "", nil
+	}
+
+	first := inputs[0].Root
+	for _, input := range inputs[1:] {
+		if input.Root.TargetCurrency != first.TargetCurrency || input.Root.ExchangeRate == nil || !input.Root.ExchangeRate.Equal(*first.ExchangeRate) {
+			return "", nil
+		}
+	}
+
+	return first.TargetCurrency, first.ExchangeRate
+}
+
 func combinedResourceSummaries(summaries []*Summary) *Summary {
 	combined := &Summary{}
 
@@ -113,6 +167,14 @@ func combineCounts(c1 *map[string]int, c2 *map[string]int) *map[string]int {
 	return &res
 }
 
+func subtractDecimal(a *decimal.Decimal, b *decimal.Decimal) *decimal.Decimal {
+	if a == nil || b == nil {
+		return a
+	}
+
+	return decimalPtr(a.Sub(*b))
+}
+
 func addIntPtrs(i1 *int, i2 *int) *int {
 	if i1 == nil && i2 == nil {
 		return nil
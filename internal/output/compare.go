@@ -0,0 +1,205 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"sort"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Comparison is a matrix of the monthly costs of the same resources, or
+// resource types, in different environments, e.g. dev, staging and prod.
+type Comparison struct {
+	RowLabel     string
+	Environments []string
+	Rows         []ComparisonRow
+	Totals       []*decimal.Decimal
+}
+
+// ComparisonRow has a cell for each environment. Cells of environments that
+// don't have the resource are nil.
+type ComparisonRow struct {
+	Name  string
+	Cells []*ComparisonCell
+}
+
+// ComparisonCell is the monthly cost of a resource in an environment. The cost
+// is nil if the resource only has usage-based costs that weren't estimated.
+type ComparisonCell struct {
+	MonthlyCost *decimal.Decimal
+}
+
+// BuildComparison compares the resources of each input, which is named by its
+// "environment" metadata. If byType is set the rows are resource types and
+// the costs of their resources are summed.
+func BuildComparison(inputs []ReportInput, byType bool) Comparison {
+	c := Comparison{
+		RowLabel:     "Resource",
+		Environments: make([]string, 0, len(inputs)),
+		Totals:       make([]*decimal.Decimal, 0, len(inputs)),
+	}
+	if byType {
+		c.RowLabel = "Resource type"
+	}
+
+	rowsByName := make(map[string]*ComparisonRow)
+
+	for i, input := range inputs {
+		c.Environments = append(c.Environments, input.Metadata["environment"])
+		c.Totals = append(c.Totals, input.Root.TotalMonthlyCost)
+
+		for _, r := range input.Root.Resources {
+			name := r.Name
+			if byType {
+				name = resourceTypeFromName(r.Name)
+			}
+
+			row, ok := rowsByName[name]
+			if !ok {
+				row = &ComparisonRow{Name: name, Cells: make([]*ComparisonCell, len(inputs))}
+				rowsByName[name] = row
+			}
+
+			if row.Cells[i] == nil {
+				row.Cells[i] = &ComparisonCell{MonthlyCost: r.MonthlyCost}
+			} else if r.MonthlyCost != nil {
+				row.Cells[i].MonthlyCost = addDecimals(row.Cells[i].MonthlyCost, r.MonthlyCost)
+			}
+		}
+	}
+
+	for _, row := range rowsByName {
+		c.Rows = append(c.Rows, *row)
+	}
+
+	sort.Slice(c.Rows, func(i, j int) bool {
+		return c.Rows[i].Name < c.Rows[j].Name
+	})
+
+	return c
+}
+
+// cellCost formats the cell, blank if the environment doesn't have the resource.
+func (c *ComparisonCell) cellCost(nf numberFormat) string {
+	if c == nil {
+		return ""
+	}
+	return formatCost2DP(c.MonthlyCost, nf)
+}
+
+// ToCompareTable renders the comparison with a column for each environment
+// and a total row.
+func ToCompareTable(c Comparison, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := table.Row{ui.UnderlineString(c.RowLabel)}
+	columns := []table.ColumnConfig{{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft}}
+	for i, env := range c.Environments {
+		headers = append(headers, ui.UnderlineString(env))
+		columns = append(columns, table.ColumnConfig{Number: i + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+	t.SetColumnConfigs(columns)
+	t.AppendHeader(headers)
+
+	t.AppendRow(table.Row{""})
+
+	for _, r := range c.Rows {
+		row := table.Row{r.Name}
+		for _, cell := range r.Cells {
+			row = append(row, cell.cellCost(nf))
+		}
+		t.AppendRow(row)
+	}
+
+	t.AppendRow(table.Row{""})
+
+	totalRow := table.Row{ui.BoldString("TOTAL")}
+	for _, total := range c.Totals {
+		totalRow = append(totalRow, formatCost2DP(total, nf))
+	}
+	t.AppendRow(totalRow)
+
+	return []byte(t.Render() + "\n"), nil
+}
+
+// ToCompareCSV renders the comparison with a column of monthly costs for each
+// environment. Cells of environments that don't have the resource are empty.
+func ToCompareCSV(c Comparison, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if opts.CSVDelimiter != 0 {
+		w.Comma = opts.CSVDelimiter
+	}
+
+	records := [][]string{append([]string{c.RowLabel}, c.Environments...)}
+
+	for _, r := range c.Rows {
+		record := []string{r.Name}
+		for _, cell := range r.Cells {
+			if cell == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, csvDecimal(cell.MonthlyCost))
+			}
+		}
+		records = append(records, record)
+	}
+
+	totalRecord := []string{"Total"}
+	for _, total := range c.Totals {
+		totalRecord = append(totalRecord, csvDecimal(total))
+	}
+	records = append(records, totalRecord)
+
+	if err := w.WriteAll(records); err != nil {
+		return []byte{}, errors.Wrap(err, "Error writing CSV")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ToCompareHTML renders the comparison using the styles of the HTML output.
+func ToCompareHTML(c Comparison, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	tmpl, err := newHTMLTemplate(nf)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	tmpl.Funcs(map[string]interface{}{
+		"cellCost": func(cell *ComparisonCell) string {
+			return cell.cellCost(nf)
+		},
+	})
+
+	tmpl, err = tmpl.New("layout").Parse(CompareHTMLTemplate)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var buf bytes.Buffer
+	bufw := bufio.NewWriter(&buf)
+
+	err = tmpl.Execute(bufw, c)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bufw.Flush()
+	return buf.Bytes(), nil
+}
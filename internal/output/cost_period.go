@@ -0,0 +1,79 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Periods of the overall totals set by --cost-period.
+const (
+	CostPeriodHourly  = "hourly"
+	CostPeriodMonthly = "monthly"
+	CostPeriodYearly  = "yearly"
+)
+
+// ValidateCostPeriod returns an error if the period isn't empty or one of the
+// cost periods.
+func ValidateCostPeriod(period string) error {
+	switch period {
+	case "", CostPeriodHourly, CostPeriodMonthly, CostPeriodYearly:
+		return nil
+	}
+
+	return fmt.Errorf("Invalid cost period %s, it must be one of: %s, %s, %s", period, CostPeriodHourly, CostPeriodMonthly, CostPeriodYearly)
+}
+
+// periodTotal is the overall total or cost change for a period. Prominent
+// totals are for the --cost-period, the others are shown for reference.
+type periodTotal struct {
+	Period    string
+	Cost      *decimal.Decimal
+	Prominent bool
+}
+
+// periodTotals returns the totals to show for the cost period. Without a
+// period the hourly and monthly totals are shown, otherwise the total for the
+// period is first followed by the monthly total, or the hourly total if the
+// period is monthly.
+func periodTotals(period string, hourly *decimal.Decimal, monthly *decimal.Decimal) []periodTotal {
+	switch period {
+	case CostPeriodHourly:
+		return []periodTotal{
+			{Period: CostPeriodHourly, Cost: hourly, Prominent: true},
+			{Period: CostPeriodMonthly, Cost: monthly},
+		}
+	case CostPeriodMonthly:
+		return []periodTotal{
+			{Period: CostPeriodMonthly, Cost: monthly, Prominent: true},
+			{Period: CostPeriodHourly, Cost: hourly},
+		}
+	case CostPeriodYearly:
+		var yearly *decimal.Decimal
+		if monthly != nil {
+			yearly = decimalPtr(monthly.Mul(decimal.NewFromInt(12)))
+		}
+
+		return []periodTotal{
+			{Period: CostPeriodYearly, Cost: yearly, Prominent: true},
+			{Period: CostPeriodMonthly, Cost: monthly},
+		}
+	}
+
+	return []periodTotal{
+		{Period: CostPeriodHourly, Cost: hourly, Prominent: true},
+		{Period: CostPeriodMonthly, Cost: monthly, Prominent: true},
+	}
+}
+
+// unit returns the unit of the period used in sentences, e.g. month.
+func (t periodTotal) unit() string {
+	switch t.Period {
+	case CostPeriodHourly:
+		return "hour"
+	case CostPeriodYearly:
+		return "year"
+	}
+
+	return "month"
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var csvHeaders = []string{"Project", "Resource", "Cost component", "Monthly quantity", "Unit", "Price", "Hourly cost", "Monthly cost"}
+
+// ToCSV returns a row for each cost component of the projects. Fields are
+// quoted and lines end with CRLF per RFC 4180 so resource names can contain
+// any characters.
+func ToCSV(out Root, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+	if opts.CSVDelimiter != 0 {
+		w.Comma = opts.CSVDelimiter
+	}
+
+	err := w.Write(csvHeaders)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error writing CSV")
+	}
+
+	var writeRows func(project string, prefix string, resources []Resource) error
+	writeRows = func(project string, prefix string, resources []Resource) error {
+		for _, r := range resources {
+			name := prefix + r.Name
+
+			for _, c := range r.CostComponents {
+				err := w.Write([]string{
+					project,
+					name,
+					c.Name,
+					csvDecimal(c.MonthlyQuantity),
+					c.Unit,
+					c.Price.String(),
+					csvDecimal(c.HourlyCost),
+					csvDecimal(c.MonthlyCost),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			err := writeRows(project, name+".", r.SubResources)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !opts.SummaryOnly {
+		for _, p := range out.Projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			err := writeRows(p.Label(), "", p.Breakdown.Resources)
+			if err != nil {
+				return []byte{}, errors.Wrap(err, "Error writing CSV")
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return []byte{}, errors.Wrap(err, "Error writing CSV")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseCSVDelimiter returns the delimiter rune, which must be a single
+// character that can be used by the CSV writer, e.g. ; for European locales.
+func ParseCSVDelimiter(s string) (rune, error) {
+	if s == `\t` || s == "tab" {
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, errors.Errorf("Invalid CSV delimiter %q, it must be a single character other than a double quote or newline", s)
+	}
+
+	return r, nil
+}
+
+func csvDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.String()
+}
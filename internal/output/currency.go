@@ -0,0 +1,136 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// BaseCurrency is the currency of the prices returned by the pricing API.
+const BaseCurrency = "USD"
+
+// ConvertCurrency returns a copy of the output with the costs and prices
+// converted from USD to the currency using the exchange rate, which is the
+// amount of the currency for 1 USD. The resources keep their USD costs so
+// the output can be reconciled or converted again.
+func ConvertCurrency(out Root, currency string, rate decimal.Decimal) Root {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == BaseCurrency {
+		return out
+	}
+
+	out = convertRoot(out, func(d decimal.Decimal) decimal.Decimal { return d.Mul(rate) }, true)
+	out.BaseCurrency = BaseCurrency
+	out.TargetCurrency = currency
+	out.ExchangeRate = &rate
+
+	return out
+}
+
+// ConvertToBaseCurrency returns a copy of an output converted by
+// ConvertCurrency with its costs and prices converted back to USD, e.g. so a
+// converted baseline can be compared with USD costs. Outputs that haven't been
+// converted are returned as they are.
+func ConvertToBaseCurrency(out Root) (Root, error) {
+	if out.TargetCurrency == "" || strings.EqualFold(out.TargetCurrency, BaseCurrency) {
+		return out, nil
+	}
+
+	if out.ExchangeRate == nil || !out.ExchangeRate.IsPositive() {
+		return out, fmt.Errorf("costs are in %s but there's no exchange rate to convert them to %s", out.TargetCurrency, BaseCurrency)
+	}
+
+	rate := *out.ExchangeRate
+	out = convertRoot(out, func(d decimal.Decimal) decimal.Decimal { return d.Div(rate) }, false)
+	out.BaseCurrency = BaseCurrency
+	out.TargetCurrency = ""
+	out.ExchangeRate = nil
+
+	return out, nil
+}
+
+// convertRoot converts the costs and prices of the output. If keepUSD is true
+// the resources keep their costs before the conversion as their USD costs,
+// otherwise their USD costs are removed.
+func convertRoot(out Root, convert func(decimal.Decimal) decimal.Decimal, keepUSD bool) Root {
+	out.Resources = convertResources(out.Resources, convert, keepUSD)
+	out.TotalHourlyCost = convertCost(out.TotalHourlyCost, convert)
+	out.TotalMonthlyCost = convertCost(out.TotalMonthlyCost, convert)
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		p.PastBreakdown = convertBreakdown(p.PastBreakdown, convert, keepUSD)
+		p.Breakdown = convertBreakdown(p.Breakdown, convert, keepUSD)
+		p.Diff = convertBreakdown(p.Diff, convert, keepUSD)
+		projects = append(projects, p)
+	}
+	out.Projects = projects
+
+	return out
+}
+
+func convertBreakdown(b *Breakdown, convert func(decimal.Decimal) decimal.Decimal, keepUSD bool) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	return &Breakdown{
+		Resources:        convertResources(b.Resources, convert, keepUSD),
+		TotalHourlyCost:  convertCost(b.TotalHourlyCost, convert),
+		TotalMonthlyCost: convertCost(b.TotalMonthlyCost, convert),
+	}
+}
+
+func convertResources(resources []Resource, convert func(decimal.Decimal) decimal.Decimal, keepUSD bool) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	converted := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		if keepUSD {
+			r.USDHourlyCost = r.HourlyCost
+			r.USDMonthlyCost = r.MonthlyCost
+		} else {
+			r.USDHourlyCost = nil
+			r.USDMonthlyCost = nil
+		}
+		r.HourlyCost = convertCost(r.HourlyCost, convert)
+		r.MonthlyCost = convertCost(r.MonthlyCost, convert)
+
+		if r.CostComponents != nil {
+			costComponents := make([]CostComponent, 0, len(r.CostComponents))
+			for _, c := range r.CostComponents {
+				c.Price = convert(c.Price)
+				c.HourlyCost = convertCost(c.HourlyCost, convert)
+				c.MonthlyCost = convertCost(c.MonthlyCost, convert)
+				costComponents = append(costComponents, c)
+			}
+			r.CostComponents = costComponents
+		}
+
+		if r.Alternatives != nil {
+			alternatives := make([]Alternative, 0, len(r.Alternatives))
+			for _, a := range r.Alternatives {
+				a.MonthlySaving = convert(a.MonthlySaving)
+				alternatives = append(alternatives, a)
+			}
+			r.Alternatives = alternatives
+		}
+
+		r.SubResources = convertResources(r.SubResources, convert, keepUSD)
+
+		converted = append(converted, r)
+	}
+
+	return converted
+}
+
+func convertCost(d *decimal.Decimal, convert func(decimal.Decimal) decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+
+	return decimalPtr(convert(*d))
+}
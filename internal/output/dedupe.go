@@ -0,0 +1,159 @@
+package output
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Dedupe policies for resources that are in more than one combined input.
+const (
+	// DedupeFirstWins counts duplicate resources once, using the first input
+	// they are in.
+	DedupeFirstWins = "first-wins"
+	// DedupeWarn keeps duplicate resources so they can be reported without
+	// changing the costs.
+	DedupeWarn = "warn"
+)
+
+// DuplicateResource is a resource with the same address and type in more than
+// one combined input.
+type DuplicateResource struct {
+	Name         string
+	ResourceType string
+	// Inputs are the group key metadata values of the inputs, e.g. the filenames
+	Inputs []string
+}
+
+type resourceKey struct {
+	name         string
+	resourceType string
+}
+
+func newResourceKey(r Resource) resourceKey {
+	return resourceKey{r.Name, resourceTypeFromName(r.Name)}
+}
+
+// FindDuplicateResources returns the resources with the same address and type
+// in more than one input, sorted by name.
+func FindDuplicateResources(inputs []ReportInput, groupKey string) []DuplicateResource {
+	seen := make(map[resourceKey][]string)
+	order := make([]resourceKey, 0)
+
+	for i, input := range inputs {
+		label := input.Metadata[groupKey]
+		if label == "" {
+			label = input.Metadata["filename"]
+		}
+
+		keys := make(map[resourceKey]bool)
+		for _, r := range input.Root.Resources {
+			k := newResourceKey(r)
+
+			// Only count each resource once per input
+			if keys[k] {
+				continue
+			}
+			keys[k] = true
+
+			if _, ok := seen[k]; !ok {
+				order = append(order, k)
+			}
+			seen[k] = append(seen[k], inputLabel(label, i))
+		}
+	}
+
+	duplicates := make([]DuplicateResource, 0)
+	for _, k := range order {
+		if len(seen[k]) > 1 {
+			duplicates = append(duplicates, DuplicateResource{
+				Name:         k.name,
+				ResourceType: k.resourceType,
+				Inputs:       seen[k],
+			})
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Name < duplicates[j].Name
+	})
+
+	return duplicates
+}
+
+func inputLabel(label string, i int) string {
+	if label != "" {
+		return label
+	}
+
+	return strconv.Itoa(i + 1)
+}
+
+// dedupeResources splits the resources into the ones that aren't in seen and
+// the ones that are.
+func dedupeResources(resources []Resource, seen map[resourceKey]bool) ([]Resource, []Resource) {
+	kept := make([]Resource, 0, len(resources))
+	removed := make([]Resource, 0)
+
+	for _, r := range resources {
+		if seen[newResourceKey(r)] {
+			removed = append(removed, r)
+			continue
+		}
+
+		kept = append(kept, r)
+	}
+
+	return kept, removed
+}
+
+func markResourcesSeen(resources []Resource, seen map[resourceKey]bool) {
+	for _, r := range resources {
+		seen[newResourceKey(r)] = true
+	}
+}
+
+// dedupeBreakdown removes the resources that are in seen from the breakdown and
+// recalculates its totals.
+func dedupeBreakdown(b *Breakdown, seen map[resourceKey]bool) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	resources, removed := dedupeResources(b.Resources, seen)
+	if len(removed) == 0 {
+		return b
+	}
+
+	deduped := *b
+	deduped.Resources = resources
+	deduped.TotalHourlyCost, deduped.TotalMonthlyCost = calculateTotalCosts(resources)
+
+	return &deduped
+}
+
+// removeFromSummary removes the resources from the supported resource counts
+// of the summary and sets the number of deduplicated resources.
+func removeFromSummary(s *Summary, removed []Resource) {
+	if s.SupportedResourceCounts != nil {
+		for _, r := range removed {
+			t := resourceTypeFromName(r.Name)
+			if (*s.SupportedResourceCounts)[t] > 0 {
+				(*s.SupportedResourceCounts)[t]--
+			}
+		}
+	}
+
+	if s.TotalSupportedResources != nil {
+		s.TotalSupportedResources = addIntPtrs(s.TotalSupportedResources, intPtr(-len(removed)))
+	}
+
+	if s.TotalResources != nil {
+		s.TotalResources = addIntPtrs(s.TotalResources, intPtr(-len(removed)))
+	}
+
+	s.TotalDedupedResources = intPtr(len(removed))
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// limitResourceDepth returns a copy of the resources where the sub-resources
+// nested deeper than maxDepth are replaced by a single cost component on their
+// parent with the total cost of the nested cost components. Top-level resources
+// have a depth of 0. This only changes how the resources are displayed, the
+// resource costs are unchanged.
+func limitResourceDepth(resources []Resource, maxDepth *int) []Resource {
+	if maxDepth == nil {
+		return resources
+	}
+
+	return limitSubResourceDepth(resources, *maxDepth, 0)
+}
+
+func limitSubResourceDepth(resources []Resource, maxDepth int, depth int) []Resource {
+	limited := make([]Resource, 0, len(resources))
+
+	for _, r := range resources {
+		if len(r.SubResources) == 0 {
+			limited = append(limited, r)
+			continue
+		}
+
+		if depth < maxDepth {
+			r.SubResources = limitSubResourceDepth(r.SubResources, maxDepth, depth+1)
+			limited = append(limited, r)
+			continue
+		}
+
+		var hourlyCost, monthlyCost *decimal.Decimal
+		for _, s := range r.SubResources {
+			hourlyCost = addNilableDecimals(hourlyCost, s.HourlyCost)
+			monthlyCost = addNilableDecimals(monthlyCost, s.MonthlyCost)
+		}
+
+		count := countCostComponents(r.SubResources)
+		name := fmt.Sprintf("+%d nested components", count)
+		if count == 1 {
+			name = "+1 nested component"
+		}
+
+		costComponents := make([]CostComponent, 0, len(r.CostComponents)+1)
+		costComponents = append(costComponents, r.CostComponents...)
+		costComponents = append(costComponents, CostComponent{
+			Name:        name,
+			HourlyCost:  hourlyCost,
+			MonthlyCost: monthlyCost,
+			nested:      true,
+		})
+
+		r.CostComponents = costComponents
+		r.SubResources = nil
+		limited = append(limited, r)
+	}
+
+	return limited
+}
+
+func countCostComponents(resources []Resource) int {
+	count := 0
+	for _, r := range resources {
+		count += len(r.CostComponents) + countCostComponents(r.SubResources)
+	}
+
+	return count
+}
+
+func addNilableDecimals(a *decimal.Decimal, b *decimal.Decimal) *decimal.Decimal {
+	if b == nil {
+		return a
+	}
+
+	return addDecimals(a, b)
+}
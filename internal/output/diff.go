@@ -2,9 +2,12 @@ package output
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/shopspring/decimal"
 )
@@ -15,9 +18,162 @@ const (
 	REMOVED
 )
 
+// BuildDiff returns the diff breakdown of the project. Each updated top-level
+// resource has a short reason for its cost change, built from the cost-relevant
+// attributes that the plan changes. If the diff is against another plan with
+// different provider versions, the changes of resources without a reason are
+// attributed to the provider version change.
+func BuildDiff(project *schema.Project) *Breakdown {
+	diff := outputBreakdown(project.Diff)
+
+	var pastVersions, versions map[string]string
+	if project.PastPlanMetadata != nil && project.PlanMetadata != nil {
+		pastVersions = project.PastPlanMetadata.ProviderVersions
+		versions = project.PlanMetadata.ProviderVersions
+	}
+
+	pastNames := make(map[string]bool)
+	for _, r := range project.PastResources {
+		pastNames[r.Name] = true
+	}
+
+	attributeChanges := make(map[string][]schema.AttributeChange)
+	resourceTypes := make(map[string]string)
+	for _, r := range project.Resources {
+		if pastNames[r.Name] {
+			attributeChanges[r.Name] = r.AttributeChanges
+			resourceTypes[r.Name] = r.ResourceType
+		}
+	}
+
+	for i := range diff.Resources {
+		changes, ok := attributeChanges[diff.Resources[i].Name]
+		if !ok {
+			continue
+		}
+
+		diff.Resources[i].ChangeReason = changeReason(changes, diff.Resources[i])
+
+		if diff.Resources[i].ChangeReason == "" && len(changes) == 0 {
+			provider := strings.SplitN(resourceTypes[diff.Resources[i].Name], "_", 2)[0]
+			if change := providerVersionChange(provider, pastVersions, versions); change != "" {
+				diff.Resources[i].ChangeReason = fmt.Sprintf("provider version %s", change)
+			}
+		}
+	}
+
+	return diff
+}
+
+// providerVersionChange returns the change of the provider's version, e.g.
+// "aws 3.74.0 → 4.0.0", or an empty string if it hasn't changed or isn't known.
+func providerVersionChange(provider string, pastVersions map[string]string, versions map[string]string) string {
+	past, ok := pastVersions[provider]
+	if !ok {
+		return ""
+	}
+
+	current, ok := versions[provider]
+	if !ok || current == past {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s → %s", provider, past, current)
+}
+
+// providerVersionChanges returns the change of each provider whose version is
+// different in the past and current plans, sorted by provider name.
+func providerVersionChanges(past *PlanMetadata, current *PlanMetadata) []string {
+	if past == nil || current == nil {
+		return nil
+	}
+
+	providers := make([]string, 0, len(current.ProviderVersions))
+	for provider := range current.ProviderVersions {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	changes := make([]string, 0)
+	for _, provider := range providers {
+		if change := providerVersionChange(provider, past.ProviderVersions, current.ProviderVersions); change != "" {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// changeReason returns a description of the changed attributes, e.g.
+// "instance type m5.large → m5.xlarge". If no cost-relevant attributes are
+// changed but the quantities are then it says so.
+func changeReason(changes []schema.AttributeChange, diffResource Resource) string {
+	if len(changes) == 0 {
+		if hasOnlyQuantityChanges(diffResource) {
+			return "quantity changed"
+		}
+
+		return ""
+	}
+
+	reasons := make([]string, 0, len(changes))
+	for _, c := range changes {
+		reasons = append(reasons, fmt.Sprintf("%s %s → %s", strings.ReplaceAll(c.Name, "_", " "), c.Before, c.After))
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+// hasOnlyQuantityChanges returns true if the prices of all the changed cost
+// components are the same and at least one of the quantities has changed.
+func hasOnlyQuantityChanges(diffResource Resource) bool {
+	quantityChanged := false
+
+	for _, c := range diffResource.CostComponents {
+		if !c.Price.IsZero() {
+			return false
+		}
+
+		if (c.HourlyQuantity != nil && !c.HourlyQuantity.IsZero()) || (c.MonthlyQuantity != nil && !c.MonthlyQuantity.IsZero()) {
+			quantityChanged = true
+		}
+	}
+
+	for _, s := range diffResource.SubResources {
+		if !hasOnlyQuantityChanges(s) {
+			return false
+		}
+		quantityChanged = true
+	}
+
+	return quantityChanged
+}
+
+// defaultDiffFields are the fields shown by the diff if opts.DiffFields isn't
+// set.
+var defaultDiffFields = []string{"monthlyCost"}
+
+// ToDiff returns the cost changes of each project. opts.DiffFields sets which
+// changes are shown for the resources and cost components. The unit field
+// adds the unit to the quantity and price changes. opts.SortKey sets the order
+// of the resources, unless opts.DiffContext is set since that's by address,
+// in which case only the path sort key is used to order the projects.
 func ToDiff(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	sortKey := opts.SortKey
+	if opts.DiffContext > 0 && strings.TrimPrefix(sortKey, "-") != SortPath {
+		sortKey = ""
+	}
+	out = sortOutput(out, sortKey)
+
 	s := ""
 
+	fields := opts.DiffFields
+	if len(fields) == 0 {
+		fields = defaultDiffFields
+	}
+
 	hasNilCosts := false
 	hasEmptyDiff := true
 
@@ -35,7 +191,29 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			project.Label(),
 		)
 
-		for _, diffResource := range project.Diff.Resources {
+		if changes := providerVersionChanges(project.PastPlanMetadata, project.PlanMetadata); len(changes) > 0 {
+			s += fmt.Sprintf("%s %s\n\n",
+				ui.BoldString("Provider versions:"),
+				strings.Join(changes, ", "),
+			)
+		}
+
+		diffResources := filterResourcesByType(project.Diff.Resources, opts.FilterResourceTypes)
+
+		var contextNames map[string]bool
+		if opts.DiffContext > 0 && project.Breakdown != nil {
+			diffResources, contextNames = withDiffContext(diffResources, filterResourcesByType(project.Breakdown.Resources, opts.FilterResourceTypes), opts.DiffContext)
+		}
+
+		for _, diffResource := range diffResources {
+			if contextNames[diffResource.Name] {
+				if !opts.SummaryOnly && !opts.CollapseByType {
+					s += contextResourceToDiff(diffResource, nf)
+					s += "\n"
+				}
+				continue
+			}
+
 			hasEmptyDiff = false
 
 			oldResource := findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
@@ -46,10 +224,25 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 				hasNilCosts = true
 			}
 
-			s += resourceToDiff(diffResource, oldResource, newResource, true)
+			if opts.SummaryOnly || opts.CollapseByType {
+				continue
+			}
+
+			s += resourceToDiff(diffResource, oldResource, newResource, true, fields, nf)
 			s += "\n"
 		}
 
+		if opts.CollapseByType && !opts.SummaryOnly {
+			for _, rollup := range BuildDiffRollups(project, opts.FilterResourceTypes) {
+				s += rollupToDiff(rollup, nf)
+				s += "\n"
+			}
+		}
+
+		if opts.NoSummary {
+			continue
+		}
+
 		var oldCost *decimal.Decimal
 		if project.PastBreakdown != nil {
 			oldCost = project.PastBreakdown.TotalMonthlyCost
@@ -63,11 +256,11 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += fmt.Sprintf("%s %s\nAmount:  %s %s",
 			ui.BoldString("Monthly cost change for"),
 			ui.BoldString(project.Label()),
-			formatCostChange(project.Diff.TotalMonthlyCost),
-			ui.FaintStringf("(%s -> %s)", formatCost(oldCost), formatCost(newCost)),
+			formatCostChange(project.Diff.TotalMonthlyCost, nf),
+			ui.FaintStringf("(%s -> %s)", formatCost(oldCost, nf), formatCost(newCost, nf)),
 		)
 
-		percent := formatPercentChange(oldCost, newCost)
+		percent := formatPercentChange(oldCost, newCost, nf)
 		if percent != "" {
 			s += fmt.Sprintf("\nPercent: %s",
 				percent,
@@ -79,6 +272,20 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		}
 	}
 
+	if opts.NoSummary {
+		return []byte(s), nil
+	}
+
+	hourlyChange, monthlyChange := overallCostChanges(out)
+	s += fmt.Sprintf("\n\n%s", ui.BoldString("Overall cost change"))
+	for _, t := range periodTotals(opts.CostPeriod, hourlyChange, monthlyChange) {
+		line := fmt.Sprintf("%-8s %s", strings.Title(t.Period)+":", formatCostChange2DP(t.Cost, nf))
+		if opts.CostPeriod != "" && t.Prominent {
+			line = ui.BoldString(line)
+		}
+		s += "\n" + line
+	}
+
 	s += "\n\n----------------------------------\n"
 	s += fmt.Sprintf("Key: %s changed, %s added, %s removed",
 		opChar(UPDATED),
@@ -86,6 +293,16 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		opChar(REMOVED),
 	)
 
+	if opts.DiffContext > 0 && !opts.SummaryOnly && !opts.CollapseByType {
+		s += fmt.Sprintf(", %s unchanged", ui.FaintString("="))
+	}
+
+	if len(opts.FilterResourceTypes) > 0 {
+		s += fmt.Sprintf("\n\nOnly showing resource types: %s. The monthly cost changes include all resources.",
+			strings.Join(opts.FilterResourceTypes, ", "),
+		)
+	}
+
 	if hasNilCosts {
 		s += fmt.Sprintf("\n\nTo estimate usage-based resources use --usage-file, see %s",
 			ui.LinkString("https://infracost.io/usage-file"),
@@ -97,6 +314,13 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			ui.PrimaryString("infracost breakdown"))
 	}
 
+	if opts.SummaryOnly {
+		countsMsg := out.resourceCountsMessage()
+		if countsMsg != "" {
+			s += "\n\n" + countsMsg
+		}
+	}
+
 	unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped)
 	if unsupportedMsg != "" {
 		s += "\n\n" + unsupportedMsg
@@ -105,7 +329,28 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
-func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *Resource, isTopLevel bool) string {
+// overallCostChanges returns the sum of the hourly and monthly cost changes
+// of all the projects. They're nil if none of the projects have a cost change.
+func overallCostChanges(out Root) (*decimal.Decimal, *decimal.Decimal) {
+	var hourly, monthly *decimal.Decimal
+
+	for _, p := range out.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		if p.Diff.TotalHourlyCost != nil {
+			hourly = addDecimals(hourly, p.Diff.TotalHourlyCost)
+		}
+		if p.Diff.TotalMonthlyCost != nil {
+			monthly = addDecimals(monthly, p.Diff.TotalMonthlyCost)
+		}
+	}
+
+	return hourly, monthly
+}
+
+func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *Resource, isTopLevel bool, fields []string, nf numberFormat) string {
 	s := ""
 
 	op := UPDATED
@@ -120,9 +365,15 @@ func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *R
 		oldCost = oldResource.MonthlyCost
 	}
 
-	var newCost *decimal.Decimal
+	var newCost, newHourlyCost *decimal.Decimal
 	if newResource != nil {
 		newCost = newResource.MonthlyCost
+		newHourlyCost = newResource.HourlyCost
+	}
+
+	var oldHourlyCost *decimal.Decimal
+	if oldResource != nil {
+		oldHourlyCost = oldResource.HourlyCost
 	}
 
 	nameLabel := diffResource.Name
@@ -136,10 +387,23 @@ func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *R
 		if oldCost == nil && newCost == nil {
 			s += "  Monthly cost depends on usage\n"
 		} else {
-			s += fmt.Sprintf("  %s%s\n",
-				formatCostChange(diffResource.MonthlyCost),
-				ui.FaintString(formatCostChangeDetails(oldCost, newCost)),
-			)
+			if contains(fields, "monthlyCost") {
+				s += fmt.Sprintf("  %s%s\n",
+					formatCostChange(diffResource.MonthlyCost, nf),
+					ui.FaintString(formatCostChangeDetails(oldCost, newCost, nf)),
+				)
+			}
+
+			if contains(fields, "hourlyCost") {
+				s += fmt.Sprintf("  Hourly: %s%s\n",
+					formatCostChange(diffResource.HourlyCost, nf),
+					ui.FaintString(formatCostChangeDetails(oldHourlyCost, newHourlyCost, nf)),
+				)
+			}
+		}
+
+		if diffResource.ChangeReason != "" {
+			s += fmt.Sprintf("  %s\n", ui.FaintStringf("Reason: %s", diffResource.ChangeReason))
 		}
 	}
 
@@ -155,7 +419,7 @@ func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *R
 		}
 
 		s += "\n"
-		s += ui.Indent(costComponentToDiff(diffComponent, oldComponent, newComponent), "    ")
+		s += ui.Indent(costComponentToDiff(diffComponent, oldComponent, newComponent, fields, nf), "    ")
 	}
 
 	for _, diffSubResource := range diffResource.SubResources {
@@ -170,13 +434,68 @@ func resourceToDiff(diffResource Resource, oldResource *Resource, newResource *R
 		}
 
 		s += "\n"
-		s += ui.Indent(resourceToDiff(diffSubResource, oldSubResource, newSubResource, false), "    ")
+		s += ui.Indent(resourceToDiff(diffSubResource, oldSubResource, newSubResource, false, fields, nf), "    ")
 	}
 
 	return s
 }
 
-func costComponentToDiff(diffComponent CostComponent, oldComponent *CostComponent, newComponent *CostComponent) string {
+// withDiffContext returns the diff resources and up to n unchanged resources
+// either side of each of them, ordered by address like unified diff context.
+// The names of the unchanged resources are returned so they can be shown
+// differently.
+func withDiffContext(diffResources []Resource, resources []Resource, n int) ([]Resource, map[string]bool) {
+	changed := make(map[string]bool, len(diffResources))
+	for _, r := range diffResources {
+		changed[r.Name] = true
+	}
+
+	all := make([]Resource, 0, len(diffResources)+len(resources))
+	all = append(all, diffResources...)
+	for _, r := range resources {
+		if !changed[r.Name] {
+			all = append(all, r)
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+
+	included := make(map[int]bool)
+	for i, r := range all {
+		if !changed[r.Name] {
+			continue
+		}
+
+		for j := i - n; j <= i+n; j++ {
+			if j >= 0 && j < len(all) {
+				included[j] = true
+			}
+		}
+	}
+
+	withContext := make([]Resource, 0, len(included))
+	contextNames := make(map[string]bool)
+	for i, r := range all {
+		if !included[i] {
+			continue
+		}
+
+		withContext = append(withContext, r)
+		if !changed[r.Name] {
+			contextNames[r.Name] = true
+		}
+	}
+
+	return withContext, contextNames
+}
+
+func contextResourceToDiff(r Resource, nf numberFormat) string {
+	return ui.FaintStringf("= %s\n  %s\n", r.Name, formatCost(r.MonthlyCost, nf))
+}
+
+func costComponentToDiff(diffComponent CostComponent, oldComponent *CostComponent, newComponent *CostComponent, fields []string, nf numberFormat) string {
 	s := ""
 
 	op := UPDATED
@@ -186,31 +505,77 @@ func costComponentToDiff(diffComponent CostComponent, oldComponent *CostComponen
 		op = REMOVED
 	}
 
-	var oldCost, newCost, oldPrice, newPrice *decimal.Decimal
+	var oldCost, newCost, oldHourlyCost, newHourlyCost, oldPrice, newPrice, oldQuantity, newQuantity *decimal.Decimal
 
 	if oldComponent != nil {
 		oldCost = oldComponent.MonthlyCost
+		oldHourlyCost = oldComponent.HourlyCost
 		oldPrice = &oldComponent.Price
+		oldQuantity = oldComponent.MonthlyQuantity
 	}
 
 	if newComponent != nil {
 		newCost = newComponent.MonthlyCost
+		newHourlyCost = newComponent.HourlyCost
 		newPrice = &newComponent.Price
+		newQuantity = newComponent.MonthlyQuantity
 	}
 
 	s += fmt.Sprintf("%s %s\n", opChar(op), diffComponent.Name)
 
 	if oldCost == nil && newCost == nil {
-		s += "  Monthly cost depends on usage\n"
-		s += ui.FaintStringf("    %s per %s%s\n",
-			formatPriceChange(diffComponent.Price),
-			diffComponent.Unit,
-			formatPriceChangeDetails(oldPrice, newPrice),
-		)
-	} else {
+		if contains(fields, "monthlyCost") {
+			s += "  Monthly cost depends on usage\n"
+		}
+
+		if contains(fields, "monthlyCost") || contains(fields, "price") {
+			s += ui.FaintStringf("    %s per %s%s\n",
+				formatPriceChange(diffComponent.Price, nf),
+				diffComponent.Unit,
+				formatPriceChangeDetails(oldPrice, newPrice, nf),
+			)
+		}
+
+		return s
+	}
+
+	if contains(fields, "monthlyCost") {
 		s += fmt.Sprintf("  %s%s\n",
-			formatCostChange(diffComponent.MonthlyCost),
-			ui.FaintString(formatCostChangeDetails(oldCost, newCost)),
+			formatCostChange(diffComponent.MonthlyCost, nf),
+			ui.FaintString(formatCostChangeDetails(oldCost, newCost, nf)),
+		)
+	}
+
+	if contains(fields, "hourlyCost") {
+		s += fmt.Sprintf("  Hourly: %s%s\n",
+			formatCostChange(diffComponent.HourlyCost, nf),
+			ui.FaintString(formatCostChangeDetails(oldHourlyCost, newHourlyCost, nf)),
+		)
+	}
+
+	unit := ""
+	if contains(fields, "unit") {
+		unit = " " + diffComponent.Unit
+	}
+
+	if contains(fields, "monthlyQuantity") && diffComponent.MonthlyQuantity != nil {
+		s += fmt.Sprintf("  Quantity: %s%s%s\n",
+			formatQuantityChange(*diffComponent.MonthlyQuantity, nf),
+			unit,
+			ui.FaintString(formatQuantityChangeDetails(oldQuantity, newQuantity, nf)),
+		)
+	}
+
+	if contains(fields, "price") {
+		priceUnit := ""
+		if unit != "" {
+			priceUnit = " per" + unit
+		}
+
+		s += fmt.Sprintf("  Price: %s%s%s\n",
+			formatPriceChange(diffComponent.Price, nf),
+			priceUnit,
+			ui.FaintString(formatPriceChangeDetails(oldPrice, newPrice, nf)),
 		)
 	}
 
@@ -248,37 +613,76 @@ func findCostComponentByName(costComponents []CostComponent, name string) *CostC
 	return nil
 }
 
-func formatCostChange(d *decimal.Decimal) string {
+// formatCostChange returns "-" for a nil cost so it's not confused with a
+// missing resource.
+func formatCostChange(d *decimal.Decimal, nf numberFormat) string {
 	if d == nil {
-		return ""
+		return "-"
 	}
 
 	abs := d.Abs()
-	return fmt.Sprintf("%s%s", getSym(*d), formatCost(&abs))
+	return fmt.Sprintf("%s%s", getSym(*d), formatCost(&abs, nf))
+}
+
+// formatCostChange2DP is like formatCostChange but always shows 2 decimal
+// places so small hourly changes aren't rounded away.
+func formatCostChange2DP(d *decimal.Decimal, nf numberFormat) string {
+	if d == nil {
+		return "-"
+	}
+
+	abs := d.Abs()
+	return fmt.Sprintf("%s%s", getSym(*d), formatCost2DP(&abs, nf))
+}
+
+// FormatCostChangeSummary returns the change from the old cost to the new
+// cost, e.g. "+$12.50 ($100.00 -> $112.50)".
+func FormatCostChangeSummary(oldCost *decimal.Decimal, newCost *decimal.Decimal, currency string) string {
+	nf := newNumberFormat(Options{Currency: currency})
+
+	if oldCost == nil || newCost == nil {
+		return formatCost2DP(newCost, nf)
+	}
+
+	diff := newCost.Sub(*oldCost)
+	return fmt.Sprintf("%s (%s -> %s)", formatCostChange2DP(&diff, nf), formatCost2DP(oldCost, nf), formatCost2DP(newCost, nf))
 }
 
-func formatCostChangeDetails(oldCost *decimal.Decimal, newCost *decimal.Decimal) string {
+func formatCostChangeDetails(oldCost *decimal.Decimal, newCost *decimal.Decimal, nf numberFormat) string {
 	if oldCost == nil || newCost == nil {
 		return ""
 	}
 
-	return fmt.Sprintf(" (%s -> %s)", formatCost(oldCost), formatCost(newCost))
+	return fmt.Sprintf(" (%s -> %s)", formatCost(oldCost, nf), formatCost(newCost, nf))
 }
 
-func formatPriceChange(d decimal.Decimal) string {
+func formatPriceChange(d decimal.Decimal, nf numberFormat) string {
 	abs := d.Abs()
-	return fmt.Sprintf("%s%s", getSym(d), formatPrice(abs))
+	return fmt.Sprintf("%s%s", getSym(d), formatPrice(abs, nf))
 }
 
-func formatPriceChangeDetails(oldPrice *decimal.Decimal, newPrice *decimal.Decimal) string {
+func formatPriceChangeDetails(oldPrice *decimal.Decimal, newPrice *decimal.Decimal, nf numberFormat) string {
 	if oldPrice == nil || newPrice == nil {
 		return ""
 	}
 
-	return fmt.Sprintf(" (%s -> %s)", formatPrice(*oldPrice), formatPrice(*newPrice))
+	return fmt.Sprintf(" (%s -> %s)", formatPrice(*oldPrice, nf), formatPrice(*newPrice, nf))
+}
+
+func formatQuantityChange(d decimal.Decimal, nf numberFormat) string {
+	abs := d.Abs()
+	return fmt.Sprintf("%s%s", getSym(d), formatQuantity(&abs, nf))
+}
+
+func formatQuantityChangeDetails(oldQuantity *decimal.Decimal, newQuantity *decimal.Decimal, nf numberFormat) string {
+	if oldQuantity == nil || newQuantity == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s -> %s)", formatQuantity(oldQuantity, nf), formatQuantity(newQuantity, nf))
 }
 
-func formatPercentChange(oldCost *decimal.Decimal, newCost *decimal.Decimal) string {
+func formatPercentChange(oldCost *decimal.Decimal, newCost *decimal.Decimal, nf numberFormat) string {
 	if oldCost == nil || oldCost.IsZero() || newCost == nil || newCost.IsZero() {
 		return ""
 	}
@@ -290,7 +694,7 @@ func formatPercentChange(oldCost *decimal.Decimal, newCost *decimal.Decimal) str
 	}
 
 	f, _ := p.Float64()
-	return fmt.Sprintf("%s%s%%", percentSym, humanize.FormatFloat("#,###.", f))
+	return fmt.Sprintf("%s%s%%", percentSym, nf.localizeNumber(humanize.FormatFloat("#,###.", f)))
 }
 
 func getSym(d decimal.Decimal) string {
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// hoursPerMonth is the number of hours per month used to calculate the monthly
+// cost of hourly cost components.
+const hoursPerMonth = 730
+
+// AddExplanations sets the explanation of each resource and sub-resource to a
+// description of how its monthly cost is calculated from its cost components.
+// The explanations are in the JSON, so they use the default locale.
+func AddExplanations(out *Root) {
+	nf := newNumberFormat(Options{Currency: out.TargetCurrency})
+
+	addResourceExplanations(out.Resources, nf)
+
+	for _, p := range out.Projects {
+		for _, b := range []*Breakdown{p.PastBreakdown, p.Breakdown} {
+			if b != nil {
+				addResourceExplanations(b.Resources, nf)
+			}
+		}
+	}
+}
+
+func addResourceExplanations(resources []Resource, nf numberFormat) {
+	for i := range resources {
+		resources[i].Explanation = explainResource(resources[i], nf)
+		addResourceExplanations(resources[i].SubResources, nf)
+	}
+}
+
+func explainResource(r Resource, nf numberFormat) string {
+	parts := make([]string, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		parts = append(parts, explainCostComponent(c, nf))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// explainCostComponent returns the calculation of the monthly cost, e.g.
+// "Instance usage (Linux/UNIX, on-demand, m5.large) = $0.096/hr × 730 hrs = $70.08/mo".
+func explainCostComponent(c CostComponent, nf numberFormat) string {
+	if c.MonthlyQuantity == nil || c.MonthlyCost == nil {
+		return fmt.Sprintf("%s = %s per %s, monthly cost depends on usage", c.Name, formatExactPrice(c.Price, nf), c.Unit)
+	}
+
+	if c.Unit == "hours" {
+		return fmt.Sprintf("%s = %s/hr × %s hrs = %s/mo", c.Name, formatExactPrice(c.Price, nf), formatQuantity(c.MonthlyQuantity, nf), formatCost2DP(c.MonthlyCost, nf))
+	}
+
+	calculation := fmt.Sprintf("%s per %s × %s %s", formatExactPrice(c.Price, nf), c.Unit, formatQuantity(c.MonthlyQuantity, nf), c.Unit)
+
+	// Some cost components have a discount so the cost doesn't equal price × quantity
+	if !c.Price.Mul(*c.MonthlyQuantity).Round(2).Equal(c.MonthlyCost.Round(2)) {
+		calculation += " less discounts"
+	}
+
+	return fmt.Sprintf("%s = %s = %s/mo", c.Name, calculation, formatCost2DP(c.MonthlyCost, nf))
+}
+
+// explanationsForBreakdown returns a line for each resource and sub-resource
+// in the breakdown that has cost components.
+func explanationsForBreakdown(breakdown Breakdown, nf numberFormat) string {
+	s := fmt.Sprintf("%s\n", ui.BoldString(fmt.Sprintf("How costs are calculated (%d hours per month):", hoursPerMonth)))
+
+	var addLines func(prefix string, resources []Resource)
+	addLines = func(prefix string, resources []Resource) {
+		for _, r := range resources {
+			name := prefix + r.Name
+
+			explanation := r.Explanation
+			if explanation == "" {
+				explanation = explainResource(r, nf)
+			}
+
+			if explanation != "" {
+				s += fmt.Sprintf(" %s: %s\n", name, explanation)
+			}
+
+			addLines(name+".", r.SubResources)
+		}
+	}
+	addLines("", breakdown.Resources)
+
+	return s
+}
+
+// formatExactPrice formats the price without rounding so the calculation adds up.
+func formatExactPrice(d decimal.Decimal, nf numberFormat) string {
+	return nf.withCurrencySymbol(nf.localizeNumber(d.String()))
+}
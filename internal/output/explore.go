@@ -0,0 +1,294 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+var moduleAddressRegex = regexp.MustCompile(`^module\.[^.\[]+(\[[^\]]*\])?\.`)
+
+// ExploreRow is a visible row of the Explorer tree.
+type ExploreRow struct {
+	Key         string
+	Depth       int
+	Name        string
+	MonthlyCost *decimal.Decimal
+	HasChildren bool
+	Expanded    bool
+}
+
+type exploreNode struct {
+	key         string
+	name        string
+	monthlyCost *decimal.Decimal
+	children    []*exploreNode
+	// sortable is false for cost components so they keep the same order as
+	// the other output formats
+	sortable bool
+	// isGroup is true for projects and modules, which cost the total of their
+	// children
+	isGroup bool
+}
+
+// Explorer builds a collapsible tree of projects, modules, resources and cost
+// components from the output, for the interactive breakdown.
+type Explorer struct {
+	out          Root
+	sortByCost   bool
+	resourceType string
+	expanded     map[string]bool
+}
+
+func NewExplorer(out Root) *Explorer {
+	e := &Explorer{
+		out:        out,
+		sortByCost: true,
+		expanded:   make(map[string]bool),
+	}
+
+	// Projects are expanded to start with so their modules and resources show
+	for i := range out.Projects {
+		e.expanded[projectNodeKey(i)] = true
+	}
+
+	return e
+}
+
+// SortByCost returns true if the rows are sorted by monthly cost, highest
+// first, instead of by name.
+func (e *Explorer) SortByCost() bool {
+	return e.sortByCost
+}
+
+func (e *Explorer) ToggleSort() {
+	e.sortByCost = !e.sortByCost
+}
+
+// ResourceType returns the resource type the rows are filtered by, or an empty
+// string if they're not filtered.
+func (e *Explorer) ResourceType() string {
+	return e.resourceType
+}
+
+func (e *Explorer) SetResourceType(resourceType string) {
+	e.resourceType = resourceType
+}
+
+// ResourceTypes returns the sorted resource types of the top-level resources.
+func (e *Explorer) ResourceTypes() []string {
+	seen := make(map[string]bool)
+	types := make([]string, 0)
+
+	for _, p := range e.out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			t := resourceTypeFromName(r.Name)
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// Title returns the total monthly cost of the output.
+func (e *Explorer) Title() string {
+	return fmt.Sprintf("Total monthly cost: %s", formatCost2DP(e.out.TotalMonthlyCost, newNumberFormat(Options{Currency: e.out.TargetCurrency})))
+}
+
+// Toggle expands or collapses the row with the key.
+func (e *Explorer) Toggle(key string) {
+	e.expanded[key] = !e.expanded[key]
+}
+
+// Rows returns the visible rows, which are the children of expanded rows.
+func (e *Explorer) Rows() []ExploreRow {
+	rows := make([]ExploreRow, 0)
+	for _, n := range e.tree() {
+		rows = e.appendRows(rows, n, 0)
+	}
+
+	return rows
+}
+
+func (e *Explorer) appendRows(rows []ExploreRow, n *exploreNode, depth int) []ExploreRow {
+	expanded := e.expanded[n.key]
+
+	rows = append(rows, ExploreRow{
+		Key:         n.key,
+		Depth:       depth,
+		Name:        n.name,
+		MonthlyCost: n.monthlyCost,
+		HasChildren: len(n.children) > 0,
+		Expanded:    expanded,
+	})
+
+	if expanded {
+		for _, c := range n.children {
+			rows = e.appendRows(rows, c, depth+1)
+		}
+	}
+
+	return rows
+}
+
+func (e *Explorer) tree() []*exploreNode {
+	nf := newNumberFormat(Options{Currency: e.out.TargetCurrency})
+
+	nodes := make([]*exploreNode, 0, len(e.out.Projects))
+
+	for i, p := range e.out.Projects {
+		projectNode := &exploreNode{key: projectNodeKey(i), name: p.Label(), sortable: true, isGroup: true}
+
+		if p.Breakdown != nil {
+			modules := make(map[string]*exploreNode)
+
+			for _, r := range p.Breakdown.Resources {
+				if e.resourceType != "" && resourceTypeFromName(r.Name) != e.resourceType {
+					continue
+				}
+
+				parent := projectNode
+				name := r.Name
+				prefix := ""
+
+				// Add a node for each module the resource is in, e.g.
+				// module.a.module.b.aws_instance.web is in module.a and module.b
+				for {
+					m := moduleAddressRegex.FindString(name)
+					if m == "" {
+						break
+					}
+
+					prefix += m
+					name = name[len(m):]
+
+					moduleNode, ok := modules[prefix]
+					if !ok {
+						moduleNode = &exploreNode{key: fmt.Sprintf("%s/%s", projectNode.key, prefix), name: strings.TrimSuffix(m, "."), sortable: true, isGroup: true}
+						modules[prefix] = moduleNode
+						parent.children = append(parent.children, moduleNode)
+					}
+
+					parent = moduleNode
+				}
+
+				parent.children = append(parent.children, resourceNode(fmt.Sprintf("%s/%s", projectNode.key, r.Name), name, r, nf))
+			}
+		}
+
+		nodes = append(nodes, projectNode)
+	}
+
+	for _, n := range nodes {
+		e.sumAndSort(n)
+	}
+
+	return nodes
+}
+
+// sumAndSort sets the cost of project and module nodes to the total of their
+// children and sorts the children.
+func (e *Explorer) sumAndSort(n *exploreNode) {
+	for _, c := range n.children {
+		e.sumAndSort(c)
+	}
+
+	if n.isGroup {
+		for _, c := range n.children {
+			if c.monthlyCost != nil {
+				n.monthlyCost = addDecimals(n.monthlyCost, c.monthlyCost)
+			}
+		}
+	}
+
+	sort.SliceStable(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		// Cost components go before the sub-resources and aren't sorted
+		if !a.sortable || !b.sortable {
+			return !a.sortable && b.sortable
+		}
+
+		if e.sortByCost {
+			ac, bc := decimal.Zero, decimal.Zero
+			if a.monthlyCost != nil {
+				ac = *a.monthlyCost
+			}
+			if b.monthlyCost != nil {
+				bc = *b.monthlyCost
+			}
+
+			if !ac.Equal(bc) {
+				return ac.GreaterThan(bc)
+			}
+		}
+
+		return a.name < b.name
+	})
+}
+
+func resourceNode(key string, name string, r Resource, nf numberFormat) *exploreNode {
+	n := &exploreNode{key: key, name: name, monthlyCost: r.MonthlyCost, sortable: true}
+
+	for _, c := range r.CostComponents {
+		n.children = append(n.children, &exploreNode{
+			key:         fmt.Sprintf("%s/%s", key, c.Name),
+			name:        fmt.Sprintf("%s (%s %s)", c.Name, formatQuantity(c.MonthlyQuantity, nf), c.Unit),
+			monthlyCost: c.MonthlyCost,
+		})
+	}
+
+	for _, s := range r.SubResources {
+		n.children = append(n.children, resourceNode(fmt.Sprintf("%s/%s", key, s.Name), s.Name, s, nf))
+	}
+
+	return n
+}
+
+func projectNodeKey(i int) string {
+	return fmt.Sprintf("p%d", i)
+}
+
+// FormatExploreRows returns the rows as lines with the costs aligned, with a
+// marker showing if each row is expanded or collapsed.
+func FormatExploreRows(rows []ExploreRow, currency string) []string {
+	nf := newNumberFormat(Options{Currency: currency})
+	labels := make([]string, 0, len(rows))
+	width := 0
+
+	for _, r := range rows {
+		marker := " "
+		if r.HasChildren && r.Expanded {
+			marker = "▾"
+		} else if r.HasChildren {
+			marker = "▸"
+		}
+
+		label := fmt.Sprintf("%s%s %s", strings.Repeat("  ", r.Depth), marker, r.Name)
+		if w := utf8.RuneCountInString(label); w > width {
+			width = w
+		}
+
+		labels = append(labels, label)
+	}
+
+	lines := make([]string, 0, len(rows))
+	for i, r := range rows {
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(labels[i]))
+		lines = append(lines, fmt.Sprintf("%s%s  %12s", labels[i], padding, formatCost2DP(r.MonthlyCost, nf)))
+	}
+
+	return lines
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"regexp"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// FilterByPath returns the output with only the resources with addresses that
+// match any of the path filters, see schema.CompilePathFilters, and the totals
+// and resource counts of the summary recalculated for them. It also returns the
+// number of resources that match, counting each resource once even if it's in
+// several of the breakdown, past breakdown and skipped resources.
+func FilterByPath(out Root, filters []*regexp.Regexp) (Root, int) {
+	matched := 0
+
+	var totalHourlyCost, totalMonthlyCost *decimal.Decimal
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		p.PastBreakdown = filterBreakdownByPath(p.PastBreakdown, filters)
+		p.Diff = filterBreakdownByPath(p.Diff, filters)
+		p.Breakdown = filterBreakdownByPath(p.Breakdown, filters)
+
+		skipped := make([]SkippedResource, 0, len(p.SkippedResources))
+		for _, s := range p.SkippedResources {
+			if schema.MatchesPathFilter(s.Name, filters) {
+				skipped = append(skipped, s)
+			}
+		}
+		p.SkippedResources = skipped
+
+		if p.Breakdown != nil {
+			if p.Breakdown.TotalHourlyCost != nil {
+				totalHourlyCost = addDecimals(totalHourlyCost, p.Breakdown.TotalHourlyCost)
+			}
+			if p.Breakdown.TotalMonthlyCost != nil {
+				totalMonthlyCost = addDecimals(totalMonthlyCost, p.Breakdown.TotalMonthlyCost)
+			}
+		}
+
+		matched += len(matchedNames(p))
+
+		projects = append(projects, p)
+	}
+
+	resources := make([]Resource, 0, len(out.Resources))
+	for _, r := range out.Resources {
+		if schema.MatchesPathFilter(r.Name, filters) {
+			resources = append(resources, r)
+		}
+	}
+
+	out.Projects = projects
+	out.Resources = resources
+	out.TotalHourlyCost = totalHourlyCost
+	out.TotalMonthlyCost = totalMonthlyCost
+
+	if out.Summary != nil {
+		out.Summary = filteredSummary(out.Summary, projects)
+	}
+
+	return out, matched
+}
+
+// matchedNames returns the names of the resources left in the project after
+// filtering.
+func matchedNames(p Project) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, b := range []*Breakdown{p.Breakdown, p.PastBreakdown} {
+		if b == nil {
+			continue
+		}
+		for _, r := range b.Resources {
+			names[r.Name] = true
+		}
+	}
+
+	for _, s := range p.SkippedResources {
+		names[s.Name] = true
+	}
+
+	return names
+}
+
+func filterBreakdownByPath(b *Breakdown, filters []*regexp.Regexp) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	kept := make([]Resource, 0, len(b.Resources))
+	for _, r := range b.Resources {
+		if schema.MatchesPathFilter(r.Name, filters) {
+			kept = append(kept, r)
+		}
+	}
+
+	filtered := *b
+	filtered.Resources = kept
+	filtered.TotalHourlyCost, filtered.TotalMonthlyCost = calculateTotalCosts(kept)
+
+	return &filtered
+}
+
+// filteredSummary returns a copy of the summary with the resource counts
+// calculated from the filtered projects the same way as BuildSummary: the
+// resources of the breakdowns are supported, and the skipped resources are
+// unsupported or have no price. Resources that are missing usage are both in
+// the breakdown and the skipped resources, so they're only counted as
+// supported. The counts the summary doesn't have, e.g. because of --fields,
+// are left out.
+func filteredSummary(s *Summary, projects []Project) *Summary {
+	supportedCounts := make(map[string]int)
+	unsupportedCounts := make(map[string]int)
+	supported, unsupported, noPrice := 0, 0, 0
+
+	for _, p := range projects {
+		if p.Breakdown != nil {
+			for _, r := range p.Breakdown.Resources {
+				supported++
+				supportedCounts[resourceTypeFromName(r.Name)]++
+			}
+		}
+
+		for _, r := range p.SkippedResources {
+			if !terraform.HasSupportedProvider(r.ResourceType) {
+				continue
+			}
+
+			switch r.Reason {
+			case SkipReasonUnsupported:
+				unsupported++
+				unsupportedCounts[r.ResourceType]++
+			case SkipReasonNoPrice:
+				noPrice++
+			}
+		}
+	}
+
+	filtered := *s
+	if s.SupportedResourceCounts != nil {
+		filtered.SupportedResourceCounts = &supportedCounts
+	}
+	if s.UnsupportedResourceCounts != nil {
+		filtered.UnsupportedResourceCounts = &unsupportedCounts
+	}
+	if s.TotalSupportedResources != nil {
+		filtered.TotalSupportedResources = intPtr(supported)
+	}
+	if s.TotalUnsupportedResources != nil {
+		filtered.TotalUnsupportedResources = intPtr(unsupported)
+	}
+	if s.TotalNoPriceResources != nil {
+		filtered.TotalNoPriceResources = intPtr(noPrice)
+	}
+	if s.TotalResources != nil {
+		filtered.TotalResources = intPtr(supported + unsupported + noPrice)
+	}
+
+	return &filtered
+}
@@ -1,21 +1,153 @@
 package output
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/dustin/go-humanize"
 	"github.com/shopspring/decimal"
 )
 
 var roundCostsAbove = 100
 
-func formatQuantity(q *decimal.Decimal) string {
+// numberLocale defines how numbers and currency amounts are formatted for a locale.
+type numberLocale struct {
+	thousandsSep string
+	decimalSep   string
+	symbolAfter  bool
+}
+
+const defaultLocale = "en-US"
+
+var locales = map[string]numberLocale{
+	"en-US": {thousandsSep: ",", decimalSep: "."},
+	"en-GB": {thousandsSep: ",", decimalSep: "."},
+	"en-IN": {thousandsSep: ",", decimalSep: "."},
+	"ja-JP": {thousandsSep: ",", decimalSep: "."},
+	"de-CH": {thousandsSep: "’", decimalSep: "."},
+	"de-DE": {thousandsSep: ".", decimalSep: ",", symbolAfter: true},
+	"es-ES": {thousandsSep: ".", decimalSep: ",", symbolAfter: true},
+	"it-IT": {thousandsSep: ".", decimalSep: ",", symbolAfter: true},
+	"nl-NL": {thousandsSep: ".", decimalSep: ","},
+	"pt-BR": {thousandsSep: ".", decimalSep: ","},
+	"fr-FR": {thousandsSep: " ", decimalSep: ",", symbolAfter: true},
+	"pl-PL": {thousandsSep: " ", decimalSep: ",", symbolAfter: true},
+	"sv-SE": {thousandsSep: " ", decimalSep: ",", symbolAfter: true},
+}
+
+// currencySymbols are the symbols of the common currencies. Other currencies
+// are shown with their code.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+	"AUD": "A$",
+	"CAD": "C$",
+}
+
+// currencySymbol returns the symbol used to format costs in the currency, see
+// Options.Currency. An empty code is USD.
+func currencySymbol(code string) string {
+	if code == "" {
+		return currencySymbols[BaseCurrency]
+	}
+
+	if symbol, ok := currencySymbols[strings.ToUpper(code)]; ok {
+		return symbol
+	}
+
+	return strings.ToUpper(code) + " "
+}
+
+// numberFormat is how numbers and costs are formatted in an output: the
+// currency symbol of Options.Currency and the separators of Options.Locale.
+type numberFormat struct {
+	currency string
+	locale   numberLocale
+}
+
+func newNumberFormat(opts Options) numberFormat {
+	l, ok := findLocale(opts.Locale)
+	if !ok {
+		l = locales[defaultLocale]
+	}
+
+	return numberFormat{currency: opts.Currency, locale: l}
+}
+
+// findLocale returns the locale with the name, ignoring case and allowing _
+// instead of -. An empty name is the default locale.
+func findLocale(name string) (numberLocale, bool) {
+	if name == "" {
+		name = defaultLocale
+	}
+
+	for k, l := range locales {
+		if strings.EqualFold(k, strings.ReplaceAll(name, "_", "-")) {
+			return l, true
+		}
+	}
+
+	return numberLocale{}, false
+}
+
+// ValidateLocale returns an error if the locale isn't supported, see
+// Options.Locale.
+func ValidateLocale(name string) error {
+	if _, ok := findLocale(name); !ok {
+		return fmt.Errorf("Unsupported locale %s. Supported locales are: %s", name, strings.Join(SupportedLocales(), ", "))
+	}
+
+	return nil
+}
+
+// SupportedLocales returns the names of the supported locales.
+func SupportedLocales() []string {
+	names := make([]string, 0, len(locales))
+	for k := range locales {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// localizeNumber converts a number formatted with , thousands and . decimal
+// separators to the separators of the locale.
+func (nf numberFormat) localizeNumber(s string) string {
+	if nf.locale.thousandsSep == "," && nf.locale.decimalSep == "." {
+		return s
+	}
+
+	parts := strings.SplitN(s, ".", 2)
+	parts[0] = strings.ReplaceAll(parts[0], ",", nf.locale.thousandsSep)
+
+	return strings.Join(parts, nf.locale.decimalSep)
+}
+
+func (nf numberFormat) withCurrencySymbol(s string) string {
+	symbol := currencySymbol(nf.currency)
+	if nf.locale.symbolAfter {
+		return s + " " + strings.TrimSpace(symbol)
+	}
+
+	return symbol + s
+}
+
+func formatQuantity(q *decimal.Decimal, nf numberFormat) string {
 	if q == nil {
 		return "-"
 	}
 	f, _ := q.Float64()
-	return humanize.CommafWithDigits(f, 4)
+	return nf.localizeNumber(humanize.CommafWithDigits(f, 4))
 }
 
-func formatCost(d *decimal.Decimal) string {
+func formatCost(d *decimal.Decimal, nf numberFormat) string {
 	if d == nil {
 		return "-"
 	}
@@ -27,10 +159,10 @@ func formatCost(d *decimal.Decimal) string {
 		s = humanize.FormatFloat("#,###.", f)
 	}
 
-	return "$" + s
+	return nf.withCurrencySymbol(nf.localizeNumber(s))
 }
 
-func formatCost2DP(d *decimal.Decimal) string {
+func formatCost2DP(d *decimal.Decimal, nf numberFormat) string {
 	if d == nil {
 		return "-"
 	}
@@ -38,16 +170,16 @@ func formatCost2DP(d *decimal.Decimal) string {
 	f, _ := d.Float64()
 
 	s := humanize.FormatFloat("#,###.##", f)
-	return "$" + s
+	return nf.withCurrencySymbol(nf.localizeNumber(s))
 }
 
-func formatPrice(d decimal.Decimal) string {
+func formatPrice(d decimal.Decimal, nf numberFormat) string {
 	if d.LessThan(decimal.NewFromFloat(0.01)) {
-		return "$" + d.String()
+		return nf.withCurrencySymbol(nf.localizeNumber(d.String()))
 	}
 
 	f, _ := d.Float64()
 
 	s := humanize.FormatFloat("#,###.##", f)
-	return "$" + s
+	return nf.withCurrencySymbol(nf.localizeNumber(s))
 }
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// The keys that the resources of the JSON output can be grouped by. Tags are
+// grouped by the value of a tag key, e.g. tag:team.
+const (
+	GroupByService = "service"
+	GroupByRegion  = "region"
+	GroupByModule  = "module"
+	GroupByTag     = "tag"
+)
+
+var modulePrefixRegex = regexp.MustCompile(`^(module\.[^.\[]+(\[[^\]]*\])?\.)+`)
+
+// ValidateJSONGroupBy returns an error if the group by key isn't empty, one of
+// the group keys or tag:<key>.
+func ValidateJSONGroupBy(groupBy string) error {
+	switch groupBy {
+	case "", GroupByService, GroupByRegion, GroupByModule:
+		return nil
+	}
+
+	if strings.HasPrefix(groupBy, GroupByTag+":") && len(groupBy) > len(GroupByTag)+1 {
+		return nil
+	}
+
+	return fmt.Errorf("group-by must be one of %s, %s, %s or %s:<key>", GroupByService, GroupByRegion, GroupByModule, GroupByTag)
+}
+
+// groupJSONResources replaces each list of resources in the JSON with a map of
+// the group key to the resources and subtotals of that group. Resources that
+// don't have a value for the key, e.g. resources that aren't in a module, are
+// in the "" group.
+func groupJSONResources(b []byte, groupBy string) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var root map[string]interface{}
+	if err := d.Decode(&root); err != nil {
+		return nil, err
+	}
+
+	root["groupBy"] = groupBy
+	root["groups"] = groupResourcesList(root["resources"], groupBy)
+	delete(root, "resources")
+
+	if projects, ok := root["projects"].([]interface{}); ok {
+		for _, p := range projects {
+			project, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, key := range []string{"pastBreakdown", "breakdown", "diff"} {
+				if breakdown, ok := project[key].(map[string]interface{}); ok {
+					breakdown["groups"] = groupResourcesList(breakdown["resources"], groupBy)
+					delete(breakdown, "resources")
+				}
+			}
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+func groupResourcesList(v interface{}, groupBy string) map[string]interface{} {
+	groups := make(map[string]interface{})
+
+	resources, ok := v.([]interface{})
+	if !ok {
+		return groups
+	}
+
+	type subtotal struct {
+		resources        []interface{}
+		totalHourlyCost  *decimal.Decimal
+		totalMonthlyCost *decimal.Decimal
+		totalAnnualCost  *decimal.Decimal
+	}
+	subtotals := make(map[string]*subtotal)
+
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		key := jsonResourceGroupKey(resource, groupBy)
+		s, ok := subtotals[key]
+		if !ok {
+			s = &subtotal{resources: make([]interface{}, 0)}
+			subtotals[key] = s
+		}
+
+		s.resources = append(s.resources, resource)
+		s.totalHourlyCost = addJSONCost(s.totalHourlyCost, resource["hourlyCost"])
+		s.totalMonthlyCost = addJSONCost(s.totalMonthlyCost, resource["monthlyCost"])
+		s.totalAnnualCost = addJSONCost(s.totalAnnualCost, resource["annualCost"])
+	}
+
+	for key, s := range subtotals {
+		groups[key] = map[string]interface{}{
+			"resources":        s.resources,
+			"totalHourlyCost":  s.totalHourlyCost,
+			"totalMonthlyCost": s.totalMonthlyCost,
+			"totalAnnualCost":  s.totalAnnualCost,
+		}
+	}
+
+	return groups
+}
+
+func jsonResourceGroupKey(resource map[string]interface{}, groupBy string) string {
+	name, _ := resource["name"].(string)
+
+	switch groupBy {
+	case GroupByService:
+		return resourceTypeFromName(name)
+	case GroupByRegion:
+		region, _ := resource["region"].(string)
+		return region
+	case GroupByModule:
+		return strings.TrimSuffix(modulePrefixRegex.FindString(name), ".")
+	}
+
+	tags, _ := resource["tags"].(map[string]interface{})
+	value, _ := tags[strings.TrimPrefix(groupBy, GroupByTag+":")].(string)
+	return value
+}
+
+// addJSONCost adds the cost from the decoded JSON to the total. The total is
+// nil if none of the costs are set.
+func addJSONCost(total *decimal.Decimal, v interface{}) *decimal.Decimal {
+	// Decimals are marshaled as strings
+	s, ok := v.(string)
+	if !ok {
+		return total
+	}
+
+	cost, err := decimal.NewFromString(s)
+	if err != nil {
+		return total
+	}
+
+	if total == nil {
+		return &cost
+	}
+
+	return decimalPtr(total.Add(cost))
+}
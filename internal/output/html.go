@@ -8,12 +8,99 @@ import (
 	"strings"
 
 	"github.com/Masterminds/sprig"
+	"github.com/shopspring/decimal"
 )
 
+type htmlDiffRow struct {
+	Project string
+	Name    string
+	Op      string
+	OpChar  string
+	OldCost *decimal.Decimal
+	NewCost *decimal.Decimal
+	Change  *decimal.Decimal
+}
+
+// reportAssumptions returns the assumptions shown in the report for the
+// currency of the output. Prices in other currencies are converted from USD.
+func reportAssumptions(currency string) []string {
+	prices := "Prices are public on-demand prices in USD and do not include any discounts or credits."
+	if currency != "" && !strings.EqualFold(currency, BaseCurrency) {
+		prices = fmt.Sprintf("Prices are public on-demand prices in USD converted to %s and do not include any discounts or credits.", strings.ToUpper(currency))
+	}
+
+	return []string{
+		prices,
+		"Monthly costs are calculated using 730 hours per month.",
+		"Usage-based costs are only estimated for resources with values in the usage file.",
+	}
+}
+
+// ToHTML renders the output using the default HTML layout. If opts.Report is set the
+// report layout is used, which shows the breakdown, diff and metadata in tabs. The
+// layout can be overridden with opts.HTMLTemplate, which can use any of the
+// templates defined in HTMLTemplate.
 func ToHTML(out Root, opts Options) ([]byte, error) {
 	var buf bytes.Buffer
 	bufw := bufio.NewWriter(&buf)
 
+	tmpl, err := newHTMLTemplate(newNumberFormat(opts))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	layout := ""
+	if opts.HTMLTemplate != "" {
+		layout = opts.HTMLTemplate
+	} else if opts.Report {
+		layout = ReportHTMLTemplate
+	}
+
+	if layout != "" {
+		tmpl, err = tmpl.New("layout").Parse(layout)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	unsupportedResourcesMessage := out.unsupportedResourcesMessage(opts.ShowSkipped)
+
+	out.Resources = limitResourceDepth(out.Resources, opts.MaxResourceDepth)
+
+	diffRows, pastTotal, newTotal, diffTotal := htmlDiffRows(out)
+
+	warnings := make([]string, 0)
+	if unsupportedResourcesMessage != "" {
+		warnings = append(warnings, unsupportedResourcesMessage)
+	}
+	if hasNilCosts(out) {
+		warnings = append(warnings, "Some resources have usage-based costs that are not estimated, use --usage-file to estimate them, see https://infracost.io/usage-file")
+	}
+
+	err = tmpl.Execute(bufw, struct {
+		Root                        Root
+		UnsupportedResourcesMessage string
+		Options                     Options
+		HasDiff                     bool
+		DiffRows                    []htmlDiffRow
+		PastTotalMonthlyCost        *decimal.Decimal
+		NewTotalMonthlyCost         *decimal.Decimal
+		DiffTotalMonthlyCost        *decimal.Decimal
+		Assumptions                 []string
+		Warnings                    []string
+	}{out, unsupportedResourcesMessage, opts, hasDiff(out), diffRows, pastTotal, newTotal, diffTotal, reportAssumptions(opts.Currency), warnings})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	bufw.Flush()
+	return buf.Bytes(), nil
+}
+
+// newHTMLTemplate returns the base template with the templates defined in
+// HTMLTemplate, which layouts can use. Costs are formatted in the currency
+// and locale.
+func newHTMLTemplate(nf numberFormat) (*template.Template, error) {
 	tmpl := template.New("base")
 	tmpl.Funcs(sprig.FuncMap())
 	tmpl.Funcs(template.FuncMap{
@@ -25,26 +112,104 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 			safe = strings.ReplaceAll(safe, "\n", "<br />")
 			return template.HTML(safe) // nolint:gosec
 		},
-		"formatCost2DP":  formatCost2DP,
-		"formatPrice":    formatPrice,
-		"formatQuantity": formatQuantity,
+		"formatCost2DP": func(d *decimal.Decimal) string {
+			return formatCost2DP(d, nf)
+		},
+		"formatPrice": func(d decimal.Decimal) string {
+			return formatPrice(d, nf)
+		},
+		"formatQuantity": func(q *decimal.Decimal) string {
+			return formatQuantity(q, nf)
+		},
+		"isNested": func(c CostComponent) bool {
+			return c.nested
+		},
 	})
-	tmpl, err := tmpl.Parse(HTMLTemplate)
-	if err != nil {
-		return []byte{}, err
+
+	return tmpl.Parse(HTMLTemplate)
+}
+
+func hasDiff(out Root) bool {
+	for _, p := range out.Projects {
+		if p.Diff != nil {
+			return true
+		}
 	}
 
-	unsupportedResourcesMessage := out.unsupportedResourcesMessage(opts.ShowSkipped)
+	return false
+}
 
-	err = tmpl.Execute(bufw, struct {
-		Root                        Root
-		UnsupportedResourcesMessage string
-		Options                     Options
-	}{out, unsupportedResourcesMessage, opts})
-	if err != nil {
-		return []byte{}, err
+func hasNilCosts(out Root) bool {
+	for _, r := range out.Resources {
+		if resourceHasNilCosts(r) {
+			return true
+		}
 	}
 
-	bufw.Flush()
-	return buf.Bytes(), nil
+	return false
+}
+
+// htmlDiffRows returns a row for each changed top-level resource along with
+// the past, new and diff total monthly costs of the projects that have a diff.
+func htmlDiffRows(out Root) ([]htmlDiffRow, *decimal.Decimal, *decimal.Decimal, *decimal.Decimal) {
+	rows := make([]htmlDiffRow, 0)
+	var pastTotal, newTotal, diffTotal *decimal.Decimal
+
+	for _, p := range out.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		if p.PastBreakdown != nil && p.PastBreakdown.TotalMonthlyCost != nil {
+			pastTotal = addDecimals(pastTotal, p.PastBreakdown.TotalMonthlyCost)
+		}
+		if p.Breakdown != nil && p.Breakdown.TotalMonthlyCost != nil {
+			newTotal = addDecimals(newTotal, p.Breakdown.TotalMonthlyCost)
+		}
+		if p.Diff.TotalMonthlyCost != nil {
+			diffTotal = addDecimals(diffTotal, p.Diff.TotalMonthlyCost)
+		}
+
+		for _, diffResource := range p.Diff.Resources {
+			var oldResource, newResource *Resource
+			if p.PastBreakdown != nil {
+				oldResource = findResourceByName(p.PastBreakdown.Resources, diffResource.Name)
+			}
+			if p.Breakdown != nil {
+				newResource = findResourceByName(p.Breakdown.Resources, diffResource.Name)
+			}
+
+			row := htmlDiffRow{
+				Project: p.Label(),
+				Name:    diffResource.Name,
+				Op:      "updated",
+				OpChar:  "~",
+				Change:  diffResource.MonthlyCost,
+			}
+
+			if oldResource == nil {
+				row.Op, row.OpChar = "added", "+"
+			} else {
+				row.OldCost = oldResource.MonthlyCost
+			}
+
+			if newResource == nil {
+				row.Op, row.OpChar = "removed", "-"
+			} else {
+				row.NewCost = newResource.MonthlyCost
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, pastTotal, newTotal, diffTotal
+}
+
+func addDecimals(a *decimal.Decimal, b *decimal.Decimal) *decimal.Decimal {
+	if a == nil {
+		return b
+	}
+
+	return decimalPtr(a.Add(*b))
 }
@@ -1,9 +1,366 @@
 package output
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"io"
 )
 
+// ToJSON returns the Infracost JSON. If opts.JSONFields is set the resources
+// only include their name, resourceHash and those fields, which makes the
+// output smaller but means it can't be used as the input of other commands,
+// e.g. for diffs. If opts.JSONGroupBy is set the resources are grouped by that
+// key with a subtotal for each group, which also can't be used as the input
+// of other commands.
 func ToJSON(out Root, opts Options) ([]byte, error) {
-	return json.Marshal(out)
+	out = withAnnualCosts(out)
+
+	if opts.SummaryOnly {
+		out = withoutResources(out)
+	} else {
+		out.Summary = withoutResourceCounts(out.Summary)
+	}
+
+	if opts.ProjectGrowth != nil {
+		out.Projection = BuildProjection(out.TotalMonthlyCost, *opts.ProjectGrowth)
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return b, err
+	}
+
+	if len(opts.JSONFields) > 0 {
+		b, err = pruneJSONFields(b, opts.JSONFields)
+		if err != nil {
+			return b, err
+		}
+	}
+
+	if opts.JSONGroupBy != "" {
+		return groupJSONResources(b, opts.JSONGroupBy)
+	}
+
+	return b, nil
+}
+
+// WriteJSON writes the same Infracost JSON as ToJSON to w. The resources are
+// marshaled one at a time so large plans don't need the whole output in
+// memory. If opts.JSONFields or opts.JSONGroupBy are set the JSON is built in
+// memory since pruning and grouping need the full output.
+func WriteJSON(w io.Writer, out Root, opts Options) error {
+	if len(opts.JSONFields) > 0 || opts.JSONGroupBy != "" {
+		b, err := ToJSON(out, opts)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+		return err
+	}
+
+	out = withAnnualCosts(out)
+
+	if opts.SummaryOnly {
+		out = withoutResources(out)
+	} else {
+		out.Summary = withoutResourceCounts(out.Summary)
+	}
+
+	if opts.ProjectGrowth != nil {
+		out.Projection = BuildProjection(out.TotalMonthlyCost, *opts.ProjectGrowth)
+	}
+
+	jw := &jsonWriter{w: bufio.NewWriter(w)}
+
+	jw.raw(`{"version":`)
+	jw.value(out.Version)
+	jw.raw(`,"baseCurrency":`)
+	jw.value(out.BaseCurrency)
+	if out.TargetCurrency != "" {
+		jw.raw(`,"targetCurrency":`)
+		jw.value(out.TargetCurrency)
+	}
+	if out.ExchangeRate != nil {
+		jw.raw(`,"exchangeRate":`)
+		jw.value(out.ExchangeRate)
+	}
+	if out.GroupBy != "" {
+		jw.raw(`,"groupBy":`)
+		jw.value(out.GroupBy)
+	}
+	jw.raw(`,"resources":`)
+	jw.resources(out.Resources)
+	jw.raw(`,"totalHourlyCost":`)
+	jw.value(out.TotalHourlyCost)
+	jw.raw(`,"totalMonthlyCost":`)
+	jw.value(out.TotalMonthlyCost)
+	jw.raw(`,"totalAnnualCost":`)
+	jw.value(out.TotalAnnualCost)
+	jw.raw(`,"projects":`)
+
+	if out.Projects == nil {
+		jw.raw("null")
+	} else {
+		jw.raw("[")
+		for i, p := range out.Projects {
+			if i > 0 {
+				jw.raw(",")
+			}
+
+			jw.raw(`{"path":`)
+			jw.value(p.Path)
+			jw.raw(`,"metadata":`)
+			jw.value(p.Metadata)
+			if p.PlanMetadata != nil {
+				jw.raw(`,"planMetadata":`)
+				jw.value(p.PlanMetadata)
+			}
+			if p.PastPlanMetadata != nil {
+				jw.raw(`,"pastPlanMetadata":`)
+				jw.value(p.PastPlanMetadata)
+			}
+			jw.raw(`,"pastBreakdown":`)
+			jw.breakdown(p.PastBreakdown)
+			jw.raw(`,"breakdown":`)
+			jw.breakdown(p.Breakdown)
+			jw.raw(`,"diff":`)
+			jw.breakdown(p.Diff)
+			jw.raw(`,"skippedResources":`)
+			jw.value(p.SkippedResources)
+			jw.raw("}")
+		}
+		jw.raw("]")
+	}
+
+	jw.raw(`,"timeGenerated":`)
+	jw.value(out.TimeGenerated)
+	jw.raw(`,"summary":`)
+	jw.value(out.Summary)
+
+	if out.Projection != nil {
+		jw.raw(`,"projection":`)
+		jw.value(out.Projection)
+	}
+
+	jw.raw("}")
+
+	if jw.err != nil {
+		return jw.err
+	}
+
+	return jw.w.Flush()
+}
+
+// jsonWriter writes JSON in the same format as json.Marshal. After the first
+// error all the writes are skipped and the error is kept in err.
+type jsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+	err error
+}
+
+func (jw *jsonWriter) raw(s string) {
+	if jw.err != nil {
+		return
+	}
+
+	_, jw.err = jw.w.WriteString(s)
+}
+
+func (jw *jsonWriter) value(v interface{}) {
+	if jw.err != nil {
+		return
+	}
+
+	if jw.enc == nil {
+		// The encoder reuses its buffer between values, unlike json.Marshal
+		jw.enc = json.NewEncoder(trimNewlineWriter{jw.w})
+	}
+
+	jw.err = jw.enc.Encode(v)
+}
+
+// trimNewlineWriter drops the newline that json.Encoder adds after each value
+// so the output is the same as json.Marshal.
+type trimNewlineWriter struct {
+	w io.Writer
+}
+
+func (t trimNewlineWriter) Write(b []byte) (int, error) {
+	n, err := t.w.Write(bytes.TrimSuffix(b, []byte("\n")))
+	if err == nil {
+		n = len(b)
+	}
+
+	return n, err
+}
+
+func (jw *jsonWriter) resources(resources []Resource) {
+	if resources == nil {
+		jw.raw("null")
+		return
+	}
+
+	jw.raw("[")
+	for i := range resources {
+		if i > 0 {
+			jw.raw(",")
+		}
+		// Pass a pointer so the resource isn't copied into the interface
+		jw.value(&resources[i])
+	}
+	jw.raw("]")
+}
+
+func (jw *jsonWriter) breakdown(b *Breakdown) {
+	if b == nil {
+		jw.raw("null")
+		return
+	}
+
+	jw.raw(`{"resources":`)
+	jw.resources(b.Resources)
+	jw.raw(`,"totalHourlyCost":`)
+	jw.value(b.TotalHourlyCost)
+	jw.raw(`,"totalMonthlyCost":`)
+	jw.value(b.TotalMonthlyCost)
+	jw.raw(`,"totalAnnualCost":`)
+	jw.value(b.TotalAnnualCost)
+	jw.raw("}")
+}
+
+// pruneJSONFields removes all the resource and cost component keys apart from
+// the name and the fields. The summary and totals are unchanged.
+func pruneJSONFields(b []byte, fields []string) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var root map[string]interface{}
+	if err := d.Decode(&root); err != nil {
+		return nil, err
+	}
+
+	root["resources"] = pruneJSONResources(root["resources"], fields)
+
+	if projects, ok := root["projects"].([]interface{}); ok {
+		for _, p := range projects {
+			project, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, key := range []string{"pastBreakdown", "breakdown", "diff"} {
+				if breakdown, ok := project[key].(map[string]interface{}); ok {
+					breakdown["resources"] = pruneJSONResources(breakdown["resources"], fields)
+				}
+			}
+		}
+	}
+
+	return json.Marshal(root)
+}
+
+func pruneJSONResources(v interface{}, fields []string) interface{} {
+	resources, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	for i, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pruned := pruneJSONKeys(resource, []string{"hourlyCost", "monthlyCost"}, fields)
+		// The annual cost is kept with the monthly cost it's calculated from
+		if _, ok := pruned["monthlyCost"]; ok {
+			pruned["annualCost"] = resource["annualCost"]
+		}
+		// Keep the hash so the pruned resources can still be matched across runs
+		if h, ok := resource["resourceHash"]; ok {
+			pruned["resourceHash"] = h
+		}
+
+		if costComponents, ok := resource["costComponents"].([]interface{}); ok {
+			for j, c := range costComponents {
+				if costComponent, ok := c.(map[string]interface{}); ok {
+					costComponents[j] = pruneJSONKeys(costComponent, validJSONCostComponentFields, fields)
+				}
+			}
+			pruned["costComponents"] = costComponents
+		}
+
+		if subresources, ok := resource["subresources"]; ok {
+			pruned["subresources"] = pruneJSONResources(subresources, fields)
+		}
+
+		resources[i] = pruned
+	}
+
+	return resources
+}
+
+var validJSONCostComponentFields = []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
+
+// pruneJSONKeys returns the name and the keys that are allowed and in fields.
+func pruneJSONKeys(m map[string]interface{}, allowed []string, fields []string) map[string]interface{} {
+	pruned := map[string]interface{}{"name": m["name"]}
+
+	for _, k := range allowed {
+		if v, ok := m[k]; ok && contains(fields, k) {
+			pruned[k] = v
+		}
+	}
+
+	return pruned
+}
+
+// withoutResources returns a copy of the output with the resource arrays removed
+// so only the totals and summary remain.
+func withoutResources(out Root) Root {
+	out.Resources = nil
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		p.PastBreakdown = breakdownWithoutResources(p.PastBreakdown)
+		p.Breakdown = breakdownWithoutResources(p.Breakdown)
+		p.Diff = breakdownWithoutResources(p.Diff)
+		projects = append(projects, p)
+	}
+	out.Projects = projects
+
+	return out
+}
+
+// withoutResourceCounts returns a copy of the summary without the resource
+// counts that are only in the JSON with --summary-only. The unsupported
+// resource counts are kept since they've always been in the JSON.
+func withoutResourceCounts(s *Summary) *Summary {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.SupportedResourceCounts = nil
+	c.TotalSupportedResources = nil
+	c.TotalUnsupportedResources = nil
+	c.TotalNoPriceResources = nil
+	c.TotalResources = nil
+
+	return &c
+}
+
+func breakdownWithoutResources(b *Breakdown) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	return &Breakdown{
+		TotalHourlyCost:  b.TotalHourlyCost,
+		TotalMonthlyCost: b.TotalMonthlyCost,
+		TotalAnnualCost:  b.TotalAnnualCost,
+	}
 }
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FlatRecord is a cost component of a resource with the fields of its project
+// and resource, so each record can be loaded into a data warehouse table
+// without the rest of the output.
+type FlatRecord struct {
+	TimeGenerated   time.Time         `json:"timeGenerated"`
+	Version         string            `json:"version"`
+	Currency        string            `json:"currency"`
+	Project         string            `json:"project"`
+	ProjectPath     string            `json:"projectPath"`
+	ProjectMetadata map[string]string `json:"projectMetadata"`
+	ResourceAddress string            `json:"resourceAddress"`
+	ResourceType    string            `json:"resourceType"`
+	Region          string            `json:"region"`
+	Tags            map[string]string `json:"tags"`
+	Metadata        map[string]string `json:"metadata"`
+	CostComponent   string            `json:"costComponent"`
+	Unit            string            `json:"unit"`
+	MonthlyQuantity *decimal.Decimal  `json:"monthlyQuantity"`
+	Price           decimal.Decimal   `json:"price"`
+	HourlyCost      *decimal.Decimal  `json:"hourlyCost"`
+	MonthlyCost     *decimal.Decimal  `json:"monthlyCost"`
+}
+
+// ToFlatJSON returns a JSON array with a record for each cost component of the
+// projects, like the CSV output. Sub-resources are included with their address
+// after their parent's, e.g. aws_instance.web.root_block_device.
+func ToFlatJSON(out Root, opts Options) ([]byte, error) {
+	currency := out.TargetCurrency
+	if currency == "" {
+		currency = BaseCurrency
+	}
+
+	records := make([]FlatRecord, 0)
+
+	// Sub-resources have the type and region of their top-level resource
+	var addRecords func(p Project, resourceType string, region string, prefix string, resources []Resource)
+	addRecords = func(p Project, resourceType string, region string, prefix string, resources []Resource) {
+		for _, r := range resources {
+			address := prefix + r.Name
+
+			t := resourceType
+			if t == "" {
+				t = resourceTypeFromName(r.Name)
+			}
+
+			reg := region
+			if r.Region != "" {
+				reg = r.Region
+			}
+
+			for _, c := range r.CostComponents {
+				records = append(records, FlatRecord{
+					TimeGenerated:   out.TimeGenerated,
+					Version:         out.Version,
+					Currency:        currency,
+					Project:         p.Label(),
+					ProjectPath:     p.Path,
+					ProjectMetadata: p.Metadata,
+					ResourceAddress: address,
+					ResourceType:    t,
+					Region:          reg,
+					Tags:            r.Tags,
+					Metadata:        r.Metadata,
+					CostComponent:   c.Name,
+					Unit:            c.Unit,
+					MonthlyQuantity: c.MonthlyQuantity,
+					Price:           c.Price,
+					HourlyCost:      c.HourlyCost,
+					MonthlyCost:     c.MonthlyCost,
+				})
+			}
+
+			addRecords(p, t, reg, address+".", r.SubResources)
+		}
+	}
+
+	if !opts.SummaryOnly {
+		for _, p := range out.Projects {
+			if p.Breakdown == nil {
+				continue
+			}
+
+			addRecords(p, "", "", "", p.Breakdown.Resources)
+		}
+	}
+
+	return json.Marshal(records)
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit returns a JUnit XML test suite with a test case for each project.
+// If opts.Threshold is set the projects with a monthly cost above it fail,
+// with how much they're over it in the failure message. Projects without a
+// breakdown couldn't be estimated, so they're errors.
+func ToJUnit(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	suite := junitTestSuite{
+		Name:      "Infracost",
+		TestCases: make([]junitTestCase, 0, len(out.Projects)),
+	}
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      p.Label(),
+				ClassName: "infracost",
+				Error: &junitFailure{
+					Message: "Error estimating the costs of the project, it has no breakdown",
+					Type:    "error",
+				},
+			})
+			suite.Errors++
+			continue
+		}
+
+		monthlyCost := decimalOrZero(p.Breakdown.TotalMonthlyCost)
+
+		tc := junitTestCase{
+			Name:      p.Label(),
+			ClassName: "infracost",
+			SystemOut: projectCostSummary(p, nf),
+		}
+
+		if opts.Threshold != nil && monthlyCost.GreaterThan(*opts.Threshold) {
+			over := monthlyCost.Sub(*opts.Threshold)
+			msg := fmt.Sprintf("Monthly cost %s is %s over the threshold of %s",
+				formatCost2DP(&monthlyCost, nf), formatCost2DP(&over, nf), formatCost2DP(opts.Threshold, nf))
+
+			tc.Failure = &junitFailure{
+				Message: msg,
+				Type:    "threshold",
+				Text:    tc.SystemOut,
+			}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	b, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+// projectCostSummary returns the monthly cost of the project and, if it has a
+// diff, its monthly cost change.
+func projectCostSummary(p Project, nf numberFormat) string {
+	monthlyCost := decimalOrZero(p.Breakdown.TotalMonthlyCost)
+	s := fmt.Sprintf("Monthly cost: %s", formatCost2DP(&monthlyCost, nf))
+
+	if p.Diff != nil && p.PastBreakdown != nil {
+		change := decimalOrZero(p.Diff.TotalMonthlyCost)
+		s += fmt.Sprintf("\nMonthly cost change: %s", formatCostChange2DP(&change, nf))
+	}
+
+	return s
+}
@@ -0,0 +1,465 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Markdown styles. MarkdownStylePlain is Markdown without any platform
+// specific HTML or emoji, suitable for wikis and docs. MarkdownStyleGitHub is
+// for PR comments, with a summary table of the projects and the resource
+// breakdowns collapsed in a <details> block. MarkdownStyleGitLab is an alias of
+// MarkdownStyleGitHub for MR comments since GitLab renders them the same way.
+const (
+	MarkdownStylePlain  = "plain"
+	MarkdownStyleGitHub = "github"
+	MarkdownStyleGitLab = "gitlab"
+)
+
+// isMarkdownCommentStyle returns true if the style is for PR or MR comments.
+func isMarkdownCommentStyle(style string) bool {
+	return style == MarkdownStyleGitHub || style == MarkdownStyleGitLab
+}
+
+// markdownMaxNameLength is the length that names in the comment summary table
+// are truncated to, so it fits the width of the PR UI without scrolling.
+const markdownMaxNameLength = 64
+
+// ValidateMarkdownStyle returns an error if the style isn't empty or one of the
+// Markdown styles.
+func ValidateMarkdownStyle(style string) error {
+	switch style {
+	case "", MarkdownStylePlain, MarkdownStyleGitHub, MarkdownStyleGitLab:
+		return nil
+	}
+
+	return fmt.Errorf("Invalid markdown style %s, it must be one of: %s, %s, %s", style, MarkdownStylePlain, MarkdownStyleGitHub, MarkdownStyleGitLab)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+var skippedCountRegex = regexp.MustCompile(`^\d+ x `)
+
+// ToMarkdown returns a Markdown table of the breakdown of each project, or of
+// the diff if the projects have one. opts.MarkdownStyle sets whether it's plain
+// Markdown or a GitHub or GitLab comment.
+func ToMarkdown(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	if isMarkdownCommentStyle(opts.MarkdownStyle) {
+		return toMarkdownComment(out, opts)
+	}
+
+	s := ""
+
+	showDiff := hasDiff(out)
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		s += fmt.Sprintf("## Project: %s\n\n", escapeMarkdown(project.Label()))
+
+		if showDiff && project.Diff != nil {
+			s += markdownDiffTable(project, opts.SummaryOnly, opts.NoSummary, false, nf)
+		} else {
+			breakdown := *project.Breakdown
+			if opts.SummaryOnly {
+				breakdown.Resources = nil
+			}
+
+			s += markdownBreakdownTable(breakdown, opts.Fields, opts.NoSummary, nf)
+		}
+
+		s += "\n"
+	}
+
+	if opts.NoSummary {
+		return []byte(strings.TrimRight(s, "\n") + "\n"), nil
+	}
+
+	if len(out.Projects) > 1 {
+		if showDiff {
+			_, pastTotal, newTotal, diffTotal := htmlDiffRows(out)
+			hourlyChange, _ := overallCostChanges(out)
+			for _, t := range periodTotals(markdownCostPeriod(opts.CostPeriod), hourlyChange, diffTotal) {
+				change := formatCostChange2DP(t.Cost, nf)
+				if t.Period == CostPeriodMonthly {
+					change = formatCostChange(t.Cost, nf) + formatCostChangeDetails(pastTotal, newTotal, nf)
+				}
+				s += fmt.Sprintf("**Overall %s cost change: %s**\n\n", t.Period, change)
+			}
+		} else {
+			totals := periodTotals(markdownCostPeriod(opts.CostPeriod), out.TotalHourlyCost, out.TotalMonthlyCost)
+			s += fmt.Sprintf("**Overall total: %s per %s (%s per %s)**\n\n", formatCost2DP(totals[0].Cost, nf), totals[0].unit(), formatCost2DP(totals[1].Cost, nf), totals[1].unit())
+		}
+	}
+
+	s += markdownNotes(out, opts)
+
+	return []byte(strings.TrimRight(s, "\n") + "\n"), nil
+}
+
+// markdownCostPeriod returns the cost period of the overall totals, which are
+// monthly and then hourly by default.
+func markdownCostPeriod(period string) string {
+	if period == "" {
+		return CostPeriodMonthly
+	}
+	return period
+}
+
+// toMarkdownComment returns a PR or MR comment with a bold line of the total
+// monthly cost, or its change if the projects have a diff, a table of the
+// projects and their breakdowns or diffs in a collapsed <details> block. The
+// diffs have 📈 and 📉 for increases and decreases.
+func toMarkdownComment(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	s := ""
+
+	showDiff := hasDiff(out)
+
+	if !opts.NoSummary {
+		if showDiff {
+			_, pastTotal, newTotal, diffTotal := htmlDiffRows(out)
+			s += fmt.Sprintf("**Monthly cost change: %s%s%s**\n\n", formatCostChange(diffTotal, nf), formatCostChangeDetails(pastTotal, newTotal, nf), costChangeEmoji(diffTotal))
+		} else {
+			s += fmt.Sprintf("**Total monthly cost: %s**\n\n", formatCost2DP(out.TotalMonthlyCost, nf))
+		}
+
+		s += markdownProjectsTable(out, showDiff, nf)
+		s += "\n"
+	}
+
+	if !opts.SummaryOnly {
+		details := ""
+		for _, project := range out.Projects {
+			if project.Breakdown == nil {
+				continue
+			}
+
+			details += fmt.Sprintf("#### %s\n\n", escapeMarkdown(project.Label()))
+
+			if showDiff && project.Diff != nil {
+				details += markdownDiffTable(project, false, opts.NoSummary, true, nf)
+			} else {
+				details += markdownBreakdownTable(*project.Breakdown, opts.Fields, opts.NoSummary, nf)
+			}
+
+			details += "\n"
+		}
+
+		summary := "Cost breakdown"
+		if showDiff {
+			summary = "Cost changes by resource"
+		}
+
+		// The blank lines are needed for the Markdown inside the block to be rendered
+		s += fmt.Sprintf("<details>\n<summary>%s</summary>\n\n%s</details>\n\n", summary, details)
+	}
+
+	s += markdownNotes(out, opts)
+
+	return []byte(strings.TrimRight(s, "\n") + "\n"), nil
+}
+
+// markdownProjectsTable returns a table of the monthly cost of each project, or
+// of its previous and new monthly costs and the change if it has a diff.
+func markdownProjectsTable(out Root, showDiff bool, nf numberFormat) string {
+	var s string
+	if showDiff {
+		s = markdownRow([]string{"Project", "Previous", "New", "Monthly Cost Change"})
+		s += markdownRow([]string{"---", "---:", "---:", "---:"})
+	} else {
+		s = markdownRow([]string{"Project", "Monthly Cost"})
+		s += markdownRow([]string{"---", "---:"})
+	}
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		name := escapeMarkdown(truncateName(project.Label(), markdownMaxNameLength))
+
+		if !showDiff {
+			s += markdownRow([]string{name, formatCost2DP(project.Breakdown.TotalMonthlyCost, nf)})
+			continue
+		}
+
+		var pastTotal, change string
+		if project.PastBreakdown != nil {
+			pastTotal = formatCost2DP(project.PastBreakdown.TotalMonthlyCost, nf)
+		}
+		if project.Diff != nil {
+			change = formatCostChange(project.Diff.TotalMonthlyCost, nf)
+			if p := formatPercentChange(pastBreakdownCost(project), project.Breakdown.TotalMonthlyCost, nf); p != "" {
+				change += fmt.Sprintf(" (%s)", p)
+			}
+			change += costChangeEmoji(project.Diff.TotalMonthlyCost)
+		}
+
+		s += markdownRow([]string{name, pastTotal, formatCost2DP(project.Breakdown.TotalMonthlyCost, nf), change})
+	}
+
+	return s
+}
+
+func pastBreakdownCost(project Project) *decimal.Decimal {
+	if project.PastBreakdown == nil {
+		return nil
+	}
+	return project.PastBreakdown.TotalMonthlyCost
+}
+
+// costChangeEmoji returns 📈 for an increase and 📉 for a decrease, with a
+// leading space.
+func costChangeEmoji(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	switch d.Sign() {
+	case 1:
+		return " 📈"
+	case -1:
+		return " 📉"
+	default:
+		return ""
+	}
+}
+
+// truncateName shortens names longer than max with an ellipsis in the middle,
+// since the start and end of paths and addresses are the useful parts.
+func truncateName(name string, max int) string {
+	runes := []rune(name)
+	if len(runes) <= max {
+		return name
+	}
+
+	half := (max - 1) / 2
+	return string(runes[:half]) + "…" + string(runes[len(runes)-(max-1-half):])
+}
+
+// markdownNotes returns the notes about the resource counts, usage-based
+// resources and unsupported resources.
+func markdownNotes(out Root, opts Options) string {
+	notes := make([]string, 0)
+
+	if opts.SummaryOnly {
+		notes = append(notes, out.resourceCountsMessage())
+	}
+
+	if hasNilCosts(out) {
+		notes = append(notes, "To estimate usage-based resources use --usage-file, see https://infracost.io/usage-file")
+	}
+
+	if msg := out.unsupportedResourcesMessage(opts.ShowSkipped); msg != "" {
+		notes = append(notes, msg)
+	}
+
+	s := ""
+	for _, note := range notes {
+		s += markdownNote(note)
+	}
+
+	return s
+}
+
+func markdownBreakdownTable(breakdown Breakdown, fields []string, noSummary bool, nf numberFormat) string {
+	headers := []string{"Name"}
+	separators := []string{"---"}
+
+	columns := []struct {
+		field      string
+		header     string
+		rightAlign bool
+	}{
+		{"price", "Price", true},
+		{"monthlyQuantity", "Monthly Qty", true},
+		{"unit", "Unit", false},
+		{"hourlyCost", "Hourly Cost", true},
+		{"monthlyCost", "Monthly Cost", true},
+	}
+
+	for _, c := range columns {
+		if contains(fields, c.field) {
+			headers = append(headers, c.header)
+			if c.rightAlign {
+				separators = append(separators, "---:")
+			} else {
+				separators = append(separators, "---")
+			}
+		}
+	}
+
+	s := markdownRow(headers) + markdownRow(separators)
+
+	addCostComponentRows := func(prefix string, costComponents []CostComponent, hasSubResources bool) {
+		for i, c := range costComponents {
+			labelPrefix := prefix + "├─"
+			if !hasSubResources && i == len(costComponents)-1 {
+				labelPrefix = prefix + "└─"
+			}
+
+			row := []string{escapeMarkdown(fmt.Sprintf("%s %s", labelPrefix, c.Name))}
+
+			if c.MonthlyCost == nil {
+				// The note is in the last column, there's nowhere to show it
+				// if there's only the name column
+				if len(headers) > 1 {
+					row = append(row, make([]string, len(headers)-2)...)
+					row = append(row, escapeMarkdown(fmt.Sprintf("Monthly cost depends on usage: %s per %s", formatPrice(c.Price, nf), c.Unit)))
+				}
+				s += markdownRow(row)
+				continue
+			}
+
+			if contains(fields, "price") {
+				row = append(row, formatPrice(c.Price, nf))
+			}
+			if contains(fields, "monthlyQuantity") {
+				row = append(row, formatQuantity(c.MonthlyQuantity, nf))
+			}
+			if contains(fields, "unit") {
+				row = append(row, escapeMarkdown(c.Unit))
+			}
+			if contains(fields, "hourlyCost") {
+				row = append(row, formatCost2DP(c.HourlyCost, nf))
+			}
+			if contains(fields, "monthlyCost") {
+				row = append(row, formatCost2DP(c.MonthlyCost, nf))
+			}
+
+			s += markdownRow(row)
+		}
+	}
+
+	var addSubResourceRows func(prefix string, subresources []Resource)
+	addSubResourceRows = func(prefix string, subresources []Resource) {
+		for i, r := range subresources {
+			labelPrefix := prefix + "├─"
+			nextPrefix := prefix + "│  "
+			if i == len(subresources)-1 {
+				labelPrefix = prefix + "└─"
+				nextPrefix = prefix + "   "
+			}
+
+			s += markdownRow(append([]string{escapeMarkdown(fmt.Sprintf("%s %s", labelPrefix, r.Name))}, make([]string, len(headers)-1)...))
+
+			addCostComponentRows(nextPrefix, r.CostComponents, len(r.SubResources) > 0)
+			addSubResourceRows(nextPrefix, r.SubResources)
+		}
+	}
+
+	for _, r := range breakdown.Resources {
+		s += markdownRow(append([]string{"**" + escapeMarkdown(r.Name) + "**"}, make([]string, len(headers)-1)...))
+
+		addCostComponentRows("", r.CostComponents, len(r.SubResources) > 0)
+		addSubResourceRows("", r.SubResources)
+	}
+
+	if noSummary {
+		return s
+	}
+
+	if len(headers) == 1 {
+		s += markdownRow([]string{"**Project total: " + formatCost2DP(breakdown.TotalMonthlyCost, nf) + "**"})
+		return s
+	}
+
+	total := append([]string{"**Project total**"}, make([]string, len(headers)-1)...)
+	total[len(headers)-1] = "**" + formatCost2DP(breakdown.TotalMonthlyCost, nf) + "**"
+	s += markdownRow(total)
+
+	return s
+}
+
+// markdownDiffTable returns the diff of the project, with 📈 and 📉 for the
+// cost changes if emoji is set.
+func markdownDiffTable(project Project, summaryOnly bool, noSummary bool, emoji bool, nf numberFormat) string {
+	s := markdownRow([]string{"Name", "Previous", "New", "Monthly Cost Change"})
+	s += markdownRow([]string{"---", "---:", "---:", "---:"})
+
+	if !summaryOnly {
+		for _, diffResource := range project.Diff.Resources {
+			var oldCost, newCost string
+			if project.PastBreakdown != nil {
+				if r := findResourceByName(project.PastBreakdown.Resources, diffResource.Name); r != nil {
+					oldCost = formatCost2DP(r.MonthlyCost, nf)
+				}
+			}
+			if project.Breakdown != nil {
+				if r := findResourceByName(project.Breakdown.Resources, diffResource.Name); r != nil {
+					newCost = formatCost2DP(r.MonthlyCost, nf)
+				}
+			}
+
+			change := formatCostChange(diffResource.MonthlyCost, nf)
+			if emoji {
+				change += costChangeEmoji(diffResource.MonthlyCost)
+			}
+
+			s += markdownRow([]string{escapeMarkdown(diffResource.Name), oldCost, newCost, change})
+		}
+	}
+
+	if noSummary {
+		return s
+	}
+
+	var pastTotal, newTotal string
+	if project.PastBreakdown != nil {
+		pastTotal = formatCost2DP(project.PastBreakdown.TotalMonthlyCost, nf)
+	}
+	newTotal = formatCost2DP(project.Breakdown.TotalMonthlyCost, nf)
+
+	s += markdownRow([]string{"**Project total**", pastTotal, newTotal, "**" + formatCostChange(project.Diff.TotalMonthlyCost, nf) + "**"})
+
+	return s
+}
+
+func markdownRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+// markdownNote returns the lines of a plain text message as paragraphs, with
+// the skipped resource counts as a list.
+func markdownNote(msg string) string {
+	s := ""
+	inList := false
+
+	for _, line := range strings.Split(msg, "\n") {
+		if skippedCountRegex.MatchString(line) {
+			if !inList {
+				s += "\n"
+			}
+			s += "- " + escapeMarkdown(line) + "\n"
+			inList = true
+		} else {
+			s += "\n" + escapeMarkdown(line) + "\n"
+			inList = false
+		}
+	}
+
+	return strings.TrimLeft(s, "\n") + "\n"
+}
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
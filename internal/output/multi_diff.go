@@ -0,0 +1,218 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+)
+
+// MultiDiff is the monthly cost change of each PR against a shared baseline,
+// and the combined change if they're all merged.
+type MultiDiff struct {
+	Baseline            string
+	PRs                 []string
+	Rows                []MultiDiffRow
+	Totals              []decimal.Decimal
+	CombinedTotal       decimal.Decimal
+	BaselineMonthlyCost decimal.Decimal
+}
+
+// MultiDiffRow is a resource changed by at least one PR. Cells of PRs that
+// don't change the resource are nil.
+type MultiDiffRow struct {
+	Name     string
+	Cells    []*decimal.Decimal
+	Combined decimal.Decimal
+}
+
+// ChangedBy returns the PRs that change the resource.
+func (r MultiDiffRow) ChangedBy(prs []string) []string {
+	changedBy := make([]string, 0)
+	for i, c := range r.Cells {
+		if c != nil {
+			changedBy = append(changedBy, prs[i])
+		}
+	}
+	return changedBy
+}
+
+// Conflicts returns the rows of the resources that are changed by more than
+// one PR, since the PRs might conflict and their combined change might not be
+// the sum of their changes.
+func (m MultiDiff) Conflicts() []MultiDiffRow {
+	conflicts := make([]MultiDiffRow, 0)
+	for _, r := range m.Rows {
+		if len(r.ChangedBy(m.PRs)) > 1 {
+			conflicts = append(conflicts, r)
+		}
+	}
+	return conflicts
+}
+
+// BuildMultiDiff diffs each PR, which is named by its "name" metadata, against
+// the baseline. A resource is changed by a PR if it's added, removed, its
+// monthly cost changes or its cost-relevant config changes. The combined change
+// assumes the PRs are independent so it's the sum of their changes.
+func BuildMultiDiff(baselineName string, baseline Root, prs []ReportInput) MultiDiff {
+	m := MultiDiff{
+		Baseline:            baselineName,
+		PRs:                 make([]string, 0, len(prs)),
+		Totals:              make([]decimal.Decimal, 0, len(prs)),
+		BaselineMonthlyCost: decimalOrZero(baseline.TotalMonthlyCost),
+	}
+
+	baselineResources := resourcesByName(baseline.Resources)
+	rowsByName := make(map[string]*MultiDiffRow)
+
+	for i, pr := range prs {
+		m.PRs = append(m.PRs, pr.Metadata["name"])
+
+		total := decimalOrZero(pr.Root.TotalMonthlyCost).Sub(m.BaselineMonthlyCost)
+		m.Totals = append(m.Totals, total)
+		m.CombinedTotal = m.CombinedTotal.Add(total)
+
+		prResources := resourcesByName(pr.Root.Resources)
+
+		names := make(map[string]bool)
+		for name := range baselineResources {
+			names[name] = true
+		}
+		for name := range prResources {
+			names[name] = true
+		}
+
+		for name := range names {
+			past, hasPast := baselineResources[name]
+			current, hasCurrent := prResources[name]
+
+			change := decimalOrZero(current.MonthlyCost).Sub(decimalOrZero(past.MonthlyCost))
+			changed := hasPast != hasCurrent || !change.IsZero() ||
+				(past.ResourceHash != "" && current.ResourceHash != "" && past.ResourceHash != current.ResourceHash)
+			if !changed {
+				continue
+			}
+
+			row, ok := rowsByName[name]
+			if !ok {
+				row = &MultiDiffRow{Name: name, Cells: make([]*decimal.Decimal, len(prs))}
+				rowsByName[name] = row
+			}
+
+			row.Cells[i] = decimalPtr(change)
+			row.Combined = row.Combined.Add(change)
+		}
+	}
+
+	for _, row := range rowsByName {
+		m.Rows = append(m.Rows, *row)
+	}
+
+	sort.Slice(m.Rows, func(i, j int) bool {
+		return m.Rows[i].Name < m.Rows[j].Name
+	})
+
+	return m
+}
+
+// resourcesByName returns the resources by name, summing the costs of
+// resources with the same name in different projects.
+func resourcesByName(resources []Resource) map[string]Resource {
+	byName := make(map[string]Resource, len(resources))
+	for _, r := range resources {
+		if existing, ok := byName[r.Name]; ok {
+			existing.MonthlyCost = decimalPtr(decimalOrZero(existing.MonthlyCost).Add(decimalOrZero(r.MonthlyCost)))
+			byName[r.Name] = existing
+			continue
+		}
+		byName[r.Name] = r
+	}
+	return byName
+}
+
+func decimalOrZero(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+	return *d
+}
+
+// ToMultiDiff renders the monthly cost changes of each resource with a column
+// for each PR and the combined change, followed by the projected monthly cost
+// and a warning about the resources changed by more than one PR.
+func ToMultiDiff(m MultiDiff, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := table.Row{ui.UnderlineString("Resource")}
+	columns := []table.ColumnConfig{{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft}}
+	for i, pr := range m.PRs {
+		headers = append(headers, ui.UnderlineString(pr))
+		columns = append(columns, table.ColumnConfig{Number: i + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+	headers = append(headers, ui.UnderlineString("Combined"))
+	columns = append(columns, table.ColumnConfig{Number: len(m.PRs) + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	t.SetColumnConfigs(columns)
+	t.AppendHeader(headers)
+
+	t.AppendRow(table.Row{""})
+
+	for _, r := range m.Rows {
+		row := table.Row{r.Name}
+		for _, c := range r.Cells {
+			if c == nil {
+				row = append(row, "")
+			} else {
+				row = append(row, formatCostChange2DP(c, nf))
+			}
+		}
+		row = append(row, formatCostChange2DP(&r.Combined, nf))
+		t.AppendRow(row)
+	}
+
+	t.AppendRow(table.Row{""})
+
+	totalRow := table.Row{ui.BoldString("TOTAL")}
+	for i := range m.Totals {
+		totalRow = append(totalRow, formatCostChange2DP(&m.Totals[i], nf))
+	}
+	totalRow = append(totalRow, formatCostChange2DP(&m.CombinedTotal, nf))
+	t.AppendRow(totalRow)
+
+	s := fmt.Sprintf("Monthly cost changes of %d PRs against %s\n\n", len(m.PRs), m.Baseline)
+	s += t.Render() + "\n\n"
+
+	projected := m.BaselineMonthlyCost.Add(m.CombinedTotal)
+	s += fmt.Sprintf("Baseline monthly cost:  %s\n", formatCost2DP(&m.BaselineMonthlyCost, nf))
+	s += fmt.Sprintf("Projected monthly cost: %s", formatCost2DP(&projected, nf))
+	if p := formatPercentChange(&m.BaselineMonthlyCost, &projected, nf); p != "" {
+		s += fmt.Sprintf(" (%s)", p)
+	}
+	s += "\n"
+
+	conflicts := m.Conflicts()
+	if len(conflicts) > 0 {
+		noun := "resources are"
+		if len(conflicts) == 1 {
+			noun = "resource is"
+		}
+
+		s += fmt.Sprintf("\n%s %d %s changed by more than one PR, so they may conflict and the combined change may not be accurate:\n",
+			ui.WarningString("Warning:"), len(conflicts), noun)
+		for _, r := range conflicts {
+			s += fmt.Sprintf("  %s: %s\n", r.Name, strings.Join(r.ChangedBy(m.PRs), ", "))
+		}
+	}
+
+	return []byte(s), nil
+}
@@ -11,24 +11,58 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-var outputVersion = "0.1"
+// outputVersion is the version of the Infracost JSON. 0.2 adds the region of
+// the resources and the grouped output of --group-by, 0.3 adds the annual
+// costs, 0.4 adds the confidence of the resources and the summary.
+var outputVersion = "0.4"
 
 type Root struct {
-	Version          string           `json:"version"`
+	Version        string           `json:"version"`
+	BaseCurrency   string           `json:"baseCurrency"`
+	TargetCurrency string           `json:"targetCurrency,omitempty"`
+	ExchangeRate   *decimal.Decimal `json:"exchangeRate,omitempty"`
+	// GroupBy is only set if the resources are grouped, in which case they're
+	// in groups instead of resources
+	GroupBy          string           `json:"groupBy,omitempty"`
 	Resources        []Resource       `json:"resources"`        // Keeping for backward compatibility.
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`  // Keeping for backward compatibility.
 	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"` // Keeping for backward compatibility.
+	TotalAnnualCost  *decimal.Decimal `json:"totalAnnualCost"`
 	Projects         []Project        `json:"projects"`
 	TimeGenerated    time.Time        `json:"timeGenerated"`
 	Summary          *Summary         `json:"summary"`
+	Projection       *Projection      `json:"projection,omitempty"`
 }
 
 type Project struct {
-	Path          string            `json:"path"`
-	Metadata      map[string]string `json:"metadata"`
-	PastBreakdown *Breakdown        `json:"pastBreakdown"`
-	Breakdown     *Breakdown        `json:"breakdown"`
-	Diff          *Breakdown        `json:"diff"`
+	Path         string            `json:"path"`
+	Metadata     map[string]string `json:"metadata"`
+	PlanMetadata *PlanMetadata     `json:"planMetadata,omitempty"`
+	// PastPlanMetadata is the metadata of the plan the diff is against, if it
+	// isn't the prior state of the plan
+	PastPlanMetadata *PlanMetadata `json:"pastPlanMetadata,omitempty"`
+	PastBreakdown    *Breakdown    `json:"pastBreakdown"`
+	Breakdown        *Breakdown    `json:"breakdown"`
+	Diff             *Breakdown    `json:"diff"`
+	// SkippedResources are the resources that weren't fully estimated, always
+	// included in the JSON regardless of --show-skipped
+	SkippedResources []SkippedResource `json:"skippedResources"`
+}
+
+// Reasons that a resource is listed in SkippedResources.
+const (
+	SkipReasonUnsupported  = "unsupported"
+	SkipReasonNoPrice      = "no-price"
+	SkipReasonUsageMissing = "usage-missing"
+)
+
+// SkippedResource is a resource that isn't in the breakdown because it's not
+// supported or free, or that is but has no cost since its usage is missing.
+type SkippedResource struct {
+	Name         string `json:"name"`
+	ResourceType string `json:"resourceType"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message,omitempty"`
 }
 
 func (p *Project) Label() string {
@@ -46,10 +80,20 @@ func (p *Project) Label() string {
 	return l
 }
 
+// PlanMetadata is the Terraform version, plan format version and working
+// directory of a project, if they're known.
+type PlanMetadata struct {
+	TerraformVersion string            `json:"terraformVersion,omitempty"`
+	FormatVersion    string            `json:"formatVersion,omitempty"`
+	WorkingDirectory string            `json:"workingDirectory,omitempty"`
+	ProviderVersions map[string]string `json:"providerVersions,omitempty"`
+}
+
 type Breakdown struct {
 	Resources        []Resource       `json:"resources"`
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
 	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	TotalAnnualCost  *decimal.Decimal `json:"totalAnnualCost"`
 }
 
 type CostComponent struct {
@@ -60,16 +104,39 @@ type CostComponent struct {
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+	nested          bool
 }
 
 type Resource struct {
 	Name           string            `json:"name"`
 	Tags           map[string]string `json:"tags,omitempty"`
+	Region         string            `json:"region,omitempty"`
+	Confidence     string            `json:"confidence,omitempty"`
 	Metadata       map[string]string `json:"metadata"`
 	HourlyCost     *decimal.Decimal  `json:"hourlyCost"`
 	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
+	AnnualCost     *decimal.Decimal  `json:"annualCost"`
+	USDHourlyCost  *decimal.Decimal  `json:"usdHourlyCost,omitempty"`
+	USDMonthlyCost *decimal.Decimal  `json:"usdMonthlyCost,omitempty"`
 	CostComponents []CostComponent   `json:"costComponents,omitempty"`
 	SubResources   []Resource        `json:"subresources,omitempty"`
+	ChangeReason   string            `json:"changeReason,omitempty"`
+	Explanation    string            `json:"explanation,omitempty"`
+	FileName       string            `json:"fileName,omitempty"`
+	StartLine      int               `json:"startLine,omitempty"`
+	ResourceHash   string            `json:"resourceHash,omitempty"`
+	Alternatives   []Alternative     `json:"alternatives,omitempty"`
+}
+
+// Alternative is a cheaper SKU suggested for a cost component of the resource.
+// Suggestions are heuristic, so the specs should be checked before switching.
+type Alternative struct {
+	CostComponent string          `json:"costComponent"`
+	Region        string          `json:"region,omitempty"`
+	Current       string          `json:"current"`
+	Suggested     string          `json:"suggested"`
+	MonthlySaving decimal.Decimal `json:"monthlySaving"`
+	SavingPercent decimal.Decimal `json:"savingPercent"`
 }
 
 type Summary struct {
@@ -79,6 +146,11 @@ type Summary struct {
 	TotalUnsupportedResources *int            `json:"totalUnsupportedResources,omitempty"`
 	TotalNoPriceResources     *int            `json:"totalNoPriceResources,omitempty"`
 	TotalResources            *int            `json:"totalResources,omitempty"`
+	TotalDedupedResources     *int            `json:"totalDedupedResources,omitempty"`
+	// Confidence is the confidence of the resources that make up most of the
+	// monthly cost, see overallConfidence
+	Confidence       string          `json:"confidence,omitempty"`
+	ConfidenceCounts *map[string]int `json:"confidenceCounts,omitempty"`
 }
 
 type SummaryOptions struct {
@@ -87,11 +159,53 @@ type SummaryOptions struct {
 }
 
 type Options struct {
-	NoColor     bool
-	ShowSkipped bool
-	GroupLabel  string
-	GroupKey    string
-	Fields      []string
+	NoColor             bool
+	ShowSkipped         bool
+	SummaryOnly         bool
+	NoSummary           bool
+	GroupLabel          string
+	GroupKey            string
+	Fields              []string
+	ProjectGrowth       *decimal.Decimal
+	Report              bool
+	HTMLTemplate        string
+	Template            string
+	TemplateName        string
+	CollapseByType      bool
+	DiffContext         int
+	FilterResourceTypes []string
+	Explain             bool
+	MarkdownStyle       string
+	Dedupe              string
+	CSVDelimiter        rune
+	MaxResourceDepth    *int
+	WrapCells           bool
+	JSONFields          []string
+	DiffFields          []string
+	JSONGroupBy         string
+	SortKey             string
+	// CostPeriod is the period of the overall totals shown first in the table,
+	// diff and markdown output, see ValidateCostPeriod. Empty shows the hourly
+	// and monthly totals.
+	CostPeriod string
+	// Currency is the code of the currency of the costs, used for the currency
+	// symbol of the table, diff, HTML and markdown output. Empty is USD.
+	Currency string
+	// Locale is the name of the locale of the thousands and decimal separators
+	// and the placement of the currency symbol, see ValidateLocale. Empty is
+	// en-US.
+	Locale string
+	// Threshold is the monthly cost above which a project fails in the JUnit
+	// output
+	Threshold *decimal.Decimal
+	// ResourceThreshold and ResourceErrorThreshold are the monthly costs above
+	// which a resource is a warning or error in the SARIF output
+	ResourceThreshold      *decimal.Decimal
+	ResourceErrorThreshold *decimal.Decimal
+	// SARIFBaseDir is the dir that the file URIs of the SARIF results are
+	// relative to, usually the root of the git repo. Empty leaves them
+	// relative to the working dir.
+	SARIFBaseDir string
 }
 
 func outputBreakdown(resources []*schema.Resource) *Breakdown {
@@ -115,6 +229,47 @@ func outputBreakdown(resources []*schema.Resource) *Breakdown {
 	}
 }
 
+// skippedResources returns the resources that are skipped, sorted by name,
+// including priced resources that have no cost because their usage is missing.
+func skippedResources(resources []*schema.Resource) []SkippedResource {
+	skipped := make([]SkippedResource, 0)
+
+	for _, r := range resources {
+		var reason string
+		switch {
+		case r.NoPrice:
+			reason = SkipReasonNoPrice
+		case r.IsSkipped:
+			reason = SkipReasonUnsupported
+		case r.MonthlyCost == nil && (len(r.CostComponents) > 0 || len(r.SubResources) > 0):
+			reason = SkipReasonUsageMissing
+		default:
+			continue
+		}
+
+		message := r.SkipMessage
+		if reason == SkipReasonUsageMissing {
+			message = r.UsageMessage
+			if message == "" {
+				message = "Usage-based costs can be estimated with --usage-file"
+			}
+		}
+
+		skipped = append(skipped, SkippedResource{
+			Name:         r.Name,
+			ResourceType: r.ResourceType,
+			Reason:       reason,
+			Message:      message,
+		})
+	}
+
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Name < skipped[j].Name
+	})
+
+	return skipped
+}
+
 func outputResource(r *schema.Resource) Resource {
 	comps := make([]CostComponent, 0, len(r.CostComponents))
 	for _, c := range r.CostComponents {
@@ -135,14 +290,45 @@ func outputResource(r *schema.Resource) Resource {
 		subresources = append(subresources, outputResource(s))
 	}
 
+	var alternatives []Alternative
+	for _, a := range r.Alternatives {
+		alternatives = append(alternatives, Alternative{
+			CostComponent: a.CostComponent,
+			Region:        a.Region,
+			Current:       a.Current,
+			Suggested:     a.Suggested,
+			MonthlySaving: a.MonthlySaving.Round(2),
+			SavingPercent: a.SavingPercent.Round(0),
+		})
+	}
+
 	return Resource{
 		Name:           r.Name,
 		Metadata:       map[string]string{},
 		Tags:           r.Tags,
+		Region:         r.Region,
+		Confidence:     r.Confidence,
 		HourlyCost:     r.HourlyCost,
 		MonthlyCost:    r.MonthlyCost,
 		CostComponents: comps,
 		SubResources:   subresources,
+		FileName:       r.SourceFileName,
+		StartLine:      r.SourceStartLine,
+		ResourceHash:   r.ResourceHash,
+		Alternatives:   alternatives,
+	}
+}
+
+func outputPlanMetadata(m *schema.PlanMetadata) *PlanMetadata {
+	if m == nil {
+		return nil
+	}
+
+	return &PlanMetadata{
+		TerraformVersion: m.TerraformVersion,
+		FormatVersion:    m.FormatVersion,
+		WorkingDirectory: m.WorkingDirectory,
+		ProviderVersions: m.ProviderVersions,
 	}
 }
 
@@ -159,7 +345,7 @@ func ToOutputFormat(projects []*schema.Project) Root {
 
 		if project.HasDiff {
 			pastBreakdown = outputBreakdown(project.PastResources)
-			diff = outputBreakdown(project.Diff)
+			diff = BuildDiff(project)
 		}
 
 		// Backward compatibility
@@ -184,22 +370,24 @@ func ToOutputFormat(projects []*schema.Project) Root {
 		}
 
 		outProjects = append(outProjects, Project{
-			Path:          project.Path,
-			Metadata:      project.Metadata,
-			PastBreakdown: pastBreakdown,
-			Breakdown:     breakdown,
-			Diff:          diff,
+			Path:             project.Path,
+			Metadata:         project.Metadata,
+			PlanMetadata:     outputPlanMetadata(project.PlanMetadata),
+			PastPlanMetadata: outputPlanMetadata(project.PastPlanMetadata),
+			PastBreakdown:    pastBreakdown,
+			Breakdown:        breakdown,
+			Diff:             diff,
+			SkippedResources: skippedResources(project.Resources),
 		})
 	}
 
-	resourceSummary := BuildSummary(schema.AllProjectResources(projects), SummaryOptions{
-		OnlyFields: []string{"UnsupportedResourceCounts"},
-	})
+	resourceSummary := BuildSummary(schema.AllProjectResources(projects), SummaryOptions{})
 
 	sortResources(outResources, "")
 
 	out := Root{
 		Version:          outputVersion,
+		BaseCurrency:     BaseCurrency,
 		Resources:        outResources,
 		TotalHourlyCost:  totalHourlyCost,
 		TotalMonthlyCost: totalMonthlyCost,
@@ -259,6 +447,64 @@ func (r *Root) unsupportedResourcesMessage(showSkipped bool) string {
 	return msg
 }
 
+// HasCostChanges returns false if none of the projects have changed resources
+// and the total monthly cost difference is zero. If resourceTypes is set only
+// the changed resources of those types count, like the diff shows, since the
+// total includes the resources of every type.
+func (r *Root) HasCostChanges(resourceTypes []string) bool {
+	for _, p := range r.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		if len(filterResourcesByType(p.Diff.Resources, resourceTypes)) > 0 {
+			return true
+		}
+
+		if len(resourceTypes) == 0 && p.Diff.TotalMonthlyCost != nil && !p.Diff.TotalMonthlyCost.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiffTotalMonthlyCost returns the total monthly cost change of the projects
+// that have a diff.
+func (r *Root) DiffTotalMonthlyCost() decimal.Decimal {
+	total := decimal.Zero
+
+	for _, p := range r.Projects {
+		if p.Diff != nil && p.Diff.TotalMonthlyCost != nil {
+			total = total.Add(*p.Diff.TotalMonthlyCost)
+		}
+	}
+
+	return total
+}
+
+func (r *Root) resourceCountsMessage() string {
+	if r.Summary == nil || r.Summary.TotalSupportedResources == nil {
+		return ""
+	}
+
+	msg := fmt.Sprintf("%d resources were estimated", *r.Summary.TotalSupportedResources)
+
+	if r.Summary.TotalNoPriceResources != nil {
+		msg += fmt.Sprintf(", %d are free", *r.Summary.TotalNoPriceResources)
+	}
+
+	if r.Summary.TotalUnsupportedResources != nil {
+		msg += fmt.Sprintf(", %d are not supported yet", *r.Summary.TotalUnsupportedResources)
+	}
+
+	if r.Summary.TotalDedupedResources != nil && *r.Summary.TotalDedupedResources > 0 {
+		msg += fmt.Sprintf(", %d duplicates were counted once", *r.Summary.TotalDedupedResources)
+	}
+
+	return msg + "."
+}
+
 func BuildSummary(resources []*schema.Resource, opts SummaryOptions) *Summary {
 	supportedResourceCounts := make(map[string]int)
 	unsupportedResourceCounts := make(map[string]int)
@@ -292,6 +538,20 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) *Summary {
 
 	s := &Summary{}
 
+	if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "Confidence") {
+		confidenceCounts := make(map[string]int)
+		for _, r := range resources {
+			if r.Confidence != "" {
+				confidenceCounts[r.Confidence]++
+			}
+		}
+
+		if len(confidenceCounts) > 0 {
+			s.Confidence = overallConfidence(resources)
+			s.ConfidenceCounts = &confidenceCounts
+		}
+	}
+
 	if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "SupportedResourceCounts") {
 		s.SupportedResourceCounts = &supportedResourceCounts
 	}
@@ -314,6 +574,44 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) *Summary {
 	return s
 }
 
+// overallConfidence returns low if the low confidence resources make up more
+// than half of the monthly cost, medium if the low and medium ones do, and
+// high otherwise. If the resources have no cost the resource counts are used
+// instead.
+func overallConfidence(resources []*schema.Resource) string {
+	levels := []string{schema.ConfidenceLow, schema.ConfidenceMedium, schema.ConfidenceHigh}
+
+	weights := make(map[string]decimal.Decimal)
+	total := decimal.Zero
+	for _, r := range resources {
+		if r.Confidence == "" || r.MonthlyCost == nil {
+			continue
+		}
+		weights[r.Confidence] = weights[r.Confidence].Add(*r.MonthlyCost)
+		total = total.Add(*r.MonthlyCost)
+	}
+
+	if total.IsZero() {
+		weights = make(map[string]decimal.Decimal)
+		for _, r := range resources {
+			if r.Confidence != "" {
+				weights[r.Confidence] = weights[r.Confidence].Add(decimal.NewFromInt(1))
+				total = total.Add(decimal.NewFromInt(1))
+			}
+		}
+	}
+
+	cumulative := decimal.Zero
+	for _, level := range levels {
+		cumulative = cumulative.Add(weights[level])
+		if cumulative.Mul(decimal.NewFromInt(2)).GreaterThan(total) {
+			return level
+		}
+	}
+
+	return schema.ConfidenceHigh
+}
+
 func calculateTotalCosts(resources []Resource) (*decimal.Decimal, *decimal.Decimal) {
 	totalHourlyCost := decimalPtr(decimal.Zero)
 	totalMonthlyCost := decimalPtr(decimal.Zero)
@@ -1,8 +1,16 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	"gopkg.in/go-playground/assert.v1"
 )
@@ -32,3 +40,1903 @@ func TestCalculateTotalCosts(t *testing.T) {
 	actual, _ = totalMonthlyCost.Float64()
 	assert.Equal(t, expected, actual)
 }
+
+func TestToJSONSummaryOnly(t *testing.T) {
+	totalMonthlyCost := decimalPtr(decimal.NewFromInt(100))
+	resources := []Resource{
+		{
+			Name:        "aws_instance.web",
+			MonthlyCost: totalMonthlyCost,
+		},
+	}
+
+	out := Root{
+		Resources: resources,
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        resources,
+					TotalMonthlyCost: totalMonthlyCost,
+				},
+			},
+		},
+		Summary: &Summary{
+			UnsupportedResourceCounts: &map[string]int{},
+			TotalSupportedResources:   intPtr(1),
+		},
+	}
+
+	b, err := ToJSON(out, Options{SummaryOnly: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), `"totalSupportedResources":1`))
+
+	// The resource counts are only added with --summary-only
+	for _, opts := range []Options{{}, {SummaryOnly: true}} {
+		var buf bytes.Buffer
+		err = WriteJSON(&buf, out, opts)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, opts.SummaryOnly, strings.Contains(buf.String(), `"totalSupportedResources"`))
+		assert.Equal(t, true, strings.Contains(buf.String(), `"unsupportedResourceCounts":{}`))
+	}
+
+	loaded, err := Load(b)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(loaded.Resources))
+	assert.Equal(t, 0, len(loaded.Projects[0].Breakdown.Resources))
+	assert.Equal(t, "100", loaded.Projects[0].Breakdown.TotalMonthlyCost.String())
+
+	// The original output should be left untouched
+	assert.Equal(t, 1, len(out.Projects[0].Breakdown.Resources))
+}
+
+func TestConvertCurrency(t *testing.T) {
+	monthlyCost := decimalPtr(decimal.NewFromInt(100))
+	resources := []Resource{
+		{
+			Name:        "aws_instance.web",
+			MonthlyCost: monthlyCost,
+			CostComponents: []CostComponent{
+				{Name: "Instance usage", Price: decimal.NewFromFloat(0.1), MonthlyCost: monthlyCost},
+			},
+		},
+	}
+
+	out := Root{
+		BaseCurrency:     BaseCurrency,
+		Resources:        resources,
+		TotalMonthlyCost: monthlyCost,
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        resources,
+					TotalMonthlyCost: monthlyCost,
+				},
+			},
+		},
+	}
+
+	b, err := ToJSON(out, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), `"baseCurrency":"USD"`))
+	assert.Equal(t, false, strings.Contains(string(b), "targetCurrency"))
+	assert.Equal(t, false, strings.Contains(string(b), "usdMonthlyCost"))
+
+	converted := ConvertCurrency(out, "eur", decimal.NewFromFloat(0.9))
+	assert.Equal(t, "EUR", converted.TargetCurrency)
+	assert.Equal(t, "0.9", converted.ExchangeRate.String())
+	assert.Equal(t, "90", converted.TotalMonthlyCost.String())
+
+	r := converted.Projects[0].Breakdown.Resources[0]
+	assert.Equal(t, "90", r.MonthlyCost.String())
+	assert.Equal(t, "100", r.USDMonthlyCost.String())
+	assert.Equal(t, "0.09", r.CostComponents[0].Price.String())
+	assert.Equal(t, "90", r.CostComponents[0].MonthlyCost.String())
+
+	// The original output should be left untouched
+	assert.Equal(t, "100", out.Projects[0].Breakdown.Resources[0].MonthlyCost.String())
+	assert.Equal(t, "0.1", out.Resources[0].CostComponents[0].Price.String())
+
+	var buf bytes.Buffer
+	err = WriteJSON(&buf, converted, Options{})
+	assert.Equal(t, nil, err)
+	b, _ = ToJSON(converted, Options{})
+	assert.Equal(t, string(b), buf.String())
+
+	assert.Equal(t, 100, int(ConvertCurrency(out, "USD", decimal.NewFromInt(2)).TotalMonthlyCost.IntPart()))
+
+	base, err := ConvertToBaseCurrency(converted)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", base.TargetCurrency)
+	assert.Equal(t, true, base.ExchangeRate == nil)
+	assert.Equal(t, "100", base.TotalMonthlyCost.String())
+	r = base.Projects[0].Breakdown.Resources[0]
+	assert.Equal(t, "100", r.MonthlyCost.String())
+	assert.Equal(t, true, r.USDMonthlyCost == nil)
+	assert.Equal(t, "0.1", r.CostComponents[0].Price.String())
+	assert.Equal(t, "100", r.CostComponents[0].MonthlyCost.String())
+
+	converted.ExchangeRate = nil
+	_, err = ConvertToBaseCurrency(converted)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestUpconvert(t *testing.T) {
+	out, err := Load([]byte(`{
+  "version": "0.1",
+  "resources": [{"name": "aws_instance.web", "hourlyCost": "0.1", "monthlyCost": "73"}],
+  "projects": [
+    {
+      "path": "path",
+      "breakdown": {
+        "resources": [{"name": "aws_instance.web", "hourlyCost": "0.1", "monthlyCost": "73"}]
+      },
+      "diff": null
+    }
+  ]
+}`))
+	assert.Equal(t, nil, err)
+
+	out = Upconvert(out)
+	assert.Equal(t, outputVersion, out.Version)
+	assert.Equal(t, BaseCurrency, out.BaseCurrency)
+	assert.Equal(t, "73", out.TotalMonthlyCost.String())
+	assert.NotEqual(t, nil, out.Summary)
+
+	p := out.Projects[0]
+	assert.Equal(t, "0.1", p.Breakdown.TotalHourlyCost.String())
+	assert.Equal(t, "73", p.Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, 0, len(p.Metadata))
+	assert.NotEqual(t, nil, p.Breakdown.Resources[0].Metadata)
+	assert.Equal(t, true, p.PastBreakdown == nil)
+	assert.Equal(t, true, p.Diff == nil)
+
+	// The oldest versions only had the top-level resources
+	out, err = Load([]byte(`{"version": "0.1", "resources": [{"name": "aws_instance.web", "monthlyCost": "73"}]}`))
+	assert.Equal(t, nil, err)
+
+	out = Upconvert(out)
+	assert.Equal(t, 1, len(out.Projects))
+	assert.Equal(t, "73", out.Projects[0].Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, "0", out.Projects[0].Breakdown.TotalHourlyCost.String())
+}
+
+func TestToJSONFields(t *testing.T) {
+	totalMonthlyCost := decimalPtr(decimal.NewFromInt(100))
+	resources := []Resource{
+		{
+			Name:        "aws_instance.web",
+			MonthlyCost: totalMonthlyCost,
+			HourlyCost:  decimalPtr(decimal.NewFromFloat(0.137)),
+			Metadata:    map[string]string{"filename": "main.tf"},
+			CostComponents: []CostComponent{
+				{
+					Name:            "Instance usage",
+					Unit:            "hours",
+					Price:           decimal.NewFromFloat(0.137),
+					MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+					MonthlyCost:     totalMonthlyCost,
+				},
+			},
+			SubResources: []Resource{
+				{
+					Name: "root_block_device",
+					CostComponents: []CostComponent{
+						{Name: "Storage", Unit: "GB", MonthlyCost: decimalPtr(decimal.Zero)},
+					},
+				},
+			},
+		},
+	}
+
+	out := Root{
+		Resources: resources,
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        resources,
+					TotalMonthlyCost: totalMonthlyCost,
+				},
+			},
+		},
+		TotalMonthlyCost: totalMonthlyCost,
+	}
+
+	b, err := ToJSON(out, Options{JSONFields: []string{"unit", "monthlyCost"}})
+	assert.Equal(t, nil, err)
+
+	var pruned map[string]interface{}
+	err = json.Unmarshal(b, &pruned)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "100", pruned["totalMonthlyCost"])
+
+	project := pruned["projects"].([]interface{})[0].(map[string]interface{})
+	breakdown := project["breakdown"].(map[string]interface{})
+	assert.Equal(t, "100", breakdown["totalMonthlyCost"])
+
+	resource := breakdown["resources"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 5, len(resource))
+	assert.Equal(t, "100", resource["monthlyCost"])
+	assert.Equal(t, "1200", resource["annualCost"])
+
+	costComponent := resource["costComponents"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Instance usage", "unit": "hours", "monthlyCost": "100"}, costComponent)
+
+	subresource := resource["subresources"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "root_block_device", subresource["name"])
+	assert.Equal(t, 4, len(subresource))
+
+	// The resources are pruned outside of the projects too
+	assert.Equal(t, 5, len(pruned["resources"].([]interface{})[0].(map[string]interface{})))
+}
+
+func TestToJSONGroupBy(t *testing.T) {
+	resources := []Resource{
+		{Name: "aws_instance.web", Tags: map[string]string{"team": "web"}, Region: "us-east-1", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+		{Name: "module.db.aws_db_instance.db", Tags: map[string]string{"team": "data"}, Region: "us-east-1", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+		{Name: "module.db.aws_instance.bastion", Region: "eu-west-1", MonthlyCost: decimalPtr(decimal.NewFromInt(25))},
+	}
+	totalMonthlyCost := decimalPtr(decimal.NewFromInt(175))
+
+	out := Root{
+		Resources: resources,
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        resources,
+					TotalMonthlyCost: totalMonthlyCost,
+				},
+			},
+		},
+		TotalMonthlyCost: totalMonthlyCost,
+	}
+
+	groupTotals := func(groupBy string) map[string]string {
+		var buf bytes.Buffer
+		err := WriteJSON(&buf, out, Options{JSONGroupBy: groupBy})
+		assert.Equal(t, nil, err)
+
+		var grouped map[string]interface{}
+		err = json.Unmarshal(buf.Bytes(), &grouped)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, groupBy, grouped["groupBy"])
+		_, hasResources := grouped["resources"]
+		assert.Equal(t, false, hasResources)
+
+		project := grouped["projects"].([]interface{})[0].(map[string]interface{})
+		breakdown := project["breakdown"].(map[string]interface{})
+		assert.Equal(t, "175", breakdown["totalMonthlyCost"])
+
+		totals := make(map[string]string)
+		for key, g := range breakdown["groups"].(map[string]interface{}) {
+			group := g.(map[string]interface{})
+			totals[key] = group["totalMonthlyCost"].(string)
+		}
+
+		return totals
+	}
+
+	assert.Equal(t, map[string]string{"aws_instance": "125", "aws_db_instance": "50"}, groupTotals(GroupByService))
+	assert.Equal(t, map[string]string{"us-east-1": "150", "eu-west-1": "25"}, groupTotals(GroupByRegion))
+	assert.Equal(t, map[string]string{"": "100", "module.db": "75"}, groupTotals(GroupByModule))
+	assert.Equal(t, map[string]string{"web": "100", "data": "50", "": "25"}, groupTotals("tag:team"))
+
+	assert.Equal(t, nil, ValidateJSONGroupBy("tag:team"))
+	assert.NotEqual(t, nil, ValidateJSONGroupBy("tag:"))
+	assert.NotEqual(t, nil, ValidateJSONGroupBy("cost"))
+}
+
+func TestToFlatJSON(t *testing.T) {
+	resources := []Resource{
+		{
+			Name:     "module.app.aws_instance.web",
+			Region:   "us-east-1",
+			Tags:     map[string]string{"team": "web"},
+			Metadata: map[string]string{},
+			CostComponents: []CostComponent{
+				{Name: "Instance usage", Unit: "hours", Price: decimal.NewFromFloat(0.1), MonthlyCost: decimalPtr(decimal.NewFromInt(73))},
+			},
+			SubResources: []Resource{
+				{Name: "root_block_device", CostComponents: []CostComponent{
+					{Name: "Storage", Unit: "GB", Price: decimal.NewFromFloat(0.1), MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+				}},
+			},
+		},
+	}
+
+	out := Root{
+		Version: "0.2",
+		Projects: []Project{
+			{Path: "path", Metadata: map[string]string{}, Breakdown: &Breakdown{Resources: resources}},
+		},
+	}
+
+	b, err := ToFlatJSON(out, Options{})
+	assert.Equal(t, nil, err)
+
+	var records []FlatRecord
+	err = json.Unmarshal(b, &records)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(records))
+
+	assert.Equal(t, "path", records[0].Project)
+	assert.Equal(t, "module.app.aws_instance.web", records[0].ResourceAddress)
+	assert.Equal(t, "aws_instance", records[0].ResourceType)
+	assert.Equal(t, "USD", records[0].Currency)
+	assert.Equal(t, "0.2", records[0].Version)
+
+	assert.Equal(t, "module.app.aws_instance.web.root_block_device", records[1].ResourceAddress)
+	assert.Equal(t, "aws_instance", records[1].ResourceType)
+	assert.Equal(t, "us-east-1", records[1].Region)
+	assert.Equal(t, "5", records[1].MonthlyCost.String())
+}
+
+func largeSyntheticRoot(n int) Root {
+	resources := make([]Resource, 0, n)
+	for i := 0; i < n; i++ {
+		resources = append(resources, Resource{
+			Name:        fmt.Sprintf("aws_instance.web[%d]", i),
+			Metadata:    map[string]string{},
+			HourlyCost:  decimalPtr(decimal.NewFromFloat(0.137)),
+			MonthlyCost: decimalPtr(decimal.NewFromFloat(100.01)),
+			CostComponents: []CostComponent{
+				{Name: "Instance usage", Unit: "hours", MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)), Price: decimal.NewFromFloat(0.137)},
+			},
+			SubResources: []Resource{
+				{Name: "root_block_device", CostComponents: []CostComponent{
+					{Name: "Storage", Unit: "GB", MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)), Price: decimal.NewFromFloat(0.1)},
+				}},
+			},
+		})
+	}
+
+	return Root{
+		Version:   "0.1",
+		Resources: resources,
+		Projects: []Project{
+			{
+				Path:          "path",
+				Metadata:      map[string]string{"terraformWorkspace": "default"},
+				PlanMetadata:  &PlanMetadata{TerraformVersion: "0.15.4", FormatVersion: "0.1"},
+				PastBreakdown: &Breakdown{Resources: resources[:n/2]},
+				Breakdown:     &Breakdown{Resources: resources, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+			},
+		},
+		Summary: &Summary{},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	out := largeSyntheticRoot(3)
+
+	for _, opts := range []Options{
+		{},
+		{SummaryOnly: true},
+		{ProjectGrowth: decimalPtr(decimal.NewFromFloat(0.05))},
+		{JSONFields: []string{"monthlyCost"}},
+	} {
+		expected, err := ToJSON(out, opts)
+		assert.Equal(t, nil, err)
+
+		var buf bytes.Buffer
+		err = WriteJSON(&buf, out, opts)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, string(expected), buf.String())
+	}
+
+	var buf bytes.Buffer
+	err := WriteJSON(&buf, Root{}, Options{})
+	assert.Equal(t, nil, err)
+	expected, _ := ToJSON(Root{}, Options{})
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func BenchmarkToJSON(b *testing.B) {
+	out := largeSyntheticRoot(20000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		j, err := ToJSON(out, Options{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = ioutil.Discard.Write(j)
+	}
+}
+
+func BenchmarkWriteJSON(b *testing.B) {
+	out := largeSyntheticRoot(20000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := WriteJSON(ioutil.Discard, out, Options{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestHasCostChanges(t *testing.T) {
+	zero := decimalPtr(decimal.Zero)
+	ten := decimalPtr(decimal.NewFromInt(10))
+
+	noChanges := Root{
+		Projects: []Project{
+			{Diff: &Breakdown{TotalMonthlyCost: zero}},
+			{Diff: nil},
+		},
+	}
+	assert.Equal(t, false, noChanges.HasCostChanges(nil))
+
+	costChange := Root{
+		Projects: []Project{
+			{Diff: &Breakdown{TotalMonthlyCost: zero}},
+			{Diff: &Breakdown{TotalMonthlyCost: ten}},
+		},
+	}
+	assert.Equal(t, true, costChange.HasCostChanges(nil))
+
+	resourceChange := Root{
+		Projects: []Project{
+			{Diff: &Breakdown{
+				Resources:        []Resource{{Name: "aws_instance.web"}},
+				TotalMonthlyCost: zero,
+			}},
+		},
+	}
+	assert.Equal(t, true, resourceChange.HasCostChanges(nil))
+
+	// Only the changed resources of the filtered types count
+	filtered := Root{
+		Projects: []Project{
+			{Diff: &Breakdown{
+				Resources:        []Resource{{Name: "module.app.aws_instance.web"}},
+				TotalMonthlyCost: ten,
+			}},
+		},
+	}
+	assert.Equal(t, true, filtered.HasCostChanges([]string{"aws_instance"}))
+	assert.Equal(t, false, filtered.HasCostChanges([]string{"aws_db_instance"}))
+}
+
+func TestBuildProjection(t *testing.T) {
+	p := BuildProjection(decimalPtr(decimal.NewFromInt(100)), decimal.NewFromFloat(0.1))
+
+	assert.Equal(t, "compound", p.Model)
+	assert.Equal(t, 3, len(p.Horizons))
+
+	assert.Equal(t, 3, p.Horizons[0].Months)
+	assert.Equal(t, "121", p.Horizons[0].FinalMonthlyCost.String())
+	assert.Equal(t, "331", p.Horizons[0].TotalCost.String())
+
+	flat := BuildProjection(decimalPtr(decimal.NewFromInt(100)), decimal.Zero)
+	assert.Equal(t, "1200", flat.Horizons[2].TotalCost.String())
+
+	empty := BuildProjection(nil, decimal.NewFromFloat(0.1))
+	assert.Equal(t, (*decimal.Decimal)(nil), empty.Horizons[0].TotalCost)
+}
+
+func TestToHTMLReport(t *testing.T) {
+	pastCost := decimalPtr(decimal.NewFromInt(60))
+	newCost := decimalPtr(decimal.NewFromInt(75))
+	diffCost := decimalPtr(decimal.NewFromInt(15))
+
+	out := Root{
+		Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: newCost}},
+		Projects: []Project{
+			{
+				Path:          "path",
+				PastBreakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: pastCost}}, TotalMonthlyCost: pastCost},
+				Breakdown:     &Breakdown{Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: newCost}}, TotalMonthlyCost: newCost},
+				Diff:          &Breakdown{Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: diffCost}}, TotalMonthlyCost: diffCost},
+			},
+		},
+		Summary: &Summary{},
+	}
+
+	b, err := ToHTML(out, Options{Report: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), `id="tab-diff"`))
+	assert.Equal(t, true, strings.Contains(string(b), "$60.00"))
+	assert.Equal(t, true, strings.Contains(string(b), "on-demand prices in USD and"))
+
+	b, err = ToHTML(out, Options{Report: true, Currency: "EUR"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "on-demand prices in USD converted to EUR and"))
+
+	b, err = ToHTML(out, Options{HTMLTemplate: `<html>{{template "diffTable" .}}</html>`})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(string(b), "<html>"))
+	assert.Equal(t, false, strings.Contains(string(b), `id="tab-diff"`))
+	assert.Equal(t, true, strings.Contains(string(b), "$15.00"))
+}
+
+func TestToTemplate(t *testing.T) {
+	cost := decimalPtr(decimal.NewFromFloat(1234.5))
+
+	out := Root{
+		TotalMonthlyCost: cost,
+		Projects: []Project{
+			{Path: "path", Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: cost}}, TotalMonthlyCost: cost}},
+		},
+		Summary: &Summary{},
+	}
+
+	tmpl := `{{ range .Root.Projects }}{{ range .Breakdown.Resources }}{{ .Name }};{{ formatCurrency .MonthlyCost }}
+{{ end }}{{ end }}total;{{ formatCost .Root.TotalMonthlyCost }}`
+
+	b, err := ToTemplate(out, Options{Template: tmpl, TemplateName: "costs.tmpl"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "aws_instance.web;$1,234.50\ntotal;$1,235", string(b))
+
+	b, err = ToTemplate(out, Options{Template: tmpl + ";{{ formatQuantity .Root.TotalMonthlyCost }}", TemplateName: "costs.tmpl", Locale: "de-DE"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "aws_instance.web;1.234,50 $\ntotal;1.235 $;1.234,5", string(b))
+
+	_, err = ToTemplate(out, Options{Template: "{{ .Root.Missing }}", TemplateName: "costs.tmpl"})
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, true, strings.Contains(err.Error(), "costs.tmpl"))
+	assert.Equal(t, true, strings.Contains(err.Error(), "<.Root.Missing>"))
+}
+
+func TestExplorer(t *testing.T) {
+	cost := func(f float64) *decimal.Decimal {
+		return decimalPtr(decimal.NewFromFloat(f))
+	}
+
+	out := Root{
+		TotalMonthlyCost: cost(60),
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(10)},
+						{Name: "module.db.aws_db_instance.db", MonthlyCost: cost(30), CostComponents: []CostComponent{{Name: "Database instance", MonthlyCost: cost(30)}}},
+						{Name: "module.db.aws_instance.bastion", MonthlyCost: cost(20)},
+					},
+				},
+			},
+		},
+	}
+
+	names := func(rows []ExploreRow) []string {
+		r := make([]string, 0, len(rows))
+		for _, row := range rows {
+			r = append(r, fmt.Sprintf("%d %s %s", row.Depth, row.Name, formatCost2DP(row.MonthlyCost, newNumberFormat(Options{}))))
+		}
+		return r
+	}
+
+	e := NewExplorer(out)
+	assert.Equal(t, []string{"0 path $60.00", "1 module.db $50.00", "1 aws_instance.web $10.00"}, names(e.Rows()))
+
+	e.Toggle("p0/module.db.")
+	assert.Equal(t, []string{"0 path $60.00", "1 module.db $50.00", "2 aws_db_instance.db $30.00", "2 aws_instance.bastion $20.00", "1 aws_instance.web $10.00"}, names(e.Rows()))
+
+	e.ToggleSort()
+	assert.Equal(t, []string{"0 path $60.00", "1 aws_instance.web $10.00", "1 module.db $50.00", "2 aws_db_instance.db $30.00", "2 aws_instance.bastion $20.00"}, names(e.Rows()))
+
+	e.SetResourceType("aws_instance")
+	assert.Equal(t, "aws_instance", e.ResourceType())
+	assert.Equal(t, []string{"aws_db_instance", "aws_instance"}, e.ResourceTypes())
+	assert.Equal(t, []string{"0 path $30.00", "1 aws_instance.web $10.00", "1 module.db $20.00", "2 aws_instance.bastion $20.00"}, names(e.Rows()))
+
+	lines := FormatExploreRows(e.Rows(), "")
+	assert.Equal(t, "▾ path"+strings.Repeat(" ", 28)+"$30.00", lines[0])
+	assert.Equal(t, "  ▾ module.db"+strings.Repeat(" ", 21)+"$20.00", lines[2])
+}
+
+func TestFormatCostLocale(t *testing.T) {
+	cost := decimalPtr(decimal.NewFromFloat(1234.56))
+
+	assert.Equal(t, "$1,234.56", formatCost2DP(cost, newNumberFormat(Options{})))
+
+	de := newNumberFormat(Options{Locale: "de-DE"})
+	assert.Equal(t, "1.234,56 $", formatCost2DP(cost, de))
+	assert.Equal(t, "1.235 $", formatCost(cost, de))
+	assert.Equal(t, "0,0004 $", formatPrice(decimal.NewFromFloat(0.0004), de))
+	assert.Equal(t, "12.345,5", formatQuantity(decimalPtr(decimal.NewFromFloat(12345.5)), de))
+
+	assert.Equal(t, "$1.234,56", formatCost2DP(cost, newNumberFormat(Options{Locale: "pt_BR"})))
+
+	assert.Equal(t, nil, ValidateLocale(""))
+	assert.Equal(t, nil, ValidateLocale("pt_BR"))
+	assert.NotEqual(t, nil, ValidateLocale("xx-XX"))
+
+	// The locale is per output, so one output doesn't change the next
+	out := Root{
+		Summary: &Summary{},
+		Projects: []Project{
+			{Path: "path", Breakdown: &Breakdown{Resources: []Resource{{Name: "aws_instance.web", MonthlyCost: cost}}, TotalMonthlyCost: cost}},
+		},
+		TotalMonthlyCost: cost,
+	}
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true, Locale: "de-DE"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "1.234,56 $"))
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "$1,234.56"))
+	assert.Equal(t, false, strings.Contains(string(b), "1.234,56"))
+}
+
+func TestBuildDiffChangeReasons(t *testing.T) {
+	instance := func(name string, price float64, quantity int64, changes []schema.AttributeChange) *schema.Resource {
+		c := &schema.CostComponent{Name: "Instance usage", Unit: "hours", UnitMultiplier: 1, HourlyQuantity: decimalPtr(decimal.NewFromInt(quantity))}
+		c.SetPrice(decimal.NewFromFloat(price))
+		return &schema.Resource{Name: name, CostComponents: []*schema.CostComponent{c}, AttributeChanges: changes}
+	}
+
+	project := schema.NewProject("test", map[string]string{})
+	project.PastResources = []*schema.Resource{
+		instance("aws_instance.resized", 0.096, 1, nil),
+		instance("aws_instance.scaled", 0.096, 1, nil),
+	}
+	project.Resources = []*schema.Resource{
+		instance("aws_instance.resized", 0.192, 1, []schema.AttributeChange{{Name: "instance_type", Before: "m5.large", After: "m5.xlarge"}}),
+		instance("aws_instance.scaled", 0.096, 3, nil),
+		instance("aws_instance.added", 0.096, 1, nil),
+	}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+
+	reasons := make(map[string]string)
+	for _, r := range BuildDiff(project).Resources {
+		reasons[r.Name] = r.ChangeReason
+	}
+
+	assert.Equal(t, "instance type m5.large → m5.xlarge", reasons["aws_instance.resized"])
+	assert.Equal(t, "quantity changed", reasons["aws_instance.scaled"])
+	assert.Equal(t, "", reasons["aws_instance.added"])
+}
+
+func TestBuildDiffProviderVersionReasons(t *testing.T) {
+	volume := func(name string, price float64) *schema.Resource {
+		c := &schema.CostComponent{Name: "Storage", Unit: "GB", UnitMultiplier: 1, MonthlyQuantity: decimalPtr(decimal.NewFromInt(100))}
+		c.SetPrice(decimal.NewFromFloat(price))
+		return &schema.Resource{Name: name, ResourceType: "aws_ebs_volume", CostComponents: []*schema.CostComponent{c}}
+	}
+
+	project := schema.NewProject("test", map[string]string{})
+	project.PastPlanMetadata = &schema.PlanMetadata{ProviderVersions: map[string]string{"aws": "3.74.0", "google": "4.0.0"}}
+	project.PlanMetadata = &schema.PlanMetadata{ProviderVersions: map[string]string{"aws": "4.0.0", "google": "4.0.0"}}
+	project.PastResources = []*schema.Resource{volume("aws_ebs_volume.data", 0.1)}
+	project.Resources = []*schema.Resource{volume("aws_ebs_volume.data", 0.08)}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+
+	diff := BuildDiff(project)
+	assert.Equal(t, 1, len(diff.Resources))
+	assert.Equal(t, "provider version aws 3.74.0 → 4.0.0", diff.Resources[0].ChangeReason)
+
+	out := ToOutputFormat([]*schema.Project{project})
+	b, err := ToDiff(out, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "Provider versions: aws 3.74.0 → 4.0.0\n"))
+}
+
+func TestToDiffFields(t *testing.T) {
+	volume := func(size int64) *schema.Resource {
+		c := &schema.CostComponent{Name: "Storage", Unit: "GB", UnitMultiplier: 1, MonthlyQuantity: decimalPtr(decimal.NewFromInt(size))}
+		c.SetPrice(decimal.NewFromFloat(0.1))
+		return &schema.Resource{Name: "aws_ebs_volume.data", ResourceType: "aws_ebs_volume", CostComponents: []*schema.CostComponent{c}}
+	}
+
+	project := schema.NewProject("test", map[string]string{})
+	project.PastResources = []*schema.Resource{volume(100)}
+	project.Resources = []*schema.Resource{volume(200)}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+	out := ToOutputFormat([]*schema.Project{project})
+
+	b, err := ToDiff(out, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "    ~ Storage\n      +$10.00 ($10.00 -> $20.00)\n"))
+	assert.Equal(t, false, strings.Contains(string(b), "Quantity:"))
+
+	b, err = ToDiff(out, Options{NoColor: true, DiffFields: []string{"monthlyQuantity", "unit", "price"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "    ~ Storage\n      Quantity: +100 GB (100 -> 200)\n      Price: $0 per GB ($0.10 -> $0.10)\n"))
+	assert.Equal(t, true, strings.Contains(string(b), "~ aws_ebs_volume.data\n  Reason: quantity changed\n"))
+}
+
+func TestToDiffSortKeyWithDiffContext(t *testing.T) {
+	resources := []Resource{
+		{Name: "aws_instance.a", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+		{Name: "aws_instance.b", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+	}
+	out := Root{Projects: []Project{{
+		Path:          "path",
+		PastBreakdown: &Breakdown{},
+		Breakdown:     &Breakdown{Resources: resources},
+		Diff:          &Breakdown{Resources: resources},
+	}}, Summary: &Summary{}}
+
+	b, err := ToDiff(out, Options{NoColor: true, SortKey: "-" + SortMonthlyCost})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Index(string(b), "aws_instance.b") < strings.Index(string(b), "aws_instance.a"))
+
+	// The diff context is ordered by address
+	b, err = ToDiff(out, Options{NoColor: true, SortKey: "-" + SortMonthlyCost, DiffContext: 1})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Index(string(b), "aws_instance.a") < strings.Index(string(b), "aws_instance.b"))
+}
+
+func TestBuildDiffRollups(t *testing.T) {
+	project := Project{
+		PastBreakdown: &Breakdown{Resources: []Resource{
+			{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(60))},
+			{Name: "module.db.aws_db_instance.main", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+		}},
+		Breakdown: &Breakdown{Resources: []Resource{
+			{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(75))},
+			{Name: "aws_instance.web[1]", MonthlyCost: decimalPtr(decimal.NewFromInt(75))},
+		}},
+		Diff: &Breakdown{
+			Resources: []Resource{
+				{Name: "aws_instance.web[0]", MonthlyCost: decimalPtr(decimal.NewFromInt(15))},
+				{Name: "aws_instance.web[1]", MonthlyCost: decimalPtr(decimal.NewFromInt(75))},
+				{Name: "module.db.aws_db_instance.main", MonthlyCost: decimalPtr(decimal.NewFromInt(-100))},
+			},
+			TotalMonthlyCost: decimalPtr(decimal.NewFromInt(-10)),
+		},
+	}
+
+	rollups := BuildDiffRollups(project, nil)
+	assert.Equal(t, 2, len(rollups))
+
+	assert.Equal(t, "aws_db_instance", rollups[0].ResourceType)
+	assert.Equal(t, 1, rollups[0].Removed)
+	assert.Equal(t, "aws_instance", rollups[1].ResourceType)
+	assert.Equal(t, 1, rollups[1].Added)
+	assert.Equal(t, 1, rollups[1].Updated)
+	assert.Equal(t, "150", rollups[1].MonthlyCost.String())
+
+	total := decimal.Zero
+	for _, r := range rollups {
+		total = total.Add(*r.DiffMonthlyCost)
+	}
+	assert.Equal(t, project.Diff.TotalMonthlyCost.String(), total.String())
+
+	rollups = BuildDiffRollups(project, []string{"aws_db_instance"})
+	assert.Equal(t, 1, len(rollups))
+	assert.Equal(t, "aws_db_instance", rollups[0].ResourceType)
+}
+
+func TestWithDiffContext(t *testing.T) {
+	resources := []Resource{
+		{Name: "aws_instance.a"},
+		{Name: "aws_instance.b"},
+		{Name: "aws_instance.c"},
+		{Name: "aws_instance.d"},
+		{Name: "aws_instance.e"},
+		{Name: "aws_instance.f"},
+	}
+	diffResources := []Resource{
+		{Name: "aws_instance.b"},
+		{Name: "aws_instance.bb"},
+	}
+
+	withContext, contextNames := withDiffContext(diffResources, resources, 1)
+
+	names := make([]string, 0, len(withContext))
+	for _, r := range withContext {
+		names = append(names, r.Name)
+	}
+
+	assert.Equal(t, []string{"aws_instance.a", "aws_instance.b", "aws_instance.bb", "aws_instance.c"}, names)
+	assert.Equal(t, map[string]bool{"aws_instance.a": true, "aws_instance.c": true}, contextNames)
+}
+
+func TestResourceTypeFromName(t *testing.T) {
+	assert.Equal(t, "aws_instance", resourceTypeFromName("aws_instance.web"))
+	assert.Equal(t, "aws_nat_gateway", resourceTypeFromName(`module.vpc["a.b"].aws_nat_gateway.main["x.y"]`))
+	assert.Equal(t, "aws_ami", resourceTypeFromName("module.vpc.data.aws_ami.ubuntu"))
+}
+
+func TestExplainCostComponent(t *testing.T) {
+	assert.Equal(t, "Instance usage (m5.large) = $0.096/hr × 730 hrs = $70.08/mo", explainCostComponent(CostComponent{
+		Name:            "Instance usage (m5.large)",
+		Unit:            "hours",
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+		Price:           decimal.NewFromFloat(0.096),
+		MonthlyCost:     decimalPtr(decimal.NewFromFloat(70.08)),
+	}, newNumberFormat(Options{})))
+
+	assert.Equal(t, "Storage = $0.1 per GB × 50 GB = $5.00/mo", explainCostComponent(CostComponent{
+		Name:            "Storage",
+		Unit:            "GB",
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(50)),
+		Price:           decimal.NewFromFloat(0.1),
+		MonthlyCost:     decimalPtr(decimal.NewFromInt(5)),
+	}, newNumberFormat(Options{})))
+
+	assert.Equal(t, "Requests = $0.4 per 1M requests, monthly cost depends on usage", explainCostComponent(CostComponent{
+		Name:  "Requests",
+		Unit:  "1M requests",
+		Price: decimal.NewFromFloat(0.4),
+	}, newNumberFormat(Options{})))
+
+	assert.Equal(t, "Storage = €0.1 per GB × 50 GB = €5.00/mo", explainCostComponent(CostComponent{
+		Name:            "Storage",
+		Unit:            "GB",
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(50)),
+		Price:           decimal.NewFromFloat(0.1),
+		MonthlyCost:     decimalPtr(decimal.NewFromInt(5)),
+	}, newNumberFormat(Options{Currency: "EUR"})))
+}
+
+func TestToMarkdown(t *testing.T) {
+	monthlyCost := decimalPtr(decimal.NewFromFloat(70.08))
+	resources := []Resource{
+		{
+			Name:        `aws_instance.web["a|b"]`,
+			MonthlyCost: monthlyCost,
+			CostComponents: []CostComponent{
+				{
+					Name:            "Instance usage (Linux/UNIX, on-demand, m5.large)",
+					Unit:            "hours",
+					Price:           decimal.NewFromFloat(0.096),
+					MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+					MonthlyCost:     monthlyCost,
+				},
+			},
+		},
+	}
+
+	out := Root{
+		Resources: resources,
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        resources,
+					TotalMonthlyCost: monthlyCost,
+				},
+			},
+		},
+		Summary: &Summary{},
+	}
+
+	b, err := ToMarkdown(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+
+	expected := `## Project: path
+
+| Name | Monthly Qty | Unit | Monthly Cost |
+| --- | ---: | --- | ---: |
+| **aws\_instance.web\["a\|b"\]** |  |  |  |
+| └─ Instance usage (Linux/UNIX, on-demand, m5.large) | 730 | hours | $70.08 |
+| **Project total** |  |  | **$70.08** |
+`
+	assert.Equal(t, expected, string(b))
+
+	b, err = ToMarkdown(out, Options{Fields: []string{"monthlyCost"}, SummaryOnly: true, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, strings.Contains(string(b), "aws\\_instance"))
+	assert.Equal(t, true, strings.Contains(string(b), "| Name | Monthly Cost |\n| --- | ---: |\n| **Project total** | **$70.08** |"))
+
+	b, err = ToMarkdown(out, Options{Fields: []string{"monthlyCost"}, NoSummary: true, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "aws\\_instance"))
+	assert.Equal(t, false, strings.Contains(string(b), "Project total"))
+
+	b, err = ToMarkdown(out, Options{Fields: []string{"unit"}, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "| Name | Unit |\n| --- | --- |\n"))
+	assert.Equal(t, true, strings.Contains(string(b), "| └─ Instance usage (Linux/UNIX, on-demand, m5.large) | hours |\n"))
+	assert.Equal(t, true, strings.Contains(string(b), "| **Project total** | **$70.08** |"))
+
+	// Without any fields the total is shown with its label
+	b, err = ToMarkdown(out, Options{Fields: []string{}, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "| **Project total: $70.08** |"))
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyCost"}, NoSummary: true, NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "Instance usage"))
+	assert.Equal(t, false, strings.Contains(string(b), "PROJECT TOTAL"))
+	assert.Equal(t, false, strings.Contains(string(b), "OVERALL TOTAL"))
+}
+
+func TestToTableOverallTotals(t *testing.T) {
+	hourlyCost := decimalPtr(decimal.NewFromFloat(0.096))
+	monthlyCost := decimalPtr(decimal.NewFromFloat(70.08))
+	resources := []Resource{
+		{
+			Name:        "aws_instance.web",
+			HourlyCost:  hourlyCost,
+			MonthlyCost: monthlyCost,
+			CostComponents: []CostComponent{
+				{
+					Name:           "Instance usage (Linux/UNIX, on-demand, m5.large)",
+					Unit:           "hours",
+					Price:          decimal.NewFromFloat(0.096),
+					HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+					HourlyCost:     hourlyCost,
+					MonthlyCost:    monthlyCost,
+				},
+			},
+		},
+	}
+
+	project := Project{
+		Path: "path",
+		Breakdown: &Breakdown{
+			Resources:        resources,
+			TotalHourlyCost:  hourlyCost,
+			TotalMonthlyCost: monthlyCost,
+		},
+	}
+
+	out := Root{
+		Resources:        append(resources, resources...),
+		Projects:         []Project{project, project},
+		TotalHourlyCost:  decimalPtr(hourlyCost.Mul(decimal.NewFromInt(2))),
+		TotalMonthlyCost: decimalPtr(monthlyCost.Mul(decimal.NewFromInt(2))),
+		Summary:          &Summary{},
+	}
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true})
+	assert.Equal(t, nil, err)
+
+	lines := strings.Split(string(b), "\n")
+	var projectTotal, hourlyTotal, monthlyTotal string
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, " PROJECT TOTAL"):
+			projectTotal = line
+		case strings.HasPrefix(line, " OVERALL TOTAL (hourly)"):
+			hourlyTotal = line
+		case strings.HasPrefix(line, " OVERALL TOTAL (monthly)"):
+			monthlyTotal = line
+		}
+	}
+
+	assert.Equal(t, true, strings.HasSuffix(hourlyTotal, " $0.19 "))
+	assert.Equal(t, true, strings.HasSuffix(monthlyTotal, " $140.16 "))
+	assert.Equal(t, len(projectTotal), len(hourlyTotal))
+	assert.Equal(t, len(projectTotal), len(monthlyTotal))
+
+	b, err = ToMarkdown(out, Options{Fields: []string{"monthlyCost"}, MarkdownStyle: MarkdownStylePlain})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "**Overall total: $140.16 per month ($0.19 per hour)**"))
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true, CostPeriod: CostPeriodYearly})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), " $1,681.92 \n OVERALL TOTAL (monthly)"))
+	assert.Equal(t, false, strings.Contains(string(b), "OVERALL TOTAL (hourly)"))
+
+	// The summary only has the overall totals, even for a single project
+	for _, projects := range [][]Project{{project, project}, {project}} {
+		summaryOut := out
+		summaryOut.Projects = projects
+		b, err = ToTable(summaryOut, Options{Fields: []string{"monthlyCost"}, NoColor: true, SummaryOnly: true})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, strings.Contains(string(b), "Project:"))
+		assert.Equal(t, false, strings.Contains(string(b), "PROJECT TOTAL"))
+		assert.Equal(t, true, strings.Contains(string(b), "OVERALL TOTAL (monthly)"))
+	}
+
+	b, err = ToMarkdown(out, Options{Fields: []string{"monthlyCost"}, MarkdownStyle: MarkdownStylePlain, CostPeriod: CostPeriodHourly})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "**Overall total: $0.19 per hour ($140.16 per month)**"))
+
+	// The totals line up with the widest table, not the last one
+	narrow := project
+	narrow.Breakdown = &Breakdown{
+		Resources:        []Resource{{Name: "a", HourlyCost: hourlyCost, MonthlyCost: monthlyCost}},
+		TotalHourlyCost:  hourlyCost,
+		TotalMonthlyCost: monthlyCost,
+	}
+	out.Projects = []Project{project, narrow}
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true})
+	assert.Equal(t, nil, err)
+
+	lines = strings.Split(string(b), "\n")
+	widest := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, " PROJECT TOTAL") && len(line) > widest {
+			widest = len(line)
+		}
+		if strings.HasPrefix(line, " OVERALL TOTAL (monthly)") {
+			monthlyTotal = line
+		}
+	}
+	assert.Equal(t, widest, len(monthlyTotal))
+
+	out.Projects = []Project{project}
+	out.TotalHourlyCost = hourlyCost
+	out.TotalMonthlyCost = monthlyCost
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyCost"}, NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "PROJECT TOTAL"))
+	assert.Equal(t, false, strings.Contains(string(b), "OVERALL TOTAL"))
+}
+
+func TestToDiffCostPeriod(t *testing.T) {
+	volume := func(size int64) *schema.Resource {
+		c := &schema.CostComponent{Name: "Storage", Unit: "GB", UnitMultiplier: 1, MonthlyQuantity: decimalPtr(decimal.NewFromInt(size))}
+		c.SetPrice(decimal.NewFromFloat(0.1))
+		return &schema.Resource{Name: "aws_ebs_volume.data", ResourceType: "aws_ebs_volume", CostComponents: []*schema.CostComponent{c}}
+	}
+
+	project := schema.NewProject("test", map[string]string{})
+	project.PastResources = []*schema.Resource{volume(100)}
+	project.Resources = []*schema.Resource{volume(200)}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+	out := ToOutputFormat([]*schema.Project{project})
+
+	b, err := ToDiff(out, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "Overall cost change\nHourly:  +$0.01\nMonthly: +$10.00\n"))
+
+	b, err = ToDiff(out, Options{NoColor: true, CostPeriod: CostPeriodYearly})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "Overall cost change\nYearly:  +$120.00\nMonthly: +$10.00\n"))
+
+	assert.NotEqual(t, nil, ValidateCostPeriod("daily"))
+	assert.Equal(t, nil, ValidateCostPeriod(""))
+}
+
+func TestCombineDedupe(t *testing.T) {
+	newInput := func(filename string, names ...string) ReportInput {
+		resources := make([]Resource, 0, len(names))
+		for _, name := range names {
+			resources = append(resources, Resource{
+				Name:        name,
+				HourlyCost:  decimalPtr(decimal.NewFromInt(1)),
+				MonthlyCost: decimalPtr(decimal.NewFromInt(730)),
+			})
+		}
+
+		total := decimalPtr(decimal.NewFromInt(int64(730 * len(names))))
+		supported := len(names)
+
+		return ReportInput{
+			Metadata: map[string]string{"filename": filename},
+			Root: Root{
+				Resources:        resources,
+				TotalHourlyCost:  decimalPtr(decimal.NewFromInt(int64(len(names)))),
+				TotalMonthlyCost: total,
+				Projects: []Project{
+					{
+						Path:      filename,
+						Breakdown: &Breakdown{Resources: resources, TotalMonthlyCost: total},
+					},
+				},
+				Summary: &Summary{
+					SupportedResourceCounts: &map[string]int{"aws_instance": supported},
+					TotalSupportedResources: &supported,
+					TotalResources:          &supported,
+				},
+			},
+		}
+	}
+
+	inputs := []ReportInput{
+		newInput("dev.json", "aws_instance.web", "aws_instance.db"),
+		newInput("prod.json", "aws_instance.web", "aws_instance.api"),
+	}
+
+	duplicates := FindDuplicateResources(inputs, "filename")
+	assert.Equal(t, []DuplicateResource{{Name: "aws_instance.web", ResourceType: "aws_instance", Inputs: []string{"dev.json", "prod.json"}}}, duplicates)
+
+	combined := Combine(inputs, Options{GroupKey: "filename", Dedupe: DedupeFirstWins})
+	assert.Equal(t, 3, len(combined.Resources))
+	assert.Equal(t, "2190", combined.TotalMonthlyCost.String())
+	assert.Equal(t, "730", combined.Projects[1].Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, 1, *combined.Summary.TotalDedupedResources)
+	assert.Equal(t, 3, *combined.Summary.TotalSupportedResources)
+	assert.Equal(t, 3, (*combined.Summary.SupportedResourceCounts)["aws_instance"])
+
+	combined = Combine(inputs, Options{GroupKey: "filename", Dedupe: DedupeWarn})
+	assert.Equal(t, 4, len(combined.Resources))
+	assert.Equal(t, "2920", combined.TotalMonthlyCost.String())
+	assert.Equal(t, true, combined.Summary.TotalDedupedResources == nil)
+}
+
+func TestToCSV(t *testing.T) {
+	monthlyCost := decimalPtr(decimal.NewFromFloat(70.08))
+	resources := []Resource{
+		{
+			Name:        `module.app["eu,west"].aws_instance.web`,
+			MonthlyCost: monthlyCost,
+			CostComponents: []CostComponent{
+				{
+					Name:            "Instance usage (Linux/UNIX, on-demand, m5.large)",
+					Unit:            "hours",
+					Price:           decimal.NewFromFloat(0.096),
+					MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+					HourlyCost:      decimalPtr(decimal.NewFromFloat(0.096)),
+					MonthlyCost:     monthlyCost,
+				},
+			},
+		},
+	}
+
+	out := Root{
+		Projects: []Project{
+			{
+				Path:      "path",
+				Breakdown: &Breakdown{Resources: resources},
+			},
+		},
+	}
+
+	b, err := ToCSV(out, Options{})
+	assert.Equal(t, nil, err)
+
+	expected := "Project,Resource,Cost component,Monthly quantity,Unit,Price,Hourly cost,Monthly cost\r\n" +
+		`path,"module.app[""eu,west""].aws_instance.web","Instance usage (Linux/UNIX, on-demand, m5.large)",730,hours,0.096,0.096,70.08` + "\r\n"
+	assert.Equal(t, expected, string(b))
+
+	delimiter, err := ParseCSVDelimiter(";")
+	assert.Equal(t, nil, err)
+
+	b, err = ToCSV(out, Options{CSVDelimiter: delimiter})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasSuffix(string(b), "path;\"module.app[\"\"eu,west\"\"].aws_instance.web\";Instance usage (Linux/UNIX, on-demand, m5.large);730;hours;0.096;0.096;70.08\r\n"))
+
+	for _, d := range []string{"", `"`, ";;", "\n"} {
+		_, err := ParseCSVDelimiter(d)
+		assert.NotEqual(t, nil, err)
+	}
+}
+
+func TestLimitResourceDepth(t *testing.T) {
+	storageCost := decimalPtr(decimal.NewFromInt(5))
+	iopsCost := decimalPtr(decimal.NewFromInt(26))
+	volumeCost := decimalPtr(storageCost.Add(*iopsCost))
+
+	resources := []Resource{
+		{
+			Name: "aws_instance.web",
+			CostComponents: []CostComponent{
+				{Name: "Instance usage", MonthlyCost: decimalPtr(decimal.NewFromFloat(70.08))},
+			},
+			SubResources: []Resource{
+				{
+					Name:        "ebs_block_device[0]",
+					MonthlyCost: volumeCost,
+					CostComponents: []CostComponent{
+						{Name: "Storage", MonthlyCost: storageCost},
+						{Name: "Provisioned IOPS", MonthlyCost: iopsCost},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, resources, limitResourceDepth(resources, nil))
+	assert.Equal(t, resources, limitResourceDepth(resources, intPtr(1)))
+
+	limited := limitResourceDepth(resources, intPtr(0))
+	assert.Equal(t, 0, len(limited[0].SubResources))
+	assert.Equal(t, 2, len(limited[0].CostComponents))
+	assert.Equal(t, "+2 nested components", limited[0].CostComponents[1].Name)
+	assert.Equal(t, volumeCost.String(), limited[0].CostComponents[1].MonthlyCost.String())
+
+	// The original resources are unchanged
+	assert.Equal(t, 1, len(resources[0].SubResources))
+	assert.Equal(t, 1, len(resources[0].CostComponents))
+}
+
+func TestWrapCell(t *testing.T) {
+	assert.Equal(t, "GB", wrapCell("GB", 16, ""))
+	assert.Equal(t, "requests per\nmillion, tiered", wrapCell("requests per million, tiered", 16, ""))
+	assert.Equal(t, "Storage (general\n│  purpose SSD)", wrapCell("Storage (general purpose SSD)", 16, "│  "))
+}
+
+func TestDiffTotalMonthlyCost(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{Diff: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(10))}},
+			{Diff: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(-15))}},
+			{Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}},
+		},
+	}
+
+	assert.Equal(t, "-5", out.DiffTotalMonthlyCost().String())
+}
+
+func TestSkippedResources(t *testing.T) {
+	priced := &schema.CostComponent{Name: "Instance usage", Unit: "hours", UnitMultiplier: 1, HourlyQuantity: decimalPtr(decimal.NewFromInt(1))}
+	priced.SetPrice(decimal.NewFromFloat(0.096))
+	usageBased := &schema.CostComponent{Name: "Requests", Unit: "1M requests", UnitMultiplier: 1000000}
+
+	project := schema.NewProject("test", map[string]string{})
+	project.Resources = []*schema.Resource{
+		{Name: "aws_instance.web", ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{priced}},
+		{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", CostComponents: []*schema.CostComponent{usageBased}},
+		{Name: "aws_glue_job.job", ResourceType: "aws_glue_job", CostComponents: []*schema.CostComponent{usageBased}, UsageMessage: "Set monthly_hrs in the usage file"},
+		{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", IsSkipped: true, NoPrice: true, SkipMessage: "Free resource."},
+		{Name: "aws_appsync_graphql_api.api", ResourceType: "aws_appsync_graphql_api", IsSkipped: true, SkipMessage: "This resource is not currently supported"},
+	}
+	schema.CalculateCosts(project)
+
+	out := ToOutputFormat([]*schema.Project{project})
+
+	assert.Equal(t, 3, len(out.Projects[0].Breakdown.Resources))
+	assert.Equal(t, []SkippedResource{
+		{Name: "aws_appsync_graphql_api.api", ResourceType: "aws_appsync_graphql_api", Reason: SkipReasonUnsupported, Message: "This resource is not currently supported"},
+		{Name: "aws_glue_job.job", ResourceType: "aws_glue_job", Reason: SkipReasonUsageMissing, Message: "Set monthly_hrs in the usage file"},
+		{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", Reason: SkipReasonNoPrice, Message: "Free resource."},
+		{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", Reason: SkipReasonUsageMissing, Message: "Usage-based costs can be estimated with --usage-file"},
+	}, out.Projects[0].SkippedResources)
+}
+
+func TestBuildSummaryConfidence(t *testing.T) {
+	resource := func(confidence string, cost int64) *schema.Resource {
+		return &schema.Resource{Name: confidence, ResourceType: "aws_instance", Confidence: confidence, MonthlyCost: decimalPtr(decimal.NewFromInt(cost))}
+	}
+
+	s := BuildSummary([]*schema.Resource{resource("high", 30), resource("medium", 50), resource("low", 10)}, SummaryOptions{})
+	assert.Equal(t, "medium", s.Confidence)
+	assert.Equal(t, map[string]int{"high": 1, "medium": 1, "low": 1}, *s.ConfidenceCounts)
+
+	s = BuildSummary([]*schema.Resource{resource("high", 80), resource("low", 20)}, SummaryOptions{})
+	assert.Equal(t, "high", s.Confidence)
+
+	s = BuildSummary([]*schema.Resource{resource("high", 0), resource("low", 0), resource("low", 0)}, SummaryOptions{})
+	assert.Equal(t, "low", s.Confidence)
+
+	s = BuildSummary([]*schema.Resource{{Name: "aws_iam_role.role", ResourceType: "aws_iam_role", IsSkipped: true, NoPrice: true}}, SummaryOptions{})
+	assert.Equal(t, "", s.Confidence)
+	assert.Equal(t, true, s.ConfidenceCounts == nil)
+}
+
+func TestBuildComparison(t *testing.T) {
+	env := func(name string, total float64, costs map[string]*decimal.Decimal) ReportInput {
+		root := Root{TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(total))}
+		for n, c := range costs {
+			root.Resources = append(root.Resources, Resource{Name: n, MonthlyCost: c})
+		}
+		return ReportInput{Metadata: map[string]string{"environment": name}, Root: root}
+	}
+
+	inputs := []ReportInput{
+		env("dev", 10, map[string]*decimal.Decimal{
+			"aws_instance.web": decimalPtr(decimal.NewFromInt(10)),
+		}),
+		env("prod", 60, map[string]*decimal.Decimal{
+			"aws_instance.web":    decimalPtr(decimal.NewFromInt(40)),
+			"aws_instance.worker": decimalPtr(decimal.NewFromInt(20)),
+			"aws_lambda_function": nil,
+		}),
+	}
+
+	b, err := ToCompareCSV(BuildComparison(inputs, false), Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Resource,dev,prod\naws_instance.web,10,40\naws_instance.worker,,20\naws_lambda_function,,\nTotal,10,60\n", string(b))
+
+	b, err = ToCompareCSV(BuildComparison(inputs, true), Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Resource type,dev,prod\naws_instance,10,60\naws_lambda_function,,\nTotal,10,60\n", string(b))
+}
+
+func TestFormatCostChangeSummary(t *testing.T) {
+	assert.Equal(t, "$100.00", FormatCostChangeSummary(nil, decimalPtr(decimal.NewFromInt(100)), ""))
+	assert.Equal(t, "+$12.50 ($100.00 -> $112.50)", FormatCostChangeSummary(decimalPtr(decimal.NewFromInt(100)), decimalPtr(decimal.NewFromFloat(112.5)), ""))
+	assert.Equal(t, "-$0.50 ($1.00 -> $0.50)", FormatCostChangeSummary(decimalPtr(decimal.NewFromInt(1)), decimalPtr(decimal.NewFromFloat(0.5)), ""))
+}
+
+func TestFormatCostChange(t *testing.T) {
+	nf := newNumberFormat(Options{})
+	assert.Equal(t, "+$12.50", formatCostChange(decimalPtr(decimal.NewFromFloat(12.5)), nf))
+	assert.Equal(t, "-$1.00", formatCostChange(decimalPtr(decimal.NewFromInt(-1)), nf))
+	assert.Equal(t, "-", formatCostChange(nil, nf))
+}
+
+func TestBuildMultiDiff(t *testing.T) {
+	root := func(total int64, costs map[string]int64) Root {
+		r := Root{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(total))}
+		for n, c := range costs {
+			r.Resources = append(r.Resources, Resource{Name: n, MonthlyCost: decimalPtr(decimal.NewFromInt(c))})
+		}
+		return r
+	}
+
+	baseline := root(30, map[string]int64{"aws_instance.web": 10, "aws_instance.worker": 20})
+	prs := []ReportInput{
+		{Metadata: map[string]string{"name": "pr1"}, Root: root(45, map[string]int64{"aws_instance.web": 15, "aws_instance.worker": 20, "aws_lambda_function.fn": 10})},
+		{Metadata: map[string]string{"name": "pr2"}, Root: root(20, map[string]int64{"aws_instance.web": 20})},
+	}
+
+	m := BuildMultiDiff("main.json", baseline, prs)
+	assert.Equal(t, []string{"pr1", "pr2"}, m.PRs)
+	assert.Equal(t, "15", m.Totals[0].String())
+	assert.Equal(t, "-10", m.Totals[1].String())
+	assert.Equal(t, "5", m.CombinedTotal.String())
+
+	assert.Equal(t, 3, len(m.Rows))
+	assert.Equal(t, "aws_instance.web", m.Rows[0].Name)
+	assert.Equal(t, "15", m.Rows[0].Combined.String())
+	assert.Equal(t, "aws_instance.worker", m.Rows[1].Name)
+	assert.Equal(t, true, m.Rows[1].Cells[0] == nil)
+	assert.Equal(t, "-20", m.Rows[1].Cells[1].String())
+	assert.Equal(t, "aws_lambda_function.fn", m.Rows[2].Name)
+
+	conflicts := m.Conflicts()
+	assert.Equal(t, 1, len(conflicts))
+	assert.Equal(t, []string{"pr1", "pr2"}, conflicts[0].ChangedBy(m.PRs))
+
+	b, err := ToMultiDiff(m, Options{NoColor: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "aws_instance.web: pr1, pr2"))
+}
+
+func TestToJSONAnnualCosts(t *testing.T) {
+	resources := []Resource{
+		{
+			Name:        "aws_instance.web",
+			MonthlyCost: decimalPtr(decimal.NewFromFloat(10.5)),
+			SubResources: []Resource{
+				{Name: "root_block_device", MonthlyCost: decimalPtr(decimal.NewFromFloat(0.5))},
+			},
+		},
+		{Name: "aws_lambda_function.fn"},
+	}
+
+	out := Root{
+		Resources:        resources,
+		TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(10.5)),
+		Projects: []Project{
+			{
+				Path:      "path",
+				Breakdown: &Breakdown{Resources: resources, TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(10.5))},
+			},
+		},
+	}
+	out = ConvertCurrency(out, "EUR", decimal.NewFromInt(2))
+
+	b, err := ToJSON(out, Options{})
+	assert.Equal(t, nil, err)
+
+	var j Root
+	err = json.Unmarshal(b, &j)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "252", j.TotalAnnualCost.String())
+	assert.Equal(t, "252", j.Projects[0].Breakdown.TotalAnnualCost.String())
+	assert.Equal(t, "252", j.Projects[0].Breakdown.Resources[0].AnnualCost.String())
+	assert.Equal(t, "12", j.Projects[0].Breakdown.Resources[0].SubResources[0].AnnualCost.String())
+	assert.Equal(t, true, j.Projects[0].Breakdown.Resources[1].AnnualCost == nil)
+
+	// The input shouldn't be changed
+	assert.Equal(t, true, out.TotalAnnualCost == nil)
+	assert.Equal(t, true, resources[0].AnnualCost == nil)
+
+	var w bytes.Buffer
+	err = WriteJSON(&w, out, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(b), w.String())
+}
+
+func TestSignJSON(t *testing.T) {
+	b := []byte(`{"version":"0.3","totalMonthlyCost":"10.50","projects":[{"path":"b","metadata":{}}]}`)
+
+	signed, err := SignJSON(b, "key")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, VerifyJSONSignature(signed, "key"))
+
+	// The signature shouldn't depend on the key order or whitespace
+	var doc map[string]interface{}
+	err = json.Unmarshal(signed, &doc)
+	assert.Equal(t, nil, err)
+	reordered := []byte(fmt.Sprintf(`{
+  "projects": [{"metadata": {}, "path": "b"}],
+  "signature": %q,
+  "totalMonthlyCost": "10.50",
+  "version": "0.3"
+}`, doc["signature"]))
+	assert.Equal(t, nil, VerifyJSONSignature(reordered, "key"))
+
+	// Signing again replaces the signature
+	resigned, err := SignJSON(signed, "key")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(signed), string(resigned))
+
+	assert.NotEqual(t, nil, VerifyJSONSignature(signed, "other key"))
+	assert.NotEqual(t, nil, VerifyJSONSignature(bytes.Replace(signed, []byte("10.50"), []byte("1.50"), 1), "key"))
+	assert.NotEqual(t, nil, VerifyJSONSignature(b, "key"))
+}
+
+func TestSortOutput(t *testing.T) {
+	resources := []Resource{
+		{Name: "a", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+		{Name: "b"},
+		{Name: "c", MonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+		{Name: "d", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+	}
+	out := Root{
+		Projects: []Project{
+			{Path: "z", Breakdown: &Breakdown{Resources: resources}},
+			{Path: "y", Breakdown: &Breakdown{Resources: resources}},
+		},
+	}
+
+	names := func(r Root) []string {
+		n := make([]string, 0)
+		for _, res := range r.Projects[0].Breakdown.Resources {
+			n = append(n, res.Name)
+		}
+		return n
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names(sortOutput(out, "")))
+	assert.Equal(t, []string{"d", "a", "c", "b"}, names(sortOutput(out, "monthlyCost")))
+	assert.Equal(t, []string{"c", "a", "d", "b"}, names(sortOutput(out, "-monthlyCost")))
+	assert.Equal(t, []string{"d", "c", "b", "a"}, names(sortOutput(out, "-name")))
+
+	sorted := sortOutput(out, "path")
+	assert.Equal(t, "y", sorted.Projects[0].Path)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names(sorted))
+	assert.Equal(t, "z", sortOutput(out, "-path").Projects[0].Path)
+
+	// The input shouldn't be changed
+	assert.Equal(t, "z", out.Projects[0].Path)
+	assert.Equal(t, "a", resources[0].Name)
+
+	assert.Equal(t, nil, ValidateSortKey("-monthlyCost"))
+	assert.NotEqual(t, nil, ValidateSortKey("cost"))
+}
+
+func TestToJUnit(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{Path: "cheap", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))}},
+			{Path: "expensive", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150))}},
+			{Path: "no-breakdown"},
+		},
+	}
+
+	b, err := ToJUnit(out, Options{Threshold: decimalPtr(decimal.NewFromInt(100))})
+	assert.Equal(t, nil, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="Infracost" tests="3" failures="1" errors="1">
+  <testcase name="cheap" classname="infracost">
+    <system-out>Monthly cost: $50.00</system-out>
+  </testcase>
+  <testcase name="expensive" classname="infracost">
+    <failure message="Monthly cost $150.00 is $50.00 over the threshold of $100.00" type="threshold">Monthly cost: $150.00</failure>
+    <system-out>Monthly cost: $150.00</system-out>
+  </testcase>
+  <testcase name="no-breakdown" classname="infracost">
+    <error message="Error estimating the costs of the project, it has no breakdown" type="error"></error>
+  </testcase>
+</testsuite>`
+	assert.Equal(t, expected, string(b))
+
+	b, err = ToJUnit(out, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, strings.Contains(string(b), "<failure"))
+	assert.Equal(t, true, strings.Contains(string(b), "<error"))
+}
+
+func TestToSARIF(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				Path: "infra/prod",
+				Breakdown: &Breakdown{Resources: []Resource{
+					{Name: "aws_instance.cheap", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(150)), FileName: "main.tf", StartLine: 12},
+					{Name: "aws_db_instance.db", MonthlyCost: decimalPtr(decimal.NewFromInt(600))},
+					{Name: "aws_lambda_function.fn"},
+				}},
+			},
+		},
+	}
+
+	b, err := ToSARIF(out, Options{
+		ResourceThreshold:      decimalPtr(decimal.NewFromInt(100)),
+		ResourceErrorThreshold: decimalPtr(decimal.NewFromInt(500)),
+	})
+	assert.Equal(t, nil, err)
+
+	var doc sarifLog
+	assert.Equal(t, nil, json.Unmarshal(b, &doc))
+	assert.Equal(t, "2.1.0", doc.Version)
+	assert.Equal(t, SARIFRuleExpensiveResource, doc.Runs[0].Tool.Driver.Rules[0].ID)
+
+	expected := []sarifResult{
+		{
+			RuleID:  SARIFRuleExpensiveResource,
+			Level:   "warning",
+			Message: sarifMessage{Text: "aws_instance.web in infra/prod has a monthly cost of $150.00, over the threshold of $100.00"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "infra/prod/main.tf"},
+				Region:           &sarifRegion{StartLine: 12},
+			}}},
+		},
+		{
+			RuleID:  SARIFRuleExpensiveResource,
+			Level:   "error",
+			Message: sarifMessage{Text: "aws_db_instance.db in infra/prod has a monthly cost of $600.00, over the threshold of $500.00"},
+		},
+	}
+	assert.Equal(t, expected, doc.Runs[0].Results)
+
+	// The error threshold is checked first, so if it's below the warning
+	// threshold the resources over it are errors
+	b, err = ToSARIF(out, Options{
+		ResourceThreshold:      decimalPtr(decimal.NewFromInt(500)),
+		ResourceErrorThreshold: decimalPtr(decimal.NewFromInt(100)),
+	})
+	assert.Equal(t, nil, err)
+
+	doc = sarifLog{}
+	assert.Equal(t, nil, json.Unmarshal(b, &doc))
+	levels := make([]string, 0, len(doc.Runs[0].Results))
+	for _, r := range doc.Runs[0].Results {
+		levels = append(levels, r.Level)
+	}
+	assert.Equal(t, []string{"error", "error"}, levels)
+	assert.Equal(t, "aws_instance.web in infra/prod has a monthly cost of $150.00, over the threshold of $100.00", doc.Runs[0].Results[0].Message.Text)
+
+	b, err = ToSARIF(out, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), `"results": []`))
+
+	dir := t.TempDir()
+	out.Projects[0].Path = filepath.Join(dir, "infra", "prod")
+	out.TargetCurrency = "EUR"
+
+	b, err = ToSARIF(out, Options{ResourceThreshold: decimalPtr(decimal.NewFromInt(100)), Currency: "EUR", SARIFBaseDir: dir})
+	assert.Equal(t, nil, err)
+
+	doc = sarifLog{}
+	assert.Equal(t, nil, json.Unmarshal(b, &doc))
+	assert.Equal(t, "aws_instance.web in "+out.Projects[0].Label()+" has a monthly cost of €150.00, over the threshold of €100.00", doc.Runs[0].Results[0].Message.Text)
+	assert.Equal(t, sarifArtifactLocation{URI: "infra/prod/main.tf", URIBaseID: sarifSourceRoot}, doc.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation)
+
+	// Plan JSON files are in the Terraform dir
+	assert.Equal(t, nil, os.MkdirAll(out.Projects[0].Path, 0700))
+	planPath := filepath.Join(out.Projects[0].Path, "plan.v1")
+	assert.Equal(t, nil, ioutil.WriteFile(planPath, []byte("{}"), 0600))
+	loc := sarifResourceLocation(Project{Path: planPath}, out.Projects[0].Breakdown.Resources[1], dir)
+	assert.Equal(t, "infra/prod/main.tf", loc.PhysicalLocation.ArtifactLocation.URI)
+
+	// Dirs with an extension aren't treated as files
+	loc = sarifResourceLocation(Project{Path: "infra/v1.2"}, out.Projects[0].Breakdown.Resources[1], "")
+	assert.Equal(t, sarifArtifactLocation{URI: "infra/v1.2/main.tf"}, loc.PhysicalLocation.ArtifactLocation)
+
+}
+
+func TestCheckCostThresholds(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(200))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(250))},
+				Diff:          &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+			},
+		},
+	}
+
+	breaches := CheckCostThresholds(out, nil, nil)
+	assert.Equal(t, 0, len(breaches))
+
+	breaches = CheckCostThresholds(out, decimalPtr(decimal.NewFromInt(30)), decimalPtr(decimal.NewFromInt(100)))
+	assert.Equal(t, 0, len(breaches))
+
+	breaches = CheckCostThresholds(out, decimalPtr(decimal.NewFromInt(20)), decimalPtr(decimal.NewFromInt(100)))
+	assert.Equal(t, []ThresholdBreach{
+		{Threshold: "percent", Message: "Monthly cost increase of 25% is 5% over the percent threshold of 20%"},
+	}, breaches)
+
+	breaches = CheckCostThresholds(out, decimalPtr(decimal.NewFromInt(20)), decimalPtr(decimal.NewFromInt(40)))
+	assert.Equal(t, []ThresholdBreach{
+		{Threshold: "absolute", Message: "Monthly cost increase of $50.00 is $10.00 over the absolute threshold of $40.00"},
+		{Threshold: "percent", Message: "Monthly cost increase of 25% is 5% over the percent threshold of 20%"},
+	}, breaches)
+
+	// Any increase from zero exceeds the percent threshold
+	out.Projects[0].PastBreakdown.TotalMonthlyCost = decimalPtr(decimal.Zero)
+	breaches = CheckCostThresholds(out, decimalPtr(decimal.NewFromInt(1000)), nil)
+	assert.Equal(t, "Monthly cost increase of $50.00 from $0.00 exceeds the percent threshold of 1000%", breaches[0].Message)
+
+	// Converted outputs are compared and shown in their currency
+	out.TargetCurrency = "EUR"
+	breaches = CheckCostThresholds(out, nil, decimalPtr(decimal.NewFromInt(40)))
+	assert.Equal(t, "Monthly cost increase of €50.00 is €10.00 over the absolute threshold of €40.00", breaches[0].Message)
+	out.TargetCurrency = ""
+
+	// Decreases never exceed the thresholds
+	out.Projects[0].Diff.TotalMonthlyCost = decimalPtr(decimal.NewFromInt(-50))
+	breaches = CheckCostThresholds(out, decimalPtr(decimal.Zero), decimalPtr(decimal.Zero))
+	assert.Equal(t, 0, len(breaches))
+}
+
+func TestToMarkdownGitHub(t *testing.T) {
+	longPath := "some/very/long/path/to/a/terraform/project/that/would/make/the/table/too/wide"
+	out := Root{
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(15)),
+		Summary:          &Summary{},
+		Projects: []Project{
+			{
+				Path: longPath,
+				PastBreakdown: &Breakdown{
+					Resources:        []Resource{{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(20))}},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20)),
+				},
+				Breakdown: &Breakdown{
+					Resources:        []Resource{{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(15))}},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(15)),
+				},
+				Diff: &Breakdown{
+					Resources:        []Resource{{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(-5))}},
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(-5)),
+				},
+			},
+		},
+	}
+
+	b, err := ToMarkdown(out, Options{MarkdownStyle: MarkdownStyleGitHub})
+	assert.Equal(t, nil, err)
+
+	expected := `**Monthly cost change: -$5.00 ($20.00 -> $15.00) 📉**
+
+| Project | Previous | New | Monthly Cost Change |
+| --- | ---: | ---: | ---: |
+| some/very/long/path/to/a/terraf…at/would/make/the/table/too/wide | $20.00 | $15.00 | -$5.00 (-25%) 📉 |
+
+<details>
+<summary>Cost changes by resource</summary>
+
+#### some/very/long/path/to/a/terraform/project/that/would/make/the/table/too/wide
+
+| Name | Previous | New | Monthly Cost Change |
+| --- | ---: | ---: | ---: |
+| aws\_instance.web | $20.00 | $15.00 | -$5.00 📉 |
+| **Project total** | $20.00 | $15.00 | **-$5.00** |
+
+</details>
+`
+	assert.Equal(t, expected, string(b))
+
+	// gitlab is an alias of github
+	b, err = ToMarkdown(out, Options{MarkdownStyle: MarkdownStyleGitLab})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expected, string(b))
+
+	b, err = ToMarkdown(out, Options{MarkdownStyle: MarkdownStyleGitHub, SummaryOnly: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, strings.Contains(string(b), "<details>"))
+
+	assert.NotEqual(t, nil, ValidateMarkdownStyle("slack"))
+}
+
+func TestCurrencySymbol(t *testing.T) {
+	cost := decimalPtr(decimal.NewFromFloat(12.5))
+
+	assert.Equal(t, "$", currencySymbol(""))
+	assert.Equal(t, "€12.50", formatCost2DP(cost, newNumberFormat(Options{Currency: "eur"})))
+	assert.Equal(t, "CHF 12.50", formatCost2DP(cost, newNumberFormat(Options{Currency: "CHF"})))
+	assert.Equal(t, "$12.50", formatCost2DP(cost, newNumberFormat(Options{})))
+
+	out := Root{
+		Summary: &Summary{},
+		Projects: []Project{
+			{
+				Path: "path",
+				Breakdown: &Breakdown{
+					Resources:        []Resource{{Name: "aws_instance.web", MonthlyCost: cost, CostComponents: []CostComponent{{Name: "Instance usage", Unit: "hours", MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)), MonthlyCost: cost}}}},
+					TotalMonthlyCost: cost,
+				},
+			},
+		},
+		TotalMonthlyCost: cost,
+	}
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyCost"}, Currency: "GBP"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "£12.50"))
+	assert.Equal(t, false, strings.Contains(string(b), "$"))
+
+	b, err = ToHTML(out, Options{Currency: "EUR"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.Contains(string(b), "€12.50"))
+
+	b, err = ToTemplate(out, Options{TemplateName: "t", Template: `{{ formatCurrency .Root.TotalMonthlyCost }}`, Currency: "EUR"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "€12.50", string(b))
+}
+
+func TestBaselineResources(t *testing.T) {
+	baseline := Root{
+		Projects: []Project{
+			{
+				Path: "infra/dev",
+				Breakdown: &Breakdown{Resources: []Resource{
+					{Name: "aws_instance.dev", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+				}},
+			},
+			{
+				Path: "infra/prod",
+				Breakdown: &Breakdown{Resources: []Resource{
+					{
+						Name:        "aws_instance.web",
+						HourlyCost:  decimalPtr(decimal.NewFromFloat(0.1)),
+						MonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+						CostComponents: []CostComponent{
+							{
+								Name:            "Instance usage",
+								Unit:            "hours",
+								Price:           decimal.NewFromFloat(0.1),
+								HourlyQuantity:  decimalPtr(decimal.NewFromInt(1)),
+								MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+								HourlyCost:      decimalPtr(decimal.NewFromFloat(0.1)),
+								MonthlyCost:     decimalPtr(decimal.NewFromInt(73)),
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	project := &schema.Project{Path: "infra/prod"}
+	p, ok := BaselineProject(baseline, project, 2)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "infra/prod", p.Path)
+
+	_, ok = BaselineProject(baseline, &schema.Project{Path: "infra/staging"}, 1)
+	assert.Equal(t, false, ok)
+
+	single := Root{Projects: baseline.Projects[:1]}
+	p, ok = BaselineProject(single, &schema.Project{Path: "other/checkout"}, 1)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "infra/dev", p.Path)
+
+	resources := BaselineResources(baseline.Projects[1])
+	assert.Equal(t, 1, len(resources))
+	assert.Equal(t, "aws_instance.web", resources[0].Name)
+	assert.Equal(t, "0.1", resources[0].CostComponents[0].Price().String())
+	assert.Equal(t, "73", resources[0].MonthlyCost.String())
+
+	project.PastResources = resources
+	project.Resources = []*schema.Resource{
+		{
+			Name:        "aws_instance.web",
+			HourlyCost:  decimalPtr(decimal.NewFromFloat(0.2)),
+			MonthlyCost: decimalPtr(decimal.NewFromInt(146)),
+		},
+	}
+	project.HasDiff = true
+	project.CalculateDiff()
+
+	assert.Equal(t, "73", project.Diff[0].MonthlyCost.String())
+}
+
+func TestFilterByPath(t *testing.T) {
+	out := Root{
+		TotalHourlyCost:  decimalPtr(decimal.NewFromFloat(0.3)),
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(219)),
+		Resources: []Resource{
+			{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(73))},
+			{Name: "module.db.aws_db_instance.main", MonthlyCost: decimalPtr(decimal.NewFromInt(146))},
+		},
+		Projects: []Project{
+			{
+				Path: "infra",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", HourlyCost: decimalPtr(decimal.NewFromFloat(0.1)), MonthlyCost: decimalPtr(decimal.NewFromInt(73))},
+						{Name: "module.db.aws_db_instance.main", HourlyCost: decimalPtr(decimal.NewFromFloat(0.2)), MonthlyCost: decimalPtr(decimal.NewFromInt(146))},
+					},
+					TotalHourlyCost:  decimalPtr(decimal.NewFromFloat(0.3)),
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(219)),
+				},
+				SkippedResources: []SkippedResource{
+					{Name: "aws_appsync_graphql_api.api", ResourceType: "aws_appsync_graphql_api", Reason: SkipReasonUnsupported},
+				},
+			},
+		},
+		Summary: &Summary{
+			SupportedResourceCounts:   &map[string]int{"aws_instance": 1, "aws_db_instance": 1},
+			UnsupportedResourceCounts: &map[string]int{"aws_appsync_graphql_api": 1},
+			TotalSupportedResources:   intPtr(2),
+			TotalUnsupportedResources: intPtr(1),
+			TotalResources:            intPtr(3),
+		},
+	}
+
+	filtered, matched := FilterByPath(out, schema.CompilePathFilters([]string{"module.db.*"}))
+	assert.Equal(t, 1, matched)
+	assert.Equal(t, 1, len(filtered.Resources))
+	assert.Equal(t, "module.db.aws_db_instance.main", filtered.Projects[0].Breakdown.Resources[0].Name)
+	assert.Equal(t, "146", filtered.Projects[0].Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, "146", filtered.TotalMonthlyCost.String())
+	assert.Equal(t, "0.2", filtered.TotalHourlyCost.String())
+	assert.Equal(t, 0, len(filtered.Projects[0].SkippedResources))
+	assert.Equal(t, map[string]int{"aws_db_instance": 1}, *filtered.Summary.SupportedResourceCounts)
+	assert.Equal(t, map[string]int{}, *filtered.Summary.UnsupportedResourceCounts)
+	assert.Equal(t, 1, *filtered.Summary.TotalSupportedResources)
+	assert.Equal(t, 0, *filtered.Summary.TotalUnsupportedResources)
+	assert.Equal(t, 1, *filtered.Summary.TotalResources)
+
+	// The unfiltered output isn't changed
+	assert.Equal(t, 2, len(out.Projects[0].Breakdown.Resources))
+	assert.Equal(t, 1, (*out.Summary.SupportedResourceCounts)["aws_instance"])
+
+	_, matched = FilterByPath(out, schema.CompilePathFilters([]string{"aws_s3_bucket.*"}))
+	assert.Equal(t, 0, matched)
+}
+
+func TestFilterByPathDiff(t *testing.T) {
+	cost := func(i int64) *decimal.Decimal { return decimalPtr(decimal.NewFromInt(i)) }
+
+	out := Root{
+		Projects: []Project{
+			{
+				Path: "infra",
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(73)},
+						{Name: "aws_instance.old", MonthlyCost: cost(73)},
+						{Name: "module.db.aws_db_instance.old", MonthlyCost: cost(146)},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(146)},
+						{Name: "aws_lambda_function.fn"},
+						{Name: "module.db.aws_db_instance.main", MonthlyCost: cost(146)},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(73)},
+						{Name: "aws_instance.old", MonthlyCost: cost(-73)},
+						{Name: "module.db.aws_db_instance.old", MonthlyCost: cost(-146)},
+						{Name: "module.db.aws_db_instance.main", MonthlyCost: cost(146)},
+					},
+				},
+				SkippedResources: []SkippedResource{
+					{Name: "aws_lambda_function.fn", ResourceType: "aws_lambda_function", Reason: SkipReasonUsageMissing},
+					{Name: "aws_appsync_graphql_api.api", ResourceType: "aws_appsync_graphql_api", Reason: SkipReasonUnsupported},
+				},
+			},
+		},
+		Summary: &Summary{
+			SupportedResourceCounts:   &map[string]int{"aws_instance": 1, "aws_lambda_function": 1, "aws_db_instance": 1},
+			UnsupportedResourceCounts: &map[string]int{"aws_appsync_graphql_api": 1},
+			TotalSupportedResources:   intPtr(3),
+			TotalUnsupportedResources: intPtr(1),
+			TotalResources:            intPtr(4),
+		},
+	}
+
+	filtered, matched := FilterByPath(out, schema.CompilePathFilters([]string{"aws_*"}))
+	// aws_instance.web and aws_lambda_function.fn are each only counted once
+	assert.Equal(t, 4, matched)
+	assert.Equal(t, "73", filtered.Projects[0].PastBreakdown.Resources[1].MonthlyCost.String())
+	assert.Equal(t, 2, len(filtered.Projects[0].Diff.Resources))
+	assert.Equal(t, "0", filtered.Projects[0].Diff.TotalMonthlyCost.String())
+	assert.Equal(t, map[string]int{"aws_instance": 1, "aws_lambda_function": 1}, *filtered.Summary.SupportedResourceCounts)
+	assert.Equal(t, map[string]int{"aws_appsync_graphql_api": 1}, *filtered.Summary.UnsupportedResourceCounts)
+	assert.Equal(t, 2, *filtered.Summary.TotalSupportedResources)
+	assert.Equal(t, 1, *filtered.Summary.TotalUnsupportedResources)
+	assert.Equal(t, 3, *filtered.Summary.TotalResources)
+	assert.Equal(t, (*int)(nil), filtered.Summary.TotalNoPriceResources)
+
+	filtered, matched = FilterByPath(out, schema.CompilePathFilters([]string{"module.db.*"}))
+	// The deleted module.db.aws_db_instance.old is only in the past breakdown
+	assert.Equal(t, 2, matched)
+	assert.Equal(t, 1, len(filtered.Projects[0].PastBreakdown.Resources))
+	assert.Equal(t, "0", filtered.Projects[0].Diff.TotalMonthlyCost.String())
+	assert.Equal(t, 1, *filtered.Summary.TotalSupportedResources)
+	assert.Equal(t, 1, *filtered.Summary.TotalResources)
+}
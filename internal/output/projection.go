@@ -0,0 +1,65 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// ProjectionModel is a naive compound growth model applied to the current
+// monthly cost. It is not a forecast of actual usage.
+const ProjectionModel = "compound"
+
+// ProjectionHorizons are the number of months the costs are projected for.
+var ProjectionHorizons = []int{3, 6, 12}
+
+// MinProjectionGrowthRate and MaxProjectionGrowthRate are the range of
+// monthly growth rates that can be used for projections.
+var MinProjectionGrowthRate = decimal.NewFromFloat(-0.5)
+var MaxProjectionGrowthRate = decimal.NewFromInt(1)
+
+type Projection struct {
+	Model             string              `json:"model"`
+	MonthlyGrowthRate decimal.Decimal     `json:"monthlyGrowthRate"`
+	Horizons          []ProjectionHorizon `json:"horizons"`
+}
+
+type ProjectionHorizon struct {
+	Months           int              `json:"months"`
+	FinalMonthlyCost *decimal.Decimal `json:"finalMonthlyCost"`
+	TotalCost        *decimal.Decimal `json:"totalCost"`
+}
+
+// BuildProjection projects the total monthly cost over each of the
+// ProjectionHorizons assuming it grows by growthRate every month. The first
+// month of each horizon is the current monthly cost.
+func BuildProjection(totalMonthlyCost *decimal.Decimal, growthRate decimal.Decimal) *Projection {
+	p := &Projection{
+		Model:             ProjectionModel,
+		MonthlyGrowthRate: growthRate,
+		Horizons:          make([]ProjectionHorizon, 0, len(ProjectionHorizons)),
+	}
+
+	factor := decimal.NewFromInt(1).Add(growthRate)
+
+	for _, months := range ProjectionHorizons {
+		h := ProjectionHorizon{Months: months}
+
+		if totalMonthlyCost != nil {
+			monthlyCost := *totalMonthlyCost
+			total := decimal.Zero
+
+			for i := 0; i < months; i++ {
+				if i > 0 {
+					monthlyCost = monthlyCost.Mul(factor)
+				}
+				total = total.Add(monthlyCost)
+			}
+
+			h.FinalMonthlyCost = decimalPtr(monthlyCost.Round(2))
+			h.TotalCost = decimalPtr(total.Round(2))
+		}
+
+		p.Horizons = append(p.Horizons, h)
+	}
+
+	return p
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// DiffRollup is the total cost change of all the changed resources of a type.
+type DiffRollup struct {
+	ResourceType    string
+	Added           int
+	Removed         int
+	Updated         int
+	PastMonthlyCost *decimal.Decimal
+	MonthlyCost     *decimal.Decimal
+	DiffMonthlyCost *decimal.Decimal
+}
+
+// BuildDiffRollups aggregates the diff of the project by resource type, sorted
+// by resource type. If resourceTypes is not empty only those types are included.
+func BuildDiffRollups(project Project, resourceTypes []string) []DiffRollup {
+	rollups := make(map[string]*DiffRollup)
+
+	if project.Diff == nil {
+		return []DiffRollup{}
+	}
+
+	for _, diffResource := range filterResourcesByType(project.Diff.Resources, resourceTypes) {
+		t := resourceTypeFromName(diffResource.Name)
+
+		rollup, ok := rollups[t]
+		if !ok {
+			rollup = &DiffRollup{ResourceType: t}
+			rollups[t] = rollup
+		}
+
+		var oldResource, newResource *Resource
+		if project.PastBreakdown != nil {
+			oldResource = findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
+		}
+		if project.Breakdown != nil {
+			newResource = findResourceByName(project.Breakdown.Resources, diffResource.Name)
+		}
+
+		if oldResource == nil {
+			rollup.Added++
+		} else if newResource == nil {
+			rollup.Removed++
+		} else {
+			rollup.Updated++
+		}
+
+		if oldResource != nil && oldResource.MonthlyCost != nil {
+			rollup.PastMonthlyCost = addDecimals(rollup.PastMonthlyCost, oldResource.MonthlyCost)
+		}
+		if newResource != nil && newResource.MonthlyCost != nil {
+			rollup.MonthlyCost = addDecimals(rollup.MonthlyCost, newResource.MonthlyCost)
+		}
+		if diffResource.MonthlyCost != nil {
+			rollup.DiffMonthlyCost = addDecimals(rollup.DiffMonthlyCost, diffResource.MonthlyCost)
+		}
+	}
+
+	result := make([]DiffRollup, 0, len(rollups))
+	for _, rollup := range rollups {
+		result = append(result, *rollup)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ResourceType < result[j].ResourceType
+	})
+
+	return result
+}
+
+func rollupToDiff(rollup DiffRollup, nf numberFormat) string {
+	op := UPDATED
+	if rollup.Removed == 0 && rollup.Updated == 0 {
+		op = ADDED
+	} else if rollup.Added == 0 && rollup.Updated == 0 {
+		op = REMOVED
+	}
+
+	s := fmt.Sprintf("%s %s\n", opChar(op), ui.BoldString(rollup.ResourceType))
+
+	if rollup.PastMonthlyCost == nil && rollup.MonthlyCost == nil {
+		s += "  Monthly cost depends on usage\n"
+	} else {
+		pastCost, cost := rollup.PastMonthlyCost, rollup.MonthlyCost
+		if pastCost == nil {
+			pastCost = decimalPtr(decimal.Zero)
+		}
+		if cost == nil {
+			cost = decimalPtr(decimal.Zero)
+		}
+
+		s += fmt.Sprintf("  %s%s\n",
+			formatCostChange(rollup.DiffMonthlyCost, nf),
+			ui.FaintString(formatCostChangeDetails(pastCost, cost, nf)),
+		)
+	}
+
+	counts := make([]string, 0, 3)
+	if rollup.Added > 0 {
+		counts = append(counts, fmt.Sprintf("%d added", rollup.Added))
+	}
+	if rollup.Removed > 0 {
+		counts = append(counts, fmt.Sprintf("%d removed", rollup.Removed))
+	}
+	if rollup.Updated > 0 {
+		counts = append(counts, fmt.Sprintf("%d changed", rollup.Updated))
+	}
+
+	total := rollup.Added + rollup.Removed + rollup.Updated
+	noun := "resources"
+	if total == 1 {
+		noun = "resource"
+	}
+
+	s += fmt.Sprintf("  %s\n", ui.FaintStringf("%d %s: %s", total, noun, strings.Join(counts, ", ")))
+
+	return s
+}
+
+func filterResourcesByType(resources []Resource, resourceTypes []string) []Resource {
+	if len(resourceTypes) == 0 {
+		return resources
+	}
+
+	filtered := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		if contains(resourceTypes, resourceTypeFromName(r.Name)) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+var addressIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// resourceTypeFromName returns the resource type from a resource address, e.g.
+// module.vpc.aws_nat_gateway.main["a"] returns aws_nat_gateway.
+func resourceTypeFromName(name string) string {
+	parts := strings.Split(addressIndexRegex.ReplaceAllString(name, ""), ".")
+	for i := 0; i < len(parts); i++ {
+		if parts[i] == "module" {
+			i++
+			continue
+		}
+
+		if parts[i] == "data" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+
+		return parts[i]
+	}
+
+	return name
+}
@@ -0,0 +1,210 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SARIFRuleExpensiveResource is the rule of the SARIF results for resources
+// with a monthly cost over the resource threshold.
+const SARIFRuleExpensiveResource = "infracost/expensive-resource"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// ToSARIF returns a SARIF log with a result for each resource with a monthly
+// cost over opts.ResourceThreshold, as a warning, or over
+// opts.ResourceErrorThreshold, as an error, which takes precedence if it's the
+// lower threshold. Results point at the resource block if its location is
+// known, so they can be shown inline by code scanning tools.
+func ToSARIF(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	results := make([]sarifResult, 0)
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			if r.MonthlyCost == nil {
+				continue
+			}
+
+			level, threshold := "", opts.ResourceThreshold
+			switch {
+			case opts.ResourceErrorThreshold != nil && r.MonthlyCost.GreaterThan(*opts.ResourceErrorThreshold):
+				level, threshold = "error", opts.ResourceErrorThreshold
+			case opts.ResourceThreshold != nil && r.MonthlyCost.GreaterThan(*opts.ResourceThreshold):
+				level = "warning"
+			default:
+				continue
+			}
+
+			res := sarifResult{
+				RuleID: SARIFRuleExpensiveResource,
+				Level:  level,
+				Message: sarifMessage{
+					Text: fmt.Sprintf("%s in %s has a monthly cost of %s, over the threshold of %s",
+						r.Name, p.Label(), formatCost2DP(r.MonthlyCost, nf), formatCost2DP(threshold, nf)),
+				},
+			}
+
+			if loc := sarifResourceLocation(p, r, opts.SARIFBaseDir); loc != nil {
+				res.Locations = []sarifLocation{*loc}
+			}
+
+			results = append(results, res)
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "Infracost",
+						InformationURI: "https://www.infracost.io",
+						Rules: []sarifRule{
+							{
+								ID:               SARIFRuleExpensiveResource,
+								Name:             "ExpensiveResource",
+								ShortDescription: sarifMessage{Text: "Resource monthly cost is over the threshold"},
+								HelpURI:          "https://www.infracost.io/docs",
+							},
+						},
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// sarifSourceRoot is the uriBaseId of URIs relative to the root of the
+// source, which code scanning tools resolve to the root of the repo.
+const sarifSourceRoot = "%SRCROOT%"
+
+// sarifResourceLocation returns the location of the resource block, or nil if
+// it isn't known. The URI is relative to the base dir if the file is in it,
+// otherwise it's the path of the file.
+func sarifResourceLocation(p Project, r Resource, baseDir string) *sarifLocation {
+	if r.FileName == "" {
+		return nil
+	}
+
+	// The file name is relative to the Terraform dir, which is the dir of the
+	// project path if it's a plan file. Paths that don't exist on this machine
+	// are treated as dirs.
+	dir := p.Path
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	path := r.FileName
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	artifact := sarifArtifactLocation{URI: strings.TrimPrefix(filepath.ToSlash(path), "./")}
+	if rel, ok := relativePath(baseDir, path); ok {
+		artifact = sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: sarifSourceRoot}
+	}
+
+	loc := &sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact},
+	}
+
+	if r.StartLine > 0 {
+		loc.PhysicalLocation.Region = &sarifRegion{StartLine: r.StartLine}
+	}
+
+	return loc
+}
+
+// relativePath returns the path relative to the base dir, or false if there
+// is no base dir or the path isn't in it.
+func relativePath(baseDir string, path string) (string, bool) {
+	if baseDir == "" {
+		return "", false
+	}
+
+	absBase, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", false
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
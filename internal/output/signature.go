@@ -0,0 +1,108 @@
+package output
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// signaturePrefix is the algorithm prefix of the signature field, so other
+// algorithms can be supported later.
+const signaturePrefix = "hmac-sha256:"
+
+// SignJSON returns the Infracost JSON with a signature field that is the
+// HMAC-SHA256 of its canonical JSON using the key. Any existing signature is
+// replaced.
+func SignJSON(b []byte, key string) ([]byte, error) {
+	doc, err := decodeSignedJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	delete(doc, "signature")
+
+	sig, err := jsonSignature(doc, key)
+	if err != nil {
+		return nil, err
+	}
+
+	doc["signature"] = sig
+
+	return canonicalJSON(doc)
+}
+
+// VerifyJSONSignature returns an error if the Infracost JSON isn't signed or
+// its signature doesn't match the signature of its canonical JSON using the
+// key, e.g. because it was modified after it was signed.
+func VerifyJSONSignature(b []byte, key string) error {
+	doc, err := decodeSignedJSON(b)
+	if err != nil {
+		return err
+	}
+
+	sig, ok := doc["signature"].(string)
+	if !ok || sig == "" {
+		return errors.New("Infracost JSON is not signed, generate it with --sign")
+	}
+	delete(doc, "signature")
+
+	if !strings.HasPrefix(sig, signaturePrefix) {
+		return errors.New("Infracost JSON signature algorithm is not supported")
+	}
+
+	expected, err := jsonSignature(doc, key)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return errors.New("Infracost JSON signature does not match, it was modified after it was signed or signed with a different key")
+	}
+
+	return nil
+}
+
+func decodeSignedJSON(b []byte) (map[string]interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	// Keep the numbers as they are so the costs aren't changed by a round trip
+	// through float64
+	d.UseNumber()
+
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, errors.Wrap(err, "Error parsing JSON")
+	}
+
+	return doc, nil
+}
+
+func jsonSignature(doc map[string]interface{}, key string) (string, error) {
+	b, err := canonicalJSON(doc)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(b)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// canonicalJSON marshals the decoded JSON with sorted object keys and no
+// whitespace, so the same document always has the same bytes whatever its
+// original formatting or key order.
+func canonicalJSON(doc map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+
+	if err := e.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
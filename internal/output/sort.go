@@ -0,0 +1,101 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort keys of the table and diff output. They can be prefixed with - to sort
+// in descending order.
+const (
+	SortMonthlyCost = "monthlyCost"
+	SortName        = "name"
+	SortPath        = "path"
+)
+
+// ValidateSortKey returns an error if the key isn't empty or one of the sort
+// keys, optionally prefixed with -.
+func ValidateSortKey(key string) error {
+	switch strings.TrimPrefix(key, "-") {
+	case "", SortMonthlyCost, SortName, SortPath:
+		return nil
+	}
+
+	return fmt.Errorf("Invalid sort key %s, it must be one of: %s, %s, %s, optionally prefixed with - for descending order", key, SortMonthlyCost, SortName, SortPath)
+}
+
+// sortOutput returns a copy of the output with its projects and their
+// resources sorted by the key. The monthlyCost and name keys sort the
+// resources of each project, where the diff is sorted by the cost change and
+// resources without a cost are always last. The path key sorts the projects
+// by their path and keeps their resources in order. An empty key keeps the
+// current order.
+func sortOutput(out Root, key string) Root {
+	if key == "" {
+		return out
+	}
+
+	desc := strings.HasPrefix(key, "-")
+	key = strings.TrimPrefix(key, "-")
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		if key != SortPath {
+			p.PastBreakdown = sortBreakdown(p.PastBreakdown, key, desc)
+			p.Breakdown = sortBreakdown(p.Breakdown, key, desc)
+			p.Diff = sortBreakdown(p.Diff, key, desc)
+		}
+		projects = append(projects, p)
+	}
+
+	if key == SortPath {
+		sort.SliceStable(projects, func(i, j int) bool {
+			if desc {
+				return projects[i].Path > projects[j].Path
+			}
+			return projects[i].Path < projects[j].Path
+		})
+	}
+
+	out.Projects = projects
+
+	return out
+}
+
+func sortBreakdown(b *Breakdown, key string, desc bool) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	sorted := *b
+	sorted.Resources = make([]Resource, len(b.Resources))
+	copy(sorted.Resources, b.Resources)
+
+	sort.SliceStable(sorted.Resources, func(i, j int) bool {
+		ri, rj := sorted.Resources[i], sorted.Resources[j]
+
+		if key == SortMonthlyCost {
+			switch {
+			case ri.MonthlyCost == nil && rj.MonthlyCost == nil:
+				// Fall through to the sort by name
+			case ri.MonthlyCost == nil:
+				return false
+			case rj.MonthlyCost == nil:
+				return true
+			case !ri.MonthlyCost.Equal(*rj.MonthlyCost):
+				if desc {
+					return ri.MonthlyCost.GreaterThan(*rj.MonthlyCost)
+				}
+				return ri.MonthlyCost.LessThan(*rj.MonthlyCost)
+			}
+		}
+
+		if desc && key == SortName {
+			return ri.Name > rj.Name
+		}
+		return ri.Name < rj.Name
+	})
+
+	return &sorted
+}
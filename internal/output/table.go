@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -9,15 +10,29 @@ import (
 )
 
 func ToTable(out Root, opts Options) ([]byte, error) {
+	nf := newNumberFormat(opts)
+
+	out = sortOutput(out, opts.SortKey)
+
 	s := ""
 
 	hasNilCosts := false
+	tableWidth := 0
 
 	for i, project := range out.Projects {
 		if project.Breakdown == nil {
 			continue
 		}
 
+		if breakdownHasNilCosts(*project.Breakdown) {
+			hasNilCosts = true
+		}
+
+		// The summary only has the overall totals and resource counts
+		if opts.SummaryOnly {
+			continue
+		}
+
 		if i != 0 {
 			s += "----------------------------------\n"
 		}
@@ -27,24 +42,60 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 			project.Label(),
 		)
 
-		if breakdownHasNilCosts(*project.Breakdown) {
-			hasNilCosts = true
+		breakdown := *project.Breakdown
+
+		displayed := breakdown
+		displayed.Resources = limitResourceDepth(breakdown.Resources, opts.MaxResourceDepth)
+
+		t := tableForBreakdown(displayed, opts.Fields, opts.WrapCells, opts.NoSummary, nf)
+		if w := maxLineWidth(t); w > tableWidth {
+			tableWidth = w
 		}
 
-		s += tableForBreakdown(*project.Breakdown, opts.Fields)
+		s += t
 		s += "\n"
 
+		if opts.Explain {
+			s += "\n"
+			s += explanationsForBreakdown(breakdown, nf)
+		}
+
+		if alternatives := alternativesForBreakdown(breakdown, nf); alternatives != "" {
+			s += "\n"
+			s += alternatives
+		}
+
 		if i != len(out.Projects)-1 {
 			s += "\n"
 		}
 	}
 
+	if opts.NoSummary {
+		return []byte(s), nil
+	}
+
+	// The project total is the overall total if there's only one project
+	if opts.SummaryOnly {
+		s += overallTotals(out, tableWidth, opts.CostPeriod, nf)
+	} else if len(out.Projects) > 1 {
+		s += "\n" + overallTotals(out, tableWidth, opts.CostPeriod, nf)
+	}
+
 	unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped)
 
-	if hasNilCosts || unsupportedMsg != "" {
+	countsMsg := ""
+	if opts.SummaryOnly {
+		countsMsg = out.resourceCountsMessage()
+	}
+
+	if hasNilCosts || unsupportedMsg != "" || countsMsg != "" {
 		s += "\n----------------------------------"
 	}
 
+	if countsMsg != "" {
+		s += "\n" + countsMsg
+	}
+
 	if hasNilCosts {
 		s += fmt.Sprintf("\nTo estimate usage-based resources use --usage-file, see %s",
 			ui.LinkString("https://infracost.io/usage-file"),
@@ -62,7 +113,52 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
-func tableForBreakdown(breakdown Breakdown, fields []string) string {
+// overallTotals returns the totals of all the projects for the cost period,
+// see periodTotals. The costs are right aligned to the width, which should be
+// the width of the widest table above, so they line up with its last cost
+// column.
+func overallTotals(out Root, width int, period string, nf numberFormat) string {
+	s := ""
+	for _, t := range periodTotals(period, out.TotalHourlyCost, out.TotalMonthlyCost) {
+		label := fmt.Sprintf("OVERALL TOTAL (%s)", t.Period)
+		cost := formatCost2DP(t.Cost, nf)
+
+		// Each table line has a leading and trailing space
+		padding := width - text.RuneCount(label) - text.RuneCount(cost) - 2
+		if padding < 2 {
+			padding = 2
+		}
+
+		if t.Prominent {
+			label = ui.BoldString(label)
+		}
+
+		s += fmt.Sprintf(" %s%s%s \n", label, strings.Repeat(" ", padding), cost)
+	}
+
+	return s
+}
+
+func maxLineWidth(s string) int {
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		if w := text.RuneCount(line); w > width {
+			width = w
+		}
+	}
+
+	return width
+}
+
+// Max widths of the name and unit cells when wrapping cells
+const (
+	wrapNameWidth = 60
+	wrapUnitWidth = 16
+)
+
+// tableForBreakdown renders the resources of the breakdown and a project
+// total row, unless noSummary is set.
+func tableForBreakdown(breakdown Breakdown, fields []string, wrapCells bool, noSummary bool, nf numberFormat) string {
 	t := table.NewWriter()
 	t.Style().Options.DrawBorder = false
 	t.Style().Options.SeparateColumns = false
@@ -139,25 +235,29 @@ func tableForBreakdown(breakdown Breakdown, fields []string) string {
 	for _, r := range breakdown.Resources {
 		t.AppendRow(table.Row{ui.BoldString(r.Name)})
 
-		buildCostComponentRows(t, r.CostComponents, "", len(r.SubResources) > 0, fields)
-		buildSubResourceRows(t, r.SubResources, "", fields)
+		buildCostComponentRows(t, r.CostComponents, "", len(r.SubResources) > 0, fields, wrapCells, nf)
+		buildSubResourceRows(t, r.SubResources, "", fields, wrapCells, nf)
 
 		t.AppendRow(table.Row{""})
 	}
 
+	if noSummary {
+		return t.Render()
+	}
+
 	var totalCostRow table.Row
 	totalCostRow = append(totalCostRow, ui.BoldString("PROJECT TOTAL"))
 	numOfFields := i - 3
 	for q := 0; q < numOfFields; q++ {
 		totalCostRow = append(totalCostRow, "")
 	}
-	totalCostRow = append(totalCostRow, formatCost2DP(breakdown.TotalMonthlyCost))
+	totalCostRow = append(totalCostRow, formatCost2DP(breakdown.TotalMonthlyCost, nf))
 	t.AppendRow(totalCostRow)
 
 	return t.Render()
 }
 
-func buildSubResourceRows(t table.Writer, subresources []Resource, prefix string, fields []string) {
+func buildSubResourceRows(t table.Writer, subresources []Resource, prefix string, fields []string, wrapCells bool, nf numberFormat) {
 	for i, r := range subresources {
 		labelPrefix := prefix + "├─"
 		nextPrefix := prefix + "│  "
@@ -166,25 +266,39 @@ func buildSubResourceRows(t table.Writer, subresources []Resource, prefix string
 			nextPrefix = prefix + "   "
 		}
 
-		t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), r.Name)})
+		name := r.Name
+		if wrapCells {
+			name = wrapCell(name, wrapNameWidth-text.RuneCount(nextPrefix), ui.FaintString(nextPrefix))
+		}
+
+		t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), name)})
 
-		buildCostComponentRows(t, r.CostComponents, nextPrefix, len(r.SubResources) > 0, fields)
-		buildSubResourceRows(t, r.SubResources, nextPrefix, fields)
+		buildCostComponentRows(t, r.CostComponents, nextPrefix, len(r.SubResources) > 0, fields, wrapCells, nf)
+		buildSubResourceRows(t, r.SubResources, nextPrefix, fields, wrapCells, nf)
 	}
 }
 
-func buildCostComponentRows(t table.Writer, costComponents []CostComponent, prefix string, hasSubResources bool, fields []string) {
+func buildCostComponentRows(t table.Writer, costComponents []CostComponent, prefix string, hasSubResources bool, fields []string, wrapCells bool, nf numberFormat) {
 	for i, c := range costComponents {
 		labelPrefix := prefix + "├─"
+		nextPrefix := prefix + "│  "
 		if !hasSubResources && i == len(costComponents)-1 {
 			labelPrefix = prefix + "└─"
+			nextPrefix = prefix + "   "
 		}
 
-		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), c.Name)
+		name := c.Name
+		unit := c.Unit
+		if wrapCells {
+			name = wrapCell(name, wrapNameWidth-text.RuneCount(nextPrefix), ui.FaintString(nextPrefix))
+			unit = wrapCell(unit, wrapUnitWidth, "")
+		}
+
+		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), name)
 
-		if c.MonthlyCost == nil {
+		if c.MonthlyCost == nil && !c.nested {
 			price := fmt.Sprintf("Monthly cost depends on usage: %s per %s",
-				formatPrice(c.Price),
+				formatPrice(c.Price, nf),
 				c.Unit,
 			)
 
@@ -195,26 +309,50 @@ func buildCostComponentRows(t table.Writer, costComponents []CostComponent, pref
 				ui.FaintString(price),
 			}, table.RowConfig{AutoMerge: true, AlignAutoMerge: text.AlignLeft})
 		} else {
+			price := formatPrice(c.Price, nf)
+			quantity := formatQuantity(c.MonthlyQuantity, nf)
+
+			// Collapsed nested components only have a cost
+			if c.nested {
+				price, quantity = "", ""
+			}
+
 			var tableRow table.Row
 			tableRow = append(tableRow, label)
 
 			if contains(fields, "price") {
-				tableRow = append(tableRow, formatPrice(c.Price))
+				tableRow = append(tableRow, price)
 			}
 			if contains(fields, "monthlyQuantity") {
-				tableRow = append(tableRow, formatQuantity(c.MonthlyQuantity))
+				tableRow = append(tableRow, quantity)
 			}
 			if contains(fields, "unit") {
-				tableRow = append(tableRow, c.Unit)
+				tableRow = append(tableRow, unit)
 			}
 			if contains(fields, "hourlyCost") {
-				tableRow = append(tableRow, formatCost2DP(c.HourlyCost))
+				tableRow = append(tableRow, formatCost2DP(c.HourlyCost, nf))
 			}
 			if contains(fields, "monthlyCost") {
-				tableRow = append(tableRow, formatCost2DP(c.MonthlyCost))
+				tableRow = append(tableRow, formatCost2DP(c.MonthlyCost, nf))
 			}
 
 			t.AppendRow(tableRow)
 		}
 	}
 }
+
+// wrapCell wraps the text on word boundaries so each line is at most width
+// characters. The lines after the first are prefixed with the continuation,
+// e.g. to keep the tree lines of the name column.
+func wrapCell(s string, width int, continuation string) string {
+	if width <= 0 || text.RuneCount(s) <= width {
+		return s
+	}
+
+	lines := strings.Split(text.WrapSoft(s, width), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	return strings.Join(lines, "\n"+continuation)
+}
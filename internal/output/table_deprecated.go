@@ -117,7 +117,7 @@ func buildCostComponentRowsDeprecated(t *tablewriter.Table, costComponents []Cos
 
 		t.Rich([]string{
 			fmt.Sprintf("%s %s", labelPrefix, c.Name),
-			formatQuantity(c.MonthlyQuantity),
+			formatQuantity(c.MonthlyQuantity, newNumberFormat(Options{})),
 			c.Unit,
 			formatCostDeprecated(&c.Price),
 			formatCostDeprecated(c.HourlyCost),
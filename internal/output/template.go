@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/shopspring/decimal"
+)
+
+// TemplateData is the data that --format template templates are executed
+// against, e.g. {{ range .Root.Projects }}{{ .Path }}{{ end }}.
+type TemplateData struct {
+	// Root has the same fields as the Infracost JSON, using the Go field names,
+	// e.g. .Root.TotalMonthlyCost and .Root.Projects
+	Root Root
+	// HasDiff is true if any of the projects have a diff
+	HasDiff bool
+	// UnsupportedResourcesMessage is the message about skipped resources shown
+	// by the other formats, or empty if there aren't any
+	UnsupportedResourcesMessage string
+}
+
+// templateFuncMap returns the sprig text functions along with the helpers
+// used to format costs in the currency and locale the same way as the other output
+// formats.
+func templateFuncMap(nf numberFormat) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+
+	// formatCost rounds costs above 100 to whole numbers like the table output
+	funcs["formatCost"] = func(d *decimal.Decimal) string {
+		return formatCost(d, nf)
+	}
+	// formatCurrency always shows two decimal places
+	funcs["formatCurrency"] = func(d *decimal.Decimal) string {
+		return formatCost2DP(d, nf)
+	}
+	funcs["formatCostChange"] = func(d *decimal.Decimal) string {
+		return formatCostChange(d, nf)
+	}
+	funcs["formatPrice"] = func(d decimal.Decimal) string {
+		return formatPrice(d, nf)
+	}
+	funcs["formatQuantity"] = func(q *decimal.Decimal) string {
+		return formatQuantity(q, nf)
+	}
+
+	return funcs
+}
+
+// ToTemplate executes opts.Template as a text/template against TemplateData.
+// The template is named opts.TemplateName, so parse and execution errors name
+// the template file and the failing action.
+func ToTemplate(out Root, opts Options) ([]byte, error) {
+	tmpl, err := template.New(opts.TemplateName).Funcs(templateFuncMap(newNumberFormat(opts))).Parse(opts.Template)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, TemplateData{
+		Root:                        out,
+		HasDiff:                     hasDiff(out),
+		UnsupportedResourcesMessage: out.unsupportedResourcesMessage(opts.ShowSkipped),
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -157,14 +157,196 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       {{if gt .Indent 0}}<span class="arrow">&#8627;</span>{{end}}
       {{.CostComponent.Name}}
     </td>
-    <td class="monthly-quantity">{{.CostComponent.MonthlyQuantity | formatQuantity }}</td>
-    <td class="unit">{{.CostComponent.Unit}}</td>
-    <td class="price">{{.CostComponent.Price | formatPrice }}</td>
+    {{if isNested .CostComponent}}
+      <td class="monthly-quantity"></td>
+      <td class="unit"></td>
+      <td class="price"></td>
+    {{else}}
+      <td class="monthly-quantity">{{.CostComponent.MonthlyQuantity | formatQuantity }}</td>
+      <td class="unit">{{.CostComponent.Unit}}</td>
+      <td class="price">{{.CostComponent.Price | formatPrice }}</td>
+    {{end}}
     <td class="hourly-cost">{{.CostComponent.HourlyCost | formatCost2DP}}</td>
     <td class="monthly-cost">{{.CostComponent.MonthlyCost | formatCost2DP}}</td>
   </tr>
 {{end}}
 
+{{define "metadata"}}
+<div class="metadata">
+  <ul>
+    <li>
+      <span class="label">Generated by:</span>
+      <span class="value"><a href="https://infracost.io" target="_blank">Infracost</a></span>
+    </li>
+    <li>
+      <span class="label">Time generated:</span>
+      <span class="value">{{.Root.TimeGenerated | date "2006-01-02 15:04:05 MST"}}</span>
+    </li>
+  </ul>
+</div>
+{{end}}
+
+{{define "breakdownTable"}}
+<table>
+  <thead>
+    <th class="name">Name</th>
+    <th class="monthly-quantity">Monthly quantity</th>
+    <th class="unit">Unit</th>
+    <th class="price">Price</th>
+    <th class="hourly-cost">Hourly cost</th>
+    <th class="monthly-cost">Monthly cost</th>
+  </thead>
+  <tbody>
+    {{$groupLabel := .Options.GroupLabel}}
+    {{$groupKey := .Options.GroupKey}}
+    {{$prevGroup := ""}}
+    {{if not .Options.SummaryOnly}}
+      {{range .Root.Resources}}
+        {{$group := index .Metadata $groupKey}}
+        {{if ne $group $prevGroup}}
+          {{template "groupRow" dict "GroupLabel" $groupLabel "Group" $group}}
+        {{end}}
+        {{template "resourceRows" dict "Resource" . "Indent" 0}}
+        {{$prevGroup = $group}}
+      {{end}}
+    {{end}}
+    <tr class="spacer"><td colspan="6"></td></tr>
+    <tr class="total">
+      <td class="name">Overall total</td>
+      <td class="monthly-quantity"></td>
+      <td class="unit"></td>
+      <td class="price"></td>
+      <td class="hourly-cost">{{.Root.TotalHourlyCost | formatCost2DP}}</td>
+      <td class="monthly-cost">{{.Root.TotalMonthlyCost | formatCost2DP}}</td>
+    </tr>
+  </tbody>
+</table>
+{{end}}
+
+{{define "diffTable"}}
+<table>
+  <thead>
+    <th class="name">Name</th>
+    <th class="monthly-cost">Previous monthly cost</th>
+    <th class="monthly-cost">New monthly cost</th>
+    <th class="monthly-cost">Monthly cost change</th>
+  </thead>
+  <tbody>
+    {{$prevProject := ""}}
+    {{range .DiffRows}}
+      {{if ne .Project $prevProject}}
+        <tr class="group">
+          <td class="name" colspan="4">Project: {{.Project}}</td>
+        </tr>
+      {{end}}
+      {{if not $.Options.SummaryOnly}}
+        <tr class="resource top-level">
+          <td class="name"><span class="op {{.Op}}">{{.OpChar}}</span> {{.Name}}</td>
+          <td class="monthly-cost">{{.OldCost | formatCost2DP}}</td>
+          <td class="monthly-cost">{{.NewCost | formatCost2DP}}</td>
+          <td class="monthly-cost">{{.Change | formatCost2DP}}</td>
+        </tr>
+      {{end}}
+      {{$prevProject = .Project}}
+    {{end}}
+    <tr class="spacer"><td colspan="4"></td></tr>
+    <tr class="total">
+      <td class="name">Overall total</td>
+      <td class="monthly-cost">{{.PastTotalMonthlyCost | formatCost2DP}}</td>
+      <td class="monthly-cost">{{.NewTotalMonthlyCost | formatCost2DP}}</td>
+      <td class="monthly-cost">{{.DiffTotalMonthlyCost | formatCost2DP}}</td>
+    </tr>
+  </tbody>
+</table>
+{{end}}
+
+{{define "reportMetadata"}}
+<div class="metadata">
+  <h3>Run info</h3>
+  <ul>
+    <li>
+      <span class="label">Generated by:</span>
+      <span class="value"><a href="https://infracost.io" target="_blank">Infracost</a></span>
+    </li>
+    <li>
+      <span class="label">Time generated:</span>
+      <span class="value">{{.Root.TimeGenerated | date "2006-01-02 15:04:05 MST"}}</span>
+    </li>
+    <li>
+      <span class="label">Output version:</span>
+      <span class="value">{{.Root.Version}}</span>
+    </li>
+    {{range .Root.Projects}}
+      <li>
+        <span class="label">Project:</span>
+        <span class="value">{{.Label}}</span>
+      </li>
+    {{end}}
+  </ul>
+
+  <h3>Assumptions</h3>
+  <ul>
+    {{range .Assumptions}}
+      <li>{{.}}</li>
+    {{end}}
+  </ul>
+
+  {{if .Warnings}}
+    <h3>Warnings</h3>
+    <div class="warnings">
+      {{range .Warnings}}
+        <p>{{. | replaceNewLines}}</p>
+      {{end}}
+    </div>
+  {{end}}
+</div>
+{{end}}
+
+{{define "reportStyle"}}
+.tabs > input {
+  display: none;
+}
+
+.tabs > label {
+  display: inline-block;
+  padding: 0.5rem 1rem;
+  margin-right: 0.25rem;
+  border: 1px solid #6b7280;
+  border-bottom: none;
+  cursor: pointer;
+  background-color: #e5e7eb;
+}
+
+.tabs > input:checked + label {
+  background-color: #6b7280;
+  color: #ffffff;
+}
+
+.tabs > .tab {
+  display: none;
+  padding-top: 1rem;
+  border-top: 1px solid #6b7280;
+}
+
+#tab-breakdown:checked ~ .tab.breakdown,
+#tab-diff:checked ~ .tab.diff,
+#tab-metadata:checked ~ .tab.metadata-tab {
+  display: block;
+}
+
+.op.added {
+  color: #16a34a;
+}
+
+.op.removed {
+  color: #dc2626;
+}
+
+.op.updated {
+  color: #ca8a04;
+}
+{{end}}
+
 <!doctype html>
 <html>
   <head>
@@ -176,54 +358,93 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
   </head>
 
   <body>
-    <div class="metadata">
-      <ul>
-        <li>
-          <span class="label">Generated by:</span>
-          <span class="value"><a href="https://infracost.io" target="_blank">Infracost</a></span>
-        </li>
-        <li>
-          <span class="label">Time generated:</span>
-          <span class="value">{{.Root.TimeGenerated | date "2006-01-02 15:04:05 MST"}}</span>
-        </li>
-      </ul>
+    {{template "metadata" .}}
+
+    {{template "breakdownTable" .}}
+
+    <div class="warnings">
+      <p>{{.UnsupportedResourcesMessage | replaceNewLines}}</p>
+    </div>
+  </body>
+</html>`
+
+// ReportHTMLTemplate is the layout used by the report format. It shows the
+// breakdown, diff and metadata in tabs using the templates defined in HTMLTemplate.
+const ReportHTMLTemplate = `<!doctype html>
+<html>
+  <head>
+    <title>Infracost cost report</title>
+    <style>
+      {{template "style"}}
+      {{template "reportStyle"}}
+    </style>
+    <link id="favicon" rel="shortcut icon" type="image/png" href="data:image/png;base64,{{template "faviconBase64"}}">
+  </head>
+
+  <body>
+    <div class="tabs">
+      <input type="radio" name="tabs" id="tab-breakdown" checked>
+      <label for="tab-breakdown">Breakdown</label>
+      {{if .HasDiff}}
+        <input type="radio" name="tabs" id="tab-diff">
+        <label for="tab-diff">Diff</label>
+      {{end}}
+      <input type="radio" name="tabs" id="tab-metadata">
+      <label for="tab-metadata">Metadata</label>
+
+      <div class="tab breakdown">
+        {{template "breakdownTable" .}}
+      </div>
+      {{if .HasDiff}}
+        <div class="tab diff">
+          {{template "diffTable" .}}
+        </div>
+      {{end}}
+      <div class="tab metadata-tab">
+        {{template "reportMetadata" .}}
+      </div>
     </div>
+  </body>
+</html>`
 
+// CompareHTMLTemplate is the layout used by the compare command. It shows the
+// monthly costs of each environment in a column using the styles defined in
+// HTMLTemplate.
+const CompareHTMLTemplate = `<!doctype html>
+<html>
+  <head>
+    <title>Infracost cost comparison</title>
+    <style>
+      {{template "style"}}
+    </style>
+    <link id="favicon" rel="shortcut icon" type="image/png" href="data:image/png;base64,{{template "faviconBase64"}}">
+  </head>
+
+  <body>
     <table>
       <thead>
-        <th class="name">Name</th>
-        <th class="monthly-quantity">Monthly quantity</th>
-        <th class="unit">Unit</th>
-        <th class="price">Price</th>
-        <th class="hourly-cost">Hourly cost</th>
-        <th class="monthly-cost">Monthly cost</th>
+        <th class="name">{{.RowLabel}}</th>
+        {{range .Environments}}
+          <th class="monthly-cost">{{.}}</th>
+        {{end}}
       </thead>
       <tbody>
-        {{$groupLabel := .Options.GroupLabel}}
-        {{$groupKey := .Options.GroupKey}}
-        {{$prevGroup := ""}}
-        {{range .Root.Resources}}
-          {{$group := index .Metadata $groupKey}}
-          {{if ne $group $prevGroup}}
-            {{template "groupRow" dict "GroupLabel" $groupLabel "Group" $group}}
-          {{end}}
-          {{template "resourceRows" dict "Resource" . "Indent" 0}}
-          {{$prevGroup = $group}}
+        {{range .Rows}}
+          <tr class="resource top-level">
+            <td class="name">{{.Name}}</td>
+            {{range .Cells}}
+              <td class="monthly-cost">{{cellCost .}}</td>
+            {{end}}
+          </tr>
         {{end}}
-        <tr class="spacer"><td colspan="6"></td></tr>
+        <tr class="spacer"><td colspan="{{len .Environments | add1}}"></td></tr>
         <tr class="total">
-          <td class="name">Overall total</td>
-          <td class="monthly-quantity"></td>
-          <td class="unit"></td>
-          <td class="price"></td>
-          <td class="hourly-cost">{{.Root.TotalHourlyCost | formatCost2DP}}</td>
-          <td class="monthly-cost">{{.Root.TotalMonthlyCost | formatCost2DP}}</td>
+          <td class="name">Total</td>
+          {{range .Totals}}
+            <td class="monthly-cost">{{. | formatCost2DP}}</td>
+          {{end}}
         </tr>
       </tbody>
     </table>
-
-    <div class="warnings">
-      <p>{{.UnsupportedResourcesMessage | replaceNewLines}}</p>
-    </div>
   </body>
 </html>`
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ThresholdBreach is a cost increase threshold that the diff exceeds.
+type ThresholdBreach struct {
+	Threshold string
+	Message   string
+}
+
+// PastTotalMonthlyCost is the total monthly cost of the past breakdowns of the
+// projects that have a diff.
+func (r *Root) PastTotalMonthlyCost() decimal.Decimal {
+	total := decimal.Zero
+
+	for _, p := range r.Projects {
+		if p.Diff != nil && p.PastBreakdown != nil && p.PastBreakdown.TotalMonthlyCost != nil {
+			total = total.Add(*p.PastBreakdown.TotalMonthlyCost)
+		}
+	}
+
+	return total
+}
+
+// CheckCostThresholds returns the thresholds that the total monthly cost
+// increase of the diff exceeds. The percent threshold is relative to the past
+// total monthly cost, so any increase from a past cost of zero exceeds it.
+// Nil thresholds aren't checked and decreases never exceed a threshold. The
+// absolute threshold is in the currency of the output, i.e. its
+// TargetCurrency if it has been converted.
+func CheckCostThresholds(r Root, percent *decimal.Decimal, absolute *decimal.Decimal) []ThresholdBreach {
+	breaches := make([]ThresholdBreach, 0)
+
+	nf := newNumberFormat(Options{Currency: r.TargetCurrency})
+
+	change := r.DiffTotalMonthlyCost()
+	if !change.IsPositive() {
+		return breaches
+	}
+
+	if absolute != nil && change.GreaterThan(*absolute) {
+		over := change.Sub(*absolute)
+		breaches = append(breaches, ThresholdBreach{
+			Threshold: "absolute",
+			Message: fmt.Sprintf("Monthly cost increase of %s is %s over the absolute threshold of %s",
+				formatCost2DP(&change, nf), formatCost2DP(&over, nf), formatCost2DP(absolute, nf)),
+		})
+	}
+
+	if percent != nil {
+		past := r.PastTotalMonthlyCost()
+
+		if past.IsZero() {
+			breaches = append(breaches, ThresholdBreach{
+				Threshold: "percent",
+				Message: fmt.Sprintf("Monthly cost increase of %s from %s exceeds the percent threshold of %s%%",
+					formatCost2DP(&change, nf), formatCost2DP(&past, nf), percent.String()),
+			})
+		} else if p := change.Div(past).Mul(decimal.NewFromInt(100)); p.GreaterThan(*percent) {
+			breaches = append(breaches, ThresholdBreach{
+				Threshold: "percent",
+				Message: fmt.Sprintf("Monthly cost increase of %s%% is %s%% over the percent threshold of %s%%",
+					p.Round(1).String(), p.Sub(*percent).Round(1).String(), percent.String()),
+			})
+		}
+	}
+
+	return breaches
+}
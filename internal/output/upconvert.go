@@ -0,0 +1,119 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Upconvert returns Infracost JSON of an older supported version in the
+// current schema, so it can be used the same way as the current output, e.g.
+// as the baseline of a diff. Fields the older version doesn't have are left
+// empty, and the breakdowns and totals it doesn't have are calculated from the
+// resources. The version is expected to have been checked by the caller.
+func Upconvert(out Root) Root {
+	if out.Version == outputVersion {
+		return out
+	}
+
+	out.Version = outputVersion
+
+	if out.BaseCurrency == "" {
+		out.BaseCurrency = BaseCurrency
+	}
+
+	// The oldest versions only had the resources at the top level
+	if len(out.Projects) == 0 && len(out.Resources) > 0 {
+		out.Projects = []Project{{
+			Breakdown: &Breakdown{Resources: out.Resources},
+		}}
+	}
+
+	projects := make([]Project, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		if p.Metadata == nil {
+			p.Metadata = make(map[string]string)
+		}
+		if p.Breakdown == nil {
+			p.Breakdown = &Breakdown{}
+		}
+		p.Breakdown = upconvertBreakdown(p.Breakdown)
+		p.PastBreakdown = upconvertBreakdown(p.PastBreakdown)
+		p.Diff = upconvertBreakdown(p.Diff)
+		if p.SkippedResources == nil {
+			p.SkippedResources = []SkippedResource{}
+		}
+		projects = append(projects, p)
+	}
+	out.Projects = projects
+
+	out.Resources = upconvertResources(out.Resources)
+
+	if out.TotalHourlyCost == nil || out.TotalMonthlyCost == nil {
+		totalHourlyCost := decimalPtr(decimal.Zero)
+		totalMonthlyCost := decimalPtr(decimal.Zero)
+		for _, p := range out.Projects {
+			totalHourlyCost = decimalPtr(totalHourlyCost.Add(*p.Breakdown.TotalHourlyCost))
+			totalMonthlyCost = decimalPtr(totalMonthlyCost.Add(*p.Breakdown.TotalMonthlyCost))
+		}
+
+		if out.TotalHourlyCost == nil {
+			out.TotalHourlyCost = totalHourlyCost
+		}
+		if out.TotalMonthlyCost == nil {
+			out.TotalMonthlyCost = totalMonthlyCost
+		}
+	}
+
+	if out.Summary == nil {
+		out.Summary = &Summary{}
+	}
+
+	return out
+}
+
+// upconvertBreakdown fills in the resources and totals of the breakdown if
+// they're missing. Nil breakdowns are kept so a missing past breakdown or diff
+// still means there isn't one.
+func upconvertBreakdown(b *Breakdown) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	resources := upconvertResources(b.Resources)
+	if resources == nil {
+		resources = []Resource{}
+	}
+
+	totalHourlyCost, totalMonthlyCost := b.TotalHourlyCost, b.TotalMonthlyCost
+	if totalHourlyCost == nil || totalMonthlyCost == nil {
+		hourly, monthly := calculateTotalCosts(resources)
+		if totalHourlyCost == nil {
+			totalHourlyCost = hourly
+		}
+		if totalMonthlyCost == nil {
+			totalMonthlyCost = monthly
+		}
+	}
+
+	return &Breakdown{
+		Resources:        resources,
+		TotalHourlyCost:  totalHourlyCost,
+		TotalMonthlyCost: totalMonthlyCost,
+	}
+}
+
+func upconvertResources(resources []Resource) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	upconverted := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		if r.Metadata == nil {
+			r.Metadata = make(map[string]string)
+		}
+		r.SubResources = upconvertResources(r.SubResources)
+		upconverted = append(upconverted, r)
+	}
+
+	return upconverted
+}
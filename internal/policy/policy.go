@@ -0,0 +1,99 @@
+// Package policy evaluates Rego cost policies against the Infracost JSON output
+// using the OPA Go library.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Query is the Rego rule evaluated by Evaluate. Policies add violations to the
+// deny set as a message string or as an object with resource and msg keys, e.g.
+//
+//	package infracost
+//
+//	deny[{"resource": r.name, "msg": msg}] {
+//	  r := input.projects[_].breakdown.resources[_]
+//	  to_number(r.monthlyCost) > 1000
+//	  msg := sprintf("monthly cost %s is more than $1000", [r.monthlyCost])
+//	}
+const Query = "data.infracost.deny"
+
+// Violation is a deny rule that matched.
+type Violation struct {
+	Resource string
+	Message  string
+}
+
+// Evaluate evaluates the Rego policies at policyPath, which can be a file or a
+// directory, with the given Infracost JSON as the input document.
+func Evaluate(policyPath string, input []byte) ([]Violation, error) {
+	var doc interface{}
+	if err := util.UnmarshalJSON(input, &doc); err != nil {
+		return nil, errors.Wrap(err, "Error parsing policy input")
+	}
+
+	log.Debugf("Evaluating %s with policies from %s", Query, policyPath)
+
+	rs, err := rego.New(
+		rego.Query(Query),
+		rego.Load([]string{policyPath}, nil),
+		rego.Input(doc),
+	).Eval(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error evaluating policies")
+	}
+
+	return violationsFromResults(rs), nil
+}
+
+func violationsFromResults(rs rego.ResultSet) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, result := range rs {
+		for _, expr := range result.Expressions {
+			values, ok := expr.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, v := range values {
+				violations = append(violations, toViolation(v))
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Resource != violations[j].Resource {
+			return violations[i].Resource < violations[j].Resource
+		}
+		return violations[i].Message < violations[j].Message
+	})
+
+	return violations
+}
+
+func toViolation(v interface{}) Violation {
+	switch t := v.(type) {
+	case string:
+		return Violation{Message: t}
+	case map[string]interface{}:
+		violation := Violation{}
+		if r, ok := t["resource"].(string); ok {
+			violation.Resource = r
+		}
+		if m, ok := t["msg"].(string); ok {
+			violation.Message = m
+		} else if m, ok := t["message"].(string); ok {
+			violation.Message = m
+		}
+		return violation
+	}
+
+	return Violation{Message: fmt.Sprintf("%v", v)}
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInput = `{
+  "projects": [
+    {
+      "breakdown": {
+        "resources": [
+          {"name": "aws_instance.web_app", "monthlyCost": "1500", "metadata": {"instanceType": "m5.4xlarge"}},
+          {"name": "aws_db_instance.db", "monthlyCost": "1200"},
+          {"name": "aws_s3_bucket.logs", "monthlyCost": "10"}
+        ]
+      }
+    }
+  ]
+}`
+
+func writePolicy(t *testing.T, policy string) string {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.rego")
+	require.NoError(t, ioutil.WriteFile(path, []byte(policy), 0600))
+	return path
+}
+
+func TestEvaluate(t *testing.T) {
+	path := writePolicy(t, `package infracost
+
+deny[{"resource": r.name, "msg": msg}] {
+  r := input.projects[_].breakdown.resources[_]
+  to_number(r.monthlyCost) > 1000
+  msg := sprintf("monthly cost %s is more than $1000", [r.monthlyCost])
+}
+
+deny[{"resource": r.name, "message": "instance type m5.4xlarge is not allowed"}] {
+  r := input.projects[_].breakdown.resources[_]
+  r.metadata.instanceType == "m5.4xlarge"
+}
+
+deny["tags are required"] {
+  true
+}
+`)
+
+	violations, err := Evaluate(path, []byte(testInput))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Violation{
+		{Message: "tags are required"},
+		{Resource: "aws_db_instance.db", Message: "monthly cost 1200 is more than $1000"},
+		{Resource: "aws_instance.web_app", Message: "instance type m5.4xlarge is not allowed"},
+		{Resource: "aws_instance.web_app", Message: "monthly cost 1500 is more than $1000"},
+	}, violations)
+}
+
+func TestEvaluateNoDenyRule(t *testing.T) {
+	path := writePolicy(t, `package other
+
+allow { true }
+`)
+
+	violations, err := Evaluate(path, []byte(testInput))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestEvaluateInvalidPolicy(t *testing.T) {
+	path := writePolicy(t, `package infracost
+
+deny[msg] {
+`)
+
+	_, err := Evaluate(path, []byte(testInput))
+	assert.Error(t, err)
+}
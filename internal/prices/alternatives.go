@@ -0,0 +1,236 @@
+package prices
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// minAlternativeSavingPercent is the smallest saving that is worth suggesting.
+var minAlternativeSavingPercent = decimal.NewFromInt(5)
+
+// instanceFamilyAlternatives are the newer or AMD-based families that have
+// the same vCPUs and memory as the family for each size. They're heuristic,
+// e.g. the network performance and supported AMIs can differ.
+var instanceFamilyAlternatives = map[string][]string{
+	"t2":  {"t3", "t3a"},
+	"t3":  {"t3a"},
+	"m4":  {"m5", "m6i"},
+	"m5":  {"m6i", "m5a"},
+	"m5a": {"m6a"},
+	"c4":  {"c5", "c6i"},
+	"c5":  {"c6i", "c5a"},
+	"r4":  {"r5", "r6i"},
+	"r5":  {"r6i", "r5a"},
+}
+
+// volumeTypeAlternatives are the EBS volume types with the same or better
+// performance as the volume type.
+var volumeTypeAlternatives = map[string][]string{
+	"gp2": {"gp3"},
+}
+
+// SuggestAlternatives sets the alternatives of each priced AWS compute and
+// storage resource in the project to the cheapest SKU of the same family and
+// size in the same region, if it saves at least 5% of the cost component.
+// This should be called after the costs have been calculated.
+func SuggestAlternatives(cfg *config.Config, project *schema.Project) error {
+	q, err := newQueryRunner(cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range project.Resources {
+		alternatives, err := resourceAlternatives(r, q)
+		if err != nil {
+			return err
+		}
+		r.Alternatives = alternatives
+	}
+
+	return savePricingDB(cfg, q)
+}
+
+func resourceAlternatives(r *schema.Resource, q QueryRunner) ([]*schema.Alternative, error) {
+	if r.IsSkipped || r.NoPrice {
+		return nil, nil
+	}
+
+	var alternatives []*schema.Alternative
+
+	for _, res := range append([]*schema.Resource{r}, r.FlattenedSubResources()...) {
+		for _, c := range res.CostComponents {
+			a, err := costComponentAlternative(c, q)
+			if err != nil {
+				return nil, err
+			}
+			if a != nil {
+				alternatives = append(alternatives, a)
+			}
+		}
+	}
+
+	return alternatives, nil
+}
+
+// costComponentAlternative prices the candidate SKUs of the cost component
+// and returns the one with the biggest saving, or nil if none are cheaper.
+func costComponentAlternative(c *schema.CostComponent, q QueryRunner) (*schema.Alternative, error) {
+	if c.MonthlyCost == nil || c.MonthlyQuantity == nil || !c.MonthlyCost.IsPositive() {
+		return nil, nil
+	}
+
+	attr, candidates := alternativeCandidates(c)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	candidateResource := &schema.Resource{Name: c.Name}
+	for _, candidate := range candidates {
+		candidateResource.CostComponents = append(candidateResource.CostComponents, withAttributeValue(c, attr.Key, candidate))
+	}
+
+	results, err := q.RunQueries(candidateResource)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *schema.Alternative
+	for _, res := range results {
+		suggested := attributeValue(res.CostComponent, attr.Key)
+
+		price, ok := firstPrice(res.Result)
+		if !ok {
+			log.Debugf("No price found for alternative %s of %s", suggested, c.Name)
+			continue
+		}
+
+		discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
+		monthlyCost := price.Mul(*c.MonthlyQuantity).Mul(discountMul)
+		saving := c.MonthlyCost.Sub(monthlyCost)
+		savingPercent := saving.Div(*c.MonthlyCost).Mul(decimal.NewFromInt(100))
+
+		if savingPercent.LessThan(minAlternativeSavingPercent) {
+			continue
+		}
+
+		if best == nil || saving.GreaterThan(best.MonthlySaving) {
+			region := ""
+			if c.ProductFilter.Region != nil {
+				region = *c.ProductFilter.Region
+			}
+
+			best = &schema.Alternative{
+				CostComponent: c.Name,
+				Region:        region,
+				Current:       *attr.Value,
+				Suggested:     suggested,
+				MonthlySaving: saving,
+				SavingPercent: savingPercent,
+			}
+		}
+	}
+
+	return best, nil
+}
+
+// alternativeCandidates returns the attribute filter of the cost component
+// that selects its SKU and the values of the same-sized alternatives.
+func alternativeCandidates(c *schema.CostComponent) (*schema.AttributeFilter, []string) {
+	if c.ProductFilter == nil || c.ProductFilter.VendorName == nil || *c.ProductFilter.VendorName != "aws" {
+		return nil, nil
+	}
+
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Value == nil {
+			continue
+		}
+
+		switch {
+		case f.Key == "instanceType" && c.Unit == "hours":
+			return f, instanceTypeAlternatives(*f.Value)
+		case f.Key == "volumeApiName" && c.Unit == "GB":
+			return f, volumeTypeAlternatives[*f.Value]
+		}
+	}
+
+	return nil, nil
+}
+
+// instanceTypeAlternatives returns the same size of the alternative families,
+// keeping any prefix, e.g. db.m5.large returns db.m6i.large and db.m5a.large.
+func instanceTypeAlternatives(instanceType string) []string {
+	parts := strings.Split(instanceType, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	family := parts[len(parts)-2]
+
+	candidates := make([]string, 0, len(instanceFamilyAlternatives[family]))
+	for _, alt := range instanceFamilyAlternatives[family] {
+		p := append([]string{}, parts...)
+		p[len(p)-2] = alt
+		candidates = append(candidates, strings.Join(p, "."))
+	}
+
+	sort.Strings(candidates)
+
+	return candidates
+}
+
+// withAttributeValue returns a copy of the cost component with a different
+// value for the attribute filter.
+func withAttributeValue(c *schema.CostComponent, key string, value string) *schema.CostComponent {
+	filters := make([]*schema.AttributeFilter, 0, len(c.ProductFilter.AttributeFilters))
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == key {
+			v := value
+			f = &schema.AttributeFilter{Key: f.Key, Value: &v}
+		}
+		filters = append(filters, f)
+	}
+
+	productFilter := *c.ProductFilter
+	productFilter.AttributeFilters = filters
+
+	return &schema.CostComponent{
+		Name:          c.Name,
+		Unit:          c.Unit,
+		ProductFilter: &productFilter,
+		PriceFilter:   c.PriceFilter,
+	}
+}
+
+func attributeValue(c *schema.CostComponent, key string) string {
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == key && f.Value != nil {
+			return *f.Value
+		}
+	}
+	return ""
+}
+
+func firstPrice(res gjson.Result) (decimal.Decimal, bool) {
+	products := res.Get("data.products").Array()
+	if len(products) == 0 {
+		return decimal.Zero, false
+	}
+
+	prices := products[0].Get("prices").Array()
+	if len(prices) == 0 {
+		return decimal.Zero, false
+	}
+
+	p, err := decimal.NewFromString(prices[0].Get("USD").String())
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	return p, true
+}
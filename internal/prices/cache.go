@@ -0,0 +1,115 @@
+package prices
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// pricingCache caches the result of each pricing API query in a file named by
+// the hash of the endpoint and query, so the many identical queries of large
+// repos are only sent once per TTL.
+type pricingCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// newPricingCache returns the pricing cache of the config, or nil if it's
+// turned off with --no-cache, its TTL is zero or there's no cache dir.
+func newPricingCache(cfg *config.Config) *pricingCache {
+	if cfg.NoPricingCache || cfg.PricingCacheTTL <= 0 {
+		return nil
+	}
+
+	dir := cfg.PricingCacheDir
+	if dir == "" {
+		cacheDir := cfg.CacheDir()
+		if cacheDir == "" {
+			return nil
+		}
+		dir = filepath.Join(cacheDir, "pricing_cache")
+	}
+
+	return &pricingCache{dir: dir, ttl: cfg.PricingCacheTTL}
+}
+
+// queryHash returns the hex SHA-256 hash of the JSON of the query, prefixed
+// by the endpoint and a zero byte if there is one.
+func queryHash(endpoint string, query GraphQLQuery) (string, error) {
+	b, err := json.Marshal(query)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	if endpoint != "" {
+		h.Write([]byte(endpoint))
+		h.Write([]byte{0})
+	}
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// get returns the cached result of the query, or false if it's not cached or
+// was cached longer ago than the TTL.
+func (c *pricingCache) get(key string) (gjson.Result, bool) {
+	path := filepath.Join(c.dir, key+".json")
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return gjson.Result{}, false
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil || !gjson.ValidBytes(b) {
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(b), true
+}
+
+// set caches the result of the query. It's written to a temporary file that
+// is renamed, so concurrent queries never read or write a partial file.
+// Errors are only logged since the result can always be fetched again.
+// Results with GraphQL errors aren't cached, so they're fetched again by the
+// next run instead of being missing prices until the TTL expires.
+func (c *pricingCache) set(key string, result gjson.Result) {
+	if result.Get("errors").Exists() {
+		log.Debugf("Not caching a pricing API result with errors: %s", result.Get("errors").Raw)
+		return
+	}
+
+	err := os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		log.Debugf("Could not create the pricing cache dir: %s", err)
+		return
+	}
+
+	f, err := ioutil.TempFile(c.dir, key+".*.tmp")
+	if err != nil {
+		log.Debugf("Could not write to the pricing cache: %s", err)
+		return
+	}
+
+	_, err = f.WriteString(result.Raw)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(c.dir, key+".json"))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		log.Debugf("Could not write to the pricing cache: %s", err)
+	}
+}
@@ -3,6 +3,8 @@ package prices
 import (
 	"fmt"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/infracost/infracost/internal/config"
@@ -16,7 +18,10 @@ import (
 )
 
 func PopulatePrices(cfg *config.Config, project *schema.Project) error {
-	q := NewGraphQLQueryRunner(fmt.Sprintf("%s/graphql", cfg.PricingAPIEndpoint), cfg.APIKey)
+	q, err := newQueryRunner(cfg)
+	if err != nil {
+		return err
+	}
 	resources := project.AllResources()
 
 	var wg sync.WaitGroup
@@ -33,47 +38,68 @@ func PopulatePrices(cfg *config.Config, project *schema.Project) error {
 		events.SendReport(cfg, "summary", summary)
 	}()
 
-	err := GetPricesConcurrent(resources, q)
+	err = GetPricesConcurrent(resources, q, cfg.PricingAPIConcurrency)
 	if err != nil {
 		return err
 	}
 
 	wg.Wait()
 
+	if gq, ok := q.(*GraphQLQueryRunner); ok {
+		gq.logStats()
+	}
+
+	err = savePricingDB(cfg, q)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
-// Concurrency level is calculated using the following formula:
+// maxBatchQueries is the most queries that are sent to the pricing API in one
+// request when the queries of several resources are batched.
+const maxBatchQueries = 100
+
+// GetPricesConcurrent gets the prices of all resources concurrently, batching
+// the queries of several resources into one request if the query runner
+// supports it. If concurrency isn't set the number of concurrent requests is
+// calculated using the following formula:
 // max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
+func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner, concurrency int) error {
 	// Set the number of workers
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
+	numWorkers := concurrency
+	if numWorkers <= 0 {
+		numWorkers = 4
+		numCPU := runtime.NumCPU()
+		if numCPU*4 > numWorkers {
+			numWorkers = numCPU * 4
+		}
+		if numWorkers > 16 {
+			numWorkers = 16
+		}
 	}
-	numJobs := len(resources)
-	jobs := make(chan *schema.Resource, numJobs)
+
+	batches := batchResources(resources, q)
+	numJobs := len(batches)
+	jobs := make(chan []*schema.Resource, numJobs)
 	resultErrors := make(chan error, numJobs)
 
 	// Fire up the workers
 	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
-			for r := range jobs {
-				err := GetPrices(r, q)
+		go func(jobs <-chan []*schema.Resource, resultErrors chan<- error) {
+			for batch := range jobs {
+				err := getBatchPrices(batch, q)
 				resultErrors <- err
 			}
 		}(jobs, resultErrors)
 	}
 
 	// Feed the workers the jobs of getting prices
-	for _, r := range resources {
-		jobs <- r
+	for _, batch := range batches {
+		jobs <- batch
 	}
+	close(jobs)
 
 	// Get the result of the jobs
 	for i := 0; i < numJobs; i++ {
@@ -85,6 +111,71 @@ func GetPricesConcurrent(resources []*schema.Resource, q QueryRunner) error {
 	return nil
 }
 
+// batchResources splits the resources that aren't skipped into batches of up
+// to maxBatchQueries queries, or batches of one resource if the query runner
+// can't batch them.
+func batchResources(resources []*schema.Resource, q QueryRunner) [][]*schema.Resource {
+	_, canBatch := q.(BatchQueryRunner)
+
+	batches := make([][]*schema.Resource, 0)
+	var batch []*schema.Resource
+	batchQueries := 0
+
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		n := resourceQueryCount(r)
+		if len(batch) > 0 && (!canBatch || batchQueries+n > maxBatchQueries) {
+			batches = append(batches, batch)
+			batch = nil
+			batchQueries = 0
+		}
+
+		batch = append(batch, r)
+		batchQueries += n
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+func resourceQueryCount(r *schema.Resource) int {
+	n := len(r.CostComponents)
+	for _, s := range r.FlattenedSubResources() {
+		n += len(s.CostComponents)
+	}
+	return n
+}
+
+func getBatchPrices(resources []*schema.Resource, q QueryRunner) error {
+	bq, ok := q.(BatchQueryRunner)
+	if !ok || len(resources) == 1 {
+		for _, r := range resources {
+			err := GetPrices(r, q)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	results, err := bq.RunBatchQueries(resources)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range resources {
+		setPrices(r, results[i], q)
+	}
+
+	return nil
+}
+
 func GetPrices(r *schema.Resource, q QueryRunner) error {
 	if r.IsSkipped {
 		return nil
@@ -94,13 +185,43 @@ func GetPrices(r *schema.Resource, q QueryRunner) error {
 		return err
 	}
 
-	for _, r := range results {
-		setCostComponentPrice(r.Resource, r.CostComponent, r.Result)
-	}
+	setPrices(r, results, q)
 
 	return nil
 }
 
+// setPrices sets the prices of the cost components of the resource from their
+// query results, or skips the resource if some of them have no result.
+func setPrices(r *schema.Resource, results []QueryResult, q QueryRunner) {
+	missing := make([]string, 0)
+
+	for _, res := range results {
+		// Results don't exist when the query isn't in the offline pricing DB, or
+		// the pricing API response is missing some of the queries
+		if !res.Result.Exists() {
+			if res.CostComponent.IgnoreIfMissingPrice {
+				res.Resource.RemoveCostComponent(res.CostComponent)
+				continue
+			}
+			missing = append(missing, res.CostComponent.Name)
+			continue
+		}
+
+		setCostComponentPrice(res.Resource, res.CostComponent, res.Result)
+	}
+
+	if len(missing) > 0 {
+		source := "The pricing API returned no prices"
+		if _, ok := q.(*offlineQueryRunner); ok {
+			source = "The pricing DB has no prices"
+		}
+
+		log.Warnf("Skipping resource %s. %s for: %s", r.Name, source, strings.Join(missing, ", "))
+		r.IsSkipped = true
+		r.SkipMessage = fmt.Sprintf("%s for: %s", source, strings.Join(missing, ", "))
+	}
+}
+
 func setCostComponentPrice(r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
 	var p decimal.Decimal
 
@@ -132,6 +253,11 @@ func setCostComponentPrice(r *schema.Resource, c *schema.CostComponent, res gjso
 		c.SetPrice(decimal.Zero)
 		return
 	}
+	if c.TieredPricing {
+		setTieredCostComponentPrice(r, c, prices)
+		return
+	}
+
 	if len(prices) > 1 {
 		log.Warnf("Multiple prices found for %s %s, using the first price", r.Name, c.Name)
 	}
@@ -147,3 +273,87 @@ func setCostComponentPrice(r *schema.Resource, c *schema.CostComponent, res gjso
 	c.SetPrice(p)
 	c.SetPriceHash(prices[0].Get("priceHash").String())
 }
+
+type priceTier struct {
+	start     decimal.Decimal
+	end       *decimal.Decimal
+	price     decimal.Decimal
+	priceHash string
+}
+
+// setTieredCostComponentPrice sets the price to the blended price of the
+// monthly quantity split across the price tiers, e.g. for 100 TB of S3 storage
+// the first 50 TB is charged at the first tier price and the rest at the next
+// tier price. If there's no quantity the price of the first tier is used. The
+// tier usage amounts are in the unit of the component, e.g. 1M requests, so
+// the quantity is converted to it by the unit multiplier before it's split.
+func setTieredCostComponentPrice(r *schema.Resource, c *schema.CostComponent, prices []gjson.Result) {
+	tiers := make([]priceTier, 0, len(prices))
+
+	for _, p := range prices {
+		price, err := decimal.NewFromString(p.Get("USD").String())
+		if err != nil {
+			log.Warnf("Error converting price (using 0.00) '%v': %s", p.Get("USD").String(), err.Error())
+			c.SetPrice(decimal.Zero)
+			return
+		}
+
+		start, err := decimal.NewFromString(p.Get("startUsageAmount").String())
+		if err != nil {
+			start = decimal.Zero
+		}
+
+		// The end of the last tier is Inf
+		var end *decimal.Decimal
+		if e, err := decimal.NewFromString(p.Get("endUsageAmount").String()); err == nil {
+			end = &e
+		}
+
+		tiers = append(tiers, priceTier{start: start, end: end, price: price, priceHash: p.Get("priceHash").String()})
+	}
+
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].start.LessThan(tiers[j].start)
+	})
+
+	c.SetPriceHash(tiers[0].priceHash)
+
+	quantity := c.MonthlyQuantity
+	if quantity == nil && c.HourlyQuantity != nil {
+		quantity = decimalPtr(c.HourlyQuantity.Mul(decimal.NewFromInt(730)))
+	}
+
+	if quantity == nil || quantity.IsZero() {
+		c.SetPrice(tiers[0].price)
+		return
+	}
+
+	multiplier := decimal.NewFromInt(1)
+	if c.UnitMultiplier > 0 {
+		multiplier = decimal.NewFromInt(int64(c.UnitMultiplier))
+	}
+	units := quantity.Div(multiplier)
+
+	total := decimal.Zero
+	for _, t := range tiers {
+		if units.LessThanOrEqual(t.start) {
+			break
+		}
+
+		tierUnits := units.Sub(t.start)
+		if t.end != nil && units.GreaterThan(*t.end) {
+			tierUnits = t.end.Sub(t.start)
+		}
+
+		// The prices are per unit of the quantity
+		total = total.Add(tierUnits.Mul(multiplier).Mul(t.price))
+	}
+
+	log.Debugf("Using the blended price of %d price tiers for %s %s", len(tiers), r.Name, c.Name)
+
+	c.SetPrice(total.Div(*quantity))
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
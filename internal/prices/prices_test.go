@@ -0,0 +1,419 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform/aws"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// tieredQueryRunner returns the S3 standard storage price tiers for tiered
+// cost components and a single price for the others.
+type tieredQueryRunner struct{}
+
+func (q *tieredQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
+	results := make([]QueryResult, 0)
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			prices := `[{"priceHash": "single", "USD": "0.01", "startUsageAmount": "0", "endUsageAmount": "Inf"}]`
+			if c.TieredPricing {
+				prices = `[
+					{"priceHash": "tier2", "USD": "0.022", "startUsageAmount": "51200", "endUsageAmount": "512000"},
+					{"priceHash": "tier1", "USD": "0.023", "startUsageAmount": "0", "endUsageAmount": "51200"},
+					{"priceHash": "tier3", "USD": "0.021", "startUsageAmount": "512000", "endUsageAmount": "Inf"}
+				]`
+			}
+
+			results = append(results, QueryResult{
+				queryKey: queryKey{res, c},
+				Result:   gjson.Parse(`{"data": {"products": [{"prices": ` + prices + `}]}}`),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+func s3StandardStorage(t *testing.T, storageGB int64) *schema.CostComponent {
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", map[string]string{}, gjson.Parse(`{"region": "us-east-1"}`))
+	u := schema.NewUsageData("aws_s3_bucket.bucket", map[string]gjson.Result{
+		"standard.storage_gb": gjson.Parse(decimal.NewFromInt(storageGB).String()),
+	})
+
+	r := aws.NewS3Bucket(d, u)
+	require.NoError(t, GetPrices(r, &tieredQueryRunner{}))
+	r.CalculateCosts()
+
+	for _, s := range r.SubResources {
+		if s.Name != "Standard" {
+			continue
+		}
+
+		for _, c := range s.CostComponents {
+			if c.Name == "Storage" {
+				return c
+			}
+		}
+	}
+
+	require.FailNow(t, "Standard storage cost component not found")
+
+	return nil
+}
+
+func TestTieredPricing(t *testing.T) {
+	// 51,200 GB at $0.023 and 48,800 GB at $0.022
+	c := s3StandardStorage(t, 100000)
+	assert.Equal(t, "2251.2", c.MonthlyCost.String())
+	assert.Equal(t, "0.022512", c.Price().String())
+	assert.Equal(t, "tier1", c.PriceHash())
+
+	// 51,200 GB at $0.023, 460,800 GB at $0.022 and 88,000 GB at $0.021. The
+	// blended price is rounded so the cost is too.
+	c = s3StandardStorage(t, 600000)
+	assert.Equal(t, "13163.2", c.MonthlyCost.Round(8).String())
+
+	c = s3StandardStorage(t, 10000)
+	assert.Equal(t, "230", c.MonthlyCost.String())
+}
+
+func TestTieredPricingUnitMultiplier(t *testing.T) {
+	// 333M requests at $3.50 per 1M and 167M at $2.80 per 1M. The tier usage
+	// amounts are in 1M requests, the unit of the component
+	c := &schema.CostComponent{
+		Name:            "Requests",
+		Unit:            "1M requests",
+		UnitMultiplier:  1000000,
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(500000000)),
+		TieredPricing:   true,
+	}
+	prices := gjson.Parse(`[
+		{"priceHash": "tier1", "USD": "0.0000035", "startUsageAmount": "0", "endUsageAmount": "333"},
+		{"priceHash": "tier2", "USD": "0.0000028", "startUsageAmount": "333", "endUsageAmount": "Inf"}
+	]`).Array()
+
+	setTieredCostComponentPrice(&schema.Resource{Name: "aws_api_gateway_rest_api.api"}, c, prices)
+	c.CalculateCosts()
+
+	assert.Equal(t, "1633.1", c.MonthlyCost.Round(8).String())
+	assert.Equal(t, "3.2662", c.UnitMultiplierPrice().Round(8).String())
+	assert.Equal(t, "500", c.UnitMultiplierMonthlyQuantity().String())
+}
+
+// alternativesQueryRunner returns the price of the instance type or volume
+// type of each cost component, or no products if it's unknown.
+type alternativesQueryRunner struct {
+	prices map[string]string
+}
+
+func (q *alternativesQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
+	results := make([]QueryResult, 0)
+
+	for _, res := range append([]*schema.Resource{r}, r.FlattenedSubResources()...) {
+		for _, c := range res.CostComponents {
+			products := `[]`
+			for _, f := range c.ProductFilter.AttributeFilters {
+				if f.Value == nil {
+					continue
+				}
+				if p, ok := q.prices[*f.Value]; ok {
+					products = `[{"prices": [{"priceHash": "` + *f.Value + `", "USD": "` + p + `"}]}]`
+				}
+			}
+
+			results = append(results, QueryResult{
+				queryKey: queryKey{res, c},
+				Result:   gjson.Parse(`{"data": {"products": ` + products + `}}`),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+func TestResourceAlternatives(t *testing.T) {
+	q := &alternativesQueryRunner{prices: map[string]string{
+		"m5.xlarge":  "0.192",
+		"m6i.xlarge": "0.176",
+		"m5a.xlarge": "0.188",
+		"gp2":        "0.10",
+		"gp3":        "0.08",
+	}}
+
+	d := schema.NewResourceData("aws_instance", "aws", "aws_instance.web", map[string]string{}, gjson.Parse(`{
+		"region": "us-east-1",
+		"instance_type": "m5.xlarge",
+		"root_block_device": [{"volume_type": "gp2", "volume_size": 100}]
+	}`))
+	r := aws.NewInstance(d, nil)
+	require.NoError(t, GetPrices(r, q))
+	r.CalculateCosts()
+
+	alternatives, err := resourceAlternatives(r, q)
+	require.NoError(t, err)
+	require.Len(t, alternatives, 2)
+
+	// m5a.xlarge only saves 2% so m6i.xlarge is suggested
+	assert.Equal(t, "m5.xlarge", alternatives[0].Current)
+	assert.Equal(t, "m6i.xlarge", alternatives[0].Suggested)
+	assert.Equal(t, "us-east-1", alternatives[0].Region)
+	assert.Equal(t, "11.68", alternatives[0].MonthlySaving.StringFixed(2))
+	assert.Equal(t, "8", alternatives[0].SavingPercent.StringFixed(0))
+
+	assert.Equal(t, "gp2", alternatives[1].Current)
+	assert.Equal(t, "gp3", alternatives[1].Suggested)
+	assert.Equal(t, "2.00", alternatives[1].MonthlySaving.StringFixed(2))
+}
+
+func TestInstanceTypeAlternatives(t *testing.T) {
+	assert.Equal(t, []string{"db.m5a.large", "db.m6i.large"}, instanceTypeAlternatives("db.m5.large"))
+	assert.Equal(t, []string{"t3a.micro"}, instanceTypeAlternatives("t3.micro"))
+	assert.Empty(t, instanceTypeAlternatives("x1e.xlarge"))
+}
+
+func TestPricingCache(t *testing.T) {
+	var requests int32
+	ts := newPricingAPIServer(t, &requests)
+	defer ts.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.PricingAPIEndpoint = ts.URL
+	cfg.PricingCacheDir = t.TempDir()
+
+	runQueries := func() []QueryResult {
+		q, err := newQueryRunner(cfg)
+		require.NoError(t, err)
+		results, err := q.RunQueries(newPricingTestResource())
+		require.NoError(t, err)
+		return results
+	}
+
+	for i := 0; i < 2; i++ {
+		results := runQueries()
+		require.Len(t, results, 2)
+		assert.Equal(t, "0.5", results[1].Result.Get("data.products.0.prices.0.USD").String())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	cfg.PricingCacheTTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	runQueries()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	cfg.PricingCacheTTL = time.Hour
+	cfg.NoPricingCache = true
+	runQueries()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestPricingCacheErrors(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var queries []GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&queries))
+
+		results := make([]string, 0, len(queries))
+		for range queries {
+			results = append(results, `{"errors":[{"message":"Internal error"}]}`)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}))
+	defer ts.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.PricingAPIEndpoint = ts.URL
+	cfg.PricingCacheDir = t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		q, err := newQueryRunner(cfg)
+		require.NoError(t, err)
+		_, err = q.RunQueries(newPricingTestResource())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	files, err := filepath.Glob(filepath.Join(cfg.PricingCacheDir, "*"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestOfflinePricingDB(t *testing.T) {
+	var requests int32
+	ts := newPricingAPIServer(t, &requests)
+	defer ts.Close()
+
+	dbPath := filepath.Join(t.TempDir(), "pricing-db.json")
+
+	cfg := config.DefaultConfig()
+	cfg.PricingAPIEndpoint = ts.URL
+	cfg.NoPricingCache = true
+	cfg.PricingDBOut = dbPath
+
+	q, err := newQueryRunner(cfg)
+	require.NoError(t, err)
+	require.NoError(t, GetPrices(newPricingTestResource(), q))
+	require.NoError(t, savePricingDB(cfg, q))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	cfg = config.DefaultConfig()
+	cfg.PricingAPIEndpoint = ts.URL
+	cfg.PricingDBPath = dbPath
+
+	q, err = newQueryRunner(cfg)
+	require.NoError(t, err)
+
+	r := newPricingTestResource()
+	require.NoError(t, GetPrices(r, q))
+	assert.False(t, r.IsSkipped)
+	assert.Equal(t, "0.5", r.CostComponents[0].Price().String())
+
+	r = newPricingTestResource()
+	r.CostComponents = append(r.CostComponents, &schema.CostComponent{
+		Name:          "Data transfer",
+		ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws"), Service: strPtr("AWSDataTransfer")},
+	})
+	require.NoError(t, GetPrices(r, q))
+	assert.True(t, r.IsSkipped)
+	assert.Equal(t, "The pricing DB has no prices for: Data transfer", r.SkipMessage)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestSetPricesMissingResult(t *testing.T) {
+	r := newPricingTestResource()
+	results := []QueryResult{
+		{queryKey: queryKey{Resource: r, CostComponent: r.CostComponents[0]}, Result: gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.5"}]}]}}`)},
+		{queryKey: queryKey{Resource: r, CostComponent: r.CostComponents[1]}},
+	}
+
+	// A short response from the pricing API isn't a missing pricing DB price
+	setPrices(r, results, &GraphQLQueryRunner{})
+	assert.True(t, r.IsSkipped)
+	assert.Equal(t, "The pricing API returned no prices for: Storage", r.SkipMessage)
+
+	r.IsSkipped = false
+	setPrices(r, results, &offlineQueryRunner{})
+	assert.True(t, r.IsSkipped)
+	assert.Equal(t, "The pricing DB has no prices for: Storage", r.SkipMessage)
+}
+
+func TestGetPricesConcurrentBatches(t *testing.T) {
+	var requests int32
+	ts := newPricingAPIServer(t, &requests)
+	defer ts.Close()
+
+	q := NewGraphQLQueryRunner(ts.URL, "")
+
+	resources := make([]*schema.Resource, 0)
+	for i := 0; i < 60; i++ {
+		resources = append(resources, newPricingTestResource())
+	}
+
+	require.NoError(t, GetPricesConcurrent(resources, q, 2))
+
+	// 120 queries in batches of up to 100
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, int64(120), q.queryCount)
+	for _, r := range resources {
+		assert.Equal(t, "0.5", r.CostComponents[1].Price().String())
+	}
+}
+
+func TestPricingAPIRetry(t *testing.T) {
+	defer func(wait time.Duration) { pricingAPIRetryWait = wait }(pricingAPIRetryWait)
+	pricingAPIRetryWait = time.Millisecond
+
+	var requests int32
+	api := pricingAPIHandler(t, &requests)
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":"Too many requests"}`)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"error":"Bad gateway"}`)
+		default:
+			api(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	q := NewGraphQLQueryRunner(ts.URL, "")
+	results, err := q.RunQueries(newPricingTestResource())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "0.5", results[0].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, int64(2), q.retryCount)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestPricingAPIRetryLimit(t *testing.T) {
+	defer func(wait time.Duration) { pricingAPIRetryWait = wait }(pricingAPIRetryWait)
+	pricingAPIRetryWait = time.Millisecond
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":"Service unavailable"}`)
+	}))
+	defer ts.Close()
+
+	q := NewGraphQLQueryRunner(ts.URL, "")
+	_, err := q.RunQueries(newPricingTestResource())
+	assert.EqualError(t, err, "Received error from pricing API: Service unavailable")
+	assert.Equal(t, int64(pricingAPIMaxRetries), q.retryCount)
+}
+
+// newPricingAPIServer returns a pricing API that counts its requests and
+// returns the same price for every query.
+func newPricingAPIServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(pricingAPIHandler(t, requests))
+}
+
+func pricingAPIHandler(t *testing.T, requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		var queries []GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&queries))
+
+		results := make([]string, 0, len(queries))
+		for range queries {
+			results = append(results, `{"data":{"products":[{"prices":[{"priceHash":"abc","USD":"0.5"}]}]}}`)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}
+}
+
+func newPricingTestResource() *schema.Resource {
+	return &schema.Resource{
+		Name: "aws_instance.web",
+		CostComponents: []*schema.CostComponent{
+			{Name: "Instance usage", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws"), Service: strPtr("AmazonEC2")}},
+			{Name: "Storage", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws"), Service: strPtr("AmazonEBS")}},
+		},
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
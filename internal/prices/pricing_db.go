@@ -0,0 +1,137 @@
+package prices
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+const pricingDBVersion = "0.1"
+
+// PricingDB is a bundle of pricing API results for offline runs that don't
+// send any requests to the pricing API. It's written by an online run with
+// --pricing-db-out and read with --pricing-db-path.
+//
+// It's a JSON object with its version and the results of the pricing API
+// queries, keyed by the hex SHA-256 hash of the JSON of each GraphQL query,
+// e.g.
+//
+//	{
+//	  "version": "0.1",
+//	  "results": {
+//	    "3b1f...": {"data": {"products": [{"prices": [{"priceHash": "...", "USD": "0.0416"}]}]}}
+//	  }
+//	}
+type PricingDB struct {
+	Version string                     `json:"version"`
+	Results map[string]json.RawMessage `json:"results"`
+
+	mu sync.Mutex
+}
+
+func newPricingDB() *PricingDB {
+	return &PricingDB{
+		Version: pricingDBVersion,
+		Results: make(map[string]json.RawMessage),
+	}
+}
+
+// LoadPricingDB reads the pricing DB from the path.
+func LoadPricingDB(path string) (*PricingDB, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the pricing DB")
+	}
+
+	db := newPricingDB()
+	err = json.Unmarshal(b, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing the pricing DB")
+	}
+
+	if db.Version != pricingDBVersion {
+		return nil, errors.Errorf("Pricing DB version %s is not supported, it should be %s", db.Version, pricingDBVersion)
+	}
+
+	return db, nil
+}
+
+func (db *PricingDB) add(hash string, result gjson.Result) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.Results[hash] = json.RawMessage(result.Raw)
+}
+
+func (db *PricingDB) get(hash string) (gjson.Result, bool) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	b, ok := db.Results[hash]
+	if !ok {
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(b), true
+}
+
+// save adds the results to the pricing DB file at the path, creating it if
+// it doesn't exist, so a DB can be built from the runs of several projects.
+func (db *PricingDB) save(path string) error {
+	existing := newPricingDB()
+	if _, err := os.Stat(path); err == nil {
+		existing, err = LoadPricingDB(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	db.mu.Lock()
+	for hash, result := range db.Results {
+		existing.Results[hash] = result
+	}
+	db.mu.Unlock()
+
+	b, err := json.Marshal(existing)
+	if err != nil {
+		return errors.Wrap(err, "Error generating the pricing DB")
+	}
+
+	err = ioutil.WriteFile(path, b, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Error writing the pricing DB")
+	}
+
+	return nil
+}
+
+// offlineQueryRunner gets the results of the queries from the pricing DB
+// instead of the pricing API. Queries that aren't in the DB have an empty
+// result, so their resources can be skipped.
+type offlineQueryRunner struct {
+	db *PricingDB
+	q  GraphQLQueryRunner
+}
+
+func (o *offlineQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
+	keys, queries := o.q.batchQueries(r)
+
+	results := make([]gjson.Result, len(queries))
+	for i, query := range queries {
+		hash, err := queryHash("", query)
+		if err != nil {
+			return []QueryResult{}, errors.Wrap(err, "Error generating the pricing DB query")
+		}
+
+		if result, ok := o.db.get(hash); ok {
+			results[i] = result
+		}
+	}
+
+	return o.q.zipQueryResults(keys, results), nil
+}
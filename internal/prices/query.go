@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -49,9 +53,37 @@ type QueryRunner interface {
 	RunQueries(resource *schema.Resource) ([]QueryResult, error)
 }
 
+// BatchQueryRunner is a QueryRunner that can also run the queries of several
+// resources in one request.
+type BatchQueryRunner interface {
+	QueryRunner
+	// RunBatchQueries returns the query results of each of the resources
+	RunBatchQueries(resources []*schema.Resource) ([][]QueryResult, error)
+}
+
+// pricingAPIMaxRetries is how many times a request that the pricing API
+// responds to with a 429 or 5xx status is retried.
+const pricingAPIMaxRetries = 5
+
+// pricingAPIRetryWait is the wait before the first retry, which doubles for
+// each retry up to pricingAPIMaxRetryWait.
+var (
+	pricingAPIRetryWait    = 500 * time.Millisecond
+	pricingAPIMaxRetryWait = 30 * time.Second
+)
+
 type GraphQLQueryRunner struct {
 	endpoint string
 	apiKey   string
+	cache    *pricingCache
+	// recorded has the result of each query if they're saved to a pricing DB
+	recorded *PricingDB
+
+	// Counts of the queries, pricing API requests and retries for the debug
+	// logs, so the concurrency can be tuned
+	queryCount   int64
+	requestCount int64
+	retryCount   int64
 }
 
 func NewGraphQLQueryRunner(endpoint string, apiKey string) *GraphQLQueryRunner {
@@ -61,22 +93,105 @@ func NewGraphQLQueryRunner(endpoint string, apiKey string) *GraphQLQueryRunner {
 	}
 }
 
-func (q *GraphQLQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
-	keys, queries := q.batchQueries(r)
+// newQueryRunner returns the query runner of the config. That's the pricing
+// DB if there is one, otherwise the pricing API with its pricing cache if it
+// has one.
+func newQueryRunner(cfg *config.Config) (QueryRunner, error) {
+	if cfg.PricingDBPath != "" {
+		db, err := LoadPricingDB(cfg.PricingDBPath)
+		if err != nil {
+			return nil, err
+		}
+		return &offlineQueryRunner{db: db}, nil
+	}
 
-	if len(queries) == 0 {
-		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
-		return []QueryResult{}, nil
+	q := NewGraphQLQueryRunner(fmt.Sprintf("%s/graphql", cfg.PricingAPIEndpoint), cfg.APIKey)
+	q.cache = newPricingCache(cfg)
+	if cfg.PricingDBOut != "" {
+		q.recorded = newPricingDB()
+	}
+
+	return q, nil
+}
+
+// savePricingDB adds the results of the queries to the pricing DB file of the
+// config, if the query runner records them.
+func savePricingDB(cfg *config.Config, q QueryRunner) error {
+	gq, ok := q.(*GraphQLQueryRunner)
+	if !ok || gq.recorded == nil {
+		return nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", q.endpoint, r.Name)
+	return gq.recorded.save(cfg.PricingDBOut)
+}
 
-	results, err := q.getQueryResults(queries)
+func (q *GraphQLQueryRunner) RunQueries(r *schema.Resource) ([]QueryResult, error) {
+	results, err := q.RunBatchQueries([]*schema.Resource{r})
 	if err != nil {
 		return []QueryResult{}, err
 	}
 
-	return q.zipQueryResults(keys, results), nil
+	return results[0], nil
+}
+
+// RunBatchQueries runs the queries of all the resources in one request to the
+// pricing API.
+func (q *GraphQLQueryRunner) RunBatchQueries(resources []*schema.Resource) ([][]QueryResult, error) {
+	allKeys := make([]queryKey, 0)
+	allQueries := make([]GraphQLQuery, 0)
+	counts := make([]int, 0, len(resources))
+	names := make([]string, 0, len(resources))
+
+	for _, r := range resources {
+		keys, queries := q.batchQueries(r)
+		allKeys = append(allKeys, keys...)
+		allQueries = append(allQueries, queries...)
+		counts = append(counts, len(queries))
+		names = append(names, r.Name)
+	}
+
+	batchResults := make([][]QueryResult, len(resources))
+
+	if len(allQueries) == 0 {
+		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", strings.Join(names, ", "))
+		for i := range batchResults {
+			batchResults[i] = []QueryResult{}
+		}
+		return batchResults, nil
+	}
+
+	log.Debugf("Getting pricing details from %s for %s", q.endpoint, strings.Join(names, ", "))
+	atomic.AddInt64(&q.queryCount, int64(len(allQueries)))
+
+	results, err := q.getQueryResults(allQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.recorded != nil {
+		for i, query := range allQueries {
+			hash, err := queryHash("", query)
+			if err == nil && i < len(results) {
+				q.recorded.add(hash, results[i])
+			}
+		}
+	}
+
+	zipped := q.zipQueryResults(allKeys, results)
+	offset := 0
+	for i, count := range counts {
+		batchResults[i] = zipped[offset : offset+count]
+		offset += count
+	}
+
+	return batchResults, nil
+}
+
+// logStats logs how many queries the runner has run, and how many requests
+// and retries that took.
+func (q *GraphQLQueryRunner) logStats() {
+	log.Debugf("Ran %d pricing API queries in %d requests with %d retries",
+		atomic.LoadInt64(&q.queryCount), atomic.LoadInt64(&q.requestCount), atomic.LoadInt64(&q.retryCount))
 }
 
 func (q *GraphQLQueryRunner) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter) GraphQLQuery {
@@ -90,6 +205,8 @@ func (q *GraphQLQueryRunner) buildQuery(product *schema.ProductFilter, price *sc
 				prices(filter: $priceFilter) {
 					priceHash
 					USD
+					startUsageAmount
+					endUsageAmount
 				}
 			}
 		}
@@ -98,7 +215,57 @@ func (q *GraphQLQueryRunner) buildQuery(product *schema.ProductFilter, price *sc
 	return GraphQLQuery{query, v}
 }
 
+// getQueryResults returns the results of the queries, using the cached
+// results where there are any and fetching and caching the others.
 func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Result, error) {
+	if q.cache == nil {
+		return q.fetchQueryResults(queries)
+	}
+
+	results := make([]gjson.Result, len(queries))
+	keys := make([]string, len(queries))
+	misses := make([]int, 0, len(queries))
+	missQueries := make([]GraphQLQuery, 0, len(queries))
+
+	for i, query := range queries {
+		key, err := queryHash(q.endpoint, query)
+		if err == nil {
+			if result, ok := q.cache.get(key); ok {
+				results[i] = result
+				continue
+			}
+		}
+
+		keys[i] = key
+		misses = append(misses, i)
+		missQueries = append(missQueries, query)
+	}
+
+	if len(missQueries) == 0 {
+		log.Debugf("Using cached results for %d queries", len(queries))
+		return results, nil
+	}
+
+	fetched, err := q.fetchQueryResults(missQueries)
+	if err != nil {
+		return []gjson.Result{}, err
+	}
+
+	for j, i := range misses {
+		if j >= len(fetched) {
+			break
+		}
+
+		results[i] = fetched[j]
+		if keys[i] != "" {
+			q.cache.set(keys[i], fetched[j])
+		}
+	}
+
+	return results, nil
+}
+
+func (q *GraphQLQueryRunner) fetchQueryResults(queries []GraphQLQuery) ([]gjson.Result, error) {
 	results := make([]gjson.Result, 0, len(queries))
 
 	if len(queries) == 0 {
@@ -111,25 +278,26 @@ func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Re
 		return results, errors.Wrap(err, "Error generating request for pricing API")
 	}
 
-	req, err := http.NewRequest("POST", q.endpoint, bytes.NewBuffer(queriesBody))
-	if err != nil {
-		return results, errors.Wrap(err, "Error generating request for pricing API")
-	}
+	var body []byte
+	var statusCode int
 
-	config.AddAuthHeaders(q.apiKey, req)
+	for retry := 0; ; retry++ {
+		body, statusCode, err = q.sendRequest(queriesBody)
+		if err != nil {
+			return results, err
+		}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return results, errors.Wrap(err, "Error sending request to pricing API")
-	}
-	defer resp.Body.Close()
+		if !isRetryableStatus(statusCode) || retry == pricingAPIMaxRetries {
+			break
+		}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return results, &PricingAPIError{err, "Invalid response from pricing API"}
+		wait := retryWait(retry)
+		log.Debugf("Retrying pricing API request in %s since it responded with status %d", wait, statusCode)
+		atomic.AddInt64(&q.retryCount, 1)
+		time.Sleep(wait)
 	}
-	if resp.StatusCode != 200 {
+
+	if statusCode != 200 {
 		var r pricingAPIErrorResponse
 		err = json.Unmarshal(body, &r)
 		if err != nil {
@@ -146,6 +314,46 @@ func (q *GraphQLQueryRunner) getQueryResults(queries []GraphQLQuery) ([]gjson.Re
 	return results, nil
 }
 
+func (q *GraphQLQueryRunner) sendRequest(queriesBody []byte) ([]byte, int, error) {
+	req, err := http.NewRequest("POST", q.endpoint, bytes.NewBuffer(queriesBody))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Error generating request for pricing API")
+	}
+
+	config.AddAuthHeaders(q.apiKey, req)
+
+	atomic.AddInt64(&q.requestCount, 1)
+
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Error sending request to pricing API")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, &PricingAPIError{err, "Invalid response from pricing API"}
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// retryWait returns the exponential backoff before the retry, with jitter so
+// concurrent requests that were rate limited together don't retry together.
+func retryWait(retry int) time.Duration {
+	wait := pricingAPIRetryWait << uint(retry)
+	if wait <= 0 || wait > pricingAPIMaxRetryWait {
+		wait = pricingAPIMaxRetryWait
+	}
+
+	half := int64(wait / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
 // Batch all the queries for this resource so we can use one GraphQL call.
 // Use queryKeys to keep track of which query maps to which sub-resource and price component.
 func (q *GraphQLQueryRunner) batchQueries(r *schema.Resource) ([]queryKey, []GraphQLQuery) {
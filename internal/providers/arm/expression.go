@@ -0,0 +1,292 @@
+package arm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// maxVariableDepth is how deep variables and parameters can reference others
+// before they're treated as unknown, so a cycle can't recurse forever.
+const maxVariableDepth = 10
+
+// evaluator evaluates the template expressions that don't depend on the
+// deployment, i.e. literals, parameters, variables, concat, toLower, toUpper
+// and copyIndex. Others, e.g. resourceGroup().location, are unknown.
+type evaluator struct {
+	params    map[string]gjson.Result
+	variables gjson.Result
+	copyIndex int
+	depth     int
+}
+
+// eval returns the value with its expressions evaluated and false if any of
+// them are unknown. Expressions start with [ and end with ], a leading [[ is
+// an escaped [.
+func (e *evaluator) eval(v gjson.Result) (gjson.Result, bool) {
+	if v.Type != gjson.String || !strings.HasPrefix(v.Str, "[") || !strings.HasSuffix(v.Str, "]") {
+		return v, true
+	}
+
+	if strings.HasPrefix(v.Str, "[[") {
+		return stringResult(v.Str[1:]), true
+	}
+
+	p := &exprParser{s: v.Str[1 : len(v.Str)-1], e: e}
+	r, err := p.parseExpr()
+	if err != nil {
+		return gjson.Result{}, false
+	}
+
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return gjson.Result{}, false
+	}
+
+	return r, true
+}
+
+// parameter returns the evaluated value of the parameter. Default values can
+// use other parameters and variables, e.g. a name prefix, so they're evaluated
+// when they're used like variables rather than in the random order of the
+// params map.
+func (e *evaluator) parameter(name string) (gjson.Result, error) {
+	if e.depth >= maxVariableDepth {
+		return gjson.Result{}, fmt.Errorf("parameter %s is nested too deeply", name)
+	}
+
+	var value gjson.Result
+	found := false
+	for k, v := range e.params {
+		if strings.EqualFold(k, name) {
+			value = v
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return gjson.Result{}, fmt.Errorf("unknown parameter %s", name)
+	}
+
+	nested := *e
+	nested.depth++
+
+	r, ok := nested.eval(value)
+	if !ok {
+		return gjson.Result{}, fmt.Errorf("parameter %s is unknown", name)
+	}
+
+	return r, nil
+}
+
+func (e *evaluator) variable(name string) (gjson.Result, error) {
+	if e.depth >= maxVariableDepth {
+		return gjson.Result{}, fmt.Errorf("variable %s is nested too deeply", name)
+	}
+
+	var value gjson.Result
+	e.variables.ForEach(func(k, v gjson.Result) bool {
+		if strings.EqualFold(k.String(), name) {
+			value = v
+			return false
+		}
+		return true
+	})
+
+	if !value.Exists() {
+		return gjson.Result{}, fmt.Errorf("unknown variable %s", name)
+	}
+
+	nested := *e
+	nested.depth++
+
+	r, ok := nested.eval(value)
+	if !ok {
+		return gjson.Result{}, fmt.Errorf("variable %s is unknown", name)
+	}
+
+	return r, nil
+}
+
+type exprParser struct {
+	s   string
+	pos int
+	e   *evaluator
+}
+
+// parseExpr parses a string literal, number or function call, followed by any
+// property accesses, e.g. parameters('vm').size.
+func (p *exprParser) parseExpr() (gjson.Result, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return gjson.Result{}, fmt.Errorf("unexpected end of expression")
+	}
+
+	var r gjson.Result
+	var err error
+
+	switch c := p.s[p.pos]; {
+	case c == '\'':
+		r, err = p.parseString()
+	case c >= '0' && c <= '9':
+		r = p.parseNumber()
+	default:
+		r, err = p.parseCall()
+	}
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	for p.pos < len(p.s) && p.s[p.pos] == '.' {
+		p.pos++
+		name := p.parseIdent()
+		if name == "" {
+			return gjson.Result{}, fmt.Errorf("expected property name at %d", p.pos)
+		}
+		r = r.Get(gjsonEscape(name))
+		if !r.Exists() {
+			return gjson.Result{}, fmt.Errorf("unknown property %s", name)
+		}
+	}
+
+	return r, nil
+}
+
+func (p *exprParser) parseString() (gjson.Result, error) {
+	var b strings.Builder
+	p.pos++
+
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+
+		if c != '\'' {
+			b.WriteByte(c)
+			continue
+		}
+
+		// Quotes in strings are escaped by doubling them
+		if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+			b.WriteByte('\'')
+			p.pos++
+			continue
+		}
+
+		return stringResult(b.String()), nil
+	}
+
+	return gjson.Result{}, fmt.Errorf("unterminated string")
+}
+
+func (p *exprParser) parseNumber() gjson.Result {
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	return gjson.Parse(p.s[start:p.pos])
+}
+
+func (p *exprParser) parseCall() (gjson.Result, error) {
+	name := p.parseIdent()
+	if name == "" {
+		return gjson.Result{}, fmt.Errorf("expected function name at %d", p.pos)
+	}
+
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != '(' {
+		return gjson.Result{}, fmt.Errorf("expected ( after %s", name)
+	}
+	p.pos++
+
+	args := make([]gjson.Result, 0)
+	for {
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == ')' {
+			p.pos++
+			break
+		}
+
+		if len(args) > 0 {
+			if p.pos >= len(p.s) || p.s[p.pos] != ',' {
+				return gjson.Result{}, fmt.Errorf("expected , or ) in arguments of %s", name)
+			}
+			p.pos++
+		}
+
+		arg, err := p.parseExpr()
+		if err != nil {
+			return gjson.Result{}, err
+		}
+		args = append(args, arg)
+	}
+
+	return p.call(strings.ToLower(name), args)
+}
+
+func (p *exprParser) call(name string, args []gjson.Result) (gjson.Result, error) {
+	switch name {
+	case "parameters", "variables":
+		if len(args) != 1 || args[0].Type != gjson.String {
+			return gjson.Result{}, fmt.Errorf("%s takes a name", name)
+		}
+		if name == "parameters" {
+			return p.e.parameter(args[0].Str)
+		}
+		return p.e.variable(args[0].Str)
+	case "concat":
+		var b strings.Builder
+		for _, a := range args {
+			b.WriteString(a.String())
+		}
+		return stringResult(b.String()), nil
+	case "tolower", "toupper":
+		if len(args) != 1 {
+			return gjson.Result{}, fmt.Errorf("%s takes a string", name)
+		}
+		if name == "tolower" {
+			return stringResult(strings.ToLower(args[0].String())), nil
+		}
+		return stringResult(strings.ToUpper(args[0].String())), nil
+	case "copyindex":
+		if p.e.copyIndex < 0 {
+			return gjson.Result{}, fmt.Errorf("copyIndex is only valid in resources with copy")
+		}
+		i := int64(p.e.copyIndex)
+		if len(args) > 0 {
+			i += args[len(args)-1].Int()
+		}
+		return gjson.Parse(fmt.Sprintf("%d", i)), nil
+	}
+
+	return gjson.Result{}, fmt.Errorf("unsupported function %s", name)
+}
+
+func (p *exprParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func stringResult(s string) gjson.Result {
+	raw, _ := json.Marshal(s)
+	return gjson.Result{Type: gjson.String, Str: s, Raw: string(raw)}
+}
+
+func gjsonEscape(s string) string {
+	return strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`).Replace(s)
+}
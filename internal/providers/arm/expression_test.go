@@ -0,0 +1,45 @@
+package arm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestEval(t *testing.T) {
+	e := &evaluator{
+		params: map[string]gjson.Result{
+			"prefix": gjson.Parse(`"App"`),
+			"vm":     gjson.Parse(`{"size": "Standard_B1s"}`),
+		},
+		variables: gjson.Parse(`{"name": "[concat(toLower(parameters('prefix')), '-', variables('suffix'))]", "suffix": "web", "cycle": "[variables('cycle')]"}`),
+		copyIndex: 2,
+	}
+
+	tests := []struct {
+		expr     string
+		expected string
+		ok       bool
+	}{
+		{`"plain"`, "plain", true},
+		{`"[[not an expression]"`, "[not an expression]", true},
+		{`"[parameters('prefix')]"`, "App", true},
+		{`"[parameters('PREFIX')]"`, "App", true},
+		{`"[parameters('vm').size]"`, "Standard_B1s", true},
+		{`"[variables('name')]"`, "app-web", true},
+		{`"[toUpper(parameters('prefix'))]"`, "APP", true},
+		{`"[concat('it''s ', copyIndex(1))]"`, "it's 3", true},
+		{`"[parameters('missing')]"`, "", false},
+		{`"[variables('cycle')]"`, "", false},
+		{`"[resourceGroup().location]"`, "", false},
+		{`"[concat('a', 'b']"`, "", false},
+		{`5`, "5", true},
+	}
+
+	for _, test := range tests {
+		r, ok := e.eval(gjson.Parse(test.expr))
+		assert.Equal(t, test.ok, ok, test.expr)
+		assert.Equal(t, test.expected, r.String(), test.expr)
+	}
+}
@@ -0,0 +1,92 @@
+package arm
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Provider parses the resources of an Azure Resource Manager template JSON
+// file, including Bicep files compiled with bicep build. The parameters are
+// read from the parameters file next to it, e.g. azuredeploy.parameters.json
+// for azuredeploy.json, if it exists.
+type Provider struct {
+	Path          string
+	defaultRegion string
+}
+
+func NewProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	return &Provider{
+		Path:          projectCfg.Path,
+		defaultRegion: cfg.DefaultRegion,
+	}
+}
+
+func (p *Provider) Type() string {
+	return "arm_template"
+}
+
+func (p *Provider) DisplayType() string {
+	return "Azure Resource Manager template"
+}
+
+func (p *Provider) LoadResources(usage map[string]*schema.UsageData) (*schema.Project, error) {
+	project := schema.NewProject(p.Path, map[string]string{})
+
+	j, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return project, errors.Wrap(err, "Error reading ARM template")
+	}
+
+	var paramsJSON []byte
+	if path := ParametersFilePath(p.Path); path != "" {
+		paramsJSON, err = ioutil.ReadFile(path)
+		if err != nil {
+			return project, errors.Wrap(err, "Error reading ARM template parameters file")
+		}
+	}
+
+	resources, err := parseTemplate(j, paramsJSON)
+	if err != nil {
+		return project, errors.Wrap(err, "Error parsing ARM template")
+	}
+
+	for _, r := range resources {
+		project.Resources = append(project.Resources, createResource(r, p.defaultRegion, usage))
+	}
+
+	return project, nil
+}
+
+// IsARMTemplate returns true if the file is an ARM template JSON, i.e. its
+// $schema is a deployment template schema.
+func IsARMTemplate(path string) bool {
+	b, err := ioutil.ReadFile(path)
+	if err != nil || !gjson.ValidBytes(b) {
+		return false
+	}
+
+	s := strings.ToLower(gjson.GetBytes(b, `\$schema`).String())
+
+	return strings.Contains(s, "deploymenttemplate.json") && gjson.GetBytes(b, "resources").IsArray()
+}
+
+// ParametersFilePath returns the path of the parameters file of the template
+// or an empty string if it doesn't have one.
+func ParametersFilePath(path string) string {
+	if !strings.HasSuffix(path, ".json") {
+		return ""
+	}
+
+	paramsPath := strings.TrimSuffix(path, ".json") + ".parameters.json"
+	if _, err := os.Stat(paramsPath); err != nil {
+		return ""
+	}
+
+	return paramsPath
+}
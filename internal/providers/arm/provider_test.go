@@ -0,0 +1,167 @@
+package arm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsARMTemplate(t *testing.T) {
+	assert.True(t, IsARMTemplate("testdata/azuredeploy.json"))
+	assert.False(t, IsARMTemplate("testdata/azuredeploy.parameters.json"))
+	assert.False(t, IsARMTemplate("testdata/missing.json"))
+
+	assert.Equal(t, "testdata/azuredeploy.parameters.json", ParametersFilePath("testdata/azuredeploy.json"))
+	assert.Equal(t, "", ParametersFilePath("testdata/azuredeploy.parameters.json"))
+}
+
+func TestParseTemplate(t *testing.T) {
+	j, err := ioutil.ReadFile("testdata/azuredeploy.json")
+	require.NoError(t, err)
+	paramsJSON, err := ioutil.ReadFile("testdata/azuredeploy.parameters.json")
+	require.NoError(t, err)
+
+	resources, err := parseTemplate(j, paramsJSON)
+	require.NoError(t, err)
+
+	addresses := make([]string, 0, len(resources))
+	for _, r := range resources {
+		addresses = append(addresses, r.Address())
+	}
+
+	assert.Equal(t, []string{
+		"Microsoft.Storage/storageAccounts/appstore",
+		"Microsoft.Storage/storageAccounts/blobServices/appstore/default",
+		"Microsoft.Compute/virtualMachines/app-vm-1",
+		"Microsoft.Compute/virtualMachines/app-vm-2",
+		"Microsoft.Network/virtualNetworks/vnet",
+		"Microsoft.KeyVault/vaults/kv",
+	}, addresses)
+
+	tfType, values := resourceValues(resources[0])
+	assert.Equal(t, "azurerm_storage_account", tfType)
+	assert.Equal(t, "Standard", values["account_tier"])
+	assert.Equal(t, "LRS", values["account_replication_type"])
+
+	tfType, values = resourceValues(resources[2])
+	assert.Equal(t, "azurerm_linux_virtual_machine", tfType)
+	assert.Equal(t, "Standard_B1s", values["size"])
+
+	vm := createResource(resources[2], "", nil)
+	assert.False(t, vm.IsSkipped)
+	assert.Equal(t, "eastus", vm.Region)
+	assert.Equal(t, "Microsoft.Compute/virtualMachines", vm.ResourceType)
+	assert.Equal(t, map[string]string{"env": "app"}, vm.Tags)
+	assert.Equal(t, "Instance usage (pay as you go, B1s)", vm.CostComponents[0].Name)
+
+	vnet := createResource(resources[4], "", nil)
+	assert.True(t, vnet.NoPrice)
+
+	kv := createResource(resources[5], "", nil)
+	assert.True(t, kv.IsSkipped)
+	assert.Equal(t, "This resource is not currently supported", kv.SkipMessage)
+
+	// Without the parameters file the location is resourceGroup().location
+	resources, err = parseTemplate(j, nil)
+	require.NoError(t, err)
+
+	vm = createResource(resources[2], "", nil)
+	assert.True(t, vm.IsSkipped)
+	assert.Equal(t, "Unknown location [parameters('location')], set it in the parameters file or set --default-region", vm.SkipMessage)
+
+	vm = createResource(resources[2], "westeurope", nil)
+	assert.False(t, vm.IsSkipped)
+	assert.Equal(t, "westeurope", vm.Region)
+}
+
+func TestParseTemplateCopyCount(t *testing.T) {
+	template := `{
+		"resources": [
+			{"type": "Microsoft.Network/publicIPAddresses", "name": "[concat('ip-', copyIndex())]", "copy": {"name": "ips", "count": %d}}
+		]
+	}`
+
+	resources, err := parseTemplate([]byte(fmt.Sprintf(template, 800)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 800, len(resources))
+
+	_, err = parseTemplate([]byte(fmt.Sprintf(template, 801)), nil)
+	assert.EqualError(t, err, "Invalid count 801 of copy loop ips, it must be between 0 and 800")
+}
+
+func TestParseTemplateUnknownCopyCount(t *testing.T) {
+	resources, err := parseTemplate([]byte(`{
+		"resources": [
+			{"type": "Microsoft.Network/publicIPAddresses", "name": "[concat('ip-', copyIndex())]", "location": "westus", "copy": {"name": "ips", "count": "[length(parameters('names'))]"}}
+		]
+	}`), nil)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	r := createResource(resources[0], "", nil)
+	assert.True(t, r.IsSkipped)
+	assert.Equal(t, "Unknown count [length(parameters('names'))] of copy loop ips, set its parameters in the parameters file", r.SkipMessage)
+}
+
+func TestParseTemplateParameterDefaults(t *testing.T) {
+	// Defaults that use other parameters are evaluated when they're used, so
+	// they don't depend on the order of the parameters
+	template := []byte(`{
+		"parameters": {
+			"a": {"defaultValue": "[concat(parameters('b'), '-a')]"},
+			"b": {"defaultValue": "[concat(parameters('c'), '-b')]"},
+			"c": {"defaultValue": "c"},
+			"d": {"defaultValue": "[parameters('d')]"}
+		},
+		"resources": [
+			{"type": "Microsoft.Network/publicIPAddresses", "name": "[parameters('a')]"},
+			{"type": "Microsoft.Network/publicIPAddresses", "name": "[parameters('d')]"}
+		]
+	}`)
+
+	for i := 0; i < 10; i++ {
+		resources, err := parseTemplate(template, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "c-b-a", resources[0].Name)
+		assert.True(t, resources[0].Known)
+		assert.False(t, resources[1].Known)
+	}
+
+	resources, err := parseTemplate(template, []byte(`{"parameters": {"c": {"value": "prod"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "prod-b-a", resources[0].Name)
+}
+
+func TestWindowsVirtualMachine(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "template.json")
+
+	err := ioutil.WriteFile(path, []byte(`{
+		"$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+		"resources": [{
+			"type": "Microsoft.Compute/virtualMachines",
+			"name": "win",
+			"location": "westus",
+			"properties": {
+				"hardwareProfile": {"vmSize": "Standard_D2s_v3"},
+				"osProfile": {"windowsConfiguration": {}}
+			}
+		}]
+	}`), 0600)
+	require.NoError(t, err)
+
+	assert.True(t, IsARMTemplate(path))
+
+	j, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	resources, err := parseTemplate(j, nil)
+	require.NoError(t, err)
+
+	tfType, _ := resourceValues(resources[0])
+	assert.Equal(t, "azurerm_windows_virtual_machine", tfType)
+}
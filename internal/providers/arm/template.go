@@ -0,0 +1,331 @@
+package arm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// templateResource is an instance of a resource of the template, with the
+// names of nested resources qualified by their parents.
+type templateResource struct {
+	Type  string
+	Name  string
+	Raw   gjson.Result
+	Eval  *evaluator
+	Known bool
+	// UnknownCount is the copy.count expression if it couldn't be evaluated,
+	// so the number of instances isn't known
+	UnknownCount string
+}
+
+// get returns the evaluated value at the path of the resource, which doesn't
+// exist if it or its expression is unknown.
+func (r templateResource) get(path string) gjson.Result {
+	v, ok := r.Eval.eval(r.Raw.Get(path))
+	if !ok {
+		return gjson.Result{}
+	}
+	return v
+}
+
+// Address is the resource type and name, like the resource IDs but without
+// the subscription and resource group since they're set by the deployment.
+func (r templateResource) Address() string {
+	return fmt.Sprintf("%s/%s", r.Type, r.Name)
+}
+
+// parseTemplate returns the resource instances of the ARM template. Default
+// values of the template parameters are overridden by the parameters file,
+// if there is one. The parameters are evaluated when they're used, see
+// evaluator.parameter.
+func parseTemplate(j []byte, paramsJSON []byte) ([]templateResource, error) {
+	if !gjson.ValidBytes(j) {
+		return nil, errors.New("Invalid JSON")
+	}
+	parsed := gjson.ParseBytes(j)
+
+	e := &evaluator{
+		params:    make(map[string]gjson.Result),
+		variables: parsed.Get("variables"),
+		copyIndex: -1,
+	}
+
+	parsed.Get("parameters").ForEach(func(k, v gjson.Result) bool {
+		if v.Get("defaultValue").Exists() {
+			e.params[k.String()] = v.Get("defaultValue")
+		}
+		return true
+	})
+
+	if paramsJSON != nil {
+		if !gjson.ValidBytes(paramsJSON) {
+			return nil, errors.New("Invalid parameters file JSON")
+		}
+
+		gjson.ParseBytes(paramsJSON).Get("parameters").ForEach(func(k, v gjson.Result) bool {
+			if v.Get("value").Exists() {
+				e.params[k.String()] = v.Get("value")
+			}
+			return true
+		})
+	}
+
+	return templateResources(e, parsed.Get("resources").Array(), "", "")
+}
+
+// maxCopyCount is the most instances Azure allows a copy loop to create.
+const maxCopyCount = 800
+
+func templateResources(e *evaluator, resources []gjson.Result, parentType string, parentName string) ([]templateResource, error) {
+	instances := make([]templateResource, 0, len(resources))
+
+	for _, raw := range resources {
+		evaluators := []*evaluator{e}
+
+		if raw.Get("copy").Exists() {
+			evaluators = nil
+			count, ok := e.eval(raw.Get("copy.count"))
+			if !ok || count.Type != gjson.Number {
+				// The resource is skipped rather than left out so the estimate
+				// shows it's missing
+				instances = append(instances, templateResource{
+					Type:         qualifiedType(raw.Get("type").String(), parentType),
+					Name:         qualifiedName(raw.Get("name").String(), raw.Get("type").String(), parentType, parentName),
+					Raw:          raw,
+					Eval:         e,
+					UnknownCount: raw.Get("copy.count").String(),
+				})
+				continue
+			}
+			if count.Int() < 0 || count.Int() > maxCopyCount {
+				return nil, fmt.Errorf("Invalid count %d of copy loop %s, it must be between 0 and %d", count.Int(), raw.Get("copy.name").String(), maxCopyCount)
+			}
+
+			for i := 0; i < int(count.Int()); i++ {
+				copyEval := *e
+				copyEval.copyIndex = i
+				evaluators = append(evaluators, &copyEval)
+			}
+		}
+
+		for i, ev := range evaluators {
+			r := templateResource{
+				Type:  raw.Get("type").String(),
+				Raw:   raw,
+				Eval:  ev,
+				Known: true,
+			}
+
+			name, ok := ev.eval(raw.Get("name"))
+			if ok {
+				r.Name = name.String()
+			} else {
+				r.Name = raw.Get("name").String()
+				r.Known = false
+			}
+
+			r.Name = qualifiedName(r.Name, r.Type, parentType, parentName)
+			r.Type = qualifiedType(r.Type, parentType)
+
+			if !ok && len(evaluators) > 1 {
+				r.Name = fmt.Sprintf("%s[%d]", r.Name, i)
+			}
+
+			nested, err := templateResources(ev, raw.Get("resources").Array(), r.Type, r.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			instances = append(instances, r)
+			instances = append(instances, nested...)
+		}
+	}
+
+	return instances, nil
+}
+
+// qualifiedType returns the type of a nested resource, which is relative to
+// its parent.
+func qualifiedType(t string, parentType string) string {
+	if parentType != "" && !strings.Contains(t, "/") {
+		return fmt.Sprintf("%s/%s", parentType, t)
+	}
+	return t
+}
+
+// qualifiedName returns the name of a nested resource, which is relative to
+// its parent.
+func qualifiedName(name string, t string, parentType string, parentName string) string {
+	if parentType != "" && !strings.Contains(t, "/") {
+		return fmt.Sprintf("%s/%s", parentName, name)
+	}
+	return name
+}
+
+// resourceValues returns the Terraform resource type that prices the ARM
+// resource and its values in the same format as the Terraform plan JSON.
+func resourceValues(r templateResource) (string, map[string]interface{}) {
+	switch strings.ToLower(r.Type) {
+	case "microsoft.compute/virtualmachines":
+		return virtualMachineValues(r)
+	case "microsoft.compute/disks":
+		return "azurerm_managed_disk", map[string]interface{}{
+			"storage_account_type": stringOr(r.get("sku.name"), "Standard_LRS"),
+			"disk_size_gb":         r.get("properties.diskSizeGB").Value(),
+			"disk_iops_read_write": r.get("properties.diskIOPSReadWrite").Value(),
+			"disk_mbps_read_write": r.get("properties.diskMBpsReadWrite").Value(),
+		}
+	case "microsoft.storage/storageaccounts":
+		sku := strings.SplitN(r.get("sku.name").String(), "_", 2)
+		tier, replication := sku[0], ""
+		if len(sku) > 1 {
+			replication = sku[1]
+		}
+
+		return "azurerm_storage_account", map[string]interface{}{
+			"account_kind":             stringOr(r.get("kind"), "StorageV2"),
+			"account_tier":             tier,
+			"account_replication_type": replication,
+			"access_tier":              r.get("properties.accessTier").Value(),
+		}
+	case "microsoft.network/publicipaddresses":
+		return "azurerm_public_ip", map[string]interface{}{
+			"sku":               stringOr(r.get("sku.name"), "Basic"),
+			"allocation_method": stringOr(r.get("properties.publicIPAllocationMethod"), "Dynamic"),
+		}
+	case "microsoft.network/virtualnetworks":
+		return "azurerm_virtual_network", map[string]interface{}{}
+	case "microsoft.network/virtualnetworks/subnets":
+		return "azurerm_subnet", map[string]interface{}{}
+	case "microsoft.network/networkinterfaces":
+		return "azurerm_network_interface", map[string]interface{}{}
+	case "microsoft.network/networksecuritygroups":
+		return "azurerm_network_security_group", map[string]interface{}{}
+	}
+
+	return "", nil
+}
+
+func virtualMachineValues(r templateResource) (string, map[string]interface{}) {
+	osDisk := map[string]interface{}{
+		// Azure uses standard HDDs for OS disks that don't set a type
+		"storage_account_type": stringOr(r.get("properties.storageProfile.osDisk.managedDisk.storageAccountType"), "Standard_LRS"),
+		"disk_size_gb":         r.get("properties.storageProfile.osDisk.diskSizeGB").Value(),
+	}
+
+	values := map[string]interface{}{
+		"size":         r.get("properties.hardwareProfile.vmSize").String(),
+		"license_type": r.get("properties.licenseType").Value(),
+		"os_disk":      []interface{}{osDisk},
+		"additional_capabilities": []interface{}{
+			map[string]interface{}{"ultra_ssd_enabled": r.get("properties.additionalCapabilities.ultraSSDEnabled").Bool()},
+		},
+	}
+
+	windows := strings.EqualFold(r.get("properties.storageProfile.osDisk.osType").String(), "Windows") ||
+		r.get("properties.osProfile.windowsConfiguration").Exists() ||
+		strings.Contains(strings.ToLower(r.get("properties.storageProfile.imageReference.publisher").String()), "windows")
+
+	if windows {
+		return "azurerm_windows_virtual_machine", values
+	}
+
+	return "azurerm_linux_virtual_machine", values
+}
+
+// createResource prices the resource with the registry item of its Terraform
+// resource type. Resources that are unknown or have an unknown location are
+// skipped.
+func createResource(r templateResource, defaultRegion string, usage map[string]*schema.UsageData) *schema.Resource {
+	tags := resourceTags(r)
+
+	skipped := func(msg string) *schema.Resource {
+		return &schema.Resource{
+			Name:         r.Address(),
+			ResourceType: r.Type,
+			Tags:         tags,
+			IsSkipped:    true,
+			SkipMessage:  msg,
+		}
+	}
+
+	tfType, values := resourceValues(r)
+	registryItem, ok := (*terraform.GetResourceRegistryMap())[tfType]
+	if !ok {
+		return skipped("This resource is not currently supported")
+	}
+
+	if registryItem.NoPrice {
+		res := skipped("Free resource.")
+		res.NoPrice = true
+		return res
+	}
+
+	if r.UnknownCount != "" {
+		return skipped(fmt.Sprintf("Unknown count %s of copy loop %s, set its parameters in the parameters file", r.UnknownCount, r.Raw.Get("copy.name").String()))
+	}
+
+	if !r.Known {
+		return skipped(fmt.Sprintf("Unknown name %s, set its parameters in the parameters file", r.Name))
+	}
+
+	location := normalizeLocation(r.get("location").String())
+	if location == "" {
+		location = defaultRegion
+	}
+	if location == "" {
+		return skipped(fmt.Sprintf("Unknown location %s, set it in the parameters file or set --default-region", r.Raw.Get("location").String()))
+	}
+	values["location"] = location
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return skipped(fmt.Sprintf("Invalid values: %s", err))
+	}
+
+	d := schema.NewResourceData(tfType, "azurerm", r.Address(), tags, gjson.ParseBytes(b))
+
+	recorder := schema.NewUsageKeyRecorder()
+
+	res := registryItem.RFunc(d, usage[r.Address()].WithRecorder(r.Address(), recorder))
+	if res == nil {
+		return skipped("This resource is not currently supported")
+	}
+
+	res.ResourceType = r.Type
+	res.Tags = tags
+	res.Region = location
+	res.EstimatedUsageKeys, res.DefaultedUsageKeys = recorder.Keys()
+
+	return res
+}
+
+func resourceTags(r templateResource) map[string]string {
+	tags := make(map[string]string)
+	r.Raw.Get("tags").ForEach(func(k, v gjson.Result) bool {
+		if t, ok := r.Eval.eval(v); ok {
+			tags[k.String()] = t.String()
+		}
+		return true
+	})
+	return tags
+}
+
+// normalizeLocation returns the location in the format of the pricing API
+// regions, e.g. eastus for East US.
+func normalizeLocation(l string) string {
+	return strings.ToLower(strings.ReplaceAll(l, " ", ""))
+}
+
+func stringOr(v gjson.Result, def string) string {
+	if v.Type == gjson.Null {
+		return def
+	}
+	return v.String()
+}
@@ -8,12 +8,18 @@ import (
 	"os"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/arm"
 	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/providers/terragrunt"
 	"github.com/infracost/infracost/internal/schema"
 )
 
 func Detect(cfg *config.Config, projectCfg *config.Project) (schema.Provider, error) {
 
+	if projectCfg.TerraformCloudRun != "" {
+		return terraform.NewCloudRunProvider(cfg, projectCfg), nil
+	}
+
 	if _, err := os.Stat(projectCfg.Path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("No such file or directory %s", projectCfg.Path)
 	}
@@ -26,10 +32,22 @@ func Detect(cfg *config.Config, projectCfg *config.Project) (schema.Provider, er
 		return terraform.NewStateJSONProvider(cfg, projectCfg), nil
 	}
 
+	if arm.IsARMTemplate(projectCfg.Path) {
+		return arm.NewProvider(cfg, projectCfg), nil
+	}
+
 	if isTerraformPlan(projectCfg.Path) {
 		return terraform.NewPlanProvider(cfg, projectCfg), nil
 	}
 
+	if isZip(projectCfg.Path) {
+		return nil, fmt.Errorf("%s is not a recognizable Terraform plan file, it should be created with terraform plan -out", projectCfg.Path)
+	}
+
+	if terragrunt.IsTerragruntDir(projectCfg.Path) {
+		return terragrunt.NewProvider(cfg, projectCfg), nil
+	}
+
 	if isTerraformDir(projectCfg.Path) {
 		return terraform.NewDirProvider(cfg, projectCfg), nil
 	}
@@ -93,6 +111,18 @@ func isTerraformPlan(path string) bool {
 	return planFile != nil
 }
 
+// isZip returns true for any zip file. Terraform plan files are zip files,
+// so this can be used to give a better error for plans that can't be read.
+func isZip(path string) bool {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	r.Close()
+
+	return true
+}
+
 func isTerraformDir(path string) bool {
 	return terraform.IsTerraformDir(path)
 }
@@ -11,12 +11,23 @@ func GetAPIGatewayStageRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_api_gateway_stage",
 		RFunc: NewAPIGatewayStage,
+		Notes: []string{"Stages without caching enabled are free, their requests are priced on the REST API."},
 	}
 }
 
 func NewAPIGatewayStage(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
 
+	// Only the cache is charged for, the requests are charged on the REST API
+	if !d.Get("cache_cluster_enabled").Bool() {
+		return &schema.Resource{
+			Name:        d.Address,
+			NoPrice:     true,
+			IsSkipped:   true,
+			SkipMessage: "Free resource.",
+		}
+	}
+
 	cacheMemorySize := decimal.Zero
 
 	if d.Get("cache_cluster_size").Exists() {
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+func GetAthenaWorkgroupRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_athena_workgroup",
+		RFunc: NewAthenaWorkgroup,
+		Notes: []string{
+			"Queries are charged by the data they scan, which needs to be set in the usage file.",
+		},
+	}
+}
+
+func NewAthenaWorkgroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	var scannedTB *decimal.Decimal
+	if u != nil && u.Get("monthly_terabytes_scanned").Exists() {
+		scannedTB = decimalPtr(decimal.NewFromFloat(u.Get("monthly_terabytes_scanned").Float()))
+	}
+
+	r := &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Data scanned",
+				Unit:            "TB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: scannedTB,
+				ProductFilter: &schema.ProductFilter{
+					VendorName: strPtr("aws"),
+					Region:     strPtr(region),
+					Service:    strPtr("AmazonAthena"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "usagetype", ValueRegex: strPtr("/DataScannedInTB$/i")},
+					},
+				},
+			},
+		},
+	}
+
+	if scannedTB == nil {
+		r.UsageMessage = "Set monthly_terabytes_scanned in the usage file to the TB the queries scan each month"
+	}
+
+	return r
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
 	log "github.com/sirupsen/logrus"
@@ -10,9 +11,32 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+// Capacities that autoscaling groups can be priced on, set by capacity_basis in
+// the usage file.
+const (
+	CapacityBasisDesired = "desired"
+	CapacityBasisMin     = "min"
+	CapacityBasisMax     = "max"
+)
+
+// ValidateCapacityBasis returns an error if the basis isn't empty or one of
+// the capacity bases.
+func ValidateCapacityBasis(basis string) error {
+	switch strings.ToLower(basis) {
+	case "", CapacityBasisDesired, CapacityBasisMin, CapacityBasisMax:
+		return nil
+	}
+
+	return fmt.Errorf("Invalid capacity basis %s, it must be one of: %s, %s, %s", basis, CapacityBasisDesired, CapacityBasisMin, CapacityBasisMax)
+}
+
 func GetAutoscalingGroupRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:  "aws_autoscaling_group",
+		Name: "aws_autoscaling_group",
+		Notes: []string{
+			"Costs are based on the desired capacity, or the min size if that isn't set. Set capacity_basis in the usage file or --capacity-basis to use the min or max size instead.",
+			"Mixed instances policies are assumed to spread the capacity evenly across the override instance types.",
+		},
 		RFunc: NewAutoscalingGroup,
 		ReferenceAttributes: []string{
 			"launch_configuration",
@@ -25,7 +49,7 @@ func GetAutoscalingGroupRegistryItem() *schema.RegistryItem {
 
 func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
-	desiredCapacity := decimal.NewFromInt(d.Get("desired_capacity").Int())
+	desiredCapacity := autoscalingGroupCapacity(d, u)
 	if u != nil && u.Get("instances").Exists() {
 		if desiredCapacity.GreaterThan(decimal.Zero) {
 			log.Debugf("Overriding the desired_capacity for %s by usage data", d.Address)
@@ -62,7 +86,9 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 			onDemandCount = decimal.Zero
 			spotCount = desiredCapacity
 		}
-		lt := newLaunchTemplate(launchTemplateRef[0].Address, launchTemplateRef[0], u, region, onDemandCount, spotCount)
+		lt := newLaunchTemplate(launchTemplateRef[0].Address, launchTemplateRef[0], u, region, []launchTemplateInstances{
+			{instanceType: launchTemplateRef[0].Get("instance_type").String(), onDemandCount: onDemandCount, spotCount: spotCount},
+		})
 
 		// AutoscalingGroup should show as not supported LaunchTemplate is not supported
 		if lt == nil {
@@ -86,6 +112,38 @@ func NewAutoscalingGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 	}
 }
 
+// autoscalingGroupCapacity returns the number of instances to price the
+// autoscaling group on. This is the desired capacity unless capacity_basis is
+// set to min or max in the usage file, or by the CapacityBasis of the resource
+// if it isn't in the usage file. If the desired capacity isn't set AWS starts the group
+// with the min size, so that is used instead.
+func autoscalingGroupCapacity(d *schema.ResourceData, u *schema.UsageData) decimal.Decimal {
+	basis := strings.ToLower(d.CapacityBasis)
+	if basis == "" {
+		basis = CapacityBasisDesired
+	}
+	if u != nil && u.Get("capacity_basis").Exists() {
+		basis = strings.ToLower(u.Get("capacity_basis").String())
+	}
+
+	switch basis {
+	case CapacityBasisMin:
+		return decimal.NewFromInt(d.Get("min_size").Int())
+	case CapacityBasisMax:
+		return decimal.NewFromInt(d.Get("max_size").Int())
+	case CapacityBasisDesired:
+	default:
+		log.Warnf("Unknown capacity_basis %s for %s, using the desired capacity", basis, d.Address)
+	}
+
+	if d.Get("desired_capacity").Type == gjson.Null || d.Get("desired_capacity").Int() == 0 {
+		log.Debugf("No desired_capacity set for %s, using the min_size", d.Address)
+		return decimal.NewFromInt(d.Get("min_size").Int())
+	}
+
+	return decimal.NewFromInt(d.Get("desired_capacity").Int())
+}
+
 func newLaunchConfiguration(name string, d *schema.ResourceData, u *schema.UsageData, region string) *schema.Resource {
 	tenancy := "Shared"
 	if d.Get("placement_tenancy").String() == "host" {
@@ -128,7 +186,15 @@ func newLaunchConfiguration(name string, d *schema.ResourceData, u *schema.Usage
 	}
 }
 
-func newLaunchTemplate(name string, d *schema.ResourceData, u *schema.UsageData, region string, onDemandCount decimal.Decimal, spotCount decimal.Decimal) *schema.Resource {
+// launchTemplateInstances is the number of on-demand and spot instances of an
+// instance type launched from a launch template.
+type launchTemplateInstances struct {
+	instanceType  string
+	onDemandCount decimal.Decimal
+	spotCount     decimal.Decimal
+}
+
+func newLaunchTemplate(name string, d *schema.ResourceData, u *schema.UsageData, region string, instances []launchTemplateInstances) *schema.Resource {
 	tenancy := "Shared"
 	if d.Get("placement.0.tenancy").String() == "host" {
 		log.Warnf("Skipping resource %s. Infracost currently does not support host tenancy for AWS Launch Templates", d.Address)
@@ -137,9 +203,10 @@ func newLaunchTemplate(name string, d *schema.ResourceData, u *schema.UsageData,
 		tenancy = "Dedicated"
 	}
 
-	instanceType := d.Get("instance_type").String()
-
-	totalCount := onDemandCount.Add(spotCount)
+	totalCount := decimal.Zero
+	for _, i := range instances {
+		totalCount = totalCount.Add(i.onDemandCount).Add(i.spotCount)
+	}
 
 	costComponents := make([]*schema.CostComponent, 0)
 
@@ -185,30 +252,87 @@ func newLaunchTemplate(name string, d *schema.ResourceData, u *schema.UsageData,
 
 	schema.MultiplyQuantities(r, totalCount)
 
-	if spotCount.GreaterThan(decimal.Zero) {
-		c := computeCostComponent(d, u, "spot", instanceType, tenancy, 1)
-		c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(spotCount))
-		r.CostComponents = append([]*schema.CostComponent{c}, r.CostComponents...)
-	}
+	computeCostComponents := make([]*schema.CostComponent, 0, len(instances)*2)
+
+	for _, i := range instances {
+		if i.onDemandCount.GreaterThan(decimal.Zero) {
+			c := computeCostComponent(d, u, "on_demand", i.instanceType, tenancy, 1)
+			c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(i.onDemandCount))
+			computeCostComponents = append(computeCostComponents, c)
+		}
 
-	if onDemandCount.GreaterThan(decimal.Zero) {
-		c := computeCostComponent(d, u, "on_demand", instanceType, tenancy, 1)
-		c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(onDemandCount))
-		r.CostComponents = append([]*schema.CostComponent{c}, r.CostComponents...)
+		if i.spotCount.GreaterThan(decimal.Zero) {
+			c := computeCostComponent(d, u, "spot", i.instanceType, tenancy, 1)
+			c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(i.spotCount))
+			computeCostComponents = append(computeCostComponents, c)
+		}
 	}
 
+	r.CostComponents = append(computeCostComponents, r.CostComponents...)
+
 	return r
 }
 
 func newMixedInstancesAwsLaunchTemplate(name string, d *schema.ResourceData, u *schema.UsageData, region string, desiredCapacity decimal.Decimal, mixedInstancePolicyData gjson.Result) *schema.Resource {
-	overrideInstanceType, totalCount := getInstanceTypeAndCount(mixedInstancePolicyData, desiredCapacity)
-	if overrideInstanceType != "" {
-		d.Set("instance_type", overrideInstanceType)
+	overrides := mixedInstancePolicyData.Get("launch_template.0.override").Array()
+
+	if len(overrides) <= 1 {
+		overrideInstanceType, totalCount := getInstanceTypeAndCount(mixedInstancePolicyData, desiredCapacity)
+		if overrideInstanceType != "" {
+			d.Set("instance_type", overrideInstanceType)
+		}
+
+		onDemandCount, spotCount := calculateOnDemandAndSpotCounts(mixedInstancePolicyData, totalCount)
+
+		return newLaunchTemplate(name, d, u, region, []launchTemplateInstances{
+			{instanceType: d.Get("instance_type").String(), onDemandCount: onDemandCount, spotCount: spotCount},
+		})
+	}
+
+	// The other cost components, e.g. CPU credits, use the first override
+	d.Set("instance_type", overrides[0].Get("instance_type").String())
+
+	return newLaunchTemplate(name, d, u, region, getWeightedInstances(mixedInstancePolicyData, overrides, desiredCapacity))
+}
+
+// getWeightedInstances spreads the capacity evenly across the override instance
+// types, so each type provides capacity/len(overrides) of the weighted capacity,
+// and splits each type into on-demand and spot using the instances distribution.
+func getWeightedInstances(mixedInstancePolicyData gjson.Result, overrides []gjson.Result, capacity decimal.Decimal) []launchTemplateInstances {
+	instances := make([]launchTemplateInstances, 0, len(overrides))
+
+	if capacity.Equals(decimal.Zero) {
+		return instances
+	}
+
+	onDemandCapacity, _ := calculateOnDemandAndSpotCounts(mixedInstancePolicyData, capacity)
+	onDemandRatio := decimal.Min(onDemandCapacity.Div(capacity), decimal.NewFromInt(1))
+	capacityPerType := capacity.Div(decimal.NewFromInt(int64(len(overrides))))
+
+	for _, override := range overrides {
+		count := capacityPerType.Div(overrideWeightedCapacity(override))
+		if overrideWeightedCapacity(override).Equals(decimal.Zero) {
+			count = decimal.Zero
+		}
+
+		onDemandCount := count.Mul(onDemandRatio)
+
+		instances = append(instances, launchTemplateInstances{
+			instanceType:  override.Get("instance_type").String(),
+			onDemandCount: onDemandCount,
+			spotCount:     count.Sub(onDemandCount),
+		})
 	}
 
-	onDemandCount, spotCount := calculateOnDemandAndSpotCounts(mixedInstancePolicyData, totalCount)
+	return instances
+}
+
+func overrideWeightedCapacity(override gjson.Result) decimal.Decimal {
+	if override.Get("weighted_capacity").Exists() && override.Get("weighted_capacity").Type != gjson.Null {
+		return decimal.NewFromInt(override.Get("weighted_capacity").Int())
+	}
 
-	return newLaunchTemplate(name, d, u, region, onDemandCount, spotCount)
+	return decimal.NewFromInt(1)
 }
 
 func elasticInferenceAcceleratorCostComponent(d *schema.ResourceData) *schema.CostComponent {
@@ -239,10 +363,7 @@ func getInstanceTypeAndCount(mixedInstancePolicyData gjson.Result, capacity deci
 	override := mixedInstancePolicyData.Get("launch_template.0.override.0")
 	if override.Exists() {
 		instanceType = override.Get("instance_type").String()
-		weightedCapacity := decimal.NewFromInt(1)
-		if override.Get("weighted_capacity").Exists() && override.Get("weighted_capacity").Type != gjson.Null {
-			weightedCapacity = decimal.NewFromInt(override.Get("weighted_capacity").Int())
-		}
+		weightedCapacity := overrideWeightedCapacity(override)
 
 		if weightedCapacity.Equals(decimal.Zero) {
 			count = decimal.Zero
@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestAutoscalingGroupCapacityDefaultBasis(t *testing.T) {
+	d := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.asg", nil, gjson.Parse(`{"min_size": 1, "desired_capacity": 2, "max_size": 3}`))
+	u := schema.NewUsageData("aws_autoscaling_group.asg", schema.ParseAttributes(map[string]interface{}{"capacity_basis": "min"}))
+
+	assert.Equal(t, int64(2), autoscalingGroupCapacity(d, nil).IntPart())
+
+	d.CapacityBasis = CapacityBasisMax
+	assert.Equal(t, int64(3), autoscalingGroupCapacity(d, nil).IntPart())
+
+	// The usage file takes precedence
+	assert.Equal(t, int64(1), autoscalingGroupCapacity(d, u).IntPart())
+}
+
+func TestValidateCapacityBasis(t *testing.T) {
+	assert.NoError(t, ValidateCapacityBasis(""))
+	assert.NoError(t, ValidateCapacityBasis("MAX"))
+	assert.Error(t, ValidateCapacityBasis("average"))
+}
@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// backupResourceTypes are the resource types that AWS Backup prices
+// separately, mapped to the suffix of their usage types.
+var backupResourceTypes = map[string]string{
+	"aurora":          "Aurora",
+	"dynamodb":        "DynamoDB",
+	"ebs":             "EBS",
+	"efs":             "EFS",
+	"fsx":             "FSx",
+	"rds":             "RDS",
+	"storage_gateway": "StorageGateway",
+}
+
+func GetBackupVaultRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_backup_vault",
+		RFunc: NewBackupVault,
+		Notes: []string{
+			"The backed up resources are selected by the backup plans, so the storage and restores need to be set in the usage file.",
+		},
+	}
+}
+
+func NewBackupVault(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	resourceType := "EBS"
+	if u != nil && u.Get("resource_type").Exists() {
+		if t, ok := backupResourceTypes[strings.ToLower(u.Get("resource_type").String())]; ok {
+			resourceType = t
+		}
+	}
+
+	var warmStorage, coldStorage, warmRestore, coldRestore *decimal.Decimal
+	if u != nil && u.Get("monthly_warm_backup_storage_gb").Exists() {
+		warmStorage = decimalPtr(decimal.NewFromFloat(u.Get("monthly_warm_backup_storage_gb").Float()))
+	}
+	if u != nil && u.Get("monthly_cold_backup_storage_gb").Exists() {
+		coldStorage = decimalPtr(decimal.NewFromFloat(u.Get("monthly_cold_backup_storage_gb").Float()))
+	}
+	if u != nil && u.Get("monthly_warm_restore_gb").Exists() {
+		warmRestore = decimalPtr(decimal.NewFromFloat(u.Get("monthly_warm_restore_gb").Float()))
+	}
+	if u != nil && u.Get("monthly_cold_restore_gb").Exists() {
+		coldRestore = decimalPtr(decimal.NewFromFloat(u.Get("monthly_cold_restore_gb").Float()))
+	}
+
+	// Not every resource type has cold storage or charges for restores, so
+	// these are dropped if there's no price for them.
+	costComponents := []*schema.CostComponent{
+		backupVaultCostComponent(region, fmt.Sprintf("Backup storage (warm, %s)", resourceType), fmt.Sprintf("WarmStorage-ByteHrs-%s", resourceType), warmStorage, false),
+		backupVaultCostComponent(region, fmt.Sprintf("Backup storage (cold, %s)", resourceType), fmt.Sprintf("ColdStorage-ByteHrs-%s", resourceType), coldStorage, true),
+		backupVaultCostComponent(region, fmt.Sprintf("Restores (warm, %s)", resourceType), fmt.Sprintf("WarmRestore-ByteHrs-%s", resourceType), warmRestore, true),
+		backupVaultCostComponent(region, fmt.Sprintf("Restores (cold, %s)", resourceType), fmt.Sprintf("ColdRestore-ByteHrs-%s", resourceType), coldRestore, true),
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func backupVaultCostComponent(region string, name string, usageType string, quantity *decimal.Decimal, ignoreIfMissingPrice bool) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:                 name,
+		Unit:                 "GB",
+		UnitMultiplier:       1,
+		MonthlyQuantity:      quantity,
+		IgnoreIfMissingPrice: ignoreIfMissingPrice,
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr(region),
+			Service:    strPtr("AWSBackup"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s$/", usageType))},
+			},
+		},
+	}
+}
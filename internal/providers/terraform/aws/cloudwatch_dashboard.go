@@ -5,10 +5,17 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// cloudwatchFreeDashboards is the number of dashboards that each account gets
+// for free, the price filter uses the price of the dashboards after them.
+const cloudwatchFreeDashboards = "3"
+
 func GetCloudwatchDashboardRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_cloudwatch_dashboard",
 		RFunc: NewCloudwatchDashboard,
+		Notes: []string{
+			"The first 3 dashboards of each account are free, which isn't deducted since the dashboards of the account aren't known.",
+		},
 	}
 }
 
@@ -29,6 +36,9 @@ func NewCloudwatchDashboard(d *schema.ResourceData, u *schema.UsageData) *schema
 						{Key: "usagetype", Value: strPtr("DashboardsUsageHour")},
 					},
 				},
+				PriceFilter: &schema.PriceFilter{
+					StartUsageAmount: strPtr(cloudwatchFreeDashboards),
+				},
 			},
 		},
 	}
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetCloudwatchEventRuleItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_cloudwatch_event_rule",
+		RFunc: NewCloudwatchEventRule,
+		Notes: []string{
+			"Rules are free, the events are charged when they're published. The events of the default event bus can be set on its rules since it isn't a Terraform resource, other buses should set them on the aws_cloudwatch_event_bus.",
+		},
+	}
+}
+
+func NewCloudwatchEventRule(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	var monthlyCustomEvents *decimal.Decimal
+	if u != nil && u.Get("monthly_custom_events").Exists() {
+		monthlyCustomEvents = decimalPtr(decimal.NewFromInt(u.Get("monthly_custom_events").Int()))
+	}
+
+	r := &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Custom events published",
+				Unit:            "1M events",
+				UnitMultiplier:  1000000,
+				MonthlyQuantity: monthlyCustomEvents,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(region),
+					Service:       strPtr("AWSEvents"),
+					ProductFamily: strPtr("EventBridge"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "eventType", Value: strPtr("Custom Event")},
+						{Key: "usagetype", ValueRegex: strPtr("/Event-64K-Chunks/")},
+					},
+				},
+			},
+		},
+	}
+
+	if monthlyCustomEvents == nil {
+		r.UsageMessage = "Set monthly_custom_events in the usage file to the custom events the rule's event bus receives each month"
+	}
+
+	return r
+}
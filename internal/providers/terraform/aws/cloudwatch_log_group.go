@@ -9,6 +9,9 @@ func GetCloudwatchLogGroupItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_cloudwatch_log_group",
 		RFunc: NewCloudwatchLogGroup,
+		Notes: []string{
+			"If storage_gb isn't set, the storage is estimated from the data ingested and the retention_in_days.",
+		},
 	}
 }
 
@@ -25,14 +28,25 @@ func NewCloudwatchLogGroup(d *schema.ResourceData, u *schema.UsageData) *schema.
 
 	if u != nil && u.Get("storage_gb").Exists() {
 		gbDataStorage = decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float()))
+	} else if gbDataIngestion != nil && d.Get("retention_in_days").Int() > 0 {
+		// Logs are kept for the retention period, so the stored logs are the
+		// ingested logs of that many days
+		retentionMonths := decimal.NewFromInt(d.Get("retention_in_days").Int()).Div(decimal.NewFromInt(30))
+		gbDataStorage = decimalPtr(gbDataIngestion.Mul(retentionMonths))
 	}
 
 	if u != nil && u.Get("monthly_data_scanned_gb").Exists() {
 		gbDataScanned = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_scanned_gb").Float()))
 	}
 
+	var usageMessage string
+	if gbDataIngestion == nil && gbDataStorage == nil && gbDataScanned == nil {
+		usageMessage = "Cost depends on monthly_data_ingested_gb, storage_gb and monthly_data_scanned_gb from the usage file"
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:         d.Address,
+		UsageMessage: usageMessage,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Data ingested",
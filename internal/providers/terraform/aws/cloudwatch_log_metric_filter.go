@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetCloudwatchLogMetricFilterItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_cloudwatch_log_metric_filter",
+		RFunc: NewCloudwatchLogMetricFilter,
+		Notes: []string{
+			"Each metric transformation is a custom metric. Metrics with dimensions are a custom metric for each combination of the dimension values, which can be set with custom_metrics in the usage file.",
+		},
+	}
+}
+
+func NewCloudwatchLogMetricFilter(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	metrics := decimal.NewFromInt(int64(len(d.Get("metric_transformation").Array())))
+	if u != nil && u.Get("custom_metrics").Exists() {
+		metrics = decimal.NewFromInt(u.Get("custom_metrics").Int())
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Custom metrics",
+				Unit:            "metrics",
+				UnitMultiplier:  1,
+				MonthlyQuantity: decimalPtr(metrics),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(region),
+					Service:       strPtr("AmazonCloudWatch"),
+					ProductFamily: strPtr("Metric"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "usagetype", ValueRegex: strPtr("/CW:MetricMonitorUsage/")},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{
+					StartUsageAmount: strPtr("0"),
+				},
+			},
+		},
+	}
+}
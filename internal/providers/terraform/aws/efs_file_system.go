@@ -125,8 +125,14 @@ func NewEFSFileSystem(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		})
 	}
 
+	var usageMessage string
+	if gbStorage == nil {
+		usageMessage = "Storage cost depends on storage_gb, and infrequent_access_storage_gb if there's a lifecycle_policy, from the usage file"
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
+		UsageMessage:   usageMessage,
 		CostComponents: costComponents,
 	}
 }
@@ -7,17 +7,19 @@ import (
 
 func GetEIPRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:  "aws_eip",
-		RFunc: NewEIP,
+		Name:                "aws_eip",
+		RFunc:               NewEIP,
+		ReferenceAttributes: []string{"instance", "network_interface"},
+		Notes: []string{
+			"Only unattached IP addresses or addresses attached to stopped instances are charged.",
+		},
 	}
 }
 
 func NewEIP(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	// The IP address is probably used if it has an instance or network_interface, the instance might
-	// be stopped but that's probably less likely
-	if (d.Get("customer_owned_ipv4_pool").Exists() && d.Get("customer_owned_ipv4_pool").String() != "") ||
-		d.Get("instance").Exists() || d.Get("network_interface").Exists() {
+	if d.Get("customer_owned_ipv4_pool").String() != "" || isEIPAttached(d) {
 		return &schema.Resource{
+			Name:      d.Address,
 			NoPrice:   true,
 			IsSkipped: true,
 		}
@@ -49,3 +51,32 @@ func NewEIP(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		},
 	}
 }
+
+// isEIPAttached checks if the IP address is attached to a running instance or
+// a network interface, either directly or by an aws_eip_association. The IDs
+// are usually unknown in the plan so the references are checked too.
+func isEIPAttached(d *schema.ResourceData) bool {
+	instances := d.References("instance")
+	networkInterfaces := d.References("network_interface")
+
+	for _, assoc := range d.References("aws_eip_association.allocation_id") {
+		if assoc.Get("instance_id").String() != "" || assoc.Get("network_interface_id").String() != "" {
+			return true
+		}
+		instances = append(instances, assoc.References("instance_id")...)
+		networkInterfaces = append(networkInterfaces, assoc.References("network_interface_id")...)
+	}
+
+	if len(networkInterfaces) > 0 || d.Get("network_interface").String() != "" {
+		return true
+	}
+
+	for _, instance := range instances {
+		// Stopped instances still keep the address, so it's charged as idle
+		if instance.Get("instance_state").String() != "stopped" {
+			return true
+		}
+	}
+
+	return len(instances) == 0 && d.Get("instance").String() != ""
+}
@@ -0,0 +1,16 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// GetEIPAssociationRegistryItem only exists so the associated aws_eip can be
+// found from its allocation_id, the association itself is free.
+func GetEIPAssociationRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_eip_association",
+		NoPrice:             true,
+		Notes:               []string{"Free resource."},
+		ReferenceAttributes: []string{"allocation_id", "instance_id", "network_interface_id"},
+	}
+}
@@ -83,7 +83,9 @@ func NewEKSNodeGroup(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 			launchTemplateRef[0].Set("instance_type", d.Get("instance_types").Array()[0].String())
 		}
 
-		lt := newLaunchTemplate(launchTemplateRef[0].Address, launchTemplateRef[0], u, region, onDemandCount, spotCount)
+		lt := newLaunchTemplate(launchTemplateRef[0].Address, launchTemplateRef[0], u, region, []launchTemplateInstances{
+			{instanceType: launchTemplateRef[0].Get("instance_type").String(), onDemandCount: onDemandCount, spotCount: spotCount},
+		})
 
 		// AutoscalingGroup should show as not supported LaunchTemplate is not supported
 		if lt == nil {
@@ -2,23 +2,33 @@ package aws
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
 
+// searchInstanceTypeRegex matches the instance types of Elasticsearch and
+// OpenSearch domains, e.g. m5.large.elasticsearch or r6g.xlarge.search.
+var searchInstanceTypeRegex = regexp.MustCompile(`^[a-z0-9-]+\.[a-z0-9]+\.(elasticsearch|search)$`)
+
 func GetElasticsearchDomainRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_elasticsearch_domain",
 		RFunc: NewElasticsearchDomain,
+		Notes: []string{
+			"The instance counts are the totals across all availability zones.",
+		},
 	}
 }
 
 func NewElasticsearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	region := d.Get("region").String()
+	return newSearchDomain(d, u, "m4.large.elasticsearch")
+}
 
-	defaultInstanceType := "m4.large.elasticsearch"
+func newSearchDomain(d *schema.ResourceData, u *schema.UsageData, defaultInstanceType string) *schema.Resource {
+	region := d.Get("region").String()
 
 	instanceType := defaultInstanceType
 	instanceCount := int64(1)
@@ -46,6 +56,18 @@ func NewElasticsearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema
 		ebsEnabled = d.Get("ebs_options.0.ebs_enabled").Bool()
 	}
 
+	coldStorageEnabled := d.Get("cluster_config.0.cold_storage_options.0.enabled").Bool()
+
+	for _, t := range []string{instanceType, d.Get("cluster_config.0.dedicated_master_type").String(), d.Get("cluster_config.0.warm_type").String()} {
+		if t != "" && !searchInstanceTypeRegex.MatchString(t) {
+			return &schema.Resource{
+				Name:        d.Address,
+				IsSkipped:   true,
+				SkipMessage: fmt.Sprintf("Unknown instance type %s", t),
+			}
+		}
+	}
+
 	costComponents := []*schema.CostComponent{
 		{
 			Name:           fmt.Sprintf("Instance (on-demand, %s)", instanceType),
@@ -68,11 +90,13 @@ func NewElasticsearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema
 		},
 	}
 
+	// The EBS volume size and IOPS are for each data node
 	if ebsEnabled {
 		gbVal := decimal.NewFromInt(int64(defaultVolumeSize))
 		if d.Get("ebs_options.0.volume_size").Exists() {
 			gbVal = decimal.NewFromFloat(d.Get("ebs_options.0.volume_size").Float())
 		}
+		gbVal = gbVal.Mul(decimal.NewFromInt(instanceCount))
 
 		ebsType := "gp2"
 		if d.Get("ebs_options.0.volume_type").Exists() {
@@ -119,6 +143,7 @@ func NewElasticsearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema
 					iopsVal = decimal.NewFromInt(1)
 				}
 			}
+			iopsVal = iopsVal.Mul(decimal.NewFromInt(instanceCount))
 
 			costComponents = append(costComponents, &schema.CostComponent{
 				Name:            fmt.Sprintf("Storage IOPS (%s)", ebsType),
@@ -200,8 +225,47 @@ func NewElasticsearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema
 		})
 	}
 
+	if ultrawarmEnabled {
+		var ultrawarmStorage *decimal.Decimal
+		if u != nil && u.Get("ultrawarm_storage_gb").Exists() {
+			ultrawarmStorage = decimalPtr(decimal.NewFromFloat(u.Get("ultrawarm_storage_gb").Float()))
+		}
+
+		costComponents = append(costComponents, searchManagedStorageCostComponent(region, "UltraWarm storage", "/ES:UltraWarmStorage/", ultrawarmStorage))
+	}
+
+	if coldStorageEnabled {
+		var coldStorage *decimal.Decimal
+		if u != nil && u.Get("cold_storage_gb").Exists() {
+			coldStorage = decimalPtr(decimal.NewFromFloat(u.Get("cold_storage_gb").Float()))
+		}
+
+		costComponents = append(costComponents, searchManagedStorageCostComponent(region, "Cold storage", "/ES:ColdStorage/", coldStorage))
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
 	}
 }
+
+func searchManagedStorageCostComponent(region string, name string, usageType string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonES"),
+			ProductFamily: strPtr("Elastic Search Volume"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(usageType)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+}
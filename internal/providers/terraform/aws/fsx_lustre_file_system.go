@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// fsxLustreDeploymentOptions are the names of the deployment types in the
+// pricing.
+var fsxLustreDeploymentOptions = map[string]string{
+	"SCRATCH_1":    "Scratch 1",
+	"SCRATCH_2":    "Scratch 2",
+	"PERSISTENT_1": "Persistent 1",
+	"PERSISTENT_2": "Persistent 2",
+}
+
+func GetFSXLustreFSRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_fsx_lustre_file_system",
+		RFunc: NewFSXLustreFS,
+		Notes: []string{
+			"The throughput is included in the storage price, which depends on the per_unit_storage_throughput of persistent file systems.",
+			"Scratch file systems don't have backups.",
+		},
+	}
+}
+
+func NewFSXLustreFS(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	deploymentType := strings.ToUpper(d.Get("deployment_type").String())
+	if deploymentType == "" {
+		deploymentType = "SCRATCH_1"
+	}
+	deploymentOption, ok := fsxLustreDeploymentOptions[deploymentType]
+	if !ok {
+		deploymentOption = fsxLustreDeploymentOptions["SCRATCH_1"]
+	}
+	isPersistent := strings.HasPrefix(deploymentType, "PERSISTENT")
+
+	storageType := strings.ToUpper(d.Get("storage_type").String())
+	if storageType == "" {
+		storageType = "SSD"
+	}
+
+	attributeFilters := []*schema.AttributeFilter{
+		{Key: "fileSystemType", Value: strPtr("Lustre")},
+		{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+		{Key: "storageType", Value: strPtr(storageType)},
+	}
+
+	name := fmt.Sprintf("%s storage (%s)", storageType, strings.ToLower(deploymentOption))
+	if isPersistent {
+		throughput := d.Get("per_unit_storage_throughput").String()
+		attributeFilters = append(attributeFilters, &schema.AttributeFilter{Key: "throughputCapacity", Value: strPtr(throughput)})
+		name = fmt.Sprintf("%s storage (%s, %s MB/s/TiB)", storageType, strings.ToLower(deploymentOption), throughput)
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            name,
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(d.Get("storage_capacity").Int())),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:       strPtr("aws"),
+				Region:           strPtr(region),
+				Service:          strPtr("AmazonFSx"),
+				ProductFamily:    strPtr("Storage"),
+				AttributeFilters: attributeFilters,
+			},
+		},
+	}
+
+	if isPersistent {
+		var backupStorage *decimal.Decimal
+		if u != nil && u.Get("backup_storage_gb").Exists() {
+			backupStorage = decimalPtr(decimal.NewFromInt(u.Get("backup_storage_gb").Int()))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Backup storage",
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: backupStorage,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonFSx"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "fileSystemType", Value: strPtr("Lustre")},
+					{Key: "usagetype", ValueRegex: strPtr("/BackupUsage/")},
+				},
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
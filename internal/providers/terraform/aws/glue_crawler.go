@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+func GetGlueCrawlerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_glue_crawler",
+		RFunc: NewGlueCrawler,
+		Notes: []string{
+			"Crawlers use a variable number of DPUs, so the DPU-hours need to be set in the usage file.",
+		},
+	}
+}
+
+func NewGlueCrawler(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	var dpuHours *decimal.Decimal
+	if u != nil && u.Get("monthly_dpu_hrs").Exists() {
+		dpuHours = decimalPtr(decimal.NewFromFloat(u.Get("monthly_dpu_hrs").Float()))
+	}
+
+	r := &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			glueDPUCostComponent(region, "Duration", "Crawler-DPU-Hour", dpuHours),
+		},
+	}
+
+	if dpuHours == nil {
+		r.UsageMessage = "Set monthly_dpu_hrs in the usage file to the DPU-hours the crawler uses each month"
+	}
+
+	return r
+}
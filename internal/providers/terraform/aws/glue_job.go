@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+// glueWorkerDPUs are the DPUs of each Glue worker type.
+var glueWorkerDPUs = map[string]float64{
+	"standard": 1,
+	"g.025x":   0.25,
+	"g.1x":     1,
+	"g.2x":     2,
+	"g.4x":     4,
+	"g.8x":     8,
+}
+
+func GetGlueJobRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_glue_job",
+		RFunc: NewGlueJob,
+		Notes: []string{
+			"The DPU-hours are the monthly_hrs from the usage file multiplied by the DPUs of the job.",
+		},
+	}
+}
+
+func NewGlueJob(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	name := "ETL jobs"
+	usageType := "ETL-DPU-Hour"
+	if strings.ToLower(d.Get("command.0.name").String()) == "pythonshell" {
+		name = "Python shell jobs"
+		usageType = "PythonShell-DPU-Hour"
+	}
+
+	var dpuHours *decimal.Decimal
+	if u != nil && u.Get("monthly_hrs").Exists() {
+		dpuHours = decimalPtr(decimal.NewFromFloat(u.Get("monthly_hrs").Float()).Mul(glueJobDPUs(d)))
+	}
+
+	r := &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			glueDPUCostComponent(region, name, usageType, dpuHours),
+		},
+	}
+
+	if dpuHours == nil {
+		r.UsageMessage = "Set monthly_hrs in the usage file to the hours the job runs each month"
+	}
+
+	return r
+}
+
+// glueJobDPUs returns the DPUs of the job from its workers or max_capacity,
+// defaulting to the 10 DPUs Glue allocates to Spark jobs.
+func glueJobDPUs(d *schema.ResourceData) decimal.Decimal {
+	if d.Get("number_of_workers").Exists() {
+		dpus, ok := glueWorkerDPUs[strings.ToLower(d.Get("worker_type").String())]
+		if !ok {
+			dpus = 1
+		}
+		return decimal.NewFromFloat(dpus).Mul(decimal.NewFromInt(d.Get("number_of_workers").Int()))
+	}
+
+	if d.Get("max_capacity").Exists() {
+		return decimal.NewFromFloat(d.Get("max_capacity").Float())
+	}
+
+	if strings.ToLower(d.Get("command.0.name").String()) == "pythonshell" {
+		return decimal.NewFromFloat(0.0625)
+	}
+
+	return decimal.NewFromInt(10)
+}
+
+func glueDPUCostComponent(region string, name string, usageType string, dpuHours *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "DPU-hours",
+		UnitMultiplier:  1,
+		MonthlyQuantity: dpuHours,
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr(region),
+			Service:    strPtr("AWSGlue"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/" + usageType + "$/i")},
+			},
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetKinesisStreamRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_kinesis_stream",
+		RFunc: NewKinesisStream,
+	}
+}
+
+func NewKinesisStream(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	retentionPeriod := int64(24)
+	if d.Get("retention_period").Exists() {
+		retentionPeriod = d.Get("retention_period").Int()
+	}
+
+	var storageGB *decimal.Decimal
+	if u != nil && u.Get("storage_gb").Exists() {
+		storageGB = decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float()))
+	}
+
+	var costComponents []*schema.CostComponent
+
+	if strings.EqualFold(d.Get("stream_mode_details.0.stream_mode").String(), "ON_DEMAND") {
+		var dataIngestedGB, dataRetrievedGB *decimal.Decimal
+		if u != nil && u.Get("monthly_data_ingested_gb").Exists() {
+			dataIngestedGB = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_ingested_gb").Float()))
+		}
+		if u != nil && u.Get("monthly_data_retrieved_gb").Exists() {
+			dataRetrievedGB = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_retrieved_gb").Float()))
+		}
+
+		costComponents = []*schema.CostComponent{
+			kinesisCostComponent(region, "Stream hours (on-demand)", "hours", "OnDemand-StreamHour", decimalPtr(decimal.NewFromInt(1)), nil),
+			kinesisCostComponent(region, "Data ingested (on-demand)", "GB", "OnDemand-BilledIncomingBytes", nil, dataIngestedGB),
+			kinesisCostComponent(region, "Data retrieval (on-demand)", "GB", "OnDemand-BilledOutgoingBytes", nil, dataRetrievedGB),
+		}
+
+		if retentionPeriod > 24 {
+			costComponents = append(costComponents, kinesisCostComponent(region, "Extended retention (on-demand)", "GB", "OnDemand-ExtendedRetention-ByteHrs", nil, storageGB))
+		}
+
+		return &schema.Resource{
+			Name:           d.Address,
+			CostComponents: costComponents,
+		}
+	}
+
+	shardCount := decimal.NewFromInt(d.Get("shard_count").Int())
+
+	recordSizeKB := decimal.NewFromInt(25)
+	if u != nil && u.Get("record_size_kb").Exists() {
+		recordSizeKB = decimal.NewFromFloat(u.Get("record_size_kb").Float())
+	}
+
+	var payloadUnits *decimal.Decimal
+	if u != nil && u.Get("monthly_put_records").Exists() {
+		// Each PUT record is billed in 25KB payload units
+		records := decimal.NewFromInt(u.Get("monthly_put_records").Int())
+		payloadUnits = decimalPtr(records.Mul(recordSizeKB.Div(decimal.NewFromInt(25)).Ceil()))
+	}
+
+	costComponents = []*schema.CostComponent{
+		kinesisCostComponent(region, "Shard hours", "hours", "Storage-ShardHour", decimalPtr(shardCount), nil),
+	}
+
+	putPayloadUnits := kinesisCostComponent(region, "PUT payload units", "1M units", "PutRequestPayloadUnits", nil, payloadUnits)
+	putPayloadUnits.UnitMultiplier = 1000000
+	costComponents = append(costComponents, putPayloadUnits)
+
+	if retentionPeriod > 24 {
+		costComponents = append(costComponents, kinesisCostComponent(region, "Extended retention (24h to 7d)", "hours", "Extended-ShardHour", decimalPtr(shardCount), nil))
+	}
+
+	if retentionPeriod > 168 {
+		costComponents = append(costComponents, kinesisCostComponent(region, "Long term retention (7d+)", "GB", "Long-Term-Retention", nil, storageGB))
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func kinesisCostComponent(region, name, unit, usageType string, hourlyQuantity, monthlyQuantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		HourlyQuantity:  hourlyQuantity,
+		MonthlyQuantity: monthlyQuantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr(region),
+			Service:    strPtr("AmazonKinesis"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/" + usageType + "$/")},
+			},
+		},
+	}
+}
@@ -9,7 +9,6 @@ import (
 func GetLambdaFunctionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_lambda_function",
-		Notes: []string{"Provisioned concurrency is not yet supported."},
 		RFunc: NewLambdaFunction,
 	}
 }
@@ -38,22 +37,7 @@ func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 	return &schema.Resource{
 		Name: d.Address,
 		CostComponents: []*schema.CostComponent{
-			{
-				Name:            "Requests",
-				Unit:            "1M requests",
-				UnitMultiplier:  1000000,
-				MonthlyQuantity: monthlyRequests,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AWSLambda"),
-					ProductFamily: strPtr("Serverless"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "group", Value: strPtr("AWS-Lambda-Requests")},
-						{Key: "usagetype", ValueRegex: strPtr("/Request/")},
-					},
-				},
-			},
+			lambdaRequestsCostComponent(region, monthlyRequests),
 			{
 				Name:            "Duration",
 				Unit:            "GB-seconds",
@@ -74,6 +58,25 @@ func NewLambdaFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 	}
 }
 
+func lambdaRequestsCostComponent(region string, monthlyRequests *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Requests",
+		Unit:            "1M requests",
+		UnitMultiplier:  1000000,
+		MonthlyQuantity: monthlyRequests,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AWSLambda"),
+			ProductFamily: strPtr("Serverless"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "group", Value: strPtr("AWS-Lambda-Requests")},
+				{Key: "usagetype", ValueRegex: strPtr("/Request/")},
+			},
+		},
+	}
+}
+
 func calculateGBSeconds(memorySize decimal.Decimal, averageRequestDuration decimal.Decimal, monthlyRequests decimal.Decimal) decimal.Decimal {
 	gb := memorySize.Div(decimal.NewFromInt(1024))
 	seconds := averageRequestDuration.Ceil().Div(decimal.NewFromInt(1000)) // Round up to closest 1ms and convert to seconds
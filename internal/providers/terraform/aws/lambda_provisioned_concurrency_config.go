@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+func GetLambdaProvisionedConcurrencyConfigRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_lambda_provisioned_concurrency_config",
+		RFunc:               NewLambdaProvisionedConcurrencyConfig,
+		ReferenceAttributes: []string{"function_name"},
+	}
+}
+
+func NewLambdaProvisionedConcurrencyConfig(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	memorySize := decimal.NewFromInt(128)
+	functionRefs := d.References("function_name")
+	if len(functionRefs) > 0 && functionRefs[0].Get("memory_size").Exists() {
+		memorySize = decimal.NewFromInt(functionRefs[0].Get("memory_size").Int())
+	}
+
+	executions := decimal.NewFromInt(d.Get("provisioned_concurrent_executions").Int())
+
+	// Provisioned concurrency is charged for the whole month it's configured for
+	monthlySeconds := decimal.NewFromInt(int64(schema.HourToMonthUnitMultiplier * 60 * 60))
+	provisionedGBSeconds := executions.Mul(memorySize.Div(decimal.NewFromInt(1024))).Mul(monthlySeconds)
+
+	averageRequestDuration := decimal.NewFromInt(1)
+	if u != nil && u.Get("request_duration_ms").Exists() {
+		averageRequestDuration = decimal.NewFromFloat(u.Get("request_duration_ms").Float())
+	}
+
+	var monthlyRequests *decimal.Decimal
+	var gbSeconds *decimal.Decimal
+
+	if u != nil && u.Get("monthly_requests").Exists() {
+		monthlyRequests = decimalPtr(decimal.NewFromFloat(u.Get("monthly_requests").Float()))
+		gbSeconds = decimalPtr(calculateGBSeconds(memorySize, averageRequestDuration, *monthlyRequests))
+	}
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Provisioned concurrency",
+				Unit:            "GB-seconds",
+				UnitMultiplier:  1,
+				MonthlyQuantity: &provisionedGBSeconds,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(region),
+					Service:       strPtr("AWSLambda"),
+					ProductFamily: strPtr("Serverless"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "group", Value: strPtr("AWS-Lambda-Provisioned-Concurrency")},
+						{Key: "usagetype", ValueRegex: strPtr("/Lambda-Provisioned-Concurrency/")},
+					},
+				},
+			},
+			lambdaRequestsCostComponent(region, monthlyRequests),
+			{
+				Name:            "Duration",
+				Unit:            "GB-seconds",
+				UnitMultiplier:  1,
+				MonthlyQuantity: gbSeconds,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(region),
+					Service:       strPtr("AWSLambda"),
+					ProductFamily: strPtr("Serverless"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "group", Value: strPtr("AWS-Lambda-Duration-Provisioned")},
+						{Key: "usagetype", ValueRegex: strPtr("/Lambda-Provisioned-GB-Second/")},
+					},
+				},
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/shopspring/decimal"
 )
@@ -20,6 +21,10 @@ func GetALBRegistryItem() *schema.RegistryItem {
 }
 
 func NewLB(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	if isLBIdle(d) {
+		log.Warnf("%s has no listeners so it's idle, it's still charged hourly", d.Address)
+	}
+
 	var maxLCU *decimal.Decimal
 
 	var newConnectionsLCU *decimal.Decimal
@@ -127,3 +132,11 @@ func newLBResource(d *schema.ResourceData, productFamily string, costComponentNa
 		CostComponents: costComponents,
 	}
 }
+
+// isLBIdle checks if the load balancer has no listeners in the plan, so it
+// can't receive any traffic. The listeners are found by their reverse
+// references to the load balancer, since their load_balancer_arn is usually
+// unknown in the plan.
+func isLBIdle(d *schema.ResourceData) bool {
+	return len(d.References("aws_lb_listener.load_balancer_arn")) == 0 && len(d.References("aws_alb_listener.load_balancer_arn")) == 0
+}
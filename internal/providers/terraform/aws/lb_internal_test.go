@@ -0,0 +1,18 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestIsLBIdle(t *testing.T) {
+	lb := schema.NewResourceData("aws_lb", "aws", "aws_lb.lb", nil, gjson.Parse(`{"load_balancer_type": "application"}`))
+	assert.True(t, isLBIdle(lb))
+
+	listener := schema.NewResourceData("aws_alb_listener", "aws", "aws_alb_listener.listener", nil, gjson.Parse(`{}`))
+	lb.AddReference("aws_alb_listener.load_balancer_arn", listener)
+	assert.False(t, isLBIdle(lb))
+}
@@ -0,0 +1,25 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// GetLBListenerRegistryItem only exists so the load balancer can find its
+// listeners to check if it's idle, the listener itself is free.
+func GetLBListenerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_lb_listener",
+		NoPrice:             true,
+		Notes:               []string{"Free resource."},
+		ReferenceAttributes: []string{"load_balancer_arn"},
+	}
+}
+
+func GetALBListenerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "aws_alb_listener",
+		NoPrice:             true,
+		Notes:               []string{"Free resource."},
+		ReferenceAttributes: []string{"load_balancer_arn"},
+	}
+}
@@ -2,13 +2,11 @@ package aws
 
 import (
 	"fmt"
-
-	"github.com/infracost/infracost/internal/schema"
-	log "github.com/sirupsen/logrus"
-
 	"strings"
 
+	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
 )
 
 func GetLightsailInstanceRegistryItem() *schema.RegistryItem {
@@ -20,6 +18,7 @@ func GetLightsailInstanceRegistryItem() *schema.RegistryItem {
 
 func NewLightsailInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
+	bundleID := d.Get("bundle_id").String()
 
 	type bundleSpecs struct {
 		vcpu   string
@@ -34,22 +33,22 @@ func NewLightsailInstance(d *schema.ResourceData, u *schema.UsageData) *schema.R
 		"large":   {"2", "8GB"},
 		"xlarge":  {"4", "16GB"},
 		"2xlarge": {"8", "32GB"},
+		"4xlarge": {"16", "64GB"},
 	}
 
 	operatingSystem := "Linux"
 	operatingSystemLabel := "Linux/UNIX"
 
-	if strings.Contains(d.Get("bundle_id").String(), "_win_") {
+	if strings.Contains(bundleID, "_win_") {
 		operatingSystem = "Windows"
 		operatingSystemLabel = "Windows"
 	}
 
-	bundlePrefix := strings.Split(d.Get("bundle_id").String(), "_")[0]
+	bundlePrefix := strings.Split(bundleID, "_")[0]
 
 	specs, ok := bundlePrefixMappings[bundlePrefix]
 	if !ok {
-		log.Warnf("Skipping resource %s. Unrecognised bundle_id %s", d.Address, d.Get("bundle_id").String())
-		return nil
+		log.Warnf("Unknown bundle_id %s for %s, it can't be priced", bundleID, d.Address)
 	}
 
 	return &schema.Resource{
@@ -57,8 +56,8 @@ func NewLightsailInstance(d *schema.ResourceData, u *schema.UsageData) *schema.R
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:           fmt.Sprintf("Virtual server (%s)", operatingSystemLabel),
-				Unit:           "hours",
-				UnitMultiplier: 1,
+				Unit:           "months",
+				UnitMultiplier: schema.HourToMonthUnitMultiplier,
 				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
 				ProductFilter: &schema.ProductFilter{
 					VendorName:    strPtr("aws"),
@@ -19,42 +19,70 @@ func NewMskCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 
 	brokerNodes := decimal.NewFromInt(d.Get("number_of_broker_nodes").Int())
 	instanceType := d.Get("broker_node_group_info.0.instance_type").String()
-	ebsVolumeSize := decimal.NewFromInt(d.Get("broker_node_group_info.0.ebs_volume_size").Int()).Mul(brokerNodes)
 
-	return &schema.Resource{
-		Name: d.Address,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:           fmt.Sprintf("Instance (%s)", instanceType),
-				Unit:           "hours",
-				UnitMultiplier: 1,
-				HourlyQuantity: &brokerNodes,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonMSK"),
-					ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/i", instanceType))},
-						{Key: "locationType", Value: strPtr("AWS Region")},
-					},
+	// Newer provider versions set the volume size in storage_info instead of
+	// ebs_volume_size
+	volumeSize := d.Get("broker_node_group_info.0.ebs_volume_size").Int()
+	if d.Get("broker_node_group_info.0.storage_info.0.ebs_storage_info.0.volume_size").Exists() {
+		volumeSize = d.Get("broker_node_group_info.0.storage_info.0.ebs_storage_info.0.volume_size").Int()
+	}
+	ebsVolumeSize := decimal.NewFromInt(volumeSize).Mul(brokerNodes)
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Instance (%s)", instanceType),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: &brokerNodes,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonMSK"),
+				ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/i", instanceType))},
+					{Key: "locationType", Value: strPtr("AWS Region")},
 				},
 			},
-			{
-				Name:            "Storage",
-				Unit:            "GB",
-				UnitMultiplier:  1,
-				MonthlyQuantity: decimalPtr(ebsVolumeSize),
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonMSK"),
-					ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "storageFamily", Value: strPtr("GP2")},
-					},
+		},
+		{
+			Name:            "Storage",
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(ebsVolumeSize),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonMSK"),
+				ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "storageFamily", Value: strPtr("GP2")},
 				},
 			},
 		},
 	}
+
+	throughput := d.Get("broker_node_group_info.0.storage_info.0.ebs_storage_info.0.provisioned_throughput.0")
+	if throughput.Get("enabled").Bool() && throughput.Get("volume_throughput").Exists() {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Provisioned storage throughput",
+			Unit:            "MiB/s",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(throughput.Get("volume_throughput").Int()).Mul(brokerNodes)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonMSK"),
+				ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/ProvisionedThroughput/i")},
+				},
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
 }
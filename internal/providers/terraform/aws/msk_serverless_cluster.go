@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetMSKServerlessClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_msk_serverless_cluster",
+		RFunc: NewMSKServerlessCluster,
+		Notes: []string{
+			"The partitions, storage and data transfer are only shown if their usage is set.",
+		},
+	}
+}
+
+func NewMSKServerlessCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	costComponents := []*schema.CostComponent{
+		mskServerlessCostComponent(region, "Cluster usage", "hours", "Cluster", nil, decimalPtr(decimal.NewFromInt(1))),
+	}
+
+	// Serverless clusters are charged for what they use, so these are skipped
+	// unless their usage is set.
+	if u != nil && u.Get("partitions").Exists() {
+		costComponents = append(costComponents, mskServerlessCostComponent(region, "Partitions", "partition-hours", "Partition", decimalPtr(decimal.NewFromInt(u.Get("partitions").Int()*730)), nil))
+	}
+	if u != nil && u.Get("storage_gb").Exists() {
+		costComponents = append(costComponents, mskServerlessCostComponent(region, "Storage", "GB", "Storage", decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float())), nil))
+	}
+	if u != nil && u.Get("monthly_data_in_gb").Exists() {
+		costComponents = append(costComponents, mskServerlessCostComponent(region, "Data in", "GB", "DataIn", decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_in_gb").Float())), nil))
+	}
+	if u != nil && u.Get("monthly_data_out_gb").Exists() {
+		costComponents = append(costComponents, mskServerlessCostComponent(region, "Data out", "GB", "DataOut", decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_out_gb").Float())), nil))
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func mskServerlessCostComponent(region string, name string, unit string, usageType string, monthlyQuantity *decimal.Decimal, hourlyQuantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: monthlyQuantity,
+		HourlyQuantity:  hourlyQuantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonMSK"),
+			ProductFamily: strPtr("Managed Streaming for Apache Kafka (MSK)"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/Serverless." + usageType + "/i")},
+			},
+		},
+	}
+}
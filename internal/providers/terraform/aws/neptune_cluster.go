@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetNeptuneClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_neptune_cluster",
+		RFunc: NewNeptuneCluster,
+		Notes: []string{
+			"The instances of the cluster are priced by their aws_neptune_cluster_instance resources.",
+		},
+	}
+}
+
+func NewNeptuneCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	var storage, ioRequests, backupStorage *decimal.Decimal
+	if u != nil && u.Get("storage_gb").Exists() {
+		storage = decimalPtr(decimal.NewFromInt(u.Get("storage_gb").Int()))
+	}
+
+	if u != nil && u.Get("monthly_io_requests").Exists() {
+		ioRequests = decimalPtr(decimal.NewFromInt(u.Get("monthly_io_requests").Int()))
+	}
+
+	if u != nil && u.Get("backup_storage_gb").Exists() {
+		backupStorage = decimalPtr(decimal.NewFromInt(u.Get("backup_storage_gb").Int()))
+	}
+
+	var usageMessage string
+	if storage == nil || ioRequests == nil {
+		usageMessage = "Storage and I/O costs depend on storage_gb and monthly_io_requests from the usage file"
+	}
+
+	return &schema.Resource{
+		Name:         d.Address,
+		UsageMessage: usageMessage,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Storage",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: storage,
+				ProductFilter:   neptuneProductFilter(region, "Database Storage", "/StorageUsage/"),
+			},
+			{
+				Name:            "I/O",
+				Unit:            "1M requests",
+				UnitMultiplier:  1000000,
+				MonthlyQuantity: ioRequests,
+				ProductFilter:   neptuneProductFilter(region, "System Operation", "/StorageIOUsage/"),
+			},
+			{
+				Name:            "Backup storage",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: backupStorage,
+				ProductFilter:   neptuneProductFilter(region, "Storage Snapshot", "/BackupUsage/"),
+			},
+		},
+	}
+}
+
+func neptuneProductFilter(region, productFamily, usageType string) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:    strPtr("aws"),
+		Region:        strPtr(region),
+		Service:       strPtr("AmazonNeptune"),
+		ProductFamily: strPtr(productFamily),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "usagetype", ValueRegex: strPtr(usageType)},
+		},
+	}
+}
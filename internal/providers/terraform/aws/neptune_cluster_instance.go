@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetNeptuneClusterInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_neptune_cluster_instance",
+		RFunc: NewNeptuneClusterInstance,
+	}
+}
+
+func NewNeptuneClusterInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+	instanceType := d.Get("instance_class").String()
+
+	if instanceType == "db.serverless" {
+		return &schema.Resource{
+			Name:        d.Address,
+			IsSkipped:   true,
+			SkipMessage: "Neptune serverless instances aren't supported",
+		}
+	}
+
+	productFilter := neptuneProductFilter(region, "Database Instance", "/InstanceUsage/")
+	productFilter.AttributeFilters = append(productFilter.AttributeFilters, &schema.AttributeFilter{
+		Key: "instanceType", Value: strPtr(instanceType),
+	})
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           fmt.Sprintf("Database instance (%s, %s)", "on-demand", instanceType),
+				Unit:           "hours",
+				UnitMultiplier: 1,
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter:  productFilter,
+				PriceFilter: &schema.PriceFilter{
+					PurchaseOption: strPtr("on_demand"),
+				},
+			},
+		},
+	}
+}
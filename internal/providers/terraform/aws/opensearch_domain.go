@@ -0,0 +1,19 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetOpenSearchDomainRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_opensearch_domain",
+		RFunc: NewOpenSearchDomain,
+		Notes: []string{
+			"The instance counts are the totals across all availability zones.",
+		},
+	}
+}
+
+func NewOpenSearchDomain(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return newSearchDomain(d, u, "m4.large.search")
+}
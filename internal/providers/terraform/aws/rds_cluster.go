@@ -57,6 +57,16 @@ func NewRDSCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		})
 	}
 
+	// Serverless v2 clusters use the provisioned engine mode with a scaling
+	// configuration and are billed for the ACU-hours used by their db.serverless instances.
+	if d.Get("serverlessv2_scaling_configuration.0").Exists() {
+		var capacityUnits *decimal.Decimal
+		if u != nil && u.Get("capacity_units_per_hr").Exists() {
+			capacityUnits = decimalPtr(decimal.NewFromFloat(u.Get("capacity_units_per_hr").Float()))
+		}
+		costComponents = append(costComponents, auroraServerlessV2CostComponent(region, databaseEngine, capacityUnits))
+	}
+
 	costComponents = append(costComponents, auroraStorageCostComponent(region, u, databaseEngineStorageType)...)
 
 	backupStorageRetention := decimal.NewFromInt(d.Get("backup_retention_period").Int())
@@ -125,6 +135,25 @@ func NewRDSCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 	}
 }
 
+func auroraServerlessV2CostComponent(region string, databaseEngine *string, capacityUnits *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           "Aurora Serverless v2 capacity",
+		Unit:           "ACU hours",
+		UnitMultiplier: 1,
+		HourlyQuantity: capacityUnits,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonRDS"),
+			ProductFamily: strPtr("ServerlessV2"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "databaseEngine", Value: databaseEngine},
+				{Key: "usagetype", ValueRegex: strPtr("/Aurora:ServerlessV2Usage/")},
+			},
+		},
+	}
+}
+
 func auroraStorageCostComponent(region string, u *schema.UsageData, databaseEngineStorageType *string) []*schema.CostComponent {
 	storageGB := decimal.Zero
 	if u != nil && u.Get("storage_gb").Exists() {
@@ -19,6 +19,17 @@ func NewRDSClusterInstance(d *schema.ResourceData, u *schema.UsageData) *schema.
 
 	instanceType := d.Get("instance_class").String()
 
+	// The capacity of Serverless v2 instances is estimated on the aws_rds_cluster
+	// resource using the capacity_units_per_hr usage of all its instances.
+	if instanceType == "db.serverless" {
+		return &schema.Resource{
+			Name:        d.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			SkipMessage: "Aurora Serverless v2 capacity is estimated on the aws_rds_cluster resource.",
+		}
+	}
+
 	var databaseEngine *string
 	switch d.Get("engine").String() {
 	case "aurora", "aurora-mysql", "":
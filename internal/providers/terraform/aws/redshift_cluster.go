@@ -13,6 +13,10 @@ func GetRedshiftClusterRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_redshift_cluster",
 		RFunc: NewRedshiftCluster,
+		Notes: []string{
+			"DC2 and DS2 node prices include their storage, RA3 clusters also pay for managed storage.",
+			"Concurrency scaling and Spectrum are only shown if their usage is set.",
+		},
 	}
 }
 
@@ -21,7 +25,7 @@ func NewRedshiftCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 
 	nodeType := d.Get("node_type").String()
 	numberOfNodes := int64(1)
-	if d.Get("number_of_nodes").Type != gjson.Null {
+	if d.Get("cluster_type").String() != "single-node" && d.Get("number_of_nodes").Type != gjson.Null {
 		numberOfNodes = d.Get("number_of_nodes").Int()
 	}
 
@@ -54,19 +58,19 @@ func NewRedshiftCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 		costComponents = append(costComponents, redshiftManagedStorageCostComponent(region, nodeType, managedStorage))
 	}
 
+	// Concurrency scaling and Spectrum are only charged when they're used, so
+	// they're skipped unless there's usage for them.
 	if strings.HasPrefix(nodeType, "ra3") || strings.HasPrefix(nodeType, "ds2") || strings.HasPrefix(nodeType, "dc2") {
-		var concurrencyScalingSeconds *decimal.Decimal
 		if u != nil && u.Get("excess_concurrency_scaling_secs").Type != gjson.Null {
-			concurrencyScalingSeconds = decimalPtr(decimal.NewFromInt(u.Get("excess_concurrency_scaling_secs").Int()))
+			concurrencyScalingSeconds := decimalPtr(decimal.NewFromInt(u.Get("excess_concurrency_scaling_secs").Int()))
+			costComponents = append(costComponents, redshiftConcurrencyScalingCostComponent(region, nodeType, numberOfNodes, concurrencyScalingSeconds))
 		}
-		costComponents = append(costComponents, redshiftConcurrencyScalingCostComponent(region, nodeType, numberOfNodes, concurrencyScalingSeconds))
 	}
 
-	var terabytesScanned *decimal.Decimal
 	if u != nil && u.Get("spectrum_data_scanned_tb").Type != gjson.Null {
-		terabytesScanned = decimalPtr(decimal.NewFromFloat(u.Get("spectrum_data_scanned_tb").Float()))
+		terabytesScanned := decimalPtr(decimal.NewFromFloat(u.Get("spectrum_data_scanned_tb").Float()))
+		costComponents = append(costComponents, redshiftSpectrumCostComponent(region, terabytesScanned))
 	}
-	costComponents = append(costComponents, redshiftSpectrumCostComponent(region, terabytesScanned))
 
 	if u != nil && u.Get("backup_storage_gb").Type != gjson.Null {
 		storageSnapshotGb := decimalPtr(decimal.NewFromInt(u.Get("backup_storage_gb").Int()))
@@ -6,13 +6,17 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAPIGatewayRestAPIRegistryItem(),
 	GetAPIGatewayStageRegistryItem(),
 	GetAPIGatewayv2ApiRegistryItem(),
+	GetAthenaWorkgroupRegistryItem(),
 	GetAutoscalingGroupRegistryItem(),
 	GetACMCertificate(),
 	GetACMPCACertificateAuthorityRegistryItem(),
+	GetBackupVaultRegistryItem(),
 	GetCloudfrontDistributionRegistryItem(),
 	GetCloudwatchDashboardRegistryItem(),
 	GetCloudwatchEventBusItem(),
+	GetCloudwatchEventRuleItem(),
 	GetCloudwatchLogGroupItem(),
+	GetCloudwatchLogMetricFilterItem(),
 	GetCloudwatchMetricAlarmRegistryItem(),
 	GetCodebuildProjectRegistryItem(),
 	GetConfigRuleItem(),
@@ -40,19 +44,32 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetECSServiceRegistryItem(),
 	GetEFSFileSystemRegistryItem(),
 	GetEIPRegistryItem(),
+	GetEIPAssociationRegistryItem(),
 	GetElastiCacheClusterItem(),
 	GetElastiCacheReplicationGroupItem(),
 	GetElasticsearchDomainRegistryItem(),
 	GetELBRegistryItem(),
+	GetFSXLustreFSRegistryItem(),
 	GetFSXWindowsFSRegistryItem(),
+	GetGlueCrawlerRegistryItem(),
+	GetGlueJobRegistryItem(),
 	GetInstanceRegistryItem(),
+	GetKinesisStreamRegistryItem(),
 	GetLambdaFunctionRegistryItem(),
+	GetLambdaProvisionedConcurrencyConfigRegistryItem(),
 	GetLBRegistryItem(),
+	GetLBListenerRegistryItem(),
+	GetLightsailDiskRegistryItem(),
 	GetLightsailInstanceRegistryItem(),
 	GetMSKClusterRegistryItem(),
+	GetMSKServerlessClusterRegistryItem(),
 	GetALBRegistryItem(),
+	GetALBListenerRegistryItem(),
 	GetMQBrokerRegistryItem(),
 	GetNATGatewayRegistryItem(),
+	GetNeptuneClusterRegistryItem(),
+	GetNeptuneClusterInstanceRegistryItem(),
+	GetOpenSearchDomainRegistryItem(),
 	GetRDSClusterRegistryItem(),
 	GetRDSClusterInstanceRegistryItem(),
 	GetRedshiftClusterRegistryItem(),
@@ -74,8 +91,10 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetNewEKSClusterItem(),
 	GetNewKMSKeyRegistryItem(),
 	GetNewKMSExternalKeyRegistryItem(),
+	GetTransferServerRegistryItem(),
 	GetVPNConnectionRegistryItem(),
 	GetVpcEndpointRegistryItem(),
+	GetWAFv2WebACLRegistryItem(),
 	GetStepFunctionRegistryItem(),
 }
 
@@ -134,6 +153,14 @@ var FreeResources []string = []string{
 	"aws_dx_public_virtual_interface",
 	"aws_dx_transit_virtual_interface",
 
+	// AWS Backup
+	"aws_backup_global_settings",
+	"aws_backup_plan",
+	"aws_backup_region_settings",
+	"aws_backup_selection",
+	"aws_backup_vault_notifications",
+	"aws_backup_vault_policy",
+
 	// AWS Cloudfront
 	"aws_cloudfront_origin_access_identity",
 	"aws_cloudfront_public_key",
@@ -141,14 +168,12 @@ var FreeResources []string = []string{
 	// AWS Cloudwatch
 	"aws_cloudwatch_log_destination",
 	"aws_cloudwatch_log_destination_policy",
-	"aws_cloudwatch_log_metric_filter",
 	"aws_cloudwatch_log_resource_policy",
 	"aws_cloudwatch_log_stream",
 	"aws_cloudwatch_log_subscription_filter",
 
 	// AWS EventBridge
 	"aws_cloudwatch_event_permission",
-	"aws_cloudwatch_event_rule",
 	"aws_cloudwatch_event_target",
 
 	// AWS CodeBuild
@@ -171,12 +196,10 @@ var FreeResources []string = []string{
 	"aws_ecs_capacity_provider",
 
 	// AWS Elastic Load Balancing
-	"aws_alb_listener",
 	"aws_alb_listener_certificate",
 	"aws_alb_listener_rule",
 	"aws_alb_target_group",
 	"aws_alb_target_group_attachment",
-	"aws_lb_listener",
 	"aws_lb_listener_certificate",
 	"aws_lb_listener_rule",
 	"aws_lb_target_group",
@@ -249,11 +272,11 @@ var FreeResources []string = []string{
 	"aws_docdb_subnet_group",
 	"aws_ecs_cluster",
 	"aws_ecs_task_definition",
-	"aws_eip_association",
 	"aws_elasticsearch_domain_policy",
 	"aws_key_pair",
 	"aws_launch_configuration",
 	"aws_launch_template",
+	"aws_lightsail_disk_attachment",
 	"aws_lightsail_domain",
 	"aws_lightsail_key_pair",
 	"aws_lightsail_static_ip",
@@ -336,6 +359,9 @@ var FreeResources []string = []string{
 	"aws_flow_log",
 	"aws_internet_gateway",
 	"aws_main_route_table_association",
+	"aws_neptune_cluster_parameter_group",
+	"aws_neptune_parameter_group",
+	"aws_neptune_subnet_group",
 	"aws_network_acl",
 	"aws_network_acl_rule",
 	"aws_network_interface",
@@ -13,19 +13,152 @@ func GetS3BucketRegistryItem() *schema.RegistryItem {
 		Name: "aws_s3_bucket",
 		Notes: []string{
 			"S3 replication time control data transfer, and batch operations are not supported by Terraform.",
+			"If storage_gb is set the storage of each class is estimated from the transitions of the lifecycle rules, assuming monthly_uploaded_gb is uploaded at a constant rate. The storage_gb of a storage class overrides its estimate.",
 		},
 		RFunc: NewS3Bucket,
 	}
 }
 
+// s3StorageClassUsageKeys are the usage keys of the storage of each storage
+// class.
+var s3StorageClassUsageKeys = map[string]string{
+	"STANDARD":            "standard.storage_gb",
+	"INTELLIGENT_TIERING": "intelligent_tiering.frequent_access_storage_gb",
+	"STANDARD_IA":         "standard_infrequent_access.storage_gb",
+	"ONEZONE_IA":          "one_zone_infrequent_access.storage_gb",
+	"GLACIER":             "glacier.storage_gb",
+	"DEEP_ARCHIVE":        "glacier_deep_archive.storage_gb",
+}
+
 func NewS3Bucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var usageMessage string
+	if !s3HasStorageUsage(u) {
+		usageMessage = "Cost depends on storage_gb and monthly_uploaded_gb, or the storage_gb of each storage class, e.g. standard.storage_gb, from the usage file"
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
+		UsageMessage:   usageMessage,
 		SubResources:   s3SubResources(d, u),
 		CostComponents: s3CostComponents(d, u),
 	}
 }
 
+func s3HasStorageUsage(u *schema.UsageData) bool {
+	if u == nil {
+		return false
+	}
+
+	if u.Get("storage_gb").Exists() || u.Get("monthly_uploaded_gb").Exists() {
+		return true
+	}
+
+	for _, k := range s3StorageClassUsageKeys {
+		if u.Get(k).Exists() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// s3Transition is the storage class that objects are moved to after the days.
+type s3Transition struct {
+	storageClass string
+	days         int64
+}
+
+// s3LifecycleStorage estimates the storage of each storage class from the
+// transitions of the enabled lifecycle rules. Objects are assumed to be
+// uploaded at a constant rate and stay in each class until the next
+// transition, so each class stores the objects of the days they're in it. The
+// rate is monthly_uploaded_gb, or if it isn't set, storage_gb over the days
+// until the objects expire.
+//
+// If storage_gb is set the classes are filled in order until it's used up and
+// the last class has what's left, so without a rate it's all in Standard.
+// Otherwise the last class only has an estimate if the objects expire.
+func s3LifecycleStorage(d *schema.ResourceData, u *schema.UsageData) map[string]*decimal.Decimal {
+	if u == nil || (!u.Get("storage_gb").Exists() && !u.Get("monthly_uploaded_gb").Exists()) {
+		return nil
+	}
+
+	transitionDays := make(map[string]int64)
+	var expirationDays int64
+	for _, rule := range d.Get("lifecycle_rule").Array() {
+		if !rule.Get("enabled").Bool() {
+			continue
+		}
+
+		for _, t := range rule.Get("transition").Array() {
+			storageClass := t.Get("storage_class").String()
+			days := t.Get("days").Int()
+			if existing, ok := transitionDays[storageClass]; !ok || days < existing {
+				transitionDays[storageClass] = days
+			}
+		}
+
+		if days := rule.Get("expiration.0.days").Int(); days > expirationDays {
+			expirationDays = days
+		}
+	}
+
+	transitions := []s3Transition{{storageClass: "STANDARD"}}
+	for storageClass, days := range transitionDays {
+		transitions = append(transitions, s3Transition{storageClass: storageClass, days: days})
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].days == transitions[j].days {
+			return transitions[i].storageClass < transitions[j].storageClass
+		}
+		return transitions[i].days < transitions[j].days
+	})
+
+	var dailyRate *decimal.Decimal
+	if u.Get("monthly_uploaded_gb").Exists() {
+		dailyRate = decimalPtr(decimal.NewFromFloat(u.Get("monthly_uploaded_gb").Float()).Div(decimal.NewFromInt(30)))
+	} else if expirationDays > 0 {
+		dailyRate = decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float()).Div(decimal.NewFromInt(expirationDays)))
+	}
+
+	var remaining *decimal.Decimal
+	if u.Get("storage_gb").Exists() {
+		remaining = decimalPtr(decimal.NewFromFloat(u.Get("storage_gb").Float()))
+	}
+
+	storage := make(map[string]*decimal.Decimal)
+	for i, t := range transitions {
+		isLast := i+1 == len(transitions)
+
+		var estimate *decimal.Decimal
+		if dailyRate != nil && (!isLast || expirationDays > 0) {
+			endDays := expirationDays
+			if !isLast {
+				endDays = transitions[i+1].days
+			}
+
+			days := decimal.NewFromInt(endDays - t.days)
+			if days.IsNegative() {
+				days = decimal.Zero
+			}
+			estimate = decimalPtr(dailyRate.Mul(days))
+		}
+
+		if remaining != nil {
+			if isLast || estimate == nil || estimate.GreaterThan(*remaining) {
+				estimate = remaining
+			}
+			remaining = decimalPtr(remaining.Sub(*estimate))
+		}
+
+		if estimate != nil {
+			storage[t.storageClass] = estimate
+		}
+	}
+
+	return storage
+}
+
 func s3CostComponents(d *schema.ResourceData, u *schema.UsageData) []*schema.CostComponent {
 	region := d.Get("region").String()
 
@@ -69,7 +202,9 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 
 	subResourceMap := make(map[string]*schema.Resource)
 
-	subResourceMap["Standard"] = s3ResourceForStorageClass(region, "STANDARD", u)
+	estimatedStorage := s3LifecycleStorage(d, u)
+
+	subResourceMap["Standard"] = s3ResourceForStorageClass(region, "STANDARD", u, estimatedStorage)
 
 	for _, rule := range d.Get("lifecycle_rule").Array() {
 		if !rule.Get("enabled").Bool() {
@@ -78,7 +213,7 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 		for _, t := range rule.Get("transition").Array() {
 			storageClass := t.Get("storage_class").String()
 			if _, ok := subResourceMap[storageClass]; !ok {
-				s := s3ResourceForStorageClass(region, storageClass, u)
+				s := s3ResourceForStorageClass(region, storageClass, u, estimatedStorage)
 				subResourceMap[s.Name] = s
 			}
 		}
@@ -89,7 +224,7 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 			}
 			storageClass := t.Get("storage_class").String()
 			if _, ok := subResourceMap[storageClass]; !ok {
-				s := s3ResourceForStorageClass(region, storageClass, u)
+				s := s3ResourceForStorageClass(region, storageClass, u, estimatedStorage)
 				if s != nil {
 					subResourceMap[s.Name] = s
 				}
@@ -100,31 +235,31 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 	if u != nil {
 		if subResourceMap["Intelligent tiering"] == nil {
 			if u.Get("intelligent_tiering.frequent_access_storage_gb").Exists() {
-				subResourceMap["Intelligent tiering"] = s3ResourceForStorageClass(region, "INTELLIGENT_TIERING", u)
+				subResourceMap["Intelligent tiering"] = s3ResourceForStorageClass(region, "INTELLIGENT_TIERING", u, estimatedStorage)
 			}
 		}
 
 		if subResourceMap["Standard - infrequent access"] == nil {
 			if u.Get("standard_infrequent_access.storage_gb").Exists() {
-				subResourceMap["Standard - infrequent access"] = s3ResourceForStorageClass(region, "STANDARD_IA", u)
+				subResourceMap["Standard - infrequent access"] = s3ResourceForStorageClass(region, "STANDARD_IA", u, estimatedStorage)
 			}
 		}
 
 		if subResourceMap["One zone - infrequent access"] == nil {
 			if u.Get("one_zone_infrequent_access.storage_gb").Exists() {
-				subResourceMap["One zone - infrequent access"] = s3ResourceForStorageClass(region, "ONEZONE_IA", u)
+				subResourceMap["One zone - infrequent access"] = s3ResourceForStorageClass(region, "ONEZONE_IA", u, estimatedStorage)
 			}
 		}
 
 		if subResourceMap["Glacier"] == nil {
 			if u.Get("glacier.storage_gb").Exists() {
-				subResourceMap["Glacier"] = s3ResourceForStorageClass(region, "GLACIER", u)
+				subResourceMap["Glacier"] = s3ResourceForStorageClass(region, "GLACIER", u, estimatedStorage)
 			}
 		}
 
 		if subResourceMap["Glacier deep archive"] == nil {
 			if u.Get("glacier_deep_archive.storage_gb").Exists() {
-				subResourceMap["Glacier deep archive"] = s3ResourceForStorageClass(region, "DEEP_ARCHIVE", u)
+				subResourceMap["Glacier deep archive"] = s3ResourceForStorageClass(region, "DEEP_ARCHIVE", u, estimatedStorage)
 			}
 		}
 	}
@@ -142,12 +277,14 @@ func s3SubResources(d *schema.ResourceData, u *schema.UsageData) []*schema.Resou
 	return subResources
 }
 
-func s3ResourceForStorageClass(region string, storageClass string, u *schema.UsageData) *schema.Resource {
+func s3ResourceForStorageClass(region string, storageClass string, u *schema.UsageData, estimatedStorage map[string]*decimal.Decimal) *schema.Resource {
 	switch storageClass {
 	case "STANDARD":
 		var dataStorage *decimal.Decimal
 		if u != nil && u.Get("standard.storage_gb").Exists() {
 			dataStorage = decimalPtr(decimal.NewFromInt(u.Get("standard.storage_gb").Int()))
+		} else {
+			dataStorage = estimatedStorage["STANDARD"]
 		}
 
 		var pcplRequests *decimal.Decimal
@@ -170,10 +307,15 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 			dataReturned = decimalPtr(decimal.NewFromInt(u.Get("standard.monthly_select_data_returned_gb").Int()))
 		}
 
+		// Standard storage is cheaper over 50 TB and 500 TB
+		storage := s3StorageVolumeTypeCostComponent("Storage", "AmazonS3", region, "TimedStorage-ByteHrs", "Standard", dataStorage)
+		storage.PriceFilter = nil
+		storage.TieredPricing = true
+
 		return &schema.Resource{
 			Name: "Standard",
 			CostComponents: []*schema.CostComponent{
-				s3StorageVolumeTypeCostComponent("Storage", "AmazonS3", region, "TimedStorage-ByteHrs", "Standard", dataStorage),
+				storage,
 				s3ApiCostComponent("PUT, COPY, POST, LIST requests", "AmazonS3", region, "Requests-Tier1", pcplRequests),
 				s3ApiCostComponent("GET, SELECT, and all other requests", "AmazonS3", region, "Requests-Tier2", allOtherRequests),
 				s3DataGroupCostComponent("Select data scanned", "AmazonS3", region, "Select-Scanned-Bytes", "S3-API-Select-Scanned", dataScanned),
@@ -184,6 +326,8 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 		var frequentDataStorage *decimal.Decimal
 		if u != nil && u.Get("intelligent_tiering.frequent_access_storage_gb").Exists() {
 			frequentDataStorage = decimalPtr(decimal.NewFromInt(u.Get("intelligent_tiering.frequent_access_storage_gb").Int()))
+		} else {
+			frequentDataStorage = estimatedStorage["INTELLIGENT_TIERING"]
 		}
 
 		var infrequentDataStorage *decimal.Decimal
@@ -244,6 +388,8 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 		var dataStorage *decimal.Decimal
 		if u != nil && u.Get("standard_infrequent_access.storage_gb").Exists() {
 			dataStorage = decimalPtr(decimal.NewFromInt(u.Get("standard_infrequent_access.storage_gb").Int()))
+		} else {
+			dataStorage = estimatedStorage["STANDARD_IA"]
 		}
 
 		var pcplRequests *decimal.Decimal
@@ -292,6 +438,8 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 		var dataStorage *decimal.Decimal
 		if u != nil && u.Get("one_zone_infrequent_access.storage_gb").Exists() {
 			dataStorage = decimalPtr(decimal.NewFromInt(u.Get("one_zone_infrequent_access.storage_gb").Int()))
+		} else {
+			dataStorage = estimatedStorage["ONEZONE_IA"]
 		}
 
 		var pcplRequests *decimal.Decimal
@@ -340,6 +488,8 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 		var dataStorage *decimal.Decimal
 		if u != nil && u.Get("glacier.storage_gb").Exists() {
 			dataStorage = decimalPtr(decimal.NewFromInt(u.Get("glacier.storage_gb").Int()))
+		} else {
+			dataStorage = estimatedStorage["GLACIER"]
 		}
 
 		var pcplRequests *decimal.Decimal
@@ -448,6 +598,8 @@ func s3ResourceForStorageClass(region string, storageClass string, u *schema.Usa
 		var dataStorage *decimal.Decimal
 		if u != nil && u.Get("glacier_deep_archive.storage_gb").Exists() {
 			dataStorage = decimalPtr(decimal.NewFromInt(u.Get("glacier_deep_archive.storage_gb").Int()))
+		} else {
+			dataStorage = estimatedStorage["DEEP_ARCHIVE"]
 		}
 
 		var pcplRequests *decimal.Decimal
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestS3LifecycleStorage(t *testing.T) {
+	t.Parallel()
+
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", nil, gjson.Parse(`{
+		"lifecycle_rule": [{
+			"enabled": true,
+			"transition": [
+				{"days": 90, "storage_class": "GLACIER"},
+				{"days": 30, "storage_class": "STANDARD_IA"}
+			],
+			"expiration": [{"days": 360}]
+		}]
+	}`))
+
+	tests := []struct {
+		usage    map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			usage:    map[string]interface{}{"monthly_uploaded_gb": 300},
+			expected: map[string]string{"STANDARD": "300", "STANDARD_IA": "600", "GLACIER": "2700"},
+		},
+		{
+			usage:    map[string]interface{}{"storage_gb": 1000, "monthly_uploaded_gb": 300},
+			expected: map[string]string{"STANDARD": "300", "STANDARD_IA": "600", "GLACIER": "100"},
+		},
+		{
+			usage:    map[string]interface{}{"storage_gb": 3600},
+			expected: map[string]string{"STANDARD": "300", "STANDARD_IA": "600", "GLACIER": "2700"},
+		},
+	}
+
+	for _, test := range tests {
+		u := schema.NewUsageData("aws_s3_bucket.bucket", schema.ParseAttributes(test.usage))
+
+		actual := make(map[string]string)
+		for storageClass, storage := range s3LifecycleStorage(d, u) {
+			actual[storageClass] = storage.String()
+		}
+		assert.Equal(t, test.expected, actual)
+	}
+
+	assert.Nil(t, s3LifecycleStorage(d, nil))
+
+	// Without expiration or an upload rate the storage is all in Standard
+	d = schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.bucket", nil, gjson.Parse(`{
+		"lifecycle_rule": [{"enabled": true, "transition": [{"days": 30, "storage_class": "STANDARD_IA"}]}]
+	}`))
+	u := schema.NewUsageData("aws_s3_bucket.bucket", schema.ParseAttributes(map[string]interface{}{"storage_gb": 500}))
+	storage := s3LifecycleStorage(d, u)
+	assert.Equal(t, "500", storage["STANDARD"].String())
+	assert.Equal(t, "0", storage["STANDARD_IA"].String())
+}
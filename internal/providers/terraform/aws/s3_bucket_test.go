@@ -14,3 +14,12 @@ func TestS3BucketGoldenFile(t *testing.T) {
 
 	tftest.GoldenFileResourceTests(t, "s3_bucket_test")
 }
+
+func TestS3BucketLifecycleGoldenFile(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTests(t, "s3_bucket_lifecycle_test")
+}
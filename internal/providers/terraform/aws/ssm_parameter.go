@@ -28,9 +28,12 @@ func NewSSMParameter(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 		costComponents = append(costComponents, apiThroughput)
 	}
 	if len(costComponents) == 0 {
+		// Standard parameters with standard throughput are free
 		return &schema.Resource{
-			NoPrice:   true,
-			IsSkipped: true,
+			Name:        d.Address,
+			NoPrice:     true,
+			IsSkipped:   true,
+			SkipMessage: "Free resource.",
 		}
 	}
 
@@ -47,7 +50,7 @@ func parameterStorageCostComponent(d *schema.ResourceData, u *schema.UsageData)
 	if d.Get("tier").Exists() {
 		tier = d.Get("tier").String()
 	}
-	if tier == "Standard" {
+	if strings.EqualFold(tier, "Standard") {
 		// Standard is free
 		return nil
 	}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/shopspring/decimal"
@@ -11,6 +13,9 @@ func GetStepFunctionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_sfn_state_machine",
 		RFunc: NewStepFunction,
+		Notes: []string{
+			"The type selects the pricing: Standard workflows are charged per state transition, Express workflows per request and GB-second.",
+		},
 	}
 }
 
@@ -22,7 +27,7 @@ func NewStepFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 
 	tier := "STANDARD"
 	if d.Get("type").Type != gjson.Null {
-		tier = d.Get("type").String()
+		tier = strings.ToUpper(d.Get("type").String())
 	}
 
 	if tier == "STANDARD" {
@@ -61,17 +66,25 @@ func NewStepFunction(d *schema.ResourceData, u *schema.UsageData) *schema.Resour
 			costComponents = append(costComponents, stepFunctionExpressDurationCostComponent("Duration (first 1K)", region, "0", unknown))
 		}
 	}
-	return &schema.Resource{
+	r := &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
 	}
+
+	if tier == "STANDARD" && transitions == nil {
+		r.UsageMessage = "Set monthly_transitions in the usage file to estimate the Standard workflow"
+	} else if tier == "EXPRESS" && gbSeconds == nil {
+		r.UsageMessage = "Set monthly_requests, workflow_duration_ms and memory_mb in the usage file to estimate the Express workflow"
+	}
+
+	return r
 }
 
 func stepFunctionStandardCostComponent(region string, quantity *decimal.Decimal) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:            "Transitions",
 		Unit:            "1K transitions",
-		UnitMultiplier:  2,
+		UnitMultiplier:  1000,
 		MonthlyQuantity: quantity,
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("aws"),
@@ -24,3 +24,9 @@ resource "aws_api_gateway_stage" "cache_2" {
   cache_cluster_enabled = true
   cache_cluster_size    = 237
 }
+
+resource "aws_api_gateway_stage" "no_cache" {
+  rest_api_id   = "api-id-3"
+  stage_name    = "no-cache-stage"
+  deployment_id = "deployment-id-3"
+}
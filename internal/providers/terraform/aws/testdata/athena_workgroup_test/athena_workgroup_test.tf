@@ -0,0 +1,27 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+
+resource "aws_athena_workgroup" "workgroup" {
+  name = "workgroup"
+}
+
+resource "aws_athena_workgroup" "workgroup_withUsage" {
+  name = "workgroup_withUsage"
+
+  configuration {
+    enforce_workgroup_configuration = true
+
+    result_configuration {
+      output_location = "s3://bucket/results/"
+    }
+  }
+}
@@ -179,6 +179,44 @@ resource "aws_autoscaling_group" "asg_lt_basic" {
   min_size         = 1
 }
 
+resource "aws_launch_template" "lt_desired_capacity" {
+  image_id      = "fake_ami"
+  instance_type = "t2.medium"
+
+  block_device_mappings {
+    device_name = "xvdf"
+    ebs {
+      volume_size = 30
+    }
+  }
+}
+
+resource "aws_autoscaling_group" "asg_lt_desired_capacity" {
+  launch_template {
+    id = aws_launch_template.lt_desired_capacity.id
+  }
+  desired_capacity = 3
+  max_size         = 5
+  min_size         = 1
+}
+
+resource "aws_autoscaling_group" "asg_lt_min_size" {
+  launch_template {
+    id = aws_launch_template.lt_desired_capacity.id
+  }
+  max_size = 4
+  min_size = 2
+}
+
+resource "aws_autoscaling_group" "asg_lt_capacity_basis_max" {
+  launch_template {
+    id = aws_launch_template.lt_desired_capacity.id
+  }
+  desired_capacity = 1
+  max_size         = 4
+  min_size         = 1
+}
+
 resource "aws_launch_template" "lt_tenancy_dedicated" {
   image_id      = "fake_ami"
   instance_type = "m3.medium"
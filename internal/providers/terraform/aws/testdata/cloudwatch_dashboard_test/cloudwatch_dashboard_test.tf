@@ -50,3 +50,9 @@ resource "aws_cloudwatch_dashboard" "dashboard" {
 }
 EOF
 }
+
+resource "aws_cloudwatch_dashboard" "count" {
+  count          = 2
+  dashboard_name = "dashboard-${count.index}"
+  dashboard_body = jsonencode({ widgets = [] })
+}
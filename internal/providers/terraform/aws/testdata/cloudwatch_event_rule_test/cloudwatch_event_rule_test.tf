@@ -0,0 +1,21 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+
+resource "aws_cloudwatch_event_rule" "rule" {
+  name          = "rule"
+  event_pattern = jsonencode({ source = ["aws.ec2"] })
+}
+
+resource "aws_cloudwatch_event_rule" "orders_withUsage" {
+  name          = "orders"
+  event_pattern = jsonencode({ source = ["my.orders"] })
+}
@@ -18,3 +18,35 @@ resource "aws_cloudwatch_log_group" "logs_count_withUsage" {
   count = 3
   name  = "log-group${count.index}"
 }
+
+resource "aws_cloudwatch_log_group" "logs_retention_withUsage" {
+  name              = "log-group-retention"
+  retention_in_days = 14
+}
+
+resource "aws_cloudwatch_log_metric_filter" "errors" {
+  name           = "errors"
+  pattern        = "ERROR"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "ErrorCount"
+    namespace = "App"
+    value     = "1"
+  }
+}
+
+resource "aws_cloudwatch_log_metric_filter" "latency_withUsage" {
+  name           = "latency"
+  pattern        = "[ip, user, latency]"
+  log_group_name = aws_cloudwatch_log_group.logs.name
+
+  metric_transformation {
+    name      = "Latency"
+    namespace = "App"
+    value     = "$latency"
+    dimensions = {
+      User = "$user"
+    }
+  }
+}
@@ -23,3 +23,18 @@ resource "aws_docdb_cluster_instance" "large" {
   cluster_identifier = "fake123"
   instance_class     = "db.r5.4xlarge"
 }
+
+resource "aws_docdb_cluster" "cluster" {
+  cluster_identifier      = "docdb-cluster"
+  engine                  = "docdb"
+  master_username         = "foo"
+  master_password         = "mustbeeightchars"
+  backup_retention_period = 7
+}
+
+resource "aws_docdb_cluster_instance" "cluster_instances" {
+  count              = 2
+  identifier         = "docdb-cluster-${count.index}"
+  cluster_identifier = aws_docdb_cluster.cluster.id
+  instance_class     = "db.r5.large"
+}
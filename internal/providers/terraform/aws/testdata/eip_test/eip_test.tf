@@ -10,3 +10,19 @@ provider "aws" {
 }
 
 resource "aws_eip" "eip1" {}
+
+resource "aws_instance" "web" {
+  ami           = "fake_ami"
+  instance_type = "t2.medium"
+}
+
+resource "aws_eip" "attached" {
+  instance = aws_instance.web.id
+}
+
+resource "aws_eip" "associated" {}
+
+resource "aws_eip_association" "associated" {
+  allocation_id = aws_eip.associated.id
+  instance_id   = aws_instance.web.id
+}
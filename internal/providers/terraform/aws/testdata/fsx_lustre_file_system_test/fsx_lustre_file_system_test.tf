@@ -0,0 +1,31 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_fsx_lustre_file_system" "scratch" {
+  storage_capacity = 1200
+  subnet_ids       = ["subnet-123"]
+  deployment_type  = "SCRATCH_2"
+}
+
+resource "aws_fsx_lustre_file_system" "persistent_withUsage" {
+  storage_capacity            = 2400
+  subnet_ids                  = ["subnet-123"]
+  deployment_type             = "PERSISTENT_1"
+  per_unit_storage_throughput = 200
+}
+
+resource "aws_fsx_lustre_file_system" "persistent_hdd" {
+  storage_capacity            = 6000
+  subnet_ids                  = ["subnet-123"]
+  deployment_type             = "PERSISTENT_1"
+  storage_type                = "HDD"
+  per_unit_storage_throughput = 12
+}
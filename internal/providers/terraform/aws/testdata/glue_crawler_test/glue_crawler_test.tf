@@ -0,0 +1,31 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+
+resource "aws_glue_crawler" "crawler" {
+  database_name = "db"
+  name          = "crawler"
+  role          = "arn:aws:iam::123456789012:role/glue"
+
+  s3_target {
+    path = "s3://bucket/data"
+  }
+}
+
+resource "aws_glue_crawler" "crawler_withUsage" {
+  database_name = "db"
+  name          = "crawler_withUsage"
+  role          = "arn:aws:iam::123456789012:role/glue"
+
+  s3_target {
+    path = "s3://bucket/data"
+  }
+}
@@ -0,0 +1,43 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+
+resource "aws_glue_job" "etl" {
+  name     = "etl"
+  role_arn = "arn:aws:iam::123456789012:role/glue"
+
+  command {
+    script_location = "s3://bucket/etl.py"
+  }
+}
+
+resource "aws_glue_job" "etl_withUsage" {
+  name              = "etl"
+  role_arn          = "arn:aws:iam::123456789012:role/glue"
+  glue_version      = "3.0"
+  worker_type       = "G.2X"
+  number_of_workers = 5
+
+  command {
+    script_location = "s3://bucket/etl.py"
+  }
+}
+
+resource "aws_glue_job" "python_shell_withUsage" {
+  name         = "python_shell"
+  role_arn     = "arn:aws:iam::123456789012:role/glue"
+  max_capacity = 1
+
+  command {
+    name            = "pythonshell"
+    script_location = "s3://bucket/shell.py"
+  }
+}
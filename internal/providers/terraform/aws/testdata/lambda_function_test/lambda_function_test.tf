@@ -30,3 +30,10 @@ resource "aws_lambda_function" "lambda_withUsage512Mem" {
   runtime       = "nodejs12.x"
   memory_size   = 512
 }
+
+resource "aws_lambda_function" "lambda_zeroUsage" {
+  function_name = "lambda_function_name"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs12.x"
+}
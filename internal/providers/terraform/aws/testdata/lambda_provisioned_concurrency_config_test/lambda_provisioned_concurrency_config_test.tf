@@ -0,0 +1,31 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_lambda_function" "lambda" {
+  function_name = "lambda_function_name"
+  role          = "arn:aws:lambda:us-east-1:account-id:resource-id"
+  handler       = "exports.test"
+  runtime       = "nodejs12.x"
+  memory_size   = 1024
+  publish       = true
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "lambda" {
+  function_name                     = aws_lambda_function.lambda.function_name
+  provisioned_concurrent_executions = 2
+  qualifier                         = aws_lambda_function.lambda.version
+}
+
+resource "aws_lambda_provisioned_concurrency_config" "lambda_withUsage" {
+  function_name                     = aws_lambda_function.lambda.function_name
+  provisioned_concurrent_executions = 2
+  qualifier                         = aws_lambda_function.lambda.version
+}
@@ -21,4 +21,29 @@ resource "aws_lightsail_instance" "win1" {
   availability_zone = "us-east-1a"
   blueprint_id      = "windows_2019"
   bundle_id         = "small_win_2_0"
-}
\ No newline at end of file
+}
+resource "aws_lightsail_instance" "medium" {
+  name              = "medium"
+  availability_zone = "us-east-1a"
+  blueprint_id      = "amazon_linux_2"
+  bundle_id         = "medium_2_0"
+}
+
+resource "aws_lightsail_disk" "medium" {
+  name              = "medium"
+  availability_zone = "us-east-1a"
+  size_in_gb        = 64
+}
+
+resource "aws_lightsail_disk_attachment" "medium" {
+  disk_name     = aws_lightsail_disk.medium.name
+  instance_name = aws_lightsail_instance.medium.name
+  disk_path     = "/dev/xvdf"
+}
+
+resource "aws_lightsail_instance" "unknown_bundle" {
+  name              = "unknown"
+  availability_zone = "us-east-1a"
+  blueprint_id      = "amazon_linux_2"
+  bundle_id         = "huge_2_0"
+}
@@ -31,4 +31,23 @@ resource "aws_msk_cluster" "cluster-4-nodes" {
     instance_type   = "kafka.m5.24xlarge"
     security_groups = []
   }
-}
\ No newline at end of file
+}
+resource "aws_msk_cluster" "cluster-3-nodes-provisioned-throughput" {
+  cluster_name           = "cluster-3-nodes-provisioned-throughput"
+  kafka_version          = "3.2.0"
+  number_of_broker_nodes = 3
+  broker_node_group_info {
+    client_subnets  = []
+    instance_type   = "kafka.m5.4xlarge"
+    security_groups = []
+    storage_info {
+      ebs_storage_info {
+        volume_size = 2000
+        provisioned_throughput {
+          enabled           = true
+          volume_throughput = 250
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,44 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_msk_serverless_cluster" "serverless" {
+  cluster_name = "serverless"
+
+  vpc_config {
+    subnet_ids         = ["subnet-12345678"]
+    security_group_ids = ["sg-12345678"]
+  }
+
+  client_authentication {
+    sasl {
+      iam {
+        enabled = true
+      }
+    }
+  }
+}
+
+resource "aws_msk_serverless_cluster" "serverless_withUsage" {
+  cluster_name = "serverless-with-usage"
+
+  vpc_config {
+    subnet_ids         = ["subnet-12345678"]
+    security_group_ids = ["sg-12345678"]
+  }
+
+  client_authentication {
+    sasl {
+      iam {
+        enabled = true
+      }
+    }
+  }
+}
@@ -0,0 +1,35 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_neptune_cluster" "cluster" {
+  cluster_identifier                  = "neptune-cluster"
+  engine                              = "neptune"
+  backup_retention_period             = 5
+  skip_final_snapshot                 = true
+  iam_database_authentication_enabled = true
+}
+
+resource "aws_neptune_cluster_instance" "instance" {
+  cluster_identifier = aws_neptune_cluster.cluster.id
+  engine             = "neptune"
+  instance_class     = "db.r5.large"
+}
+
+resource "aws_neptune_cluster" "without_usage" {
+  cluster_identifier  = "neptune-without-usage"
+  engine              = "neptune"
+  skip_final_snapshot = true
+}
+
+resource "aws_neptune_cluster_instance" "without_usage" {
+  cluster_identifier = aws_neptune_cluster.without_usage.id
+  instance_class     = "db.t3.medium"
+}
@@ -0,0 +1,82 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_opensearch_domain" "multi_az" {
+  domain_name    = "multi-az"
+  engine_version = "OpenSearch_1.3"
+
+  cluster_config {
+    instance_type            = "r6g.large.search"
+    instance_count           = 3
+    zone_awareness_enabled   = true
+    dedicated_master_enabled = true
+    dedicated_master_type    = "m6g.large.search"
+    dedicated_master_count   = 3
+    warm_enabled             = true
+    warm_count               = 2
+    warm_type                = "ultrawarm1.medium.search"
+
+    zone_awareness_config {
+      availability_zone_count = 3
+    }
+
+    cold_storage_options {
+      enabled = true
+    }
+  }
+
+  ebs_options {
+    ebs_enabled = true
+    volume_size = 100
+    volume_type = "gp2"
+  }
+}
+
+resource "aws_opensearch_domain" "multi_az_withUsage" {
+  domain_name    = "multi-az-with-usage"
+  engine_version = "OpenSearch_1.3"
+
+  cluster_config {
+    instance_type            = "r6g.large.search"
+    instance_count           = 3
+    zone_awareness_enabled   = true
+    dedicated_master_enabled = true
+    dedicated_master_type    = "m6g.large.search"
+    dedicated_master_count   = 3
+    warm_enabled             = true
+    warm_count               = 2
+    warm_type                = "ultrawarm1.medium.search"
+
+    zone_awareness_config {
+      availability_zone_count = 3
+    }
+
+    cold_storage_options {
+      enabled = true
+    }
+  }
+
+  ebs_options {
+    ebs_enabled = true
+    volume_size = 100
+    volume_type = "gp2"
+  }
+}
+
+resource "aws_opensearch_domain" "unknown_instance_type" {
+  domain_name    = "unknown-instance-type"
+  engine_version = "OpenSearch_1.3"
+
+  cluster_config {
+    instance_type  = "r6g.large"
+    instance_count = 1
+  }
+}
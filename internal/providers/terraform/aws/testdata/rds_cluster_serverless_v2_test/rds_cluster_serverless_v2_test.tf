@@ -0,0 +1,59 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  skip_get_ec2_platforms      = true
+  skip_region_validation      = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_rds_cluster" "mysql_serverless_v2" {
+  cluster_identifier = "aurora-mysql-serverless-v2"
+  engine             = "aurora-mysql"
+  engine_mode        = "provisioned"
+  engine_version     = "8.0.mysql_aurora.3.02.0"
+  master_username    = "foo"
+  master_password    = "barbut8chars"
+
+  serverlessv2_scaling_configuration {
+    min_capacity = 0.5
+    max_capacity = 16
+  }
+}
+
+resource "aws_rds_cluster_instance" "mysql_serverless_v2" {
+  cluster_identifier = aws_rds_cluster.mysql_serverless_v2.id
+  instance_class     = "db.serverless"
+  engine             = aws_rds_cluster.mysql_serverless_v2.engine
+  engine_version     = aws_rds_cluster.mysql_serverless_v2.engine_version
+}
+
+resource "aws_rds_cluster" "postgres_serverless_v2" {
+  cluster_identifier = "aurora-postgres-serverless-v2"
+  engine             = "aurora-postgresql"
+  engine_mode        = "provisioned"
+  engine_version     = "13.6"
+  master_username    = "foo"
+  master_password    = "barbut8chars"
+
+  serverlessv2_scaling_configuration {
+    min_capacity = 2
+    max_capacity = 32
+  }
+}
+
+resource "aws_rds_cluster" "postgres_serverless_v2_without_usage" {
+  cluster_identifier = "aurora-postgres-serverless-v2-without-usage"
+  engine             = "aurora-postgresql"
+  engine_mode        = "provisioned"
+  engine_version     = "13.6"
+  master_username    = "foo"
+  master_password    = "barbut8chars"
+
+  serverlessv2_scaling_configuration {
+    min_capacity = 0.5
+    max_capacity = 8
+  }
+}
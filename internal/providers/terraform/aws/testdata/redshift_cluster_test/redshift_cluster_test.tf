@@ -45,4 +45,23 @@ resource "aws_redshift_cluster" "manageda_withUsage" {
   master_password    = "Mustbe8characters"
   node_type          = "ra3.16xlarge"
   cluster_type       = "single-node"
-}
\ No newline at end of file
+}
+
+resource "aws_redshift_cluster" "ra3_two_node" {
+  cluster_identifier = "tf-ra3-two-node-cluster"
+  database_name      = "mydb"
+  master_username    = "foo"
+  master_password    = "Mustbe8characters"
+  node_type          = "ra3.4xlarge"
+  cluster_type       = "multi-node"
+  number_of_nodes    = 2
+}
+
+resource "aws_redshift_cluster" "dc2_single_node" {
+  cluster_identifier = "tf-dc2-single-node-cluster"
+  database_name      = "mydb"
+  master_username    = "foo"
+  master_password    = "Mustbe8characters"
+  node_type          = "dc2.large"
+  cluster_type       = "single-node"
+}
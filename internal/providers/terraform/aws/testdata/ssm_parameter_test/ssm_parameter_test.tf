@@ -22,3 +22,9 @@ resource "aws_ssm_parameter" "ssm_parameter_advancedWithUsage" {
   value = "Advanced Parameter"
   tier  = "Advanced"
 }
+
+resource "aws_ssm_parameter" "ssm_parameter_standard" {
+  name  = "my-standard-ssm-parameter"
+  type  = "String"
+  value = "Standard Parameter"
+}
@@ -9,6 +9,24 @@ provider "aws" {
   secret_key                  = "mock_secret_key"
 }
 
+resource "aws_subnet" "a" {
+  vpc_id            = "vpc-123456"
+  cidr_block        = "10.0.1.0/24"
+  availability_zone = "us-east-1a"
+}
+
+resource "aws_subnet" "b" {
+  vpc_id            = "vpc-123456"
+  cidr_block        = "10.0.2.0/24"
+  availability_zone = "us-east-1b"
+}
+
+resource "aws_vpc_endpoint" "gateway" {
+  service_name      = "com.amazonaws.us-east-1.s3"
+  vpc_id            = "vpc-123456"
+  vpc_endpoint_type = "Gateway"
+}
+
 resource "aws_vpc_endpoint" "interface" {
   service_name      = "com.amazonaws.region.ec2"
   vpc_id            = "vpc-123456"
@@ -32,7 +50,7 @@ resource "aws_vpc_endpoint" "multiple_interfaces" {
   vpc_id            = "vpc-123456"
   vpc_endpoint_type = "Interface"
   subnet_ids = [
-    "subnet-123456",
-    "subnet-654321"
+    aws_subnet.a.id,
+    aws_subnet.b.id,
   ]
 }
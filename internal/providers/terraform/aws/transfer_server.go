@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+
+	"github.com/shopspring/decimal"
+)
+
+func GetTransferServerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_transfer_server",
+		RFunc: NewTransferServer,
+	}
+}
+
+func NewTransferServer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	protocols := make([]string, 0)
+	for _, p := range d.Get("protocols").Array() {
+		protocols = append(protocols, strings.ToUpper(p.String()))
+	}
+
+	// SFTP is the default protocol if none are set
+	if len(protocols) == 0 {
+		protocols = append(protocols, "SFTP")
+	}
+
+	costComponents := make([]*schema.CostComponent, 0, len(protocols)+2)
+
+	// Each enabled protocol is charged by the hour, even if it's not used
+	for _, protocol := range protocols {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("%s protocol enabled", protocol),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Region:     strPtr(region),
+				Service:    strPtr("AWSTransfer"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/ProtocolHours/")},
+				},
+			},
+		})
+	}
+
+	if u != nil && u.Get("monthly_data_uploaded_gb").Exists() {
+		costComponents = append(costComponents, transferServerDataCostComponent(region, "Data uploaded", "UploadBytes", decimal.NewFromFloat(u.Get("monthly_data_uploaded_gb").Float())))
+	}
+
+	if u != nil && u.Get("monthly_data_downloaded_gb").Exists() {
+		costComponents = append(costComponents, transferServerDataCostComponent(region, "Data downloaded", "DownloadBytes", decimal.NewFromFloat(u.Get("monthly_data_downloaded_gb").Float())))
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func transferServerDataCostComponent(region string, name string, usageType string, quantity decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: decimalPtr(quantity),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr(region),
+			Service:    strPtr("AWSTransfer"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", usageType))},
+			},
+		},
+	}
+}
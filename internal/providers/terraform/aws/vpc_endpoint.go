@@ -12,6 +12,9 @@ func GetVpcEndpointRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_vpc_endpoint",
 		RFunc: NewVpcEndpoint,
+		Notes: []string{
+			"Interface endpoints are charged for each AZ, which is the number of subnet_ids.",
+		},
 	}
 }
 
@@ -20,8 +23,6 @@ func NewVpcEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 
 	vpcEndpointType := "Gateway"
 
-	vpcEndpointInterfaces := 1
-
 	var endpointHours string
 	var endpointBytes string
 
@@ -29,13 +30,10 @@ func NewVpcEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 		vpcEndpointType = d.Get("vpc_endpoint_type").String()
 	}
 
-	if len(d.Get("subnet_ids").Array()) > 1 {
-		vpcEndpointInterfaces = len(d.Get("subnet_ids").Array())
-	}
-
 	// Gateway endpoints don't have a cost associated with them
 	if vpcEndpointType == "Gateway" {
 		return &schema.Resource{
+			Name:      d.Address,
 			NoPrice:   true,
 			IsSkipped: true,
 		}
@@ -55,39 +53,56 @@ func NewVpcEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 		gbDataProcessed = decimalPtr(decimal.NewFromFloat(u.Get("monthly_data_processed_gb").Float()))
 	}
 
-	return &schema.Resource{
-		Name: d.Address,
-		CostComponents: []*schema.CostComponent{
-			{
-				Name:           fmt.Sprintf("Endpoint (%s)", vpcEndpointType),
-				Unit:           "hours",
-				UnitMultiplier: 1,
-				HourlyQuantity: decimalPtr(decimal.NewFromInt(int64(vpcEndpointInterfaces))),
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonVPC"),
-					ProductFamily: strPtr("VpcEndpoint"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", endpointHours))},
-					},
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Endpoint (%s)", vpcEndpointType),
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(vpcEndpointAZCount(d))),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonVPC"),
+				ProductFamily: strPtr("VpcEndpoint"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", endpointHours))},
 				},
 			},
-			{
-				Name:            "Data processed",
-				Unit:            "GB",
-				UnitMultiplier:  1,
-				MonthlyQuantity: gbDataProcessed,
-				ProductFilter: &schema.ProductFilter{
-					VendorName:    strPtr("aws"),
-					Region:        strPtr(region),
-					Service:       strPtr("AmazonVPC"),
-					ProductFamily: strPtr("VpcEndpoint"),
-					AttributeFilters: []*schema.AttributeFilter{
-						{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", endpointBytes))},
-					},
+		},
+	}
+
+	// The data processed is only shown with usage, since the hourly cost is
+	// the part that adds up across many endpoints
+	if gbDataProcessed != nil {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Data processed",
+			Unit:            "GB",
+			UnitMultiplier:  1,
+			MonthlyQuantity: gbDataProcessed,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonVPC"),
+				ProductFamily: strPtr("VpcEndpoint"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr(fmt.Sprintf("/%s/", endpointBytes))},
 				},
 			},
-		},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
 	}
 }
+
+// vpcEndpointAZCount returns the number of AZs of the endpoint, which is the
+// number of its subnets since an endpoint can only have one subnet per AZ.
+func vpcEndpointAZCount(d *schema.ResourceData) int64 {
+	if n := len(d.Get("subnet_ids").Array()); n > 1 {
+		return int64(n)
+	}
+
+	return 1
+}
@@ -6,6 +6,10 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// vpnConnectionTunnels is the number of tunnels of each VPN connection, AWS
+// always creates two.
+const vpnConnectionTunnels = 2
+
 func GetVPNConnectionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "aws_vpn_connection",
@@ -33,6 +37,24 @@ func NewVPNConnection(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		},
 	}
 
+	// Accelerated connections have a Global Accelerator for each tunnel, which
+	// is charged hourly on top of the connection
+	if d.Get("enable_acceleration").Bool() {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           "Accelerated tunnels",
+			Unit:           "hours",
+			UnitMultiplier: 1,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(vpnConnectionTunnels)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AWSGlobalAccelerator"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/Accelerator-Hours/")},
+				},
+			},
+		})
+	}
+
 	if d.Get("transit_gateway_id").String() != "" {
 		costComponents = append(costComponents, transitGatewayAttachmentCostComponent(region, "TransitGatewayVPN"))
 
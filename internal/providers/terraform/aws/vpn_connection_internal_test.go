@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestVPNConnectionAcceleratedTunnels(t *testing.T) {
+	d := schema.NewResourceData("aws_vpn_connection", "aws", "aws_vpn_connection.vpn", nil, gjson.Parse(`{"region": "us-east-1", "enable_acceleration": true}`))
+
+	r := NewVPNConnection(d, nil)
+	assert.Len(t, r.CostComponents, 2)
+	assert.Equal(t, "Accelerated tunnels", r.CostComponents[1].Name)
+	assert.Equal(t, int64(vpnConnectionTunnels), r.CostComponents[1].HourlyQuantity.IntPart())
+
+	d = schema.NewResourceData("aws_vpn_connection", "aws", "aws_vpn_connection.vpn", nil, gjson.Parse(`{"region": "us-east-1"}`))
+
+	r = NewVPNConnection(d, nil)
+	assert.Len(t, r.CostComponents, 1)
+	assert.Equal(t, "VPN connection", r.CostComponents[0].Name)
+}
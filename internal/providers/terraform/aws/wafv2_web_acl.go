@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+func GetWAFv2WebACLRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "aws_wafv2_web_acl",
+		RFunc: NewWAFv2WebACL,
+		Notes: []string{
+			"Managed rule groups are priced as rules, the extra subscription fees of Bot Control and Account Takeover Prevention are not yet supported.",
+		},
+	}
+}
+
+func NewWAFv2WebACL(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	// Web ACLs for CloudFront distributions are global and priced in us-east-1
+	if d.Get("scope").String() == "CLOUDFRONT" {
+		region = "us-east-1"
+	}
+
+	var rules, ruleGroups, managedRuleGroups int64
+	for _, rule := range d.Get("rule").Array() {
+		switch {
+		case rule.Get("statement.0.managed_rule_group_statement.0").Exists():
+			managedRuleGroups++
+		case rule.Get("statement.0.rule_group_reference_statement.0").Exists():
+			ruleGroups++
+		default:
+			rules++
+		}
+	}
+
+	costComponents := []*schema.CostComponent{
+		wafv2CostComponent(region, "Web ACL usage", "months", "/WebACLV2/", decimal.NewFromInt(1)),
+	}
+
+	if rules > 0 {
+		costComponents = append(costComponents, wafv2CostComponent(region, "Rules", "rules", "/RuleV2/", decimal.NewFromInt(rules)))
+	}
+
+	if ruleGroups > 0 {
+		costComponents = append(costComponents, wafv2CostComponent(region, "Rule groups", "groups", "/RuleV2/", decimal.NewFromInt(ruleGroups)))
+	}
+
+	if managedRuleGroups > 0 {
+		costComponents = append(costComponents, wafv2CostComponent(region, "Managed rule groups", "groups", "/RuleV2/", decimal.NewFromInt(managedRuleGroups)))
+	}
+
+	var monthlyRequests *decimal.Decimal
+	if u != nil && u.Get("monthly_requests").Exists() {
+		monthlyRequests = decimalPtr(decimal.NewFromInt(u.Get("monthly_requests").Int()))
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            "Requests",
+		Unit:            "1M requests",
+		UnitMultiplier:  1000000,
+		MonthlyQuantity: monthlyRequests,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("awswaf"),
+			ProductFamily: strPtr("Web Application Firewall"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr("/RequestV2-Tier1/")},
+			},
+		},
+	})
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func wafv2CostComponent(region string, name string, unit string, usageType string, quantity decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: decimalPtr(quantity),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("awswaf"),
+			ProductFamily: strPtr("Web Application Firewall"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: strPtr(usageType)},
+			},
+		},
+	}
+}
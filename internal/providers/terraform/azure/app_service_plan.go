@@ -17,14 +17,12 @@ func GetAzureRMAppServicePlanRegistryItem() *schema.RegistryItem {
 
 func NewAzureRMAppServicePlan(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	sku := d.Get("sku.0.size").String()
-	skuRefactor := ""
 	os := "windows"
 	capacity := d.Get("sku.0.capacity").Int()
 	location := d.Get("location").String()
-	productName := "Standard Plan"
 
 	// These are used by azurerm_function_app, their costs are calculated there as they don't have prices in the azurerm_app_service_plan resource
-	if strings.ToLower(sku[:2]) == "ep" {
+	if isFunctionAppSKU(sku) {
 		return &schema.Resource{
 			Name:      d.Address,
 			IsSkipped: true,
@@ -32,6 +30,31 @@ func NewAzureRMAppServicePlan(d *schema.ResourceData, u *schema.UsageData) *sche
 		}
 	}
 
+	if d.Get("kind").Exists() {
+		os = strings.ToLower(d.Get("kind").String())
+	}
+	if os == "app" {
+		os = "windows"
+	}
+
+	skuRefactor, productName := appServicePlanSKUFilters(sku, os)
+
+	costComponents := make([]*schema.CostComponent, 0)
+
+	costComponents = append(costComponents, AppServicePlanCostComponent(fmt.Sprintf("Instance usage (%s)", sku), location, productName, skuRefactor, capacity))
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+// appServicePlanSKUFilters returns the skuName and productName values used to
+// look up the price of an App Service Plan SKU for the given OS.
+func appServicePlanSKUFilters(sku, os string) (string, string) {
+	skuRefactor := ""
+	productName := "Standard Plan"
+
 	switch strings.ToLower(sku[2:]) {
 	case "v1":
 		skuRefactor = sku[:2]
@@ -61,24 +84,11 @@ func NewAzureRMAppServicePlan(d *schema.ResourceData, u *schema.UsageData) *sche
 		productName = "Basic Plan"
 	}
 
-	if d.Get("kind").Exists() {
-		os = strings.ToLower(d.Get("kind").String())
-	}
-	if os == "app" {
-		os = "windows"
-	}
 	if os != "windows" && productName != "Premium Plan" {
 		productName += " - Linux"
 	}
 
-	costComponents := make([]*schema.CostComponent, 0)
-
-	costComponents = append(costComponents, AppServicePlanCostComponent(fmt.Sprintf("Instance usage (%s)", sku), location, productName, skuRefactor, capacity))
-
-	return &schema.Resource{
-		Name:           d.Address,
-		CostComponents: costComponents,
-	}
+	return skuRefactor, productName
 }
 
 func AppServicePlanCostComponent(name, location, productName, skuRefactor string, capacity int64) *schema.CostComponent {
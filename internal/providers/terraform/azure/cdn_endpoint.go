@@ -0,0 +1,177 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+func GetAzureRMCDNEndpointRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_cdn_endpoint",
+		RFunc: NewAzureRMCDNEndpoint,
+		ReferenceAttributes: []string{
+			"profile_name",
+		},
+		Notes: []string{
+			"Outbound data transfer is priced by the CDN zone of the endpoint location.",
+			"Only the rules engine of Standard_Microsoft profiles has request charges.",
+		},
+	}
+}
+
+// cdnProductNames are the product names of the CDN profile SKUs
+var cdnProductNames = map[string]string{
+	"Standard_Microsoft": "Azure CDN from Microsoft",
+	"Standard_Akamai":    "Azure CDN from Akamai",
+	"Standard_Verizon":   "Azure CDN from Verizon",
+	"Premium_Verizon":    "Azure CDN from Verizon",
+}
+
+// cdnDataTransferTiers are the sizes in GB of the outbound data transfer tiers
+var cdnDataTransferTiers = []int{10240, 40960, 102400, 358400, 512000, 4096000}
+
+func NewAzureRMCDNEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	profiles := d.References("profile_name")
+	if len(profiles) == 0 {
+		log.Warnf("Skipping resource %s. Could not find its CDN profile.", d.Address)
+		return nil
+	}
+
+	sku := profiles[0].Get("sku").String()
+	productName, ok := cdnProductNames[sku]
+	if !ok {
+		log.Warnf("Skipping resource %s. Infracost doesn't support the %s CDN profile SKU.", d.Address, sku)
+		return nil
+	}
+
+	location := d.Get("location").String()
+	if location == "" {
+		location = profiles[0].Get("location").String()
+	}
+	zone := cdnZone(location)
+	skuName := strings.Split(sku, "_")[0]
+
+	var costComponents []*schema.CostComponent
+	var usageMessage string
+
+	if u != nil && u.Get("monthly_outbound_gb").Exists() {
+		outbound := decimal.NewFromInt(u.Get("monthly_outbound_gb").Int())
+		tiers := usage.CalculateTierBuckets(outbound, cdnDataTransferTiers)
+
+		names := []string{"first 10TB", "next 40TB", "next 100TB", "next 350TB", "next 500TB", "next 4000TB", "over 5000TB"}
+		startUsage := 0
+		for i, quantity := range tiers {
+			if i > 0 && quantity.IsZero() {
+				break
+			}
+
+			costComponents = append(costComponents, cdnOutboundDataTransferCostComponent(
+				fmt.Sprintf("Outbound data transfer (%s)", names[i]),
+				zone,
+				productName,
+				skuName,
+				fmt.Sprintf("%d", startUsage),
+				decimalPtr(quantity),
+			))
+
+			if i < len(cdnDataTransferTiers) {
+				startUsage += cdnDataTransferTiers[i]
+			}
+		}
+	} else {
+		costComponents = append(costComponents, cdnOutboundDataTransferCostComponent("Outbound data transfer (first 10TB)", zone, productName, skuName, "0", nil))
+		usageMessage = "Data transfer cost depends on monthly_outbound_gb from the usage file"
+	}
+
+	rules := len(d.Get("delivery_rule").Array())
+	if sku == "Standard_Microsoft" && rules > 0 {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Rules engine rules",
+			Unit:            "rules",
+			UnitMultiplier:  1,
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(int64(rules))),
+			ProductFilter:   cdnProductFilter(productName, skuName, "/Rule$/"),
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		})
+
+		var requests *decimal.Decimal
+		if u != nil && u.Get("monthly_rules_engine_requests").Exists() {
+			requests = decimalPtr(decimal.NewFromInt(u.Get("monthly_rules_engine_requests").Int()).Div(decimal.NewFromInt(1000000)))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Rules engine requests",
+			Unit:            "1M requests",
+			UnitMultiplier:  1,
+			MonthlyQuantity: requests,
+			ProductFilter:   cdnProductFilter(productName, skuName, "/Rules Engine Requests$/"),
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		UsageMessage:   usageMessage,
+		CostComponents: costComponents,
+	}
+}
+
+func cdnOutboundDataTransferCostComponent(name, zone, productName, skuName, startUsage string, quantity *decimal.Decimal) *schema.CostComponent {
+	productFilter := cdnProductFilter(productName, skuName, "/Data Transfer$/")
+	productFilter.Region = strPtr(zone)
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter:   productFilter,
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption:   strPtr("Consumption"),
+			StartUsageAmount: strPtr(startUsage),
+		},
+	}
+}
+
+func cdnProductFilter(productName, skuName, meterName string) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:    strPtr("azure"),
+		Service:       strPtr("Content Delivery Network"),
+		ProductFamily: strPtr("Networking"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "productName", Value: strPtr(productName)},
+			{Key: "skuName", Value: strPtr(skuName)},
+			{Key: "meterName", ValueRegex: strPtr(meterName)},
+		},
+	}
+}
+
+// cdnZone returns the CDN billing zone of the location. Locations that aren't
+// in another zone are in Zone 1, which is North America, Europe, the Middle
+// East and Africa.
+func cdnZone(location string) string {
+	location = strings.ToLower(strings.ReplaceAll(location, " ", ""))
+
+	switch {
+	case strings.HasPrefix(location, "australia"):
+		return "Zone 4"
+	case strings.HasPrefix(location, "brazil"):
+		return "Zone 3"
+	case strings.HasSuffix(location, "india"):
+		return "Zone 5"
+	case strings.HasPrefix(location, "eastasia"), strings.HasPrefix(location, "southeastasia"),
+		strings.HasPrefix(location, "japan"), strings.HasPrefix(location, "korea"):
+		return "Zone 2"
+	}
+
+	return "Zone 1"
+}
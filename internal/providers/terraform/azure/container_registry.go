@@ -68,7 +68,7 @@ func NewAzureRMContainerRegistry(d *schema.ResourceData, u *schema.UsageData) *s
 		}
 	}
 
-	if u == nil {
+	if u == nil || !u.Get("storage_gb").Exists() {
 		costComponents = append(costComponents, ContainerRegistryStorageCostComponent(fmt.Sprintf("Storage (over %sGB)", includedStorage), location, sku, storageGb))
 	}
 
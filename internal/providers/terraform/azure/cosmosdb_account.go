@@ -0,0 +1,65 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+func GetAzureRMCosmosdbAccountRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_cosmosdb_account",
+		RFunc: NewAzureCosmosdbAccount,
+		Notes: []string{
+			"Throughput is taken from the usage file. Don't include throughput that is already set on databases or keyspaces of the account as it is priced by those resources.",
+			"Storage is priced by the databases and keyspaces of the account, so it isn't priced here.",
+		},
+	}
+}
+
+// NewAzureCosmosdbAccount prices the throughput shared by the account, which
+// is provisioned RU/s per region or serverless request units. Storage is only
+// priced by the databases and keyspaces so it isn't counted twice.
+func NewAzureCosmosdbAccount(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	costComponents := []*schema.CostComponent{}
+
+	mainLocation := d.Get("location").String()
+	geoLocations := d.Get("geo_location").Array()
+	if len(geoLocations) == 0 {
+		geoLocations = []gjson.Result{gjson.Parse(`{"location": "` + mainLocation + `"}`)}
+	}
+
+	skuName := "RUs"
+	if d.Get("enable_multiple_write_locations").Bool() {
+		skuName = "mRUs"
+	}
+
+	if isCosmosdbServerless(d) {
+		availabilityZone := geoLocations[0].Get("zone_redundant").Bool()
+		costComponents = append(costComponents, serverlessCosmosCostComponent(mainLocation, availabilityZone, u))
+	} else {
+		var throughputs *decimal.Decimal
+		if u != nil && u.Get("provisioned_throughput").Exists() {
+			throughputs = decimalPtr(decimal.NewFromInt(u.Get("provisioned_throughput").Int()))
+		}
+
+		// Multi-region accounts have a cost component for each region, so the
+		// throughput cost is multiplied by the region count
+		costComponents = append(costComponents, provisionedCosmosCostComponents(Provisioned, throughputs, geoLocations, skuName, u)...)
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+func isCosmosdbServerless(d *schema.ResourceData) bool {
+	for _, c := range d.Get("capabilities").Array() {
+		if c.Get("name").String() == "EnableServerless" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -154,7 +154,7 @@ func serverlessCosmosCostComponent(location string, availabilityZone bool, u *sc
 		requestUnits = decimalPtr(requestUnits.Div(decimal.NewFromInt(1000000)))
 	}
 
-	if availabilityZone {
+	if availabilityZone && requestUnits != nil {
 		requestUnits = decimalPtr(requestUnits.Mul(decimal.NewFromFloat(1.25)))
 	}
 
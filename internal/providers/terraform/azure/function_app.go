@@ -9,6 +9,19 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+// vCPU and memory (GB) per instance of the Elastic Premium function app SKUs
+var functionAppSkuCPU = map[string]int64{
+	"ep1": 1,
+	"ep2": 2,
+	"ep3": 4,
+}
+
+var functionAppSkuMemory = map[string]float64{
+	"ep1": 3.5,
+	"ep2": 7.0,
+	"ep3": 14.0,
+}
+
 func GetAzureRMAppFunctionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_function_app",
@@ -36,37 +49,29 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 		execTimeMulMemorySize = &multiplicationForExecTime
 	}
 
-	skuMapCPU := map[string]int64{
-		"ep1": 1,
-		"ep2": 2,
-		"ep3": 4,
-	}
-	skuMapMemory := map[string]float64{
-		"ep1": 3.5,
-		"ep2": 7.0,
-		"ep3": 14.0,
-	}
-
+	var skuTier, skuSize string
 	appServicePlanID := d.References("app_service_plan_id")
-	skuTier := strings.ToLower(appServicePlanID[0].Get("sku.0.tier").String())
-	skuSize := strings.ToLower(appServicePlanID[0].Get("sku.0.size").String())
-
 	if len(appServicePlanID) > 0 {
+		skuTier = strings.ToLower(appServicePlanID[0].Get("sku.0.tier").String())
+		skuSize = strings.ToLower(appServicePlanID[0].Get("sku.0.size").String())
 		kind = strings.ToLower(appServicePlanID[0].Get("kind").String())
 	}
 
-	if val, ok := skuMapCPU[skuSize]; ok {
+	if val, ok := functionAppSkuCPU[skuSize]; ok {
 		skuCPU = decimalPtr(decimal.NewFromInt(val))
 	}
-	if val, ok := skuMapMemory[skuSize]; ok {
+	if val, ok := functionAppSkuMemory[skuSize]; ok {
 		skuMemory = decimalPtr(decimal.NewFromFloat(val))
 	}
-	if skuCPU == nil || skuMemory == nil {
+	// Consumption plans use the Y1 SKU of the Dynamic tier and are priced by
+	// usage, so they don't need the CPU and memory of the SKU
+	consumption := kind == "functionapp" || skuTier == "dynamic"
+	if !consumption && (skuCPU == nil || skuMemory == nil) {
 		log.Warnf("Skipping resource %s. Could not find its CPU or Memory from its SKU.", d.Address)
 		return nil
 	}
 
-	if u != nil && u.Get("instances").Type != gjson.Null {
+	if u != nil && u.Get("instances").Type != gjson.Null && skuCPU != nil && skuMemory != nil {
 		instances = decimalPtr(decimal.NewFromFloat(u.Get("instances").Float()))
 		multiplicationForCPU = instances.Mul(*skuCPU)
 		multiplicationForMemory = instances.Mul(*skuMemory)
@@ -80,7 +85,7 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 		costComponents = append(costComponents, AppFunctionPremiumCPUCostComponent(instMulCPU, location))
 		costComponents = append(costComponents, AppFunctionPremiumMemoryCostComponent(instMulMemory, location))
 	}
-	if kind == "functionapp" {
+	if consumption {
 		costComponents = append(costComponents, AppFunctionConsumptionExecutionTimeCostComponent(execTimeMulMemorySize, location))
 		costComponents = append(costComponents, AppFunctionConsumptionExecutionsCostComponent(executions, location))
 	}
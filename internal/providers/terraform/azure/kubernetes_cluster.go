@@ -10,6 +10,9 @@ func GetAzureRMKubernetesClusterRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_kubernetes_cluster",
 		RFunc: NewAzureRMKubernetesCluster,
+		Notes: []string{
+			"Auto-scaling node pools are priced on node_count, or min_count if node_count isn't set. Set default_node_pool.nodes in the usage file to estimate a different number of nodes.",
+		},
 	}
 }
 
@@ -24,7 +27,8 @@ func NewAzureRMKubernetesCluster(d *schema.ResourceData, u *schema.UsageData) *s
 		skuTier = d.Get("sku_tier").String()
 	}
 
-	if skuTier == "Paid" {
+	// Standard is the newer name of the Paid tier
+	if skuTier == "Paid" || skuTier == "Standard" {
 		costComponents = append(costComponents, &schema.CostComponent{
 			Name:           "Uptime SLA",
 			Unit:           "hours",
@@ -45,10 +49,7 @@ func NewAzureRMKubernetesCluster(d *schema.ResourceData, u *schema.UsageData) *s
 		})
 	}
 
-	nodeCount := decimal.NewFromInt(1)
-	if d.Get("default_node_pool.0.node_count").Type != gjson.Null {
-		nodeCount = decimal.NewFromInt(d.Get("default_node_pool.0.node_count").Int())
-	}
+	nodeCount := aksNodeCount(d.Get("default_node_pool.0"))
 	if u != nil && u.Get("default_node_pool.nodes").Exists() {
 		nodeCount = decimal.NewFromInt(u.Get("default_node_pool.nodes").Int())
 	}
@@ -1,6 +1,9 @@
 package azure
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
@@ -11,6 +14,9 @@ func GetAzureRMKubernetesClusterNodePoolRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_kubernetes_cluster_node_pool",
 		RFunc: NewAzureRMKubernetesClusterNodePool,
+		Notes: []string{
+			"Auto-scaling node pools are priced on node_count, or min_count if node_count isn't set. Set nodes in the usage file to estimate a different number of nodes.",
+		},
 		ReferenceAttributes: []string{
 			"kubernetes_cluster_id",
 		},
@@ -25,10 +31,7 @@ func NewAzureRMKubernetesClusterNodePool(d *schema.ResourceData, u *schema.Usage
 		location = mainCluster[0].Get("location").String()
 	}
 
-	nodeCount := decimal.NewFromInt(1)
-	if d.Get("node_count").Type != gjson.Null {
-		nodeCount = decimal.NewFromInt(d.Get("node_count").Int())
-	}
+	nodeCount := aksNodeCount(d.RawValues)
 	if u != nil && u.Get("nodes").Exists() {
 		nodeCount = decimal.NewFromInt(u.Get("nodes").Int())
 	}
@@ -44,7 +47,11 @@ func aksClusterNodePool(name, location string, n gjson.Result, nodeCount decimal
 		Name: name,
 	}
 	instanceType := n.Get("vm_size").String()
-	costComponents = append(costComponents, linuxVirtualMachineCostComponent(location, instanceType))
+	if strings.EqualFold(n.Get("priority").String(), "Spot") {
+		costComponents = append(costComponents, aksSpotCostComponent(location, instanceType))
+	} else {
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(location, instanceType))
+	}
 	mainResource.CostComponents = costComponents
 	schema.MultiplyQuantities(mainResource, nodeCount)
 
@@ -69,6 +76,36 @@ func aksClusterNodePool(name, location string, n gjson.Result, nodeCount decimal
 	return mainResource
 }
 
+// aksNodeCount returns the number of nodes in the pool. Auto-scaling pools
+// don't need a node_count so the minimum is used instead.
+func aksNodeCount(n gjson.Result) decimal.Decimal {
+	if n.Get("node_count").Type != gjson.Null {
+		return decimal.NewFromInt(n.Get("node_count").Int())
+	}
+
+	if n.Get("enable_auto_scaling").Bool() && n.Get("min_count").Type != gjson.Null {
+		return decimal.NewFromInt(n.Get("min_count").Int())
+	}
+
+	return decimal.NewFromInt(1)
+}
+
+// aksSpotCostComponent prices the nodes of a spot node pool, which have their
+// own "<size> Spot" SKUs.
+func aksSpotCostComponent(location, instanceType string) *schema.CostComponent {
+	c := linuxVirtualMachineCostComponent(location, instanceType)
+	skuName := parseVMSKUName(instanceType)
+
+	c.Name = fmt.Sprintf("Instance usage (spot, %s)", skuName)
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == "skuName" {
+			f.Value = strPtr(fmt.Sprintf("%s Spot", skuName))
+		}
+	}
+
+	return c
+}
+
 func aksOSDiskSubResource(region string, diskSize int) *schema.Resource {
 	diskType := "Premium_LRS"
 
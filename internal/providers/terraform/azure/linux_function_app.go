@@ -0,0 +1,90 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+func GetAzureRMLinuxFunctionAppRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_linux_function_app",
+		RFunc: NewAzureRMServicePlanFunctionApp,
+		ReferenceAttributes: []string{
+			"service_plan_id",
+		},
+	}
+}
+
+// NewAzureRMServicePlanFunctionApp prices the function apps that use the newer
+// azurerm_service_plan resource. Dedicated plans are priced on the plan itself.
+func NewAzureRMServicePlanFunctionApp(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	location := d.Get("location").String()
+
+	servicePlans := d.References("service_plan_id")
+	if len(servicePlans) == 0 {
+		log.Warnf("Skipping resource %s. Could not find its service plan.", d.Address)
+		return nil
+	}
+	servicePlan := servicePlans[0]
+	skuSize := strings.ToLower(servicePlan.Get("sku_name").String())
+
+	if !isFunctionAppSKU(skuSize) {
+		return &schema.Resource{
+			Name:      d.Address,
+			IsSkipped: true,
+			NoPrice:   true,
+		}
+	}
+
+	costComponents := make([]*schema.CostComponent, 0)
+
+	if skuSize == "y1" {
+		var executions, gbSeconds *decimal.Decimal
+
+		// The first 1M executions and 400,000 GB-seconds per month are free
+		if u != nil && u.Get("monthly_executions").Type != gjson.Null {
+			monthlyExecutions := decimal.NewFromFloat(u.Get("monthly_executions").Float())
+			executions = decimalPtr(decimal.Max(monthlyExecutions.Sub(decimal.NewFromInt(1000000)), decimal.Zero).Div(decimal.NewFromInt(10)))
+
+			if u.Get("execution_duration_ms").Type != gjson.Null && u.Get("memory_mb").Type != gjson.Null {
+				durationSeconds := decimal.NewFromFloat(u.Get("execution_duration_ms").Float()).Div(decimal.NewFromInt(1000))
+				memoryGB := decimal.NewFromFloat(u.Get("memory_mb").Float()).Div(decimal.NewFromInt(1024))
+				totalGBSeconds := monthlyExecutions.Mul(durationSeconds).Mul(memoryGB)
+				gbSeconds = decimalPtr(decimal.Max(totalGBSeconds.Sub(decimal.NewFromInt(400000)), decimal.Zero))
+			}
+		}
+
+		executionTime := AppFunctionConsumptionExecutionTimeCostComponent(nil, location)
+		executionTime.MonthlyQuantity = gbSeconds
+		costComponents = append(costComponents, executionTime)
+		// Executions are priced per 10 executions
+		costComponents = append(costComponents, AppFunctionConsumptionExecutionsCostComponent(executions, location))
+	} else {
+		skuCPU, okCPU := functionAppSkuCPU[skuSize]
+		skuMemory, okMemory := functionAppSkuMemory[skuSize]
+		if !okCPU || !okMemory {
+			log.Warnf("Skipping resource %s. Could not find its CPU or Memory from its SKU.", d.Address)
+			return nil
+		}
+
+		instances := decimal.NewFromInt(1)
+		if servicePlan.Get("worker_count").Type != gjson.Null {
+			instances = decimal.NewFromInt(servicePlan.Get("worker_count").Int())
+		}
+		if u != nil && u.Get("instances").Type != gjson.Null {
+			instances = decimal.NewFromFloat(u.Get("instances").Float())
+		}
+
+		costComponents = append(costComponents, AppFunctionPremiumCPUCostComponent(decimalPtr(instances.Mul(decimal.NewFromInt(skuCPU))), location))
+		costComponents = append(costComponents, AppFunctionPremiumMemoryCostComponent(decimalPtr(instances.Mul(decimal.NewFromFloat(skuMemory))), location))
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
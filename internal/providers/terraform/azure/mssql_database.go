@@ -2,6 +2,7 @@ package azure
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -33,6 +34,13 @@ func NewAzureMSSQLDatabase(d *schema.ResourceData, u *schema.UsageData) *schema.
 		sku = d.Get("sku_name").String()
 	}
 
+	if dtuTier, ok := mssqlDTUTier(sku); ok {
+		return &schema.Resource{
+			Name:           d.Address,
+			CostComponents: mssqlDTUCostComponents(d, u, region, serviceName, sku, dtuTier),
+		}
+	}
+
 	tier, family, cores, err := parseMSSQLSku(d.Address, sku)
 	if err != nil {
 		log.Warnf(string(err.Error()))
@@ -124,31 +132,135 @@ func NewAzureMSSQLDatabase(d *schema.ResourceData, u *schema.UsageData) *schema.
 		if u != nil && u.Get("long_term_retention_storage_gb").Exists() {
 			retention = decimalPtr(decimal.NewFromInt(u.Get("long_term_retention_storage_gb").Int()))
 		}
-		costComponents = append(costComponents, &schema.CostComponent{
-			Name:            "Long-term retention",
-			Unit:            "GB",
+		costComponents = append(costComponents, mssqlLongTermRetentionComponent(region, serviceName, retention))
+	}
+
+	return &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+}
+
+// mssqlDTUTier returns the service tier of DTU purchasing model SKUs, e.g.
+// Basic, S0 or P1.
+func mssqlDTUTier(sku string) (string, bool) {
+	if strings.EqualFold(sku, "basic") {
+		return "Basic", true
+	}
+
+	m := mssqlDTUSkuRegex.FindStringSubmatch(strings.ToUpper(sku))
+	if m == nil {
+		return "", false
+	}
+
+	if m[1] == "S" {
+		return "Standard", true
+	}
+
+	return "Premium", true
+}
+
+var mssqlDTUSkuRegex = regexp.MustCompile(`^([SP])\d+$`)
+
+// mssqlDTUIncludedStorageGB is the storage included in the price of DTU
+// databases, any extra storage up to max_size_gb is charged separately.
+func mssqlDTUIncludedStorageGB(sku, tier string) int64 {
+	switch {
+	case tier == "Basic":
+		return 2
+	case tier == "Standard":
+		return 250
+	case strings.EqualFold(sku, "P11"), strings.EqualFold(sku, "P15"):
+		return 4096
+	default:
+		return 500
+	}
+}
+
+func mssqlDTUCostComponents(d *schema.ResourceData, u *schema.UsageData, region, serviceName, sku, tier string) []*schema.CostComponent {
+	skuName := strings.ToUpper(sku)
+	if tier == "Basic" {
+		skuName = "B"
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            fmt.Sprintf("Compute (%s)", skuName),
+			Unit:            "days",
 			UnitMultiplier:  1,
-			MonthlyQuantity: retention,
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(730).Div(decimal.NewFromInt(24))),
 			ProductFilter: &schema.ProductFilter{
 				VendorName:    strPtr("azure"),
 				Region:        strPtr(region),
 				Service:       strPtr(serviceName),
 				ProductFamily: strPtr("Databases"),
 				AttributeFilters: []*schema.AttributeFilter{
-					{Key: "productName", ValueRegex: strPtr("/LTR Backup Storage/")},
-					{Key: "skuName", Value: strPtr("Backup RA-GRS")},
-					{Key: "meterName", Value: strPtr("RA-GRS Data Stored")},
+					{Key: "productName", ValueRegex: strPtr(fmt.Sprintf("/^SQL Database Single %s$/", tier))},
+					{Key: "skuName", Value: strPtr(skuName)},
+					{Key: "meterName", ValueRegex: strPtr("/DTUs?$/")},
 				},
 			},
 			PriceFilter: &schema.PriceFilter{
 				PurchaseOption: strPtr("Consumption"),
 			},
-		})
+		},
 	}
 
-	return &schema.Resource{
-		Name:           d.Address,
-		CostComponents: costComponents,
+	if tier != "Basic" && d.Get("max_size_gb").Type != gjson.Null {
+		extraStorageGB := d.Get("max_size_gb").Int() - mssqlDTUIncludedStorageGB(sku, tier)
+		if extraStorageGB > 0 {
+			costComponents = append(costComponents, &schema.CostComponent{
+				Name:            "Extra data storage",
+				Unit:            "GB",
+				UnitMultiplier:  1,
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(extraStorageGB)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("azure"),
+					Region:        strPtr(region),
+					Service:       strPtr(serviceName),
+					ProductFamily: strPtr("Databases"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "productName", ValueRegex: strPtr(fmt.Sprintf("/^SQL Database %s - Storage$/", tier))},
+						{Key: "skuName", Value: strPtr(tier)},
+						{Key: "meterName", ValueRegex: strPtr("/Data Stored$/")},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{
+					PurchaseOption: strPtr("Consumption"),
+				},
+			})
+		}
+	}
+
+	var retention *decimal.Decimal
+	if u != nil && u.Get("long_term_retention_storage_gb").Exists() {
+		retention = decimalPtr(decimal.NewFromInt(u.Get("long_term_retention_storage_gb").Int()))
+	}
+	costComponents = append(costComponents, mssqlLongTermRetentionComponent(region, serviceName, retention))
+
+	return costComponents
+}
+
+func mssqlLongTermRetentionComponent(region, serviceName string, retention *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Long-term retention",
+		Unit:            "GB",
+		UnitMultiplier:  1,
+		MonthlyQuantity: retention,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr(serviceName),
+			ProductFamily: strPtr("Databases"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", ValueRegex: strPtr("/LTR Backup Storage/")},
+				{Key: "skuName", Value: strPtr("Backup RA-GRS")},
+				{Key: "meterName", Value: strPtr("RA-GRS Data Stored")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+		},
 	}
 }
 
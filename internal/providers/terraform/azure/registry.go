@@ -5,6 +5,7 @@ import "github.com/infracost/infracost/internal/schema"
 var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMAppServiceCertificateBindingRegistryItem(),
 	GetAzureRMAppServiceCertificateOrderRegistryItem(),
+	GetAzureRMCosmosdbAccountRegistryItem(),
 	GetAzureRMCosmosdbCassandraKeyspaceRegistryItem(),
 	GetAzureRMDatabricksWorkspaceRegistryItem(),
 	GetAzureRMFirewallRegistryItem(),
@@ -33,6 +34,9 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMAppServicePlanRegistryItem(),
 	GetAzureRMAppIsolatedServicePlanRegistryItem(),
 	GetAzureRMAppFunctionRegistryItem(),
+	GetAzureRMServicePlanRegistryItem(),
+	GetAzureRMLinuxFunctionAppRegistryItem(),
+	GetAzureRMWindowsFunctionAppRegistryItem(),
 	GetAzureRMContainerRegistryRegistryItem(),
 	GetAzureRMAppIntegrationServiceEnvironmentRegistryItem(),
 	GetAzureRMPublicIPRegistryItem(),
@@ -40,6 +44,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMAppNATGatewayRegistryItem(),
 	GetAzureRMAppServiceCustomHostnameBindingRegistryItem(),
 	GetAzureRMNotificationHubsRegistryItem(),
+	GetAzureRMCDNEndpointRegistryItem(),
 }
 
 // FreeResources grouped alphabetically
@@ -62,6 +67,9 @@ var FreeResources []string = []string{
 	// Azure Blueprints
 	"azurerm_blueprint_assignment",
 
+	// Azure CDN
+	"azurerm_cdn_profile",
+
 	// Azure Firewall
 	"azurerm_firewall_application_rule_collection",
 	"azurerm_firewall_nat_rule_collection",
@@ -96,6 +104,9 @@ var FreeResources []string = []string{
 	"azurerm_container_registry_token",
 	"azurerm_container_registry_webhook",
 
+	// Azure Storage, containers are priced by their account
+	"azurerm_storage_container",
+
 	// Azure Virtual Machines
 	"azurerm_virtual_machine_data_disk_attachment",
 
@@ -0,0 +1,62 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+func GetAzureRMServicePlanRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_service_plan",
+		RFunc: NewAzureRMServicePlan,
+	}
+}
+
+func NewAzureRMServicePlan(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	sku := d.Get("sku_name").String()
+	location := d.Get("location").String()
+
+	workerCount := int64(1)
+	if d.Get("worker_count").Type != gjson.Null {
+		workerCount = d.Get("worker_count").Int()
+	}
+
+	if len(sku) < 2 {
+		log.Warnf("Skipping resource %s. Could not find its SKU.", d.Address)
+		return nil
+	}
+
+	// Elastic premium and consumption plans are billed on the function apps that use them
+	if isFunctionAppSKU(sku) {
+		return &schema.Resource{
+			Name:      d.Address,
+			IsSkipped: true,
+			NoPrice:   true,
+		}
+	}
+
+	os := "windows"
+	if strings.ToLower(d.Get("os_type").String()) == "linux" {
+		os = "linux"
+	}
+
+	skuRefactor, productName := appServicePlanSKUFilters(sku, os)
+
+	return &schema.Resource{
+		Name: d.Address,
+		CostComponents: []*schema.CostComponent{
+			AppServicePlanCostComponent(fmt.Sprintf("Instance usage (%s)", sku), location, productName, skuRefactor, workerCount),
+		},
+	}
+}
+
+// isFunctionAppSKU returns true for the Elastic Premium (EP1-3) and
+// Consumption (Y1) SKUs, which are priced per function app instead of per plan.
+func isFunctionAppSKU(sku string) bool {
+	s := strings.ToLower(sku)
+	return strings.HasPrefix(s, "ep") || s == "y1"
+}
@@ -27,8 +27,8 @@ func NewAzureStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema
 		accountKind = d.Get("account_kind").String()
 	}
 
-	if accountKind != "BlockBlobStorage" && accountKind != "FileStorage" {
-		log.Warnf("Skipping resource %s. Infracost only supports BlockBlobStorage and FileStorage account kinds", d.Address)
+	if accountKind == "Storage" {
+		log.Warnf("Skipping resource %s. Infracost only supports StorageV2, BlobStorage, BlockBlobStorage and FileStorage account kinds", d.Address)
 		return nil
 	}
 
@@ -39,12 +39,19 @@ func NewAzureStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema
 		accessTier = d.Get("access_tier").String()
 	}
 
-	if accountKind == "BlockBlobStorage" {
+	var usageMessage string
+
+	if accountKind != "FileStorage" {
 		productName = map[string]string{
 			"Standard": "Blob Storage",
 			"Premium":  "Premium Block Blob",
 		}[accountTier]
 
+		// General purpose v2 accounts have their own block blob prices
+		if accountKind == "StorageV2" && accountTier == "Standard" {
+			productName = "General Block Blob v2"
+		}
+
 		if productName == "" {
 			log.Warnf("Unrecognized account tier for resource %s: %s", d.Address, accountTier)
 			return nil
@@ -109,6 +116,22 @@ func NewAzureStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema
 			var unknown *decimal.Decimal
 
 			costComponents = append(costComponents, blobDataStorageCostComponent(location, "Capacity", skuName, "0", productName, unknown))
+
+			usageMessage = "Blob storage cost depends on storage_gb, and archive_storage_gb for archived blobs, from the usage file"
+		}
+
+		// Blobs can be moved to the archive tier individually, so it's only
+		// shown if the usage file has the archived storage. The replication
+		// type of the account is part of the archive price.
+		if accountTier != "Premium" && u != nil && u.Get("archive_storage_gb").Type != gjson.Null {
+			archiveSkuName := fmt.Sprintf("Archive %s", accountReplicationType)
+			costComponents = append(costComponents, blobDataStorageCostComponent(
+				location,
+				"Archive capacity",
+				archiveSkuName,
+				"0",
+				productName,
+				decimalPtr(decimal.NewFromInt(u.Get("archive_storage_gb").Int()))))
 		}
 
 		if u != nil && u.Get("monthly_write_operations").Type != gjson.Null {
@@ -224,6 +247,8 @@ func NewAzureStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema
 
 		if u != nil && u.Get("data_at_rest_storage_gb").Type != gjson.Null {
 			dataAtRest = decimalPtr(decimal.NewFromInt(u.Get("data_at_rest_storage_gb").Int()))
+		} else {
+			usageMessage = "File storage cost depends on data_at_rest_storage_gb from the usage file"
 		}
 		costComponents = append(costComponents, fileDataStorageCostComponent(
 			location,
@@ -337,6 +362,7 @@ func NewAzureStorageAccount(d *schema.ResourceData, u *schema.UsageData) *schema
 	}
 	return &schema.Resource{
 		Name:           d.Address,
+		UsageMessage:   usageMessage,
 		CostComponents: costComponents,
 	}
 }
@@ -0,0 +1,73 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "West Europe"
+}
+
+resource "azurerm_cdn_profile" "microsoft" {
+  name                = "microsoft"
+  location            = "global"
+  resource_group_name = azurerm_resource_group.example.name
+  sku                 = "Standard_Microsoft"
+}
+
+resource "azurerm_cdn_profile" "verizon" {
+  name                = "verizon"
+  location            = "global"
+  resource_group_name = azurerm_resource_group.example.name
+  sku                 = "Standard_Verizon"
+}
+
+resource "azurerm_cdn_endpoint" "microsoft" {
+  name                = "microsoft"
+  profile_name        = azurerm_cdn_profile.microsoft.name
+  location            = "westeurope"
+  resource_group_name = azurerm_resource_group.example.name
+
+  origin {
+    name      = "example"
+    host_name = "www.contoso.com"
+  }
+
+  delivery_rule {
+    name  = "redirect"
+    order = 1
+
+    request_scheme_condition {
+      match_values = ["HTTP"]
+    }
+
+    url_redirect_action {
+      redirect_type = "Found"
+      protocol      = "Https"
+    }
+  }
+}
+
+resource "azurerm_cdn_endpoint" "verizon_australia" {
+  name                = "verizon-australia"
+  profile_name        = azurerm_cdn_profile.verizon.name
+  location            = "australiaeast"
+  resource_group_name = azurerm_resource_group.example.name
+
+  origin {
+    name      = "example"
+    host_name = "www.contoso.com"
+  }
+}
+
+resource "azurerm_cdn_endpoint" "without_usage_file" {
+  name                = "without-usage-file"
+  profile_name        = azurerm_cdn_profile.microsoft.name
+  location            = "eastus"
+  resource_group_name = azurerm_resource_group.example.name
+
+  origin {
+    name      = "example"
+    host_name = "www.contoso.com"
+  }
+}
@@ -33,6 +33,18 @@ resource "azurerm_app_service_plan" "funcApp" {
     capacity = 1
   }
 }
+resource "azurerm_app_service_plan" "consumption" {
+  name                = "api-appserviceplan-consumption"
+  location            = azurerm_resource_group.example1.location
+  resource_group_name = azurerm_resource_group.example1.name
+  kind                = "FunctionApp"
+  reserved            = false
+
+  sku {
+    tier = "Dynamic"
+    size = "Y1"
+  }
+}
 
 resource "azurerm_storage_account" "example" {
   name                     = "functionsapptestsa"
@@ -73,3 +85,11 @@ resource "azurerm_function_app" "my_functions3" {
   storage_account_name       = azurerm_storage_account.example.name
   storage_account_access_key = azurerm_storage_account.example.primary_access_key
 }
+resource "azurerm_function_app" "my_functions4" {
+  name                       = "test-azure-functions"
+  location                   = azurerm_resource_group.example1.location
+  resource_group_name        = azurerm_resource_group.example1.name
+  app_service_plan_id        = azurerm_app_service_plan.consumption.id
+  storage_account_name       = azurerm_storage_account.example.name
+  storage_account_access_key = azurerm_storage_account.example.primary_access_key
+}
@@ -64,3 +64,31 @@ resource "azurerm_kubernetes_cluster" "usage_ephemeral" {
   }
 }
 
+
+resource "azurerm_kubernetes_cluster" "standard_system_user" {
+  name                = "example-aks1"
+  location            = "eastus"
+  resource_group_name = azurerm_resource_group.example.name
+  dns_prefix          = "exampleaks1"
+  sku_tier            = "Standard"
+
+  default_node_pool {
+    name                         = "system"
+    vm_size                      = "Standard_D2_v2"
+    only_critical_addons_enabled = true
+    enable_auto_scaling          = true
+    min_count                    = 2
+    max_count                    = 5
+  }
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "user_spot" {
+  name                  = "user"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.standard_system_user.id
+  vm_size               = "Standard_D2_v2"
+  mode                  = "User"
+  priority              = "Spot"
+  eviction_policy       = "Delete"
+  spot_max_price        = -1
+  node_count            = 3
+}
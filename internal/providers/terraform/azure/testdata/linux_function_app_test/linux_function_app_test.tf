@@ -0,0 +1,89 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "exampleRG1"
+  location = "eastus"
+}
+
+resource "azurerm_service_plan" "elastic_premium" {
+  name                = "api-serviceplan-ep2"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Linux"
+  sku_name            = "EP2"
+  worker_count        = 2
+}
+
+resource "azurerm_service_plan" "consumption" {
+  name                = "api-serviceplan-y1"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Linux"
+  sku_name            = "Y1"
+}
+
+resource "azurerm_service_plan" "dedicated" {
+  name                = "api-serviceplan-s1"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Windows"
+  sku_name            = "S1"
+}
+
+resource "azurerm_linux_function_app" "premium" {
+  name                       = "test-premium-functions"
+  location                   = azurerm_resource_group.example.location
+  resource_group_name        = azurerm_resource_group.example.name
+  service_plan_id            = azurerm_service_plan.elastic_premium.id
+  storage_account_name       = "functionsapptestsa"
+  storage_account_access_key = "access-key"
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "premium_with_usage" {
+  name                       = "test-premium-functions-usage"
+  location                   = azurerm_resource_group.example.location
+  resource_group_name        = azurerm_resource_group.example.name
+  service_plan_id            = azurerm_service_plan.elastic_premium.id
+  storage_account_name       = "functionsapptestsa"
+  storage_account_access_key = "access-key"
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "consumption" {
+  name                       = "test-consumption-functions"
+  location                   = azurerm_resource_group.example.location
+  resource_group_name        = azurerm_resource_group.example.name
+  service_plan_id            = azurerm_service_plan.consumption.id
+  storage_account_name       = "functionsapptestsa"
+  storage_account_access_key = "access-key"
+
+  site_config {}
+}
+
+resource "azurerm_linux_function_app" "consumption_with_usage" {
+  name                       = "test-consumption-functions-usage"
+  location                   = azurerm_resource_group.example.location
+  resource_group_name        = azurerm_resource_group.example.name
+  service_plan_id            = azurerm_service_plan.consumption.id
+  storage_account_name       = "functionsapptestsa"
+  storage_account_access_key = "access-key"
+
+  site_config {}
+}
+
+resource "azurerm_windows_function_app" "dedicated" {
+  name                       = "test-dedicated-functions"
+  location                   = azurerm_resource_group.example.location
+  resource_group_name        = azurerm_resource_group.example.name
+  service_plan_id            = azurerm_service_plan.dedicated.id
+  storage_account_name       = "functionsapptestsa"
+  storage_account_access_key = "access-key"
+
+  site_config {}
+}
@@ -73,3 +73,22 @@ resource "azurerm_mssql_database" "LTR" {
   server_id = azurerm_sql_server.example.id
   sku_name  = "GP_Gen5_4"
 }
+
+resource "azurerm_mssql_database" "dtu_basic" {
+  name      = "acctest-db-d"
+  server_id = azurerm_sql_server.example.id
+  sku_name  = "Basic"
+}
+
+resource "azurerm_mssql_database" "dtu_standard" {
+  name      = "acctest-db-d"
+  server_id = azurerm_sql_server.example.id
+  sku_name  = "S0"
+}
+
+resource "azurerm_mssql_database" "dtu_premium_extra_storage" {
+  name        = "acctest-db-d"
+  server_id   = azurerm_sql_server.example.id
+  sku_name    = "P1"
+  max_size_gb = 1024
+}
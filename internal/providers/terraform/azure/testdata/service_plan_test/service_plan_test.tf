@@ -0,0 +1,42 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "exampleRG1"
+  location = "eastus"
+}
+
+resource "azurerm_service_plan" "standard_s1" {
+  name                = "api-serviceplan-s1"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Windows"
+  sku_name            = "S1"
+}
+
+resource "azurerm_service_plan" "premium_v2" {
+  name                = "api-serviceplan-p1v2"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Linux"
+  sku_name            = "P1v2"
+  worker_count        = 3
+}
+
+resource "azurerm_service_plan" "basic" {
+  name                = "api-serviceplan-b2"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Linux"
+  sku_name            = "B2"
+}
+
+resource "azurerm_service_plan" "elastic_premium" {
+  name                = "api-serviceplan-ep1"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  os_type             = "Linux"
+  sku_name            = "EP1"
+}
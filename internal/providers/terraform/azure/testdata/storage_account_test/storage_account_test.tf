@@ -136,4 +136,24 @@ resource "azurerm_storage_account" "file_hot_grs" {
   account_tier             = "Standard"
   account_replication_type = "GRS"
   access_tier              = "Hot"
-}
\ No newline at end of file
+}
+resource "azurerm_storage_account" "v2_Standard_GRS_Hot" {
+  name                     = "storageaccountname"
+  resource_group_name      = azurerm_resource_group.example.name
+  location                 = "eastus"
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+}
+
+resource "azurerm_storage_account" "v2_GRS_without_usage" {
+  name                     = "storageaccountname"
+  resource_group_name      = azurerm_resource_group.example.name
+  location                 = "eastus"
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+}
+
+resource "azurerm_storage_container" "v2_Standard_GRS_Hot" {
+  name                 = "content"
+  storage_account_name = azurerm_storage_account.v2_Standard_GRS_Hot.name
+}
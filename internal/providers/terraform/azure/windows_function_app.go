@@ -0,0 +1,15 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetAzureRMWindowsFunctionAppRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_windows_function_app",
+		RFunc: NewAzureRMServicePlanFunctionApp,
+		ReferenceAttributes: []string{
+			"service_plan_id",
+		},
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/infracost/infracost/internal/config"
 	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -19,6 +20,8 @@ import (
 
 var ErrMissingCloudToken = errors.New("No Terraform Cloud Token is set")
 var ErrInvalidCloudToken = errors.New("Invalid Terraform Cloud Token")
+var ErrCloudNotFound = errors.New("Not found in Terraform Cloud")
+var ErrMissingCloudPlanJSON = errors.New("Could not parse path to plan JSON from remote")
 
 type terraformConfig struct {
 	Credentials map[string]struct {
@@ -27,8 +30,6 @@ type terraformConfig struct {
 }
 
 func cloudAPI(host string, path string, token string) ([]byte, error) {
-	client := &http.Client{}
-
 	url := fmt.Sprintf("https://%s%s", host, path)
 	log.Debugf("Calling Terraform Cloud API: %s", url)
 	req, err := http.NewRequest("GET", url, nil)
@@ -37,7 +38,7 @@ func cloudAPI(host string, path string, token string) ([]byte, error) {
 	}
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	resp, err := client.Do(req)
+	resp, err := config.HTTPClient().Do(req)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -45,6 +46,9 @@ func cloudAPI(host string, path string, token string) ([]byte, error) {
 
 	if resp.StatusCode == 401 {
 		return []byte{}, ErrInvalidCloudToken
+	} else if resp.StatusCode == 404 {
+		// Terraform Cloud also responds with 404 if the token can't access the resource
+		return []byte{}, ErrCloudNotFound
 	} else if resp.StatusCode != 200 {
 		return []byte{}, errors.Errorf("invalid response from Terraform remote: %s", resp.Status)
 	}
@@ -52,6 +56,29 @@ func cloudAPI(host string, path string, token string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// cloudPlanJSON downloads the plan JSON of a finished Terraform Cloud run.
+func cloudPlanJSON(host string, runID string, token string) ([]byte, error) {
+	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var parsedResp struct {
+		Data struct {
+			Links map[string]string
+		}
+	}
+	if err := json.Unmarshal(body, &parsedResp); err != nil {
+		return []byte{}, err
+	}
+
+	jsonPath, ok := parsedResp.Data.Links["json-output"]
+	if !ok || jsonPath == "" {
+		return []byte{}, ErrMissingCloudPlanJSON
+	}
+	return cloudAPI(host, jsonPath, token)
+}
+
 func findCloudToken(host string) string {
 	if os.Getenv("TF_CLI_CONFIG_FILE") != "" {
 		log.Debugf("TF_CLI_CONFIG_FILE is set, checking %s for Terraform Cloud credentials", os.Getenv("TF_CLI_CONFIG_FILE"))
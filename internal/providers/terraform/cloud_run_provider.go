@@ -0,0 +1,197 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/pkg/errors"
+)
+
+// cloudRunPollInterval and cloudRunTimeout control how long to wait for a
+// Terraform Cloud run that hasn't finished planning yet.
+const (
+	cloudRunPollInterval = 5 * time.Second
+	cloudRunTimeout      = 30 * time.Minute
+)
+
+// Runs in these statuses are still waiting for their plan to finish.
+var cloudRunPendingStatuses = []string{
+	"pending",
+	"fetching",
+	"fetching_completed",
+	"queuing",
+	"pre_plan_running",
+	"pre_plan_completed",
+	"plan_queued",
+	"planning",
+}
+
+// Runs in these statuses never produce a plan that can be estimated.
+var cloudRunFailedStatuses = []string{
+	"errored",
+	"canceled",
+	"force_canceled",
+}
+
+// CloudRunProvider fetches the plan JSON of a Terraform Cloud run and parses
+// it like a plan JSON file.
+type CloudRunProvider struct {
+	RunID               string
+	TerraformCloudHost  string
+	TerraformCloudToken string
+	env                 *config.Environment
+	spinnerOpts         ui.SpinnerOptions
+	regions             *regionMapper
+	capacityBasis       string
+}
+
+func NewCloudRunProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	return &CloudRunProvider{
+		RunID:               projectCfg.TerraformCloudRun,
+		TerraformCloudHost:  projectCfg.TerraformCloudHost,
+		TerraformCloudToken: projectCfg.TerraformCloudToken,
+		env:                 cfg.Environment,
+		spinnerOpts: ui.SpinnerOptions{
+			EnableLogging: cfg.IsLogging(),
+			NoColor:       cfg.NoColor,
+			Indent:        "  ",
+		},
+		regions:       newRegionMapper(cfg.RegionMapping, cfg.DefaultRegion),
+		capacityBasis: strings.ToLower(cfg.CapacityBasis),
+	}
+}
+
+func (p *CloudRunProvider) Type() string {
+	return "terraform_cloud_run"
+}
+
+func (p *CloudRunProvider) DisplayType() string {
+	return "Terraform Cloud run"
+}
+
+func (p *CloudRunProvider) LoadResources(usage map[string]*schema.UsageData) (*schema.Project, error) {
+	metadata := map[string]string{
+		"terraformCloudRun": p.RunID,
+	}
+	var project *schema.Project = schema.NewProject(p.RunID, metadata)
+
+	j, err := p.fetchPlanJSON()
+	if err != nil {
+		return project, err
+	}
+
+	parser := NewParser(p.env)
+	parser.regions = p.regions
+	parser.capacityBasis = p.capacityBasis
+
+	pastResources, resources, err := parser.parseJSON(j, usage)
+	if err != nil {
+		return project, errors.Wrap(err, "Error parsing Terraform Cloud plan JSON")
+	}
+
+	project.PastResources = pastResources
+	project.Resources = resources
+	project.PlanMetadata = parsePlanMetadata(j, "", "", "")
+
+	return project, nil
+}
+
+func (p *CloudRunProvider) fetchPlanJSON() ([]byte, error) {
+	host := p.TerraformCloudHost
+	if host == "" {
+		host = "app.terraform.io"
+	}
+
+	token := p.TerraformCloudToken
+	if token == "" {
+		token = findCloudToken(host)
+	}
+	if token == "" {
+		return []byte{}, errors.Wrap(ErrMissingCloudToken, "Set --terraform-cloud-token or INFRACOST_TERRAFORM_CLOUD_TOKEN, or run terraform login")
+	}
+
+	spinner := ui.NewSpinner(fmt.Sprintf("Fetching Terraform Cloud run %s", p.RunID), p.spinnerOpts)
+
+	err := waitForCloudRun(host, p.RunID, token)
+	if err == nil {
+		var j []byte
+		j, err = cloudPlanJSON(host, p.RunID, token)
+		if err == nil {
+			spinner.Success()
+			return j, nil
+		}
+		// The run exists, so a 404 means the plan JSON doesn't
+		if errors.Is(err, ErrCloudNotFound) || errors.Is(err, ErrMissingCloudPlanJSON) {
+			err = errors.Errorf("Run %s doesn't have a JSON plan, this needs Terraform 0.12 or later", p.RunID)
+		}
+	}
+
+	spinner.Fail()
+
+	switch {
+	case errors.Is(err, ErrInvalidCloudToken):
+		return []byte{}, errors.Wrapf(err, "Terraform Cloud rejected the token for %s", host)
+	case errors.Is(err, ErrCloudNotFound):
+		return []byte{}, errors.Errorf("Run %s could not be found on %s, check the run ID and that the token can access its workspace", p.RunID, host)
+	}
+
+	return []byte{}, err
+}
+
+// waitForCloudRun polls the run until its plan has finished.
+func waitForCloudRun(host string, runID string, token string) error {
+	deadline := time.Now().Add(cloudRunTimeout)
+
+	for {
+		status, err := cloudRunStatus(host, runID, token)
+		if err != nil {
+			return err
+		}
+
+		pending, err := checkCloudRunStatus(runID, status)
+		if err != nil || !pending {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("Timed out waiting for run %s to finish planning, it is %s", runID, status)
+		}
+
+		time.Sleep(cloudRunPollInterval)
+	}
+}
+
+func cloudRunStatus(host string, runID string, token string) (string, error) {
+	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s", runID), token)
+	if err != nil {
+		return "", err
+	}
+
+	var parsedResp struct {
+		Data struct {
+			Attributes struct {
+				Status string
+			}
+		}
+	}
+	if err := json.Unmarshal(body, &parsedResp); err != nil {
+		return "", errors.Wrap(err, "Error parsing Terraform Cloud run")
+	}
+
+	return parsedResp.Data.Attributes.Status, nil
+}
+
+// checkCloudRunStatus returns true if the run is still planning, or an error
+// if it will never have a plan.
+func checkCloudRunStatus(runID string, status string) (bool, error) {
+	if containsString(cloudRunFailedStatuses, status) {
+		return false, errors.Errorf("Run %s is %s so it has no plan to estimate", runID, status)
+	}
+
+	return containsString(cloudRunPendingStatuses, status), nil
+}
@@ -0,0 +1,30 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCloudRunStatus(t *testing.T) {
+	tests := []struct {
+		status  string
+		pending bool
+		err     bool
+	}{
+		{"plan_queued", true, false},
+		{"planning", true, false},
+		{"planned", false, false},
+		{"planned_and_finished", false, false},
+		{"cost_estimated", false, false},
+		{"applied", false, false},
+		{"errored", false, true},
+		{"canceled", false, true},
+	}
+
+	for _, test := range tests {
+		pending, err := checkCloudRunStatus("run-123", test.status)
+		assert.Equal(t, test.pending, pending, test.status)
+		assert.Equal(t, test.err, err != nil, test.status)
+	}
+}
@@ -21,6 +21,10 @@ type CmdOptions struct {
 	Dir                 string
 	TerraformWorkspace  string
 	TerraformConfigFile string
+	// SensitiveValues are redacted from the command and its output before they are logged
+	SensitiveValues []string
+	// Env is added to the environment of the command, it's never logged
+	Env []string
 }
 
 type CmdError struct {
@@ -39,7 +43,7 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 	}
 
 	cmd := exec.Command(exe, args...)
-	log.Infof("Running command: %s", cmd.String())
+	log.Infof("Running command: %s", redactValues(cmd.String(), opts.SensitiveValues))
 	cmd.Dir = opts.Dir
 	cmd.Env = os.Environ()
 	cmd.Env = append(cmd.Env, "TF_IN_AUTOMATION=true")
@@ -52,14 +56,18 @@ func Cmd(opts *CmdOptions, args ...string) ([]byte, error) {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", opts.TerraformConfigFile))
 	}
 
+	cmd.Env = append(cmd.Env, opts.Env...)
+
 	logWriter := &cmdLogWriter{
-		logger: log.StandardLogger().WithField("binary", "terraform"),
-		level:  log.DebugLevel,
+		logger:          log.StandardLogger().WithField("binary", "terraform"),
+		level:           log.DebugLevel,
+		sensitiveValues: opts.SensitiveValues,
 	}
 
 	terraformLogWriter := &cmdLogWriter{
-		logger: log.StandardLogger().WithField("binary", "terraform"),
-		level:  log.DebugLevel,
+		logger:          log.StandardLogger().WithField("binary", "terraform"),
+		level:           log.DebugLevel,
+		sensitiveValues: opts.SensitiveValues,
 	}
 
 	var outbuf bytes.Buffer
@@ -90,10 +98,11 @@ type cmdLogger interface {
 // Adapted from https://github.com/sirupsen/logrus/issues/564#issuecomment-345471558
 // Needed to ensure we can log large Terraform output lines.
 type cmdLogWriter struct {
-	logger cmdLogger
-	level  log.Level
-	buf    bytes.Buffer
-	mu     sync.Mutex
+	logger          cmdLogger
+	level           log.Level
+	sensitiveValues []string
+	buf             bytes.Buffer
+	mu              sync.Mutex
 }
 
 func (w *cmdLogWriter) Write(b []byte) (int, error) {
@@ -118,7 +127,7 @@ func (w *cmdLogWriter) Write(b []byte) (int, error) {
 }
 
 func (w *cmdLogWriter) alwaysFlush() {
-	w.logger.Log(w.level, w.buf.String())
+	w.logger.Log(w.level, redactValues(w.buf.String(), w.sensitiveValues))
 	w.buf.Reset()
 }
 
@@ -1,7 +1,6 @@
 package terraform
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -9,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/infracost/infracost/internal/config"
@@ -31,9 +31,16 @@ type DirProvider struct {
 	PlanFlags           string
 	Workspace           string
 	UseState            bool
+	VarsFrom            []string
 	TerraformBinary     string
 	TerraformCloudHost  string
 	TerraformCloudToken string
+	UsageAnnotations    string
+	// UsePlanCache reuses the plan JSON of earlier runs in the same process
+	// if the Terraform files and plan flags haven't changed, see planCacheKey
+	UsePlanCache  bool
+	regions       *regionMapper
+	capacityBasis string
 }
 
 func NewDirProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
@@ -53,9 +60,14 @@ func NewDirProvider(cfg *config.Config, projectCfg *config.Project) schema.Provi
 		PlanFlags:           projectCfg.TerraformPlanFlags,
 		Workspace:           projectCfg.TerraformWorkspace,
 		UseState:            projectCfg.TerraformUseState,
+		VarsFrom:            projectCfg.TerraformVarsFrom,
+		UsageAnnotations:    projectCfg.UsageAnnotations,
 		TerraformBinary:     terraformBinary,
 		TerraformCloudHost:  projectCfg.TerraformCloudHost,
 		TerraformCloudToken: projectCfg.TerraformCloudToken,
+		UsePlanCache:        cfg.Watch,
+		regions:             newRegionMapper(cfg.RegionMapping, cfg.DefaultRegion),
+		capacityBasis:       strings.ToLower(cfg.CapacityBasis),
 	}
 }
 
@@ -82,7 +94,7 @@ func (p *DirProvider) checks() error {
 
 func (p *DirProvider) LoadResources(usage map[string]*schema.UsageData) (*schema.Project, error) {
 	metadata := make(map[string]string)
-	if p.Workspace != "" {
+	if p.Workspace != "" && !p.UseState {
 		metadata["terraformWorkspace"] = p.Workspace
 	}
 
@@ -100,22 +112,57 @@ func (p *DirProvider) LoadResources(usage map[string]*schema.UsageData) (*schema
 		return project, err
 	}
 
+	if p.UsageAnnotations != "" && p.UsageAnnotations != UsageAnnotationsOff {
+		usage = mergeUsageAnnotations(usage, loadUsageAnnotations(p.Path), p.UsageAnnotations == UsageAnnotationsOverride)
+	}
+
 	parser := NewParser(p.env)
+	parser.regions = p.regions
+	parser.capacityBasis = p.capacityBasis
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
 		return project, errors.Wrap(err, "Error parsing Terraform JSON")
 	}
 
+	setSourceLocations(p.Path, pastResources, resources)
+
 	project.HasDiff = !p.UseState
 	if project.HasDiff {
 		project.PastResources = pastResources
 	}
 	project.Resources = resources
+	project.PlanMetadata = parsePlanMetadata(j, p.env.TerraformVersion, p.Path, p.Path)
 
 	return project, nil
 }
 
 func (p *DirProvider) generatePlanJSON() ([]byte, error) {
+	if !p.UsePlanCache {
+		return p.runPlanJSON()
+	}
+
+	key, err := p.planCacheKey()
+	if err != nil {
+		log.Debugf("Not using the plan cache: %s", err)
+		return p.runPlanJSON()
+	}
+
+	if j, ok := getCachedPlan(p.Path, key); ok {
+		log.Debugf("Using the cached plan of %s", p.Path)
+		return j, nil
+	}
+
+	j, err := p.runPlanJSON()
+	if err != nil {
+		return j, err
+	}
+
+	setCachedPlan(p.Path, key, j)
+
+	return j, nil
+}
+
+func (p *DirProvider) runPlanJSON() ([]byte, error) {
 	err := p.checks()
 	if err != nil {
 		return []byte{}, err
@@ -129,7 +176,23 @@ func (p *DirProvider) generatePlanJSON() ([]byte, error) {
 		defer os.Remove(opts.TerraformConfigFile)
 	}
 
-	planFile, planJSON, err := p.runPlan(opts, true)
+	if p.Workspace != "" {
+		restore, err := p.selectWorkspace(opts, true)
+		if err != nil {
+			return []byte{}, err
+		}
+		defer restore()
+	}
+
+	vars, err := resolveVarsFrom(p.VarsFrom)
+	if err != nil {
+		return []byte{}, err
+	}
+	for _, v := range vars {
+		opts.SensitiveValues = append(opts.SensitiveValues, v)
+	}
+
+	planFile, planJSON, err := p.runPlan(opts, vars, true)
 	defer os.Remove(planFile)
 
 	if err != nil {
@@ -162,9 +225,8 @@ func (p *DirProvider) generateStateJSON() ([]byte, error) {
 
 func (p *DirProvider) buildCommandOpts() (*CmdOptions, error) {
 	opts := &CmdOptions{
-		TerraformBinary:    p.TerraformBinary,
-		TerraformWorkspace: p.Workspace,
-		Dir:                p.Path,
+		TerraformBinary: p.TerraformBinary,
+		Dir:             p.Path,
 	}
 
 	cfgFile, err := CreateConfigFile(p.Path, p.TerraformCloudHost, p.TerraformCloudToken)
@@ -177,7 +239,7 @@ func (p *DirProvider) buildCommandOpts() (*CmdOptions, error) {
 	return opts, nil
 }
 
-func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte, error) {
+func (p *DirProvider) runPlan(opts *CmdOptions, vars map[string]string, initOnFail bool) (string, []byte, error) {
 	spinner := ui.NewSpinner("Running terraform plan", p.spinnerOpts)
 	var planJSON []byte
 
@@ -194,7 +256,21 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 
 	args := []string{"plan", "-input=false", "-lock=false", "-no-color"}
 	args = append(args, flags...)
-	_, err = Cmd(opts, append(args, fmt.Sprintf("-out=%s", f.Name()))...)
+
+	// Pass these as TF_VAR_ environment variables rather than -var flags so the
+	// values don't show up in the process list
+	varNames := make([]string, 0, len(vars))
+	for name := range vars {
+		varNames = append(varNames, name)
+	}
+	sort.Strings(varNames)
+	planOpts := *opts
+	planOpts.Env = append([]string{}, opts.Env...)
+	for _, name := range varNames {
+		planOpts.Env = append(planOpts.Env, fmt.Sprintf("TF_VAR_%s=%s", name, vars[name]))
+	}
+
+	_, err = Cmd(&planOpts, append(args, fmt.Sprintf("-out=%s", f.Name()))...)
 
 	// Check if the error requires a remote run or an init
 	if err != nil {
@@ -204,18 +280,14 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 		if strings.HasPrefix(extractedErr, "Error: Saving a generated plan is currently not supported") {
 			log.Info("Continuing with Terraform Remote Execution Mode")
 			p.env.TerraformRemoteExecutionModeEnabled = true
-			planJSON, err = p.runRemotePlan(opts, args)
-		} else if initOnFail && (strings.Contains(extractedErr, "Error: Could not load plugin") ||
-			strings.Contains(extractedErr, "Error: Initialization required") ||
-			strings.Contains(extractedErr, "Error: Module not installed") ||
-			strings.Contains(extractedErr, "Error: Provider requirements cannot be satisfied by locked dependencies") ||
-			strings.Contains(extractedErr, "Error: Module not installed")) {
+			planJSON, err = p.runRemotePlan(&planOpts, args)
+		} else if initOnFail && requiresInit(extractedErr) {
 			spinner.Stop()
 			err = p.runInit(opts)
 			if err != nil {
 				return "", planJSON, err
 			}
-			return p.runPlan(opts, false)
+			return p.runPlan(opts, vars, false)
 		}
 	}
 
@@ -233,7 +305,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 			msg += "Create a Team or User API Token in the Terraform Cloud dashboard and set this environment variable."
 			fmt.Fprintln(os.Stderr, msg)
 		} else {
-			printTerraformErr(err)
+			printTerraformErr(err, opts.SensitiveValues)
 		}
 		return "", planJSON, errors.Wrap(err, "Error running terraform plan")
 	}
@@ -243,13 +315,65 @@ func (p *DirProvider) runPlan(opts *CmdOptions, initOnFail bool) (string, []byte
 	return f.Name(), planJSON, nil
 }
 
+// selectWorkspace runs terraform workspace select for the workspace of the
+// project and returns a func that selects the previously selected workspace
+// again, which should be deferred so it's restored even if the plan fails.
+func (p *DirProvider) selectWorkspace(opts *CmdOptions, initOnFail bool) (func(), error) {
+	out, err := Cmd(opts, "workspace", "show")
+	if err != nil {
+		printTerraformErr(err, opts.SensitiveValues)
+		return func() {}, errors.Wrap(err, "Error running terraform workspace show")
+	}
+	previous := strings.TrimSpace(string(out))
+
+	if previous == p.Workspace {
+		return func() {}, nil
+	}
+
+	spinner := ui.NewSpinner(fmt.Sprintf("Selecting terraform workspace %s", p.Workspace), p.spinnerOpts)
+
+	_, err = Cmd(opts, "workspace", "select", p.Workspace)
+	if err != nil {
+		if initOnFail && requiresInit(extractStderr(err)) {
+			spinner.Stop()
+			err = p.runInit(opts)
+			if err != nil {
+				return func() {}, err
+			}
+			return p.selectWorkspace(opts, false)
+		}
+
+		spinner.Fail()
+		printTerraformErr(err, opts.SensitiveValues)
+		return func() {}, errors.Wrap(err, "Error running terraform workspace select")
+	}
+
+	spinner.Success()
+
+	return func() {
+		_, err := Cmd(opts, "workspace", "select", previous)
+		if err != nil {
+			ui.PrintWarningf("Could not restore the terraform workspace %s: %s", previous, extractStderr(err))
+		}
+	}, nil
+}
+
+// requiresInit returns true if the stderr of a Terraform command shows that
+// terraform init has to be run first.
+func requiresInit(stderr string) bool {
+	return strings.Contains(stderr, "Error: Could not load plugin") ||
+		strings.Contains(stderr, "Error: Initialization required") ||
+		strings.Contains(stderr, "Error: Module not installed") ||
+		strings.Contains(stderr, "Error: Provider requirements cannot be satisfied by locked dependencies")
+}
+
 func (p *DirProvider) runInit(opts *CmdOptions) error {
 	spinner := ui.NewSpinner("Running terraform init", p.spinnerOpts)
 
 	_, err := Cmd(opts, "init", "-input=false", "-no-color")
 	if err != nil {
 		spinner.Fail()
-		printTerraformErr(err)
+		printTerraformErr(err, opts.SensitiveValues)
 		return errors.Wrap(err, "Error running terraform init")
 	}
 
@@ -289,25 +413,7 @@ func (p *DirProvider) runRemotePlan(opts *CmdOptions, args []string) ([]byte, er
 		return []byte{}, ErrMissingCloudToken
 	}
 
-	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	var parsedResp struct {
-		Data struct {
-			Links map[string]string
-		}
-	}
-	if json.Unmarshal(body, &parsedResp) != nil {
-		return []byte{}, err
-	}
-
-	jsonPath, ok := parsedResp.Data.Links["json-output"]
-	if !ok || jsonPath == "" {
-		return []byte{}, errors.New("Could not parse path to plan JSON from remote")
-	}
-	return cloudAPI(host, jsonPath, token)
+	return cloudPlanJSON(host, runID, token)
 }
 
 func (p *DirProvider) runShow(opts *CmdOptions, planFile string) ([]byte, error) {
@@ -320,7 +426,7 @@ func (p *DirProvider) runShow(opts *CmdOptions, planFile string) ([]byte, error)
 	out, err := Cmd(opts, args...)
 	if err != nil {
 		spinner.Fail()
-		printTerraformErr(err)
+		printTerraformErr(err, opts.SensitiveValues)
 		return []byte{}, errors.Wrap(err, "Error running terraform show")
 	}
 	spinner.Success()
@@ -349,8 +455,10 @@ func checkTerraformVersion(env *config.Environment) (string, bool) {
 	return v, semver.Compare(v, minTerraformVer) >= 0
 }
 
-func printTerraformErr(err error) {
-	stderr := extractStderr(err)
+// printTerraformErr prints the stderr of a failed Terraform command with any
+// sensitive values redacted, plus a hint for the common errors.
+func printTerraformErr(err error, sensitiveValues []string) {
+	stderr := redactValues(extractStderr(err), sensitiveValues)
 	if stderr == "" {
 		return
 	}
@@ -0,0 +1,128 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectWorkspace(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows since it uses a shell script")
+	}
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "commands.log")
+	binary := filepath.Join(dir, "terraform")
+
+	script := `#!/bin/sh
+echo "$@" >> ` + logFile + `
+if [ "$1 $2" = "workspace show" ]; then
+  echo default
+fi
+`
+	require.NoError(t, ioutil.WriteFile(binary, []byte(script), 0700))
+
+	p := &DirProvider{Path: dir, Workspace: "prod", TerraformBinary: binary}
+	opts := &CmdOptions{TerraformBinary: binary, Dir: dir}
+
+	restore, err := p.selectWorkspace(opts, true)
+	require.NoError(t, err)
+	restore()
+
+	b, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"workspace show",
+		"workspace select prod",
+		"workspace select default",
+	}, strings.Split(strings.TrimSpace(string(b)), "\n"))
+}
+
+func TestGeneratePlanJSONUsesPlanCache(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_instance" "a" {}`), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".terraform"), 0700))
+
+	p := &DirProvider{Path: dir, PlanFlags: "-var a=b", UsePlanCache: true}
+
+	key, err := p.planCacheKey()
+	require.NoError(t, err)
+	setCachedPlan(dir, key, []byte(`{"cached":true}`))
+
+	// Files in hidden dirs don't change the key
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".terraform", "x.tf"), []byte("changed"), 0600))
+
+	j, err := p.generatePlanJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"cached":true}`, string(j))
+
+	p.PlanFlags = "-var a=c"
+	changedFlags, err := p.planCacheKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changedFlags)
+
+	p.PlanFlags = "-var a=b"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_instance" "b" {}`), 0600))
+	changedFiles, err := p.planCacheKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changedFiles)
+
+	// Only the latest plan of the dir is kept
+	setCachedPlan(dir, changedFiles, []byte(`{"changed":true}`))
+	_, ok := getCachedPlan(dir, key)
+	assert.False(t, ok)
+	j, ok = getCachedPlan(dir, changedFiles)
+	assert.True(t, ok)
+	assert.Equal(t, `{"changed":true}`, string(j))
+}
+
+func TestRunPlanPassesVarsInEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows since it uses a shell script")
+	}
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "commands.log")
+	binary := filepath.Join(dir, "terraform")
+
+	script := `#!/bin/sh
+echo "$@" >> ` + logFile + `
+echo "TF_VAR_db_password=$TF_VAR_db_password" >> ` + logFile + `
+echo "Error: invalid password $TF_VAR_db_password" >&2
+exit 1
+`
+	require.NoError(t, ioutil.WriteFile(binary, []byte(script), 0700))
+
+	p := &DirProvider{Path: dir, TerraformBinary: binary}
+	opts := &CmdOptions{TerraformBinary: binary, Dir: dir, SensitiveValues: []string{"s3cr3t"}}
+
+	stderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	_, _, err = p.runPlan(opts, map[string]string{"db_password": "s3cr3t"}, false)
+
+	w.Close()
+	os.Stderr = stderr
+	assert.Error(t, err)
+
+	printed, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(printed), "Error: invalid password ***")
+	assert.NotContains(t, string(printed), "s3cr3t")
+
+	b, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], "s3cr3t")
+	assert.Equal(t, "TF_VAR_db_password=s3cr3t", lines[1])
+}
@@ -0,0 +1,143 @@
+package google
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+var secondsPerMonth = decimal.NewFromInt(730 * 60 * 60)
+
+func GetCloudRunServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_cloud_run_service",
+		RFunc: NewCloudRunService,
+		Notes: []string{
+			"The min instances are charged at the idle rate for the whole month.",
+			"The request CPU and memory assume each instance serves average_concurrent_requests at a time, defaulting to 1.",
+		},
+	}
+}
+
+func NewCloudRunService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("location").String()
+	if region == "" {
+		region = d.Get("region").String()
+	}
+
+	limits := d.Get("template.0.spec.0.containers.0.resources.0.limits").Map()
+	vCPU := cloudRunCPU(limits["cpu"].String())
+	memoryGiB := cloudRunMemoryGiB(limits["memory"].String())
+
+	costComponents := make([]*schema.CostComponent, 0)
+
+	minInstances := decimal.Zero
+	if v, ok := d.Get("template.0.metadata.0.annotations").Map()["autoscaling.knative.dev/minScale"]; ok {
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			minInstances = decimal.NewFromInt(i)
+		}
+	}
+
+	if minInstances.IsPositive() {
+		instanceSeconds := minInstances.Mul(secondsPerMonth)
+		costComponents = append(costComponents,
+			cloudRunCostComponent(region, "Min instances CPU", "vCPU-seconds", "/^Idle Min-Instance CPU Allocation Time/", decimalPtr(instanceSeconds.Mul(vCPU))),
+			cloudRunCostComponent(region, "Min instances memory", "GiB-seconds", "/^Idle Min-Instance Memory Allocation Time/", decimalPtr(instanceSeconds.Mul(memoryGiB))),
+		)
+	}
+
+	var cpuSeconds, memorySeconds, requests *decimal.Decimal
+	if u != nil && u.Get("monthly_requests").Exists() && u.Get("request_duration_ms").Exists() {
+		concurrency := decimal.NewFromInt(1)
+		if u.Get("average_concurrent_requests").Int() > 0 {
+			concurrency = decimal.NewFromInt(u.Get("average_concurrent_requests").Int())
+		}
+
+		requests = decimalPtr(decimal.NewFromInt(u.Get("monthly_requests").Int()))
+		instanceSeconds := requests.Mul(decimal.NewFromInt(u.Get("request_duration_ms").Int())).Div(decimal.NewFromInt(1000)).Div(concurrency)
+		cpuSeconds = decimalPtr(instanceSeconds.Mul(vCPU))
+		memorySeconds = decimalPtr(instanceSeconds.Mul(memoryGiB))
+	}
+
+	requestsComponent := cloudRunCostComponent(region, "Requests", "1M requests", "/^Requests/", requests)
+	requestsComponent.UnitMultiplier = 1000000
+
+	costComponents = append(costComponents,
+		cloudRunCostComponent(region, "CPU", "vCPU-seconds", "/^CPU Allocation Time/", cpuSeconds),
+		cloudRunCostComponent(region, "Memory", "GiB-seconds", "/^Memory Allocation Time/", memorySeconds),
+		requestsComponent,
+	)
+
+	r := &schema.Resource{
+		Name:           d.Address,
+		CostComponents: costComponents,
+	}
+
+	if requests == nil {
+		r.UsageMessage = "Set monthly_requests and request_duration_ms in the usage file to estimate the request costs"
+	}
+
+	return r
+}
+
+func cloudRunCostComponent(region string, name string, unit string, description string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  1,
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("Cloud Run"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: strPtr(description)},
+			},
+		},
+	}
+}
+
+// cloudRunCPU parses a Kubernetes CPU quantity, e.g. 1000m or 2, defaulting
+// to the 1 vCPU Cloud Run uses if there's no limit.
+func cloudRunCPU(cpu string) decimal.Decimal {
+	if strings.HasSuffix(cpu, "m") {
+		if v, err := decimal.NewFromString(strings.TrimSuffix(cpu, "m")); err == nil {
+			return v.Div(decimal.NewFromInt(1000))
+		}
+	}
+
+	if v, err := decimal.NewFromString(cpu); err == nil {
+		return v
+	}
+
+	return decimal.NewFromInt(1)
+}
+
+// cloudRunMemoryGiB parses a Kubernetes memory quantity, e.g. 512Mi or 2G,
+// defaulting to the 512Mi Cloud Run uses if there's no limit.
+func cloudRunMemoryGiB(memory string) decimal.Decimal {
+	units := []struct {
+		suffix string
+		bytes  float64
+	}{
+		{"Ki", 1 << 10},
+		{"Mi", 1 << 20},
+		{"Gi", 1 << 30},
+		{"K", 1e3},
+		{"M", 1e6},
+		{"G", 1e9},
+	}
+
+	for _, unit := range units {
+		if strings.HasSuffix(memory, unit.suffix) {
+			if v, err := decimal.NewFromString(strings.TrimSuffix(memory, unit.suffix)); err == nil {
+				return v.Mul(decimal.NewFromFloat(unit.bytes)).Div(decimal.NewFromInt(1 << 30))
+			}
+		}
+	}
+
+	return decimal.NewFromFloat(0.5)
+}
@@ -49,7 +49,7 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 		networkEgrees = decimalPtr(decimal.NewFromInt(u.Get("monthly_outbound_data_gb").Int()))
 	}
 
-	return &schema.Resource{
+	r := &schema.Resource{
 		Name: d.Address,
 		CostComponents: []*schema.CostComponent{
 			{
@@ -120,6 +120,12 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 			},
 		},
 	}
+
+	if invocations == nil {
+		r.UsageMessage = "Set monthly_function_invocations and request_duration_ms in the usage file to estimate the CPU, memory and invocation costs"
+	}
+
+	return r
 }
 
 func calculateGBSeconds(memorySize decimal.Decimal, averageRequestDuration decimal.Decimal, monthlyRequests decimal.Decimal) decimal.Decimal {
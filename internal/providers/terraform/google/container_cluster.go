@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 
 	"github.com/shopspring/decimal"
@@ -18,6 +19,7 @@ func GetContainerClusterRegistryItem() *schema.RegistryItem {
 			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
 			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
 			"Custom machine types are not supported.",
+			"Autopilot clusters only include the cluster management fee, the pod resource requests are not supported.",
 		},
 	}
 }
@@ -32,7 +34,13 @@ func NewContainerCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 
 	subResources := make([]*schema.Resource, 0)
 
-	if !d.Get("remove_default_node_pool").Bool() {
+	// Autopilot clusters don't have node pools, instead the pods are billed by their resource requests
+	isAutopilot := d.Get("enable_autopilot").Bool()
+	if isAutopilot {
+		log.Warnf("Skipping the pod resource requests of the Autopilot cluster %s. Infracost currently only supports the cluster management fee for Autopilot clusters", d.Address)
+	}
+
+	if !isAutopilot && !d.Get("remove_default_node_pool").Bool() {
 		zones := int64(zoneCount(d.RawValues, ""))
 
 		countPerZone := int64(3)
@@ -57,7 +65,12 @@ func NewContainerCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Re
 		subResources = append(subResources, defaultPool)
 	}
 
-	for i, values := range d.Get("node_pool").Array() {
+	nodePools := d.Get("node_pool").Array()
+	if isAutopilot {
+		nodePools = nil
+	}
+
+	for i, values := range nodePools {
 		var countPerZoneOverride *int64
 		k := fmt.Sprintf("node_pool[%d].nodes", i)
 		if u != nil && u.Get(k).Exists() {
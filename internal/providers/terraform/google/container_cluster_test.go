@@ -14,3 +14,12 @@ func TestContainerClusterGoldenFile(t *testing.T) {
 
 	tftest.GoldenFileResourceTests(t, "container_cluster_test")
 }
+
+func TestContainerClusterWithNodePoolGoldenFile(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTests(t, "container_cluster_with_node_pool_test")
+}
@@ -18,6 +18,7 @@ func GetContainerNodePoolRegistryItem() *schema.RegistryItem {
 			"Sustained use discounts are applied to monthly costs, but not to hourly costs.",
 			"Costs associated with non-standard Linux images, such as Windows and RHEL are not supported.",
 			"Custom machine types are not supported.",
+			"Node pools with autoscaling use the node_count or initial_node_count, falling back to the autoscaling min_node_count. Use the nodes usage parameter to set the expected node count.",
 		},
 	}
 }
@@ -70,6 +71,12 @@ func newNodePool(address string, d gjson.Result, countPerZoneOverride *int64, cl
 
 	countPerZone := int64(3)
 
+	// For node pools with autoscaling assume the desired or initial node count,
+	// otherwise the minimum node count, since the actual count depends on the load.
+	if d.Get("autoscaling.0.min_node_count").Type != gjson.Null {
+		countPerZone = d.Get("autoscaling.0.min_node_count").Int()
+	}
+
 	if d.Get("initial_node_count").Type != gjson.Null {
 		countPerZone = d.Get("initial_node_count").Int()
 	}
@@ -78,8 +85,8 @@ func newNodePool(address string, d gjson.Result, countPerZoneOverride *int64, cl
 		countPerZone = d.Get("node_count").Int()
 	}
 
-	if d.Get("autoscaling.0.min_node_count").Type != gjson.Null {
-		countPerZone = d.Get("autoscaling.0.min_node_count").Int()
+	if d.Get("autoscaling.0").Exists() && countPerZoneOverride == nil {
+		log.Debugf("Using %d nodes per zone for %s which has autoscaling, set the nodes usage parameter to override this", countPerZone, address)
 	}
 
 	if countPerZoneOverride != nil {
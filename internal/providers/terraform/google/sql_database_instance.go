@@ -7,6 +7,7 @@ import (
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
 )
 
 type SQLInstanceDBType int
@@ -23,47 +24,70 @@ func GetSQLInstanceRegistryItem() *schema.RegistryItem {
 		RFunc: NewSQLInstance,
 		Notes: []string{
 			"Cloud SQL network, SQL Server license, 1-3 years commitments costs are not yet supported.",
+			"Read replicas use the tier, disk and database version of their primary instance if they don't set them.",
 		},
+		ReferenceAttributes: []string{"master_instance_name"},
 	}
 }
 
 func NewSQLInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	var resource *schema.Resource
 
-	replica := false
-	if d.Get("replica_configuration").Exists() {
-		replica = true
+	// Read replicas are separate resources that reference their primary
+	if d.Get("master_instance_name").String() != "" {
+		var primary *schema.ResourceData
+		if refs := d.References("master_instance_name"); len(refs) > 0 {
+			primary = refs[0]
+		}
+
+		availabilityType := "ZONAL"
+		if d.Get("settings.0.availability_type").Exists() {
+			availabilityType = d.Get("settings.0.availability_type").String()
+		}
+
+		return sqlDatabaseInstanceCostComponents(d, primary, u, availabilityType, true, d.Address)
+	}
+
+	availabilityType := "ZONAL"
+	if d.Get("settings.0.availability_type").Exists() {
+		availabilityType = d.Get("settings.0.availability_type").String()
 	}
 
-	resource = sqlDatabaseInstanceCostComponents(d, u, false, d.Address)
-	if replica {
-		resource.SubResources = append(resource.SubResources, sqlDatabaseInstanceCostComponents(d, u, true, "Replica"))
+	resource = sqlDatabaseInstanceCostComponents(d, nil, u, availabilityType, false, d.Address)
+	if d.Get("replica_configuration").Exists() {
+		resource.SubResources = append(resource.SubResources, sqlDatabaseInstanceCostComponents(d, nil, u, "ZONAL", true, "Replica"))
 	}
 
 	return resource
 }
 
-func sqlDatabaseInstanceCostComponents(d *schema.ResourceData, u *schema.UsageData, replica bool, name string) *schema.Resource {
-	var costComponents []*schema.CostComponent
-	tier := d.Get("settings.0").Get("tier").String()
-
-	availabilityType := "ZONAL"
-	if d.Get("settings.0").Get("availability_type").Exists() && !replica {
-		availabilityType = d.Get("settings.0").Get("availability_type").String()
+// sqlInstanceSetting returns the value of the key from the instance, or from
+// the primary if the instance is a replica that doesn't set it.
+func sqlInstanceSetting(d *schema.ResourceData, primary *schema.ResourceData, key string) gjson.Result {
+	v := d.Get(key)
+	if (!v.Exists() || v.Type == gjson.Null || v.String() == "") && primary != nil {
+		return primary.Get(key)
 	}
 
-	region := d.Get("region").String()
-	dbVersion := d.Get("database_version").String()
+	return v
+}
+
+func sqlDatabaseInstanceCostComponents(d *schema.ResourceData, primary *schema.ResourceData, u *schema.UsageData, availabilityType string, replica bool, name string) *schema.Resource {
+	var costComponents []*schema.CostComponent
+	tier := sqlInstanceSetting(d, primary, "settings.0.tier").String()
+
+	region := sqlInstanceSetting(d, primary, "region").String()
+	dbVersion := sqlInstanceSetting(d, primary, "database_version").String()
 	dbType := sqlInstanceDBVersionToDBType(dbVersion)
 
 	diskType := "PD_SSD"
-	if d.Get("settings.0").Get("disk_type").Exists() {
-		diskType = d.Get("settings.0").Get("disk_type").String()
+	if v := sqlInstanceSetting(d, primary, "settings.0.disk_type"); v.Exists() && v.Type != gjson.Null {
+		diskType = v.String()
 	}
 
 	var diskSizeGB int64 = 10
-	if d.Get("settings.0").Get("disk_size").Exists() {
-		diskSizeGB = d.Get("settings.0").Get("disk_size").Int()
+	if v := sqlInstanceSetting(d, primary, "settings.0.disk_size"); v.Exists() && v.Type != gjson.Null {
+		diskSizeGB = v.Int()
 	}
 
 	if sqlInstanceTierToResourceGroup(tier) != "" && dbType != SQLServer {
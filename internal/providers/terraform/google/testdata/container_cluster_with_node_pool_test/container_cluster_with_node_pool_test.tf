@@ -0,0 +1,34 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_container_cluster" "standard" {
+  name                     = "standard"
+  location                 = "us-central1"
+  remove_default_node_pool = true
+  initial_node_count       = 1
+}
+
+resource "google_container_node_pool" "primary" {
+  name       = "primary"
+  cluster    = google_container_cluster.standard.id
+  node_count = 2
+
+  autoscaling {
+    min_node_count = 1
+    max_node_count = 5
+  }
+
+  node_config {
+    machine_type = "e2-standard-4"
+    disk_type    = "pd-ssd"
+    disk_size_gb = 50
+  }
+}
+
+resource "google_container_cluster" "autopilot" {
+  name             = "autopilot"
+  location         = "us-central1"
+  enable_autopilot = true
+}
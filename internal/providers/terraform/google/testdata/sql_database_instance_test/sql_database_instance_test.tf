@@ -111,3 +111,22 @@ resource "google_sql_database_instance" "usage" {
     availability_type = "ZONAL"
   }
 }
+
+resource "google_sql_database_instance" "ha_primary" {
+  name             = "ha-primary"
+  database_version = "POSTGRES_11"
+  settings {
+    tier              = "db-custom-4-15360"
+    availability_type = "REGIONAL"
+    disk_size         = 100
+  }
+}
+
+resource "google_sql_database_instance" "read_replica" {
+  name                 = "read-replica"
+  database_version     = "POSTGRES_11"
+  master_instance_name = google_sql_database_instance.ha_primary.name
+  settings {
+    tier = "db-custom-4-15360"
+  }
+}
@@ -2,12 +2,16 @@ package terraform
 
 import (
 	"fmt"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -22,6 +26,15 @@ var defaultProviderRegions = map[string]string{
 	"azurerm": "eastus",
 }
 
+// reverseReferenceAttributes are the attributes, by the type of the
+// referencing resource, that are also added to the referenced resource as a
+// reverse reference, see addReference.
+var reverseReferenceAttributes = map[string][]string{
+	"aws_eip_association": {"allocation_id"},
+	"aws_alb_listener":    {"load_balancer_arn"},
+	"aws_lb_listener":     {"load_balancer_arn"},
+}
+
 // ARN attribute mapping for resources that don't have a standard 'arn' attribute
 var arnAttributeMap = map[string]string{
 	"aws_cloudwatch_dashboard":     "dashboard_arn",
@@ -37,11 +50,36 @@ var arnAttributeMap = map[string]string{
 }
 
 type Parser struct {
-	env *config.Environment
+	env     *config.Environment
+	regions *regionMapper
+	// capacityBasis is set as the CapacityBasis of the resources
+	capacityBasis string
+	// unknownRegions are the regions of resources that aren't canonical and
+	// couldn't be mapped. They're used as is, since they might be newer than
+	// the check, with a warning
+	unknownRegions map[string]bool
+	// fallbackRegions are the addresses of the resources that use the default
+	// region since their region couldn't be mapped
+	fallbackRegions map[string]bool
 }
 
 func NewParser(env *config.Environment) *Parser {
-	return &Parser{env: env}
+	return &Parser{
+		env:             env,
+		regions:         newRegionMapper(nil, ""),
+		unknownRegions:  make(map[string]bool),
+		fallbackRegions: make(map[string]bool),
+	}
+}
+
+// dataRegion returns the region of the resource, or the location of Azure
+// resources.
+func dataRegion(d *schema.ResourceData) string {
+	if region := d.Get("region").String(); region != "" {
+		return region
+	}
+
+	return d.Get("location").String()
 }
 
 func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
@@ -63,10 +101,17 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 			}
 		}
 
-		res := registryItem.RFunc(d, u)
+		// The keys read are recorded for each resource, including those
+		// without usage data so the defaulted keys are known
+		recorder := schema.NewUsageKeyRecorder()
+
+		res := registryItem.RFunc(d, u.WithRecorder(d.Address, recorder))
 		if res != nil {
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.Region = dataRegion(d)
+			res.RegionFallback = p.fallbackRegions[d.Address]
+			res.EstimatedUsageKeys, res.DefaultedUsageKeys = recorder.Keys()
 			return res
 		}
 	}
@@ -94,6 +139,7 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 	}
 
 	resData := p.parseResourceData(providerConf, vals, conf, vars)
+	changes := parseResourceChanges(parsed)
 
 	p.parseReferences(resData, conf)
 	p.loadInfracostProviderUsageData(usage, resData)
@@ -112,6 +158,11 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 			}
 		}
 		if r := p.createResource(d, usageData); r != nil {
+			if c, ok := changes[d.Address]; ok {
+				r.ChangeActions = c.actions
+				r.IsTagOnlyChange = c.isTagOnly
+				r.AttributeChanges = c.attributeChanges
+			}
 			resources = append(resources, r)
 		}
 	}
@@ -119,6 +170,164 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 	return resources
 }
 
+// tagAttributes are the attributes that only contain tags or labels. The AWS
+// provider default_tags are added to tags_all.
+var tagAttributes = []string{"tags", "tags_all", "labels"}
+
+// costRelevantAttributes are the attribute names, or suffixes of attribute names,
+// that usually affect the cost of a resource, e.g. instance_type or desired_capacity.
+var costRelevantAttributes = []string{"type", "class", "size", "count", "capacity", "tier", "sku", "sku_name", "iops", "throughput", "storage", "replicas", "gb"}
+
+type resourceChange struct {
+	actions          []string
+	isTagOnly        bool
+	attributeChanges []schema.AttributeChange
+}
+
+// parseResourceChanges returns the change actions of each resource in the plan
+// JSON keyed by resource address. State JSON doesn't have any resource changes.
+func parseResourceChanges(parsed gjson.Result) map[string]resourceChange {
+	changes := make(map[string]resourceChange)
+
+	for _, c := range parsed.Get("resource_changes").Array() {
+		actions := make([]string, 0)
+		for _, a := range c.Get("change.actions").Array() {
+			actions = append(actions, a.String())
+		}
+
+		isTagOnly := len(actions) == 1 && actions[0] == "update" &&
+			isTagOnlyChange(c.Get("change.before"), c.Get("change.after"), c.Get("change.after_unknown"))
+
+		var attributeChanges []schema.AttributeChange
+		if containsString(actions, "update") || containsString(actions, "delete") && containsString(actions, "create") {
+			attributeChanges = costRelevantChanges(c.Get("change.before"), c.Get("change.after"), c.Get("change.after_unknown"))
+		}
+
+		changes[c.Get("address").String()] = resourceChange{
+			actions:          actions,
+			isTagOnly:        isTagOnly,
+			attributeChanges: attributeChanges,
+		}
+	}
+
+	return changes
+}
+
+// isTagOnlyChange returns true if the only attributes that differ between the
+// before and after values are tag attributes.
+func isTagOnlyChange(before, after, afterUnknown gjson.Result) bool {
+	keys := make(map[string]bool)
+	for k := range before.Map() {
+		keys[k] = true
+	}
+	for k := range after.Map() {
+		keys[k] = true
+	}
+	for k, v := range afterUnknown.Map() {
+		if hasUnknownValue(v) {
+			keys[k] = true
+		}
+	}
+
+	tagsChanged := false
+	for k := range keys {
+		isChanged := hasUnknownValue(afterUnknown.Get(gjsonEscape(k))) ||
+			!jsonEqual(before.Get(gjsonEscape(k)), after.Get(gjsonEscape(k)))
+
+		if !isChanged {
+			continue
+		}
+
+		if !containsString(tagAttributes, k) {
+			return false
+		}
+
+		tagsChanged = true
+	}
+
+	return tagsChanged
+}
+
+// costRelevantChanges returns the before and after values of the top-level
+// cost-relevant attributes that are changed, sorted by attribute name. Nested
+// blocks and attributes that are unknown until apply are ignored to keep the
+// change reasons short.
+func costRelevantChanges(before, after, afterUnknown gjson.Result) []schema.AttributeChange {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, vals := range []gjson.Result{before, after} {
+		for k := range vals.Map() {
+			if !seen[k] && isCostRelevantAttribute(k) {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []schema.AttributeChange
+	for _, k := range keys {
+		b := before.Get(gjsonEscape(k))
+		a := after.Get(gjsonEscape(k))
+
+		if hasUnknownValue(afterUnknown.Get(gjsonEscape(k))) || b.IsObject() || b.IsArray() || a.IsObject() || a.IsArray() {
+			continue
+		}
+
+		if jsonEqual(b, a) {
+			continue
+		}
+
+		changes = append(changes, schema.AttributeChange{
+			Name:   k,
+			Before: attributeValueString(b),
+			After:  attributeValueString(a),
+		})
+	}
+
+	return changes
+}
+
+func isCostRelevantAttribute(k string) bool {
+	for _, a := range costRelevantAttributes {
+		if k == a || strings.HasSuffix(k, "_"+a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func attributeValueString(v gjson.Result) string {
+	if !v.Exists() || v.Type == gjson.Null {
+		return "none"
+	}
+
+	return v.String()
+}
+
+// hasUnknownValue returns true if the after_unknown value of an attribute
+// marks it, or any of its nested values, as unknown until apply.
+func hasUnknownValue(v gjson.Result) bool {
+	if v.Type == gjson.True {
+		return true
+	}
+
+	unknown := false
+	if v.IsObject() || v.IsArray() {
+		v.ForEach(func(_, nested gjson.Result) bool {
+			unknown = hasUnknownValue(nested)
+			return !unknown
+		})
+	}
+
+	return unknown
+}
+
+func jsonEqual(a, b gjson.Result) bool {
+	return reflect.DeepEqual(a.Value(), b.Value())
+}
+
 func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
 	baseResources := p.loadUsageFileResources(usage)
 
@@ -134,9 +343,52 @@ func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*sch
 	pastResources := p.parseJSONResources(true, baseResources, usage, parsed, providerConf, conf, vars)
 	resources := p.parseJSONResources(false, baseResources, usage, parsed, providerConf, conf, vars)
 
+	for _, alias := range p.regions.unusedMappings() {
+		ui.PrintWarningf("Region mapping for %s did not match any resources", alias)
+	}
+
+	unknownRegions := make([]string, 0, len(p.unknownRegions))
+	for region := range p.unknownRegions {
+		unknownRegions = append(unknownRegions, region)
+	}
+	sort.Strings(unknownRegions)
+
+	for _, region := range unknownRegions {
+		ui.PrintWarningf("Unknown region %s, it might not have prices. Map it using --region-mapping or set --default-region if it's an alias", region)
+	}
+
 	return pastResources, resources, nil
 }
 
+// parsePlanMetadata returns the Terraform, format and provider versions from
+// the plan or state JSON. The terraformVersion is used if the JSON doesn't have one, e.g.
+// the version of the binary that generated it. The provider versions are read
+// from the lock file in lockFileDir, which is the Terraform directory or the
+// directory of the plan JSON file, so each plan of a diff has its own.
+func parsePlanMetadata(j []byte, terraformVersion string, workingDir string, lockFileDir string) *schema.PlanMetadata {
+	parsed := gjson.ParseBytes(j)
+
+	m := &schema.PlanMetadata{
+		TerraformVersion: parsed.Get("terraform_version").String(),
+		FormatVersion:    parsed.Get("format_version").String(),
+		WorkingDirectory: workingDir,
+	}
+
+	if m.TerraformVersion == "" {
+		m.TerraformVersion = strings.TrimPrefix(terraformVersion, "v")
+	}
+
+	if m.WorkingDirectory != "" {
+		if abs, err := filepath.Abs(m.WorkingDirectory); err == nil {
+			m.WorkingDirectory = abs
+		}
+	}
+
+	m.ProviderVersions = parseProviderVersions(parsed, lockFileDir)
+
+	return m
+}
+
 func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schema.Resource {
 	resources := make([]*schema.Resource, 0)
 
@@ -144,6 +396,7 @@ func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schem
 		for _, t := range GetUsageOnlyResources() {
 			if strings.HasPrefix(k, fmt.Sprintf("%s.", t)) {
 				d := schema.NewResourceData(t, "global", k, map[string]string{}, gjson.Result{})
+				d.CapacityBasis = p.capacityBasis
 				if r := p.createResource(d, v); r != nil {
 					resources = append(resources, r)
 				}
@@ -173,11 +426,22 @@ func (p *Parser) parseResourceData(providerConf, planVals gjson.Result, conf gjs
 			region = providerRegion(addr, providerConf, vars, t, resConf)
 		}
 
+		if p.regions.usesDefaultRegion(t, region) {
+			p.fallbackRegions[addr] = true
+		}
+
+		region, ok := p.regions.mapRegion(t, region)
+		if !ok {
+			p.unknownRegions[region] = true
+		}
+
 		v = schema.AddRawValue(v, "region", region)
 
 		tags := parseTags(t, v)
 
-		resources[addr] = schema.NewResourceData(t, provider, addr, tags, v)
+		d := schema.NewResourceData(t, provider, addr, tags, v)
+		d.CapacityBasis = p.capacityBasis
+		resources[addr] = d
 	}
 
 	// Recursively add any resources for child modules
@@ -376,7 +640,7 @@ func (p *Parser) parseReferences(resData map[string]*schema.ResourceData, conf g
 				idRefs, ok := idMap[refVal.String()]
 				if ok {
 					for _, ref := range idRefs {
-						d.AddReference(attr, ref)
+						addReference(d, attr, ref)
 					}
 				}
 
@@ -384,7 +648,7 @@ func (p *Parser) parseReferences(resData map[string]*schema.ResourceData, conf g
 				arnRefs, ok := arnMap[refVal.String()]
 				if ok {
 					for _, ref := range arnRefs {
-						d.AddReference(attr, ref)
+						addReference(d, attr, ref)
 					}
 				}
 			}
@@ -440,7 +704,7 @@ func (p *Parser) parseConfReferences(resData map[string]*schema.ResourceData, co
 		if ok {
 			found = true
 
-			d.AddReference(attr, refData)
+			addReference(d, attr, refData)
 		}
 	}
 
@@ -575,3 +839,15 @@ func gjsonEscape(s string) string {
 
 	return s
 }
+
+// addReference adds the reference. If the attribute is in
+// reverseReferenceAttributes it also adds a reverse reference keyed by the type
+// and attribute of the referencing resource, e.g.
+// aws_eip_association.allocation_id, so the resource can find what refers to it.
+func addReference(d *schema.ResourceData, attr string, ref *schema.ResourceData) {
+	d.AddReference(attr, ref)
+
+	if containsString(reverseReferenceAttributes[d.Type], attr) {
+		ref.AddReference(fmt.Sprintf("%s.%s", d.Type, attr), d)
+	}
+}
@@ -1,12 +1,16 @@
 package terraform
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 )
 
@@ -596,6 +600,37 @@ func TestParseReferences_plan(t *testing.T) {
 	p.parseReferences(resData, conf)
 
 	assert.Equal(t, []*schema.ResourceData{vol1}, resData["aws_ebs_snapshot.snapshot1"].References("volume_id"))
+	// Only the attributes in reverseReferenceAttributes are added to the referenced resource
+	assert.Empty(t, resData["aws_ebs_volume.volume1"].References("aws_ebs_snapshot.volume_id"))
+}
+
+func TestParseReferencesReverse(t *testing.T) {
+	lb := schema.NewResourceData("aws_lb", "aws", "aws_lb.lb", map[string]string{}, gjson.Parse(`{}`))
+	listener := schema.NewResourceData("aws_lb_listener", "aws", "aws_lb_listener.listener", map[string]string{}, gjson.Parse(`{}`))
+
+	resData := map[string]*schema.ResourceData{
+		lb.Address:       lb,
+		listener.Address: listener,
+	}
+
+	conf := gjson.Parse(`{
+		"resources": [
+			{
+				"address": "aws_lb_listener.listener",
+				"type": "aws_lb_listener",
+				"name": "listener",
+				"expressions": {
+					"load_balancer_arn": {"references": ["aws_lb.lb.arn", "aws_lb.lb"]}
+				}
+			}
+		]
+	}`)
+
+	p := NewParser(config.NewEnvironment())
+	p.parseReferences(resData, conf)
+
+	assert.Equal(t, []*schema.ResourceData{lb}, listener.References("load_balancer_arn"))
+	assert.Equal(t, []*schema.ResourceData{listener}, lb.References("aws_lb_listener.load_balancer_arn"))
 }
 
 func TestParseReferences_state(t *testing.T) {
@@ -636,4 +671,245 @@ func TestParseReferences_state(t *testing.T) {
 	p.parseReferences(resData, conf)
 
 	assert.Equal(t, []*schema.ResourceData{vol1}, resData["aws_ebs_snapshot.snapshot1"].References("volume_id"))
+	// Only the attributes in reverseReferenceAttributes are added to the referenced resource
+	assert.Empty(t, resData["aws_ebs_volume.volume1"].References("aws_ebs_snapshot.volume_id"))
+}
+
+func TestParseResourceChanges(t *testing.T) {
+	planJSON := `{
+		"resource_changes": [
+			{
+				"address": "aws_instance.tags_only",
+				"change": {
+					"actions": ["update"],
+					"before": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web"}},
+					"after": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web", "Team": "infra"}},
+					"after_unknown": {"root_block_device": [{}], "tags": {}, "tags_all": {}}
+				}
+			},
+			{
+				"address": "aws_instance.resized",
+				"change": {
+					"actions": ["update"],
+					"before": {"instance_type": "t3.micro", "tags": {"Name": "web"}},
+					"after": {"instance_type": "t3.large", "tags": {"Name": "app"}},
+					"after_unknown": {}
+				}
+			},
+			{
+				"address": "aws_instance.computed",
+				"change": {
+					"actions": ["update"],
+					"before": {"instance_type": "t3.micro", "tags": {"Name": "web"}},
+					"after": {"tags": {"Name": "app"}},
+					"after_unknown": {"instance_type": true}
+				}
+			},
+			{
+				"address": "aws_instance.unchanged",
+				"change": {
+					"actions": ["no-op"],
+					"before": {"instance_type": "t3.micro"},
+					"after": {"instance_type": "t3.micro"},
+					"after_unknown": {}
+				}
+			}
+		]
+	}`
+
+	changes := parseResourceChanges(gjson.Parse(planJSON))
+
+	assert.Equal(t, resourceChange{actions: []string{"update"}, isTagOnly: true}, changes["aws_instance.tags_only"])
+	assert.Equal(t, resourceChange{
+		actions:          []string{"update"},
+		isTagOnly:        false,
+		attributeChanges: []schema.AttributeChange{{Name: "instance_type", Before: "t3.micro", After: "t3.large"}},
+	}, changes["aws_instance.resized"])
+	assert.Equal(t, resourceChange{actions: []string{"update"}, isTagOnly: false}, changes["aws_instance.computed"])
+	assert.Equal(t, resourceChange{actions: []string{"no-op"}, isTagOnly: false}, changes["aws_instance.unchanged"])
+}
+
+func TestParseJSONTagOnlyChangeHasEmptyDiff(t *testing.T) {
+	planJSON := []byte(`{
+		"format_version": "0.1",
+		"terraform_version": "0.15.0",
+		"prior_state": {
+			"values": {
+				"root_module": {
+					"resources": [
+						{
+							"address": "aws_instance.web",
+							"type": "aws_instance",
+							"name": "web",
+							"provider_name": "registry.terraform.io/hashicorp/aws",
+							"values": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web"}}
+						}
+					]
+				}
+			}
+		},
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{
+						"address": "aws_instance.web",
+						"type": "aws_instance",
+						"name": "web",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web", "Team": "infra"}}
+					}
+				]
+			}
+		},
+		"resource_changes": [
+			{
+				"address": "aws_instance.web",
+				"change": {
+					"actions": ["update"],
+					"before": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web"}},
+					"after": {"instance_type": "t3.micro", "tags": {"Name": "web"}, "tags_all": {"Name": "web", "Team": "infra"}},
+					"after_unknown": {}
+				}
+			}
+		],
+		"configuration": {
+			"provider_config": {
+				"aws": {
+					"name": "aws",
+					"expressions": {
+						"region": {"constant_value": "us-east-1"},
+						"default_tags": [{"tags": {"constant_value": {"Team": "infra"}}}]
+					}
+				}
+			},
+			"root_module": {}
+		}
+	}`)
+
+	p := NewParser(config.NewEnvironment())
+	pastResources, resources, err := p.parseJSON(planJSON, map[string]*schema.UsageData{})
+	assert.NoError(t, err)
+
+	project := schema.NewProject("test", map[string]string{})
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	assert.Equal(t, true, resources[0].IsTagOnlyChange)
+	assert.Equal(t, false, resources[0].IsChanged())
+
+	project.RemoveUnchangedResources()
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+
+	assert.Equal(t, 0, len(project.Resources))
+	assert.Equal(t, 0, len(project.Diff))
+}
+
+func TestParsePlanMetadata(t *testing.T) {
+	m := parsePlanMetadata([]byte(`{"format_version": "0.1", "terraform_version": "0.15.4"}`), "v1.0.0", "", "")
+	assert.Equal(t, &schema.PlanMetadata{TerraformVersion: "0.15.4", FormatVersion: "0.1"}, m)
+
+	m = parsePlanMetadata([]byte(`{"format_version": "0.1"}`), "v1.0.0", "/tmp/project", "/tmp/project")
+	assert.Equal(t, &schema.PlanMetadata{TerraformVersion: "1.0.0", FormatVersion: "0.1", WorkingDirectory: "/tmp/project"}, m)
+}
+
+func TestParseProviderVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infracost-provider-versions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, lockFileName), []byte(`provider "registry.terraform.io/hashicorp/aws" {
+  version     = "3.74.0"
+  constraints = "~> 3.0"
+}
+`), 0600))
+
+	plan := gjson.Parse(`{"configuration": {"provider_config": {
+		"aws": {"name": "aws", "version_constraint": "~> 3.0"},
+		"google": {"name": "google", "version_constraint": ">= 4.0"},
+		"azurerm": {"name": "azurerm"}
+	}}}`)
+
+	assert.Equal(t, map[string]string{"aws": "~> 3.0", "google": ">= 4.0"}, parseProviderVersions(plan, ""))
+	assert.Equal(t, map[string]string{"aws": "3.74.0", "google": ">= 4.0"}, parseProviderVersions(plan, dir))
+	assert.Nil(t, parseProviderVersions(gjson.Parse(`{}`), ""))
+}
+
+func TestParseJSONUnknownRegion(t *testing.T) {
+	planJSON := []byte(`{
+		"format_version": "0.1",
+		"terraform_version": "0.15.0",
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{
+						"address": "aws_instance.web",
+						"type": "aws_instance",
+						"name": "web",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": {"instance_type": "t3.micro"}
+					}
+				]
+			}
+		},
+		"configuration": {
+			"provider_config": {
+				"aws": {
+					"name": "aws",
+					"expressions": {"region": {"constant_value": "prod-west"}}
+				}
+			},
+			"root_module": {}
+		}
+	}`)
+
+	p := NewParser(config.NewEnvironment())
+	_, resources, err := p.parseJSON(planJSON, map[string]*schema.UsageData{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(resources))
+	assert.Equal(t, false, resources[0].IsSkipped)
+	assert.Equal(t, "prod-west", resources[0].Region)
+	assert.Equal(t, map[string]bool{"prod-west": true}, p.unknownRegions)
+}
+
+func TestCreateResourceRecordsDefaultedUsageKeys(t *testing.T) {
+	d := schema.NewResourceData("aws_lambda_function", "aws", "aws_lambda_function.fn", map[string]string{}, gjson.Parse(`{"region": "us-east-1", "memory_size": 128}`))
+
+	p := NewParser(config.NewEnvironment())
+	res := p.createResource(d, nil)
+
+	assert.Empty(t, res.EstimatedUsageKeys)
+	assert.Equal(t, []string{"monthly_requests", "request_duration_ms"}, res.DefaultedUsageKeys)
+
+	u := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn": map[string]interface{}{"monthly_requests": 100},
+	})["aws_lambda_function.fn"]
+	res = p.createResource(d, u)
+
+	assert.Equal(t, []string{"monthly_requests"}, res.EstimatedUsageKeys)
+	assert.Equal(t, []string{"request_duration_ms"}, res.DefaultedUsageKeys)
+}
+
+func TestParseResourceDataCapacityBasis(t *testing.T) {
+	planVals := gjson.Parse(`{
+		"resources": [
+			{
+				"address": "aws_autoscaling_group.asg",
+				"type": "aws_autoscaling_group",
+				"name": "asg",
+				"values": {"min_size": 1, "desired_capacity": 2, "max_size": 3}
+			}
+		]
+	}`)
+
+	p := NewParser(config.NewEnvironment())
+	p.capacityBasis = "max"
+	actual := p.parseResourceData(gjson.Result{}, planVals, gjson.Result{}, gjson.Result{})
+	assert.Equal(t, "max", actual["aws_autoscaling_group.asg"].CapacityBasis)
+
+	// Each parser has its own capacity basis, so it doesn't leak into the next
+	// run
+	actual = NewParser(config.NewEnvironment()).parseResourceData(gjson.Result{}, planVals, gjson.Result{}, gjson.Result{})
+	assert.Equal(t, "", actual["aws_autoscaling_group.asg"].CapacityBasis)
 }
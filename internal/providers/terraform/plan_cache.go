@@ -0,0 +1,121 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// planCache is the latest plan JSON of each Terraform dir along with its
+// planCacheKey, so watch mode only runs terraform plan again when the files or
+// flags change. Only the latest plan of a dir is kept since the earlier ones
+// can't be used again once the files have changed. It's only kept in memory for
+// the run of the command.
+var planCache = struct {
+	sync.Mutex
+	plans map[string]cachedPlan
+}{plans: make(map[string]cachedPlan)}
+
+type cachedPlan struct {
+	key  string
+	json []byte
+}
+
+// PlanFileExtensions are the extensions of the files that change the plan, so
+// changes to them invalidate the plan cache and re-run --watch.
+var PlanFileExtensions = []string{".tf", ".tf.json", ".tfvars", ".tfvars.json", ".hcl"}
+
+// IsPlanFile returns true if the path has one of the PlanFileExtensions.
+func IsPlanFile(path string) bool {
+	for _, ext := range PlanFileExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getCachedPlan(dir string, key string) ([]byte, bool) {
+	planCache.Lock()
+	defer planCache.Unlock()
+
+	c, ok := planCache.plans[dir]
+	if !ok || c.key != key {
+		return nil, false
+	}
+
+	return c.json, true
+}
+
+func setCachedPlan(dir string, key string, j []byte) {
+	planCache.Lock()
+	defer planCache.Unlock()
+
+	planCache.plans[dir] = cachedPlan{key: key, json: j}
+}
+
+// planCacheKey returns the SHA-256 of the Terraform files in the dir, their
+// contents and the options of the plan. Hidden dirs such as .terraform are
+// skipped. Local modules outside the dir aren't included, so changes to them
+// don't change the key.
+func (p *DirProvider) planCacheKey() (string, error) {
+	files := make([]string, 0)
+
+	err := filepath.Walk(p.Path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != p.Path && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if IsPlanFile(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, s := range []string{p.TerraformBinary, p.PlanFlags, p.Workspace, strings.Join(p.VarsFrom, "\x00")} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	for _, path := range files {
+		rel, err := filepath.Rel(p.Path, path)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(filepath.ToSlash(rel)))
+		h.Write([]byte{0})
+
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -9,14 +11,18 @@ import (
 )
 
 type PlanJSONProvider struct {
-	Path string
-	env  *config.Environment
+	Path          string
+	env           *config.Environment
+	regions       *regionMapper
+	capacityBasis string
 }
 
 func NewPlanJSONProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
 	return &PlanJSONProvider{
-		Path: projectCfg.Path,
-		env:  cfg.Environment,
+		Path:          projectCfg.Path,
+		env:           cfg.Environment,
+		regions:       newRegionMapper(cfg.RegionMapping, cfg.DefaultRegion),
+		capacityBasis: strings.ToLower(cfg.CapacityBasis),
 	}
 }
 
@@ -36,15 +42,35 @@ func (p *PlanJSONProvider) LoadResources(usage map[string]*schema.UsageData) (*s
 		return project, errors.Wrap(err, "Error reading Terraform plan JSON file")
 	}
 
-	parser := NewParser(p.env)
+	err = loadPlanJSONResources(project, j, usage, p.env, p.regions, p.capacityBasis, filepath.Dir(p.Path))
+	if err != nil {
+		return project, errors.Wrap(err, "Error parsing Terraform plan JSON file")
+	}
+
+	return project, nil
+}
+
+// LoadPlanJSON sets the past and planned resources of the project from plan
+// JSON generated outside of the Terraform providers, e.g. by Terragrunt.
+func LoadPlanJSON(cfg *config.Config, project *schema.Project, j []byte, usage map[string]*schema.UsageData) error {
+	return loadPlanJSONResources(project, j, usage, cfg.Environment, newRegionMapper(cfg.RegionMapping, cfg.DefaultRegion), strings.ToLower(cfg.CapacityBasis), "")
+}
+
+// loadPlanJSONResources parses the plan JSON into the project. The provider
+// versions are read from the lock file in lockFileDir, if it's set.
+func loadPlanJSONResources(project *schema.Project, j []byte, usage map[string]*schema.UsageData, env *config.Environment, regions *regionMapper, capacityBasis string, lockFileDir string) error {
+	parser := NewParser(env)
+	parser.regions = regions
+	parser.capacityBasis = capacityBasis
 
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
-		return project, errors.Wrap(err, "Error parsing Terraform plan JSON file")
+		return err
 	}
 
 	project.PastResources = pastResources
 	project.Resources = resources
+	project.PlanMetadata = parsePlanMetadata(j, "", "", lockFileDir)
 
-	return project, nil
+	return nil
 }
@@ -0,0 +1,44 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanJSONProviderVersionsFromOwnLockFile(t *testing.T) {
+	writePlan := func(version string) string {
+		dir := t.TempDir()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, lockFileName), []byte(fmt.Sprintf(`provider "registry.terraform.io/hashicorp/aws" {
+  version     = "%s"
+  constraints = "~> 3.0"
+}
+`, version)), 0600))
+
+		path := filepath.Join(dir, "plan.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"format_version": "0.1", "configuration": {"provider_config": {
+			"aws": {"name": "aws", "version_constraint": "~> 3.0"}
+		}}}`), 0600))
+
+		return path
+	}
+
+	load := func(path string) *schema.Project {
+		p := NewPlanJSONProvider(&config.Config{Environment: config.NewEnvironment()}, &config.Project{Path: path})
+		project, err := p.LoadResources(map[string]*schema.UsageData{})
+		require.NoError(t, err)
+		return project
+	}
+
+	past := load(writePlan("3.60.0"))
+	current := load(writePlan("3.74.0"))
+
+	assert.Equal(t, map[string]string{"aws": "3.60.0"}, past.PlanMetadata.ProviderVersions)
+	assert.Equal(t, map[string]string{"aws": "3.74.0"}, current.PlanMetadata.ProviderVersions)
+}
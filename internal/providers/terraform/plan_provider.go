@@ -45,14 +45,19 @@ func (p *PlanProvider) LoadResources(usage map[string]*schema.UsageData) (*schem
 	}
 
 	parser := NewParser(p.env)
+	parser.regions = p.regions
+	parser.capacityBasis = p.capacityBasis
 
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
 		return project, errors.Wrap(err, "Error parsing Terraform JSON")
 	}
 
+	setSourceLocations(filepath.Dir(p.Path), pastResources, resources)
+
 	project.PastResources = pastResources
 	project.Resources = resources
+	project.PlanMetadata = parsePlanMetadata(j, p.env.TerraformVersion, p.DirProvider.Path, p.DirProvider.Path)
 
 	return project, nil
 }
@@ -63,11 +68,16 @@ func (p *PlanProvider) generatePlanJSON() ([]byte, error) {
 
 	if !IsTerraformDir(dir) {
 		log.Debugf("%s is not a Terraform directory, checking current working directory", dir)
-		dir, err := os.Getwd()
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return []byte{}, err
+		}
+
+		planPath, err = filepath.Abs(p.Path)
 		if err != nil {
 			return []byte{}, err
 		}
-		planPath = p.Path
 
 		if !IsTerraformDir(dir) {
 			return []byte{}, fmt.Errorf("%s %s.\n%s\n\n%s\n%s\n%s %s",
@@ -99,5 +109,13 @@ func (p *PlanProvider) generatePlanJSON() ([]byte, error) {
 		defer os.Remove(opts.TerraformConfigFile)
 	}
 
-	return p.runShow(opts, planPath)
+	j, err := p.runShow(opts, planPath)
+	if err != nil {
+		if stderr := extractStderr(err); stderr != "" {
+			return []byte{}, errors.Errorf("Could not read Terraform plan file %s:\n%s", p.Path, stderr)
+		}
+		return []byte{}, errors.Wrapf(err, "Could not read Terraform plan file %s", p.Path)
+	}
+
+	return j, nil
 }
@@ -0,0 +1,95 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// lockFileName is the dependency lock file that Terraform 0.14+ writes to the
+// working directory with the selected version of each provider.
+const lockFileName = ".terraform.lock.hcl"
+
+// parseProviderVersions returns the version of each provider keyed by its
+// name, e.g. aws. The plan JSON only has the version constraints so the
+// selected versions in the lock file of the directory are used if there is
+// one.
+func parseProviderVersions(parsed gjson.Result, dir string) map[string]string {
+	versions := make(map[string]string)
+
+	parsed.Get("configuration.provider_config").ForEach(func(key gjson.Result, provider gjson.Result) bool {
+		constraint := provider.Get("version_constraint").String()
+		if constraint == "" {
+			return true
+		}
+
+		name := provider.Get("name").String()
+		if name == "" {
+			name = key.String()
+		}
+		versions[name] = constraint
+
+		return true
+	})
+
+	if dir != "" {
+		for name, version := range loadLockFileVersions(filepath.Join(dir, lockFileName)) {
+			versions[name] = version
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil
+	}
+
+	return versions
+}
+
+// loadLockFileVersions returns the provider versions in the lock file, keyed
+// by the last part of the provider address, e.g. registry.terraform.io/hashicorp/aws
+// is aws.
+func loadLockFileVersions(filename string) map[string]string {
+	versions := make(map[string]string)
+
+	if _, err := os.Stat(filename); err != nil {
+		return versions
+	}
+
+	file, diags := hclparse.NewParser().ParseHCLFile(filename)
+	if diags.HasErrors() {
+		log.Debugf("Could not parse %s to find the provider versions: %s", filename, diags.Error())
+		return versions
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return versions
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+
+		attr, ok := block.Body.Attributes["version"]
+		if !ok {
+			continue
+		}
+
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+			continue
+		}
+
+		address := block.Labels[0]
+		versions[address[strings.LastIndex(address, "/")+1:]] = v.AsString()
+	}
+
+	return versions
+}
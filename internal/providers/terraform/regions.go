@@ -0,0 +1,108 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Regions are canonical if they look like a real region of the provider. Only
+// AWS and Google are checked since Azure resources are priced by location.
+var canonicalRegionRegex = map[string]*regexp.Regexp{
+	"aws":    regexp.MustCompile(`^[a-z]{2}(-gov|-iso|-isob)?-(north|south|east|west|central|northeast|northwest|southeast|southwest)-\d+(-[a-z]+-\d+[a-z]?)?$`),
+	"google": regexp.MustCompile(`^[a-z]+-(north|south|east|west|central|northeast|northwest|southeast|southwest)\d+$`),
+}
+
+// regionMapper translates region aliases, e.g. prod-east, to the canonical
+// regions used for pricing.
+type regionMapper struct {
+	mapping       map[string]string
+	defaultRegion string
+	used          map[string]bool
+}
+
+func newRegionMapper(mapping map[string]string, defaultRegion string) *regionMapper {
+	return &regionMapper{
+		mapping:       mapping,
+		defaultRegion: defaultRegion,
+		used:          make(map[string]bool),
+	}
+}
+
+// mapRegion returns the canonical region for the resource type. If the region
+// is not canonical and can't be mapped it returns the region unchanged and
+// false.
+func (m *regionMapper) mapRegion(resourceType string, region string) (string, bool) {
+	if m == nil {
+		return region, true
+	}
+
+	if mapped, ok := m.mapping[region]; ok {
+		m.used[region] = true
+		return mapped, true
+	}
+
+	if isCanonicalRegion(resourceType, region) {
+		return region, true
+	}
+
+	if m.defaultRegion != "" {
+		return m.defaultRegion, true
+	}
+
+	return region, false
+}
+
+// usesDefaultRegion returns true if mapRegion falls back to the default region
+// for the resource type and region.
+func (m *regionMapper) usesDefaultRegion(resourceType string, region string) bool {
+	if m == nil || m.defaultRegion == "" {
+		return false
+	}
+
+	if _, ok := m.mapping[region]; ok {
+		return false
+	}
+
+	return !isCanonicalRegion(resourceType, region)
+}
+
+func isCanonicalRegion(resourceType string, region string) bool {
+	re, ok := canonicalRegionRegex[strings.Split(resourceType, "_")[0]]
+	return !ok || region == "" || re.MatchString(region)
+}
+
+// unusedMappings returns the sorted aliases that didn't match any resources.
+func (m *regionMapper) unusedMappings() []string {
+	unused := make([]string, 0)
+	if m == nil {
+		return unused
+	}
+
+	for alias := range m.mapping {
+		if !m.used[alias] {
+			unused = append(unused, alias)
+		}
+	}
+
+	sort.Strings(unused)
+
+	return unused
+}
+
+// ParseRegionMapping parses alias=region values into a map.
+func ParseRegionMapping(values []string) (map[string]string, error) {
+	mapping := make(map[string]string, len(values))
+
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid region mapping %q, it should be in the format alias=region, e.g. prod-east=us-east-1", v)
+		}
+
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return mapping, nil
+}
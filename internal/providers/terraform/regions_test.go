@@ -0,0 +1,57 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapRegion(t *testing.T) {
+	m := newRegionMapper(map[string]string{"prod-east": "us-east-1", "unused": "us-west-2"}, "")
+
+	tests := []struct {
+		resourceType string
+		region       string
+		expected     string
+		ok           bool
+	}{
+		{"aws_instance", "prod-east", "us-east-1", true},
+		{"aws_instance", "eu-west-1", "eu-west-1", true},
+		{"aws_instance", "us-gov-west-1", "us-gov-west-1", true},
+		{"aws_instance", "us-west-2-lax-1", "us-west-2-lax-1", true},
+		{"aws_instance", "prod-west", "prod-west", false},
+		{"google_compute_instance", "europe-west2", "europe-west2", true},
+		{"google_compute_instance", "prod-west", "prod-west", false},
+		{"azurerm_linux_virtual_machine", "prod-west", "prod-west", true},
+	}
+
+	for _, test := range tests {
+		region, ok := m.mapRegion(test.resourceType, test.region)
+		assert.Equal(t, test.expected, region, test.region)
+		assert.Equal(t, test.ok, ok, test.region)
+	}
+
+	assert.Equal(t, []string{"unused"}, m.unusedMappings())
+}
+
+func TestMapRegionDefault(t *testing.T) {
+	m := newRegionMapper(nil, "us-east-2")
+
+	region, ok := m.mapRegion("aws_instance", "prod-west")
+	assert.True(t, ok)
+	assert.Equal(t, "us-east-2", region)
+
+	assert.True(t, m.usesDefaultRegion("aws_instance", "prod-west"))
+	assert.False(t, m.usesDefaultRegion("aws_instance", "eu-west-1"))
+	assert.False(t, newRegionMapper(nil, "").usesDefaultRegion("aws_instance", "prod-west"))
+}
+
+func TestParseRegionMapping(t *testing.T) {
+	mapping, err := ParseRegionMapping([]string{"prod-east=us-east-1", " prod-eu = eu-west-1 "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"prod-east": "us-east-1", "prod-eu": "eu-west-1"}, mapping)
+
+	_, err = ParseRegionMapping([]string{"prod-east"})
+	assert.Error(t, err)
+}
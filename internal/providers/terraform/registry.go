@@ -56,8 +56,12 @@ func GetUsageOnlyResources() []string {
 	return r
 }
 
+// HasSupportedProvider returns true if the resource type is from one of the
+// supported Terraform providers or is an Azure Resource Manager type, e.g.
+// Microsoft.Compute/virtualMachines.
 func HasSupportedProvider(rType string) bool {
-	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_")
+	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_") ||
+		strings.HasPrefix(rType, "Microsoft.")
 }
 
 func createFreeResources(l []string) []*schema.RegistryItem {
@@ -0,0 +1,52 @@
+package terraform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceHashIgnoresComputedAttributes(t *testing.T) {
+	plan := func(values string) []byte {
+		return []byte(fmt.Sprintf(`{
+			"format_version": "0.1",
+			"terraform_version": "0.14.0",
+			"configuration": {
+				"provider_config": {"aws": {"name": "aws", "expressions": {"region": {"constant_value": "us-east-1"}}}},
+				"root_module": {}
+			},
+			"planned_values": {
+				"root_module": {
+					"resources": [{
+						"address": "aws_instance.web",
+						"mode": "managed",
+						"type": "aws_instance",
+						"name": "web",
+						"provider_name": "registry.terraform.io/hashicorp/aws",
+						"values": %s
+					}]
+				}
+			}
+		}`, values))
+	}
+
+	hash := func(values string) string {
+		p := NewParser(config.NewEnvironment())
+		_, resources, err := p.parseJSON(plan(values), map[string]*schema.UsageData{})
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+
+		schema.CalculateCosts(&schema.Project{Resources: resources})
+
+		return resources[0].ResourceHash
+	}
+
+	base := hash(`{"instance_type": "m5.large", "tags": {"env": "prod"}}`)
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, hash(`{"instance_type": "m5.large", "id": "i-1234", "arn": "arn:aws:ec2:us-east-1:123456789012:instance/i-1234", "tags": {"env": "prod"}}`))
+	assert.NotEqual(t, base, hash(`{"instance_type": "m5.xlarge", "tags": {"env": "prod"}}`))
+}
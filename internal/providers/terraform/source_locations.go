@@ -0,0 +1,117 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type sourceLocation struct {
+	fileName  string
+	startLine int
+}
+
+var addressIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// loadSourceLocations parses the Terraform files in the dir and any local
+// modules it calls and returns the location of each resource block, keyed by
+// the resource address without any count or for_each indexes.
+func loadSourceLocations(dir string) map[string]sourceLocation {
+	locations := make(map[string]sourceLocation)
+	addSourceLocations(hclparse.NewParser(), locations, dir, "", 0)
+
+	return locations
+}
+
+func addSourceLocations(parser *hclparse.Parser, locations map[string]sourceLocation, dir string, prefix string, depth int) {
+	// Guard against modules that call each other
+	if depth > 10 {
+		return
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		log.Debugf("Could not read %s to find resource locations: %s", dir, err)
+		return
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".tf" {
+			continue
+		}
+
+		filename := filepath.Join(dir, f.Name())
+
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			log.Debugf("Could not parse %s to find resource locations: %s", filename, diags.Error())
+			continue
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			switch {
+			case block.Type == "resource" && len(block.Labels) == 2:
+				locations[prefix+block.Labels[0]+"."+block.Labels[1]] = sourceLocation{filename, block.DefRange().Start.Line}
+			case block.Type == "data" && len(block.Labels) == 2:
+				locations[prefix+"data."+block.Labels[0]+"."+block.Labels[1]] = sourceLocation{filename, block.DefRange().Start.Line}
+			case block.Type == "module" && len(block.Labels) == 1:
+				source := moduleSource(block)
+				if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+					addSourceLocations(parser, locations, filepath.Join(dir, source), prefix+"module."+block.Labels[0]+".", depth+1)
+				}
+			}
+		}
+	}
+}
+
+func moduleSource(block *hclsyntax.Block) string {
+	attr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return ""
+	}
+
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// setSourceLocations sets the file name, relative to the dir, and start line
+// of the resources that are defined in the Terraform files of the dir.
+func setSourceLocations(dir string, resourceLists ...[]*schema.Resource) {
+	locations := loadSourceLocations(dir)
+	if len(locations) == 0 {
+		return
+	}
+
+	for _, resources := range resourceLists {
+		for _, r := range resources {
+			loc, ok := locations[addressIndexRegex.ReplaceAllString(r.Name, "")]
+			if !ok {
+				continue
+			}
+
+			fileName := loc.fileName
+			if rel, err := filepath.Rel(dir, loc.fileName); err == nil {
+				fileName = rel
+			}
+
+			r.SourceFileName = fileName
+			r.SourceStartLine = loc.startLine
+		}
+	}
+}
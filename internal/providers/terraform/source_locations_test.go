@@ -0,0 +1,55 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSourceLocations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infracost-source-locations")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules", "web"), 0755))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_instance" "web_app" {
+  count         = 2
+  ami           = "ami-674cbc1e"
+  instance_type = "m5.4xlarge"
+}
+
+module "web" {
+  source = "./modules/web"
+}
+`), 0600))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "modules", "web", "lb.tf"), []byte(`resource "aws_lb" "lb" {
+  load_balancer_type = "application"
+}
+`), 0600))
+
+	resources := []*schema.Resource{
+		{Name: "aws_instance.web_app[1]"},
+		{Name: "module.web.aws_lb.lb"},
+		{Name: "aws_nat_gateway.missing"},
+	}
+
+	setSourceLocations(dir, resources)
+
+	assert.Equal(t, "main.tf", resources[0].SourceFileName)
+	assert.Equal(t, 5, resources[0].SourceStartLine)
+	assert.Equal(t, filepath.Join("modules", "web", "lb.tf"), resources[1].SourceFileName)
+	assert.Equal(t, 1, resources[1].SourceStartLine)
+	assert.Equal(t, "", resources[2].SourceFileName)
+	assert.Equal(t, 0, resources[2].SourceStartLine)
+}
@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -9,14 +11,18 @@ import (
 )
 
 type StateJSONProvider struct {
-	Path string
-	env  *config.Environment
+	Path          string
+	env           *config.Environment
+	regions       *regionMapper
+	capacityBasis string
 }
 
 func NewStateJSONProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
 	return &StateJSONProvider{
-		Path: projectCfg.Path,
-		env:  cfg.Environment,
+		Path:          projectCfg.Path,
+		env:           cfg.Environment,
+		regions:       newRegionMapper(cfg.RegionMapping, cfg.DefaultRegion),
+		capacityBasis: strings.ToLower(cfg.CapacityBasis),
 	}
 }
 
@@ -37,6 +43,8 @@ func (p *StateJSONProvider) LoadResources(usage map[string]*schema.UsageData) (*
 	}
 
 	parser := NewParser(p.env)
+	parser.regions = p.regions
+	parser.capacityBasis = p.capacityBasis
 
 	pastResources, resources, err := parser.parseJSON(j, usage)
 	if err != nil {
@@ -45,6 +53,7 @@ func (p *StateJSONProvider) LoadResources(usage map[string]*schema.UsageData) (*
 
 	project.PastResources = pastResources
 	project.Resources = resources
+	project.PlanMetadata = parsePlanMetadata(j, "", "", filepath.Dir(p.Path))
 
 	return project, nil
 }
@@ -0,0 +1,177 @@
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// Usage annotations are comments directly above a resource block that set its
+// usage, e.g.
+//
+//	# infracost: monthly_requests=1000000, request_duration_ms=500
+//	resource "aws_lambda_function" "fn" {
+//
+// They're only read for Terraform directories.
+const (
+	UsageAnnotationsOff      = "off"
+	UsageAnnotationsOverride = "override"
+	UsageAnnotationsFallback = "fallback"
+)
+
+var usageAnnotationRegex = regexp.MustCompile(`^\s*(?:#|//)\s*infracost:(.*)$`)
+var commentLineRegex = regexp.MustCompile(`^\s*(?:#|//)`)
+
+// ValidateUsageAnnotations returns an error if the mode isn't empty or one of
+// the usage annotation modes.
+func ValidateUsageAnnotations(mode string) error {
+	switch mode {
+	case "", UsageAnnotationsOff, UsageAnnotationsOverride, UsageAnnotationsFallback:
+		return nil
+	}
+
+	return fmt.Errorf("usage-annotations must be one of %s, %s or %s", UsageAnnotationsOff, UsageAnnotationsOverride, UsageAnnotationsFallback)
+}
+
+// loadUsageAnnotations returns the usage set by the annotations of the
+// resources in the dir and the local modules it calls. Each resource has the
+// usage for its address and, for resources with count or for_each, all of
+// its instances.
+func loadUsageAnnotations(dir string) map[string]*schema.UsageData {
+	usage := make(map[string]*schema.UsageData)
+
+	fileLines := make(map[string][]string)
+
+	addresses := make([]string, 0)
+	locations := loadSourceLocations(dir)
+	for address := range locations {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		loc := locations[address]
+
+		lines, ok := fileLines[loc.fileName]
+		if !ok {
+			b, err := ioutil.ReadFile(loc.fileName)
+			if err != nil {
+				log.Debugf("Could not read %s to find usage annotations: %s", loc.fileName, err)
+			}
+			lines = strings.Split(string(b), "\n")
+			fileLines[loc.fileName] = lines
+		}
+
+		attributes := parseUsageAnnotations(address, lines, loc.startLine)
+		if len(attributes) == 0 {
+			continue
+		}
+
+		usage[address] = schema.NewUsageData(address, schema.ParseAttributes(attributes))
+		usage[address+"[*]"] = schema.NewUsageData(address+"[*]", schema.ParseAttributes(attributes))
+	}
+
+	return usage
+}
+
+// parseUsageAnnotations parses the annotations in the comment lines directly
+// above the start line of the resource. Malformed annotations are skipped with a
+// warning.
+func parseUsageAnnotations(address string, lines []string, startLine int) map[string]interface{} {
+	attributes := make(map[string]interface{})
+
+	// Line numbers start at 1, so the line above the block is startLine-2
+	for i := startLine - 2; i >= 0 && i < len(lines) && commentLineRegex.MatchString(lines[i]); i-- {
+		m := usageAnnotationRegex.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+
+		for _, pair := range strings.FieldsFunc(m[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				ui.PrintWarningf("Ignoring malformed infracost annotation %q of %s, annotations should be key=value", pair, address)
+				continue
+			}
+
+			// Keep the annotations closest to the block if a key is repeated
+			if _, ok := attributes[parts[0]]; ok {
+				continue
+			}
+
+			attributes[parts[0]] = usageAnnotationValue(parts[1])
+		}
+	}
+
+	return attributes
+}
+
+func usageAnnotationValue(v string) interface{} {
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return i
+	}
+
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+
+	return strings.Trim(v, `"'`)
+}
+
+// mergeUsageAnnotations returns the usage with the annotations added. If
+// override is set the annotations replace the usage file values of the same
+// keys, otherwise they're only used for keys that aren't in the usage file.
+// Usage file instances, e.g. aws_instance.web[0], are merged with the
+// annotations of all the instances, since the parser uses the usage of the
+// instance instead of aws_instance.web[*] if it has any.
+func mergeUsageAnnotations(usage map[string]*schema.UsageData, annotations map[string]*schema.UsageData, override bool) map[string]*schema.UsageData {
+	merged := make(map[string]*schema.UsageData, len(usage)+len(annotations))
+	for address, a := range annotations {
+		merged[address] = a
+	}
+
+	for address, u := range usage {
+		a, ok := annotations[address]
+		if !ok {
+			a, ok = annotations[instancesAddress(address)]
+		}
+		if !ok {
+			merged[address] = u
+			continue
+		}
+
+		attributes := make(map[string]gjson.Result, len(u.Attributes)+len(a.Attributes))
+		for k, v := range u.Attributes {
+			attributes[k] = v
+		}
+		for k, v := range a.Attributes {
+			if _, exists := attributes[k]; exists && !override {
+				continue
+			}
+			attributes[k] = v
+		}
+
+		merged[address] = schema.NewUsageData(address, attributes)
+	}
+
+	return merged
+}
+
+// instancesAddress returns the address of all the instances of a resource
+// instance, e.g. aws_instance.web[*] for aws_instance.web[0], or an empty
+// string if the address isn't an instance.
+func instancesAddress(address string) string {
+	if !strings.HasSuffix(address, "]") || strings.HasSuffix(address, "[*]") {
+		return ""
+	}
+
+	return address[:strings.LastIndex(address, "[")] + "[*]"
+}
@@ -0,0 +1,103 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadUsageAnnotations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "infracost-usage-annotations")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`# Handles the uploads
+# infracost: monthly_requests=1000000, request_duration_ms=500
+// infracost: runtime_name="custom"
+resource "aws_lambda_function" "fn" {
+  function_name = "fn"
+}
+
+# infracost: storage_gb=100 malformed
+resource "aws_dynamodb_table" "table" {
+  count = 2
+}
+
+# infracost: storage_gb=1
+
+resource "aws_sqs_queue" "not_adjacent" {
+}
+`), 0600))
+
+	usage := loadUsageAnnotations(dir)
+
+	fn := usage["aws_lambda_function.fn"]
+	require.NotNil(t, fn)
+	assert.Equal(t, int64(1000000), fn.Get("monthly_requests").Int())
+	assert.Equal(t, int64(500), fn.Get("request_duration_ms").Int())
+	assert.Equal(t, "custom", fn.Get("runtime_name").String())
+
+	table := usage["aws_dynamodb_table.table[*]"]
+	require.NotNil(t, table)
+	assert.Equal(t, int64(100), table.Get("storage_gb").Int())
+	assert.False(t, table.Get("malformed").Exists())
+
+	assert.Nil(t, usage["aws_sqs_queue.not_adjacent"])
+}
+
+func TestMergeUsageAnnotations(t *testing.T) {
+	usage := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn": map[string]interface{}{
+			"monthly_requests": 10,
+		},
+	})
+	annotations := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn": map[string]interface{}{
+			"monthly_requests":    20,
+			"request_duration_ms": 500,
+		},
+		"aws_sqs_queue.queue": map[string]interface{}{
+			"monthly_requests": 30,
+		},
+	})
+
+	merged := mergeUsageAnnotations(usage, annotations, false)
+	assert.Equal(t, int64(10), merged["aws_lambda_function.fn"].Get("monthly_requests").Int())
+	assert.Equal(t, int64(500), merged["aws_lambda_function.fn"].Get("request_duration_ms").Int())
+	assert.Equal(t, int64(30), merged["aws_sqs_queue.queue"].Get("monthly_requests").Int())
+
+	merged = mergeUsageAnnotations(usage, annotations, true)
+	assert.Equal(t, int64(20), merged["aws_lambda_function.fn"].Get("monthly_requests").Int())
+
+	// The usage file data shouldn't be changed
+	assert.Equal(t, int64(10), usage["aws_lambda_function.fn"].Get("monthly_requests").Int())
+	assert.False(t, usage["aws_lambda_function.fn"].Get("request_duration_ms").Exists())
+}
+
+func TestMergeUsageAnnotationsInstances(t *testing.T) {
+	usage := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn[0]": map[string]interface{}{
+			"monthly_requests": 10,
+		},
+	})
+	annotations := schema.NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn[*]": map[string]interface{}{
+			"monthly_requests":    20,
+			"request_duration_ms": 500,
+		},
+	})
+
+	merged := mergeUsageAnnotations(usage, annotations, true)
+	assert.Equal(t, int64(20), merged["aws_lambda_function.fn[0]"].Get("monthly_requests").Int())
+	assert.Equal(t, int64(500), merged["aws_lambda_function.fn[0]"].Get("request_duration_ms").Int())
+	assert.Equal(t, int64(20), merged["aws_lambda_function.fn[*]"].Get("monthly_requests").Int())
+
+	merged = mergeUsageAnnotations(usage, annotations, false)
+	assert.Equal(t, int64(10), merged["aws_lambda_function.fn[0]"].Get("monthly_requests").Int())
+	assert.Equal(t, int64(500), merged["aws_lambda_function.fn[0]"].Get("request_duration_ms").Int())
+}
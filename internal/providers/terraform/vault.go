@@ -0,0 +1,165 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultVaultAddr = "https://127.0.0.1:8200"
+
+// varFrom is a Terraform variable whose value is read from an external source,
+// specified as vault:secret/path#key=tfvar_name
+type varFrom struct {
+	Source  string
+	Path    string
+	Key     string
+	VarName string
+}
+
+func parseVarFrom(s string) (*varFrom, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] != "vault" {
+		return nil, errors.Errorf("Invalid terraform-var-from value %q, only the vault source is supported, e.g. vault:secret/path#key=tfvar_name", s)
+	}
+
+	ref := parts[1]
+	eq := strings.LastIndex(ref, "=")
+	hash := strings.LastIndex(ref, "#")
+	if hash <= 0 || eq < hash+2 || eq == len(ref)-1 {
+		return nil, errors.Errorf("Invalid terraform-var-from value %q, expected the format vault:secret/path#key=tfvar_name", s)
+	}
+
+	return &varFrom{
+		Source:  parts[0],
+		Path:    strings.Trim(ref[:hash], "/"),
+		Key:     ref[hash+1 : eq],
+		VarName: ref[eq+1:],
+	}, nil
+}
+
+// resolveVarsFrom reads the values of the given variables from Vault using the
+// VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables. The values
+// are secrets so they must never be logged.
+func resolveVarsFrom(specs []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	if len(specs) == 0 {
+		return vars, nil
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return vars, errors.New("VAULT_TOKEN environment variable must be set to read Terraform variables from Vault")
+	}
+
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		addr = defaultVaultAddr
+	}
+
+	secrets := make(map[string]map[string]interface{})
+
+	for _, spec := range specs {
+		v, err := parseVarFrom(spec)
+		if err != nil {
+			return vars, err
+		}
+
+		data, ok := secrets[v.Path]
+		if !ok {
+			data, err = readVaultSecret(addr, token, v.Path)
+			if err != nil {
+				return vars, errors.Wrapf(err, "Error reading Vault secret %s", v.Path)
+			}
+			secrets[v.Path] = data
+		}
+
+		val, ok := data[v.Key]
+		if !ok {
+			return vars, errors.Errorf("Vault secret %s does not contain the key %s", v.Path, v.Key)
+		}
+
+		switch t := val.(type) {
+		case string:
+			vars[v.VarName] = t
+		default:
+			b, err := json.Marshal(t)
+			if err != nil {
+				return vars, errors.Wrapf(err, "Error reading key %s of Vault secret %s", v.Key, v.Path)
+			}
+			vars[v.VarName] = string(b)
+		}
+
+		log.Debugf("Resolved Terraform variable %s from Vault secret %s", v.VarName, v.Path)
+	}
+
+	return vars, nil
+}
+
+// readVaultSecret returns the data of the secret at the given path. For the KV
+// version 2 secrets engine the path should include the data/ prefix, e.g. secret/data/path.
+func readVaultSecret(addr string, token string, path string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimRight(addr, "/"), path)
+	log.Debugf("Calling Vault API: %s", url)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Add("X-Vault-Namespace", ns)
+	}
+
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 403 {
+		return nil, errors.New("permission denied, check your VAULT_TOKEN")
+	} else if resp.StatusCode == 404 {
+		return nil, errors.New("secret not found")
+	} else if resp.StatusCode != 200 {
+		return nil, errors.Errorf("invalid response from Vault: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedResp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsedResp); err != nil {
+		return nil, errors.Wrap(err, "invalid response from Vault")
+	}
+
+	// KV version 2 secrets nest the values under data.data
+	if nested, ok := parsedResp.Data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := parsedResp.Data["metadata"]; hasMetadata {
+			return nested, nil
+		}
+	}
+
+	return parsedResp.Data, nil
+}
+
+// redactValues replaces any of the given values in s so secrets aren't logged.
+func redactValues(s string, values []string) string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, v, "***")
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package terraform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVarFrom(t *testing.T) {
+	v, err := parseVarFrom("vault:secret/data/app#db_password=db_password")
+	require.NoError(t, err)
+	assert.Equal(t, &varFrom{Source: "vault", Path: "secret/data/app", Key: "db_password", VarName: "db_password"}, v)
+
+	for _, s := range []string{
+		"secret/app#key=var",
+		"aws:secret/app#key=var",
+		"vault:secret/app",
+		"vault:secret/app#key",
+		"vault:secret/app#=var",
+		"vault:secret/app#key=",
+	} {
+		_, err := parseVarFrom(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestResolveVarsFrom(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/app":
+			_, _ = w.Write([]byte(`{"data": {"data": {"password": "s3cret", "port": 5432}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/app":
+			_, _ = w.Write([]byte(`{"data": {"api_key": "abc123"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("VAULT_ADDR", server.URL)
+	os.Setenv("VAULT_TOKEN", "test-token")
+	defer os.Unsetenv("VAULT_ADDR")
+	defer os.Unsetenv("VAULT_TOKEN")
+
+	vars, err := resolveVarsFrom([]string{
+		"vault:secret/data/app#password=db_password",
+		"vault:secret/data/app#port=db_port",
+		"vault:kv/app#api_key=api_key",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"db_password": "s3cret",
+		"db_port":     "5432",
+		"api_key":     "abc123",
+	}, vars)
+
+	_, err = resolveVarsFrom([]string{"vault:secret/data/missing#password=db_password"})
+	assert.EqualError(t, err, "Error reading Vault secret secret/data/missing: secret not found")
+
+	_, err = resolveVarsFrom([]string{"vault:secret/data/app#username=db_username"})
+	assert.EqualError(t, err, "Vault secret secret/data/app does not contain the key username")
+}
+
+func TestRedactValues(t *testing.T) {
+	s := redactValues("terraform plan -var db_password=s3cret -var api_key=abc123", []string{"s3cret", "abc123", ""})
+	assert.Equal(t, "terraform plan -var db_password=*** -var api_key=***", s)
+}
@@ -0,0 +1,178 @@
+package terragrunt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/events"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+)
+
+const defaultTerragruntBinary = "terragrunt"
+
+// Provider runs terragrunt plan in a Terragrunt directory and parses the plan
+// JSON the same way as the Terraform providers.
+type Provider struct {
+	cfg              *config.Config
+	Path             string
+	spinnerOpts      ui.SpinnerOptions
+	PlanFlags        string
+	Workspace        string
+	TerragruntBinary string
+}
+
+// NewProvider returns a Terragrunt provider for the project. The Terraform
+// binary of the project is used if it's a terragrunt binary, otherwise
+// terragrunt is used.
+func NewProvider(cfg *config.Config, projectCfg *config.Project) schema.Provider {
+	binary := defaultTerragruntBinary
+	if strings.HasPrefix(filepath.Base(projectCfg.TerraformBinary), "terragrunt") {
+		binary = projectCfg.TerraformBinary
+	}
+
+	return &Provider{
+		cfg:  cfg,
+		Path: projectCfg.Path,
+		spinnerOpts: ui.SpinnerOptions{
+			EnableLogging: cfg.IsLogging(),
+			NoColor:       cfg.NoColor,
+			Indent:        "  ",
+		},
+		PlanFlags:        projectCfg.TerraformPlanFlags,
+		Workspace:        projectCfg.TerraformWorkspace,
+		TerragruntBinary: binary,
+	}
+}
+
+func (p *Provider) Type() string {
+	return "terragrunt_dir"
+}
+
+func (p *Provider) DisplayType() string {
+	return "Terragrunt directory"
+}
+
+func (p *Provider) LoadResources(usage map[string]*schema.UsageData) (*schema.Project, error) {
+	metadata := make(map[string]string)
+	if p.Workspace != "" {
+		metadata["terraformWorkspace"] = p.Workspace
+	}
+
+	project := schema.NewProject(p.Path, metadata)
+
+	j, err := p.generatePlanJSON()
+	if err != nil {
+		return project, err
+	}
+
+	err = terraform.LoadPlanJSON(p.cfg, project, j, usage)
+	if err != nil {
+		return project, errors.Wrap(err, "Error parsing Terragrunt plan JSON")
+	}
+
+	project.HasDiff = true
+
+	return project, nil
+}
+
+func (p *Provider) generatePlanJSON() ([]byte, error) {
+	_, err := exec.LookPath(p.TerragruntBinary)
+	if err != nil {
+		msg := fmt.Sprintf("Terragrunt binary \"%s\" could not be found.\nSet a custom Terragrunt binary in your Infracost config or using the environment variable INFRACOST_TERRAFORM_BINARY.", p.TerragruntBinary)
+		return []byte{}, events.NewError(errors.Errorf(msg), "Terragrunt binary could not be found")
+	}
+
+	opts := &terraform.CmdOptions{
+		TerraformBinary:    p.TerragruntBinary,
+		TerraformWorkspace: p.Workspace,
+		Dir:                p.Path,
+	}
+
+	planFile, err := p.runPlan(opts)
+	if planFile != "" {
+		defer os.Remove(planFile)
+	}
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return p.runShow(opts, planFile)
+}
+
+func (p *Provider) runPlan(opts *terraform.CmdOptions) (string, error) {
+	spinner := ui.NewSpinner("Running terragrunt plan", p.spinnerOpts)
+
+	f, err := ioutil.TempFile(os.TempDir(), "tfplan")
+	if err != nil {
+		spinner.Fail()
+		return "", errors.Wrap(err, "Error creating temporary file 'tfplan'")
+	}
+	f.Close()
+
+	flags, err := shellquote.Split(p.PlanFlags)
+	if err != nil {
+		spinner.Fail()
+		return f.Name(), errors.Wrap(err, "Error parsing terraform plan flags")
+	}
+
+	// Terragrunt runs Terraform in its cache dir, so the plan file has to be an
+	// absolute path for it to be written where we can find it
+	args := []string{"plan", "-input=false", "-lock=false", "-no-color"}
+	args = append(args, flags...)
+	args = append(args, fmt.Sprintf("-out=%s", f.Name()))
+
+	_, err = terraform.Cmd(opts, args...)
+	if err != nil {
+		spinner.Fail()
+		printTerragruntErr(err)
+		return f.Name(), errors.Wrap(err, "Error running terragrunt plan")
+	}
+
+	spinner.Success()
+
+	return f.Name(), nil
+}
+
+func (p *Provider) runShow(opts *terraform.CmdOptions, planFile string) ([]byte, error) {
+	spinner := ui.NewSpinner("Running terragrunt show", p.spinnerOpts)
+
+	out, err := terraform.Cmd(opts, "show", "-no-color", "-json", planFile)
+	if err != nil {
+		spinner.Fail()
+		printTerragruntErr(err)
+		return []byte{}, errors.Wrap(err, "Error running terragrunt show")
+	}
+	spinner.Success()
+
+	return out, nil
+}
+
+// IsTerragruntDir returns true if the path is a directory with a
+// terragrunt.hcl file.
+func IsTerragruntDir(path string) bool {
+	info, err := os.Stat(filepath.Join(path, "terragrunt.hcl"))
+	return err == nil && !info.IsDir()
+}
+
+func printTerragruntErr(err error) {
+	e, ok := err.(*terraform.CmdError)
+	if !ok {
+		return
+	}
+
+	stderr := strings.TrimSpace(string(e.Stderr))
+	if stderr == "" {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\n  Terragrunt command failed with:\n%s\n\n", ui.Indent(stderr, "    "))
+}
@@ -0,0 +1,26 @@
+package terragrunt
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTerragruntDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.False(t, IsTerragruntDir(dir))
+
+	err := ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte{}, 0600)
+	assert.NoError(t, err)
+	assert.False(t, IsTerragruntDir(dir))
+
+	err = os.Mkdir(filepath.Join(dir, "child"), 0700)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "child", "terragrunt.hcl"), []byte{}, 0600)
+	assert.NoError(t, err)
+	assert.False(t, IsTerragruntDir(dir))
+	assert.True(t, IsTerragruntDir(filepath.Join(dir, "child")))
+}
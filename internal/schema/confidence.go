@@ -0,0 +1,71 @@
+package schema
+
+import "github.com/shopspring/decimal"
+
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// ConfidenceRank orders the confidence levels from the lowest.
+var ConfidenceRank = map[string]int{
+	ConfidenceLow:    0,
+	ConfidenceMedium: 1,
+	ConfidenceHigh:   2,
+}
+
+// calculateConfidence sets how reliable the cost estimate of the resource is:
+//
+//   - low if a cost component has no quantity, so its cost is missing until
+//     the usage is set, or if the usage file values drive most of the cost.
+//     Only cost components with a monthly quantity can come from usage, so
+//     they're counted as usage estimates if any usage was set.
+//   - medium if usage was set but fixed hourly costs make up most of the
+//     cost, if the resource read usage keys that weren't set so it assumed
+//     defaults, or if its region fell back to --default-region.
+//   - high otherwise, since the cost only depends on the Terraform config
+//     and the prices.
+//
+// Skipped resources don't have a confidence. The cost components of the
+// subresources are included since the usage is read by the resource.
+func (r *Resource) calculateConfidence() {
+	r.Confidence = ""
+	if r.IsSkipped {
+		return
+	}
+
+	components := make([]*CostComponent, 0, len(r.CostComponents))
+	components = append(components, r.CostComponents...)
+	for _, s := range r.FlattenedSubResources() {
+		components = append(components, s.CostComponents...)
+	}
+
+	fixed := decimal.Zero
+	estimated := decimal.Zero
+	for _, c := range components {
+		if c.MonthlyQuantity == nil {
+			r.Confidence = ConfidenceLow
+			return
+		}
+
+		if c.MonthlyCost == nil {
+			continue
+		}
+
+		if c.monthlyQuantityOnly {
+			estimated = estimated.Add(*c.MonthlyCost)
+		} else {
+			fixed = fixed.Add(*c.MonthlyCost)
+		}
+	}
+
+	switch {
+	case len(r.EstimatedUsageKeys) > 0 && estimated.GreaterThan(fixed):
+		r.Confidence = ConfidenceLow
+	case len(r.EstimatedUsageKeys) > 0, len(r.DefaultedUsageKeys) > 0, r.RegionFallback:
+		r.Confidence = ConfidenceMedium
+	default:
+		r.Confidence = ConfidenceHigh
+	}
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateConfidence(t *testing.T) {
+	hourly := func(price int64) *CostComponent {
+		c := &CostComponent{Name: "Instance usage", HourlyQuantity: decimalPtr(decimal.NewFromInt(1))}
+		c.SetPrice(decimal.NewFromInt(price))
+		return c
+	}
+	monthly := func(price int64) *CostComponent {
+		c := &CostComponent{Name: "Requests", MonthlyQuantity: decimalPtr(decimal.NewFromInt(1))}
+		c.SetPrice(decimal.NewFromInt(price))
+		return c
+	}
+
+	project := NewProject("test", map[string]string{})
+	project.Resources = []*Resource{
+		{Name: "fixed", CostComponents: []*CostComponent{hourly(1), monthly(1000)}},
+		{Name: "defaults", CostComponents: []*CostComponent{hourly(1)}, DefaultedUsageKeys: []string{"request_duration_ms"}},
+		{Name: "fallback", CostComponents: []*CostComponent{hourly(1)}, RegionFallback: true},
+		{Name: "mostly_fixed", CostComponents: []*CostComponent{hourly(1), monthly(100)}, EstimatedUsageKeys: []string{"monthly_requests"}},
+		{Name: "mostly_usage", CostComponents: []*CostComponent{hourly(1), monthly(1000)}, EstimatedUsageKeys: []string{"monthly_requests"}},
+		{Name: "missing_usage", CostComponents: []*CostComponent{hourly(1), {Name: "Requests"}}},
+		{Name: "subresource_usage", SubResources: []*Resource{{Name: "sub", CostComponents: []*CostComponent{{Name: "Requests"}}}}},
+		{Name: "skipped", IsSkipped: true},
+	}
+	CalculateCosts(project)
+
+	confidences := make(map[string]string)
+	for _, r := range project.Resources {
+		confidences[r.Name] = r.Confidence
+	}
+
+	assert.Equal(t, map[string]string{
+		"fixed":             ConfidenceHigh,
+		"defaults":          ConfidenceMedium,
+		"fallback":          ConfidenceMedium,
+		"mostly_fixed":      ConfidenceMedium,
+		"mostly_usage":      ConfidenceLow,
+		"missing_usage":     ConfidenceLow,
+		"subresource_usage": ConfidenceLow,
+		"skipped":           "",
+	}, confidences)
+	assert.Equal(t, "", project.Resources[6].SubResources[0].Confidence)
+}
+
+func TestUsageKeyRecorder(t *testing.T) {
+	u := NewUsageMap(map[string]interface{}{
+		"aws_lambda_function.fn": map[string]interface{}{
+			"monthly_requests": 100,
+		},
+	})["aws_lambda_function.fn"]
+
+	recorder := NewUsageKeyRecorder()
+	recorded := u.WithRecorder(u.Address, recorder)
+	recorded.Get("monthly_requests")
+	recorded.Get("request_duration_ms")
+
+	set, unset := recorder.Keys()
+	assert.Equal(t, []string{"monthly_requests"}, set)
+	assert.Equal(t, []string{"request_duration_ms"}, unset)
+
+	// Reads of the shared usage data aren't recorded
+	u.Get("storage_gb")
+	_, unset = recorder.Keys()
+	assert.Equal(t, []string{"request_duration_ms"}, unset)
+
+	// Resources without usage data still record the keys that were defaulted
+	var missing *UsageData
+	recorder = NewUsageKeyRecorder()
+	missing.WithRecorder("aws_lambda_function.other", recorder).Get("monthly_requests")
+	set, unset = recorder.Keys()
+	assert.Empty(t, set)
+	assert.Equal(t, []string{"monthly_requests"}, unset)
+}
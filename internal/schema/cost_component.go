@@ -9,15 +9,22 @@ type CostComponent struct {
 	Unit                 string
 	UnitMultiplier       int
 	IgnoreIfMissingPrice bool
-	ProductFilter        *ProductFilter
-	PriceFilter          *PriceFilter
-	HourlyQuantity       *decimal.Decimal
-	MonthlyQuantity      *decimal.Decimal
-	MonthlyDiscountPerc  float64
-	price                decimal.Decimal
-	priceHash            string
-	HourlyCost           *decimal.Decimal
-	MonthlyCost          *decimal.Decimal
+	// TieredPricing prices the quantity using all the price tiers returned for
+	// the product, so the PriceFilter shouldn't filter by the start usage
+	// amount. The price is set to the blended price of the tiers.
+	TieredPricing       bool
+	ProductFilter       *ProductFilter
+	PriceFilter         *PriceFilter
+	HourlyQuantity      *decimal.Decimal
+	MonthlyQuantity     *decimal.Decimal
+	MonthlyDiscountPerc float64
+	price               decimal.Decimal
+	priceHash           string
+	HourlyCost          *decimal.Decimal
+	MonthlyCost         *decimal.Decimal
+	// monthlyQuantityOnly is true if only the monthly quantity was set, so
+	// the quantity isn't a fixed hourly one
+	monthlyQuantityOnly bool
 }
 
 func (c *CostComponent) CalculateCosts() {
@@ -33,6 +40,7 @@ func (c *CostComponent) CalculateCosts() {
 
 func (c *CostComponent) fillQuantities() {
 	if c.MonthlyQuantity != nil && c.HourlyQuantity == nil {
+		c.monthlyQuantityOnly = true
 		c.HourlyQuantity = decimalPtr(c.MonthlyQuantity.Div(hourToMonthMultiplier))
 	} else if c.HourlyQuantity != nil && c.MonthlyQuantity == nil {
 		c.MonthlyQuantity = decimalPtr(c.HourlyQuantity.Mul(hourToMonthMultiplier))
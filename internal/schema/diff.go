@@ -76,6 +76,7 @@ func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string
 		IsSkipped:    baseResource.IsSkipped,
 		NoPrice:      baseResource.NoPrice,
 		SkipMessage:  baseResource.SkipMessage,
+		UsageMessage: baseResource.UsageMessage,
 		ResourceType: baseResource.ResourceType,
 		Tags:         baseResource.Tags,
 
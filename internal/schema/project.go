@@ -1,14 +1,34 @@
 package schema
 
+import (
+	"regexp"
+	"strings"
+)
+
 // Project contains the existing, planned state of
 // resources and the diff between them.
 type Project struct {
-	Path          string
-	Metadata      map[string]string
-	PastResources []*Resource
-	Resources     []*Resource
-	Diff          []*Resource
-	HasDiff       bool
+	Path         string
+	Metadata     map[string]string
+	PlanMetadata *PlanMetadata
+	// PastPlanMetadata is only set if the past resources are from another
+	// plan instead of the prior state of the plan
+	PastPlanMetadata *PlanMetadata
+	PastResources    []*Resource
+	Resources        []*Resource
+	Diff             []*Resource
+	HasDiff          bool
+}
+
+// PlanMetadata describes the Terraform plan or state a project was parsed
+// from. Fields that can't be determined are left empty.
+type PlanMetadata struct {
+	TerraformVersion string
+	FormatVersion    string
+	WorkingDirectory string
+	// ProviderVersions are the provider versions, or their version constraints
+	// if the versions aren't known, keyed by the provider name
+	ProviderVersions map[string]string
 }
 
 func NewProject(path string, metadata map[string]string) *Project {
@@ -34,6 +54,80 @@ func (p *Project) CalculateDiff() {
 	}
 }
 
+// RemoveUnchangedResources removes any resources that are not changed by the
+// plan, including resources where only the tags are changed.
+func (p *Project) RemoveUnchangedResources() {
+	p.PastResources = changedResources(p.PastResources)
+	p.Resources = changedResources(p.Resources)
+}
+
+// FilterResourcesByPath removes the resources with addresses that don't match
+// any of the path filters, see CompilePathFilters. It returns the number of
+// resources that match.
+func (p *Project) FilterResourcesByPath(filters []*regexp.Regexp) int {
+	p.PastResources = FilterResourcesByPath(p.PastResources, filters)
+	p.Resources = FilterResourcesByPath(p.Resources, filters)
+
+	return len(p.PastResources) + len(p.Resources)
+}
+
+// FilterResourcesByPath returns the resources with addresses that match any of
+// the path filters.
+func FilterResourcesByPath(resources []*Resource, filters []*regexp.Regexp) []*Resource {
+	filtered := make([]*Resource, 0, len(resources))
+
+	for _, r := range resources {
+		if MatchesPathFilter(r.Name, filters) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// CompilePathFilters returns the regexps of the glob patterns of resource
+// addresses, where * matches any characters, including dots, and ? matches
+// any one character, e.g. module.db.* matches all the resources of the db
+// module. Other characters, such as the brackets of count indexes, only match
+// themselves.
+func CompilePathFilters(patterns []string) []*regexp.Regexp {
+	filters := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		re := regexp.QuoteMeta(pattern)
+		re = strings.ReplaceAll(re, `\*`, ".*")
+		re = strings.ReplaceAll(re, `\?`, ".")
+
+		filters = append(filters, regexp.MustCompile("^"+re+"$"))
+	}
+
+	return filters
+}
+
+// MatchesPathFilter returns true if the resource address matches any of the
+// path filters.
+func MatchesPathFilter(address string, filters []*regexp.Regexp) bool {
+	for _, re := range filters {
+		if re.MatchString(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func changedResources(resources []*Resource) []*Resource {
+	changed := make([]*Resource, 0, len(resources))
+
+	for _, r := range resources {
+		if r.IsChanged() {
+			changed = append(changed, r)
+		}
+	}
+
+	return changed
+}
+
 // AllProjectResources returns the resources for all projects
 func AllProjectResources(projects []*Project) []*Resource {
 	resources := make([]*Resource, 0)
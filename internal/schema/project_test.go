@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesPathFilter(t *testing.T) {
+	tests := []struct {
+		address  string
+		patterns []string
+		expected bool
+	}{
+		{"module.db.aws_db_instance.main", []string{"module.db.*"}, true},
+		{"module.db.module.replica.aws_db_instance.main", []string{"module.db.*"}, true},
+		{"module.dbx.aws_db_instance.main", []string{"module.db.*"}, false},
+		{"aws_instance.web", []string{"module.db.*"}, false},
+		{"aws_instance.web", []string{"module.db.*", "aws_instance.*"}, true},
+		{"aws_instance.web[0]", []string{"aws_instance.web[0]"}, true},
+		{"aws_instance.web[1]", []string{"aws_instance.web[?]"}, true},
+		{"aws_instance.web0", []string{"aws_instance.web[0]"}, false},
+		{"aws_instance.web", []string{}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, MatchesPathFilter(test.address, CompilePathFilters(test.patterns)), test.address)
+	}
+}
+
+func TestFilterResourcesByPath(t *testing.T) {
+	p := &Project{
+		PastResources: []*Resource{{Name: "module.db.aws_db_instance.main"}},
+		Resources: []*Resource{
+			{Name: "module.db.aws_db_instance.main"},
+			{Name: "aws_instance.web"},
+		},
+	}
+
+	assert.Equal(t, 2, p.FilterResourcesByPath(CompilePathFilters([]string{"module.db.*"})))
+	assert.Equal(t, 1, len(p.PastResources))
+	assert.Equal(t, []*Resource{{Name: "module.db.aws_db_instance.main"}}, p.Resources)
+
+	assert.Equal(t, 0, p.FilterResourcesByPath(CompilePathFilters([]string{"aws_s3_bucket.*"})))
+	assert.Equal(t, 0, len(p.Resources))
+}
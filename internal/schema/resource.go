@@ -20,13 +20,82 @@ type Resource struct {
 	IsSkipped      bool
 	NoPrice        bool
 	SkipMessage    string
-	ResourceType   string
-	Tags           map[string]string
+	// UsageMessage is which usage keys are needed to estimate the usage-based
+	// costs of the resource when they're missing from the usage file
+	UsageMessage string
+	ResourceType string
+	Tags         map[string]string
+	// Region is the region or location of the resource, if it has one
+	Region string
+	// RegionFallback is true if the region couldn't be mapped so it fell back
+	// to --default-region
+	RegionFallback bool
+	// EstimatedUsageKeys are the usage keys read by the resource that were
+	// set, and DefaultedUsageKeys those that weren't so defaults were used
+	EstimatedUsageKeys []string
+	DefaultedUsageKeys []string
+	// Confidence is how reliable the cost estimate is, see calculateConfidence
+	Confidence    string
+	ChangeActions []string
+	// IsTagOnlyChange is true if the plan only updates the tags of the resource
+	IsTagOnlyChange bool
+	// AttributeChanges are the cost-relevant attributes changed by the plan
+	AttributeChanges []AttributeChange
+	// SourceFileName and SourceStartLine are the location of the resource block
+	// in the Terraform files, if known
+	SourceFileName  string
+	SourceStartLine int
+	// ResourceHash is a stable hash of the cost components of the resource, so
+	// unchanged resources can be detected across runs, see calculateHash
+	ResourceHash string
+	// Alternatives are cheaper SKUs suggested for the cost components of the
+	// resource, if --suggest-alternatives is used
+	Alternatives []*Alternative
+}
+
+// Alternative is a cheaper SKU of the same family and size as the one used by
+// a cost component, e.g. m6i.xlarge instead of m5.xlarge.
+type Alternative struct {
+	CostComponent string
+	Region        string
+	Current       string
+	Suggested     string
+	MonthlySaving decimal.Decimal
+	SavingPercent decimal.Decimal
+}
+
+// AttributeChange is the before and after value of a changed resource attribute.
+type AttributeChange struct {
+	Name   string
+	Before string
+	After  string
+}
+
+// IsChanged returns false if the plan doesn't change the resource or only
+// changes its tags. Resources without any plan information are treated as changed.
+func (r *Resource) IsChanged() bool {
+	if r.ChangeActions == nil {
+		return true
+	}
+
+	if r.IsTagOnlyChange {
+		return false
+	}
+
+	for _, a := range r.ChangeActions {
+		if a != "no-op" && a != "read" {
+			return true
+		}
+	}
+
+	return false
 }
 
 func CalculateCosts(project *Project) {
 	for _, r := range project.AllResources() {
 		r.CalculateCosts()
+		r.calculateConfidence()
+		r.calculateHash()
 	}
 }
 
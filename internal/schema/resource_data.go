@@ -7,11 +7,14 @@ import (
 )
 
 type ResourceData struct {
-	Type          string
-	ProviderName  string
-	Address       string
-	Tags          map[string]string
-	RawValues     gjson.Result
+	Type         string
+	ProviderName string
+	Address      string
+	Tags         map[string]string
+	RawValues    gjson.Result
+	// CapacityBasis is the default capacity basis of the autoscaling groups,
+	// from the --capacity-basis flag, when it's not set in the usage file
+	CapacityBasis string
 	referencesMap map[string][]*ResourceData
 }
 
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
+type hashedCostComponent struct {
+	Name            string `json:"name"`
+	Unit            string `json:"unit"`
+	Price           string `json:"price"`
+	HourlyQuantity  string `json:"hourlyQuantity"`
+	MonthlyQuantity string `json:"monthlyQuantity"`
+}
+
+type hashedResource struct {
+	Name           string                `json:"name,omitempty"`
+	CostComponents []hashedCostComponent `json:"costComponents"`
+	SubResources   []hashedResource      `json:"subResources"`
+}
+
+// calculateHash sets the ResourceHash to a hex encoded SHA-256 hash of the
+// inputs of the cost of the resource, once the prices are set. The hash is
+// built from:
+//   - the resource type
+//   - the name, unit, price and hourly and monthly quantities of each cost
+//     component
+//   - the same for each subresource, along with the subresource name
+//
+// Nothing else feeds the hash, so a re-plan that only changes attributes that
+// don't affect the cost, e.g. the id, arn or tags, keeps the same hash. The
+// address isn't included so identical resources have the same hash. Skipped
+// resources don't have a hash.
+func (r *Resource) calculateHash() {
+	r.ResourceHash = ""
+	if r.IsSkipped {
+		return
+	}
+
+	b, _ := json.Marshal(struct {
+		Type     string         `json:"type"`
+		Resource hashedResource `json:"resource"`
+	}{
+		Type:     r.ResourceType,
+		Resource: hashResource(r, false),
+	})
+
+	sum := sha256.Sum256(b)
+	r.ResourceHash = hex.EncodeToString(sum[:])
+}
+
+func hashResource(r *Resource, withName bool) hashedResource {
+	h := hashedResource{
+		CostComponents: make([]hashedCostComponent, 0, len(r.CostComponents)),
+		SubResources:   make([]hashedResource, 0, len(r.SubResources)),
+	}
+
+	if withName {
+		h.Name = r.Name
+	}
+
+	for _, c := range r.CostComponents {
+		h.CostComponents = append(h.CostComponents, hashedCostComponent{
+			Name:            c.Name,
+			Unit:            c.Unit,
+			Price:           c.Price().String(),
+			HourlyQuantity:  hashedQuantity(c.HourlyQuantity),
+			MonthlyQuantity: hashedQuantity(c.MonthlyQuantity),
+		})
+	}
+
+	for _, s := range r.SubResources {
+		h.SubResources = append(h.SubResources, hashResource(s, true))
+	}
+
+	return h
+}
+
+func hashedQuantity(q *decimal.Decimal) string {
+	if q == nil {
+		return ""
+	}
+
+	return q.String()
+}
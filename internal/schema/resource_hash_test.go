@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateHash(t *testing.T) {
+	newResource := func(name string, price float64) *Resource {
+		c := &CostComponent{Name: "Instance usage", Unit: "hours", HourlyQuantity: decimalPtr(decimal.NewFromInt(1))}
+		c.SetPrice(decimal.NewFromFloat(price))
+
+		r := &Resource{Name: name, ResourceType: "aws_instance", CostComponents: []*CostComponent{c}}
+		r.calculateHash()
+		return r
+	}
+
+	base := newResource("aws_instance.a", 0.1)
+	assert.Len(t, base.ResourceHash, 64)
+	assert.Equal(t, base.ResourceHash, newResource("aws_instance.b", 0.1).ResourceHash)
+	assert.NotEqual(t, base.ResourceHash, newResource("aws_instance.a", 0.2).ResourceHash)
+
+	skipped := &Resource{Name: "aws_instance.a", IsSkipped: true}
+	skipped.calculateHash()
+	assert.Equal(t, "", skipped.ResourceHash)
+}
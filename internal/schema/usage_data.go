@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/tidwall/gjson"
@@ -11,6 +12,8 @@ import (
 type UsageData struct {
 	Address    string
 	Attributes map[string]gjson.Result
+	// recorder notes the keys read through this usage data, see WithRecorder
+	recorder *UsageKeyRecorder
 }
 
 func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData {
@@ -20,7 +23,37 @@ func NewUsageData(address string, attributes map[string]gjson.Result) *UsageData
 	}
 }
 
+// WithRecorder returns a copy of the usage data that notes the keys read by
+// the resource in the recorder. The usage data itself isn't changed by reads,
+// so it can be shared by the instances of count and for_each resources. If
+// the resource has no usage data an empty one is returned, so the keys that
+// fell back to defaults are still recorded.
+func (u *UsageData) WithRecorder(address string, recorder *UsageKeyRecorder) *UsageData {
+	if u == nil {
+		return &UsageData{
+			Address:    address,
+			Attributes: map[string]gjson.Result{},
+			recorder:   recorder,
+		}
+	}
+
+	c := *u
+	c.recorder = recorder
+
+	return &c
+}
+
 func (u *UsageData) Get(key string) gjson.Result {
+	v := u.get(key)
+
+	if u.recorder != nil {
+		u.recorder.record(key, v.Exists())
+	}
+
+	return v
+}
+
+func (u *UsageData) get(key string) gjson.Result {
 	if u.Attributes[key].Type != gjson.Null {
 		return u.Attributes[key]
 	} else if strings.Contains(key, "[") && strings.Contains(key, "]") {
@@ -30,6 +63,40 @@ func (u *UsageData) Get(key string) gjson.Result {
 	return u.Attributes[key]
 }
 
+// UsageKeyRecorder records the usage keys read by a resource and whether they
+// were set.
+type UsageKeyRecorder struct {
+	read map[string]bool
+}
+
+func NewUsageKeyRecorder() *UsageKeyRecorder {
+	return &UsageKeyRecorder{read: make(map[string]bool)}
+}
+
+func (r *UsageKeyRecorder) record(key string, set bool) {
+	r.read[key] = r.read[key] || set
+}
+
+// Keys returns the sorted keys that have been read that were set, and those
+// that weren't.
+func (r *UsageKeyRecorder) Keys() ([]string, []string) {
+	set := make([]string, 0)
+	unset := make([]string, 0)
+
+	for k, ok := range r.read {
+		if ok {
+			set = append(set, k)
+		} else {
+			unset = append(unset, k)
+		}
+	}
+
+	sort.Strings(set)
+	sort.Strings(unset)
+
+	return set, unset
+}
+
 func convertArrayKeyToWildcard(key string) string {
 	lastOpenBracket := strings.LastIndex(key, "[")
 	lastCloseBracket := strings.LastIndex(key, "]")
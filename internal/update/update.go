@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -137,7 +136,7 @@ func getLatestBrewVersion() (string, error) {
 		Versions versionsResp `json:"versions"`
 	}
 
-	resp, err := http.Get("https://formulae.brew.sh/api/formula/infracost.json")
+	resp, err := config.HTTPClient().Get("https://formulae.brew.sh/api/formula/infracost.json")
 	if err != nil {
 		return "", err
 	}
@@ -166,7 +165,7 @@ func getLatestGitHubVersion() (string, error) {
 		TagName string `json:"tag_name"`
 	}
 
-	resp, err := http.Get("https://api.github.com/repos/infracost/infracost/releases/latest")
+	resp, err := config.HTTPClient().Get("https://api.github.com/repos/infracost/infracost/releases/latest")
 	if err != nil {
 		return "", err
 	}